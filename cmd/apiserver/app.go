@@ -4,12 +4,14 @@ package main
 import (
 	"context"
 	"fantom-api-graphql/cmd/apiserver/build"
+	"fantom-api-graphql/internal/alerts"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/graphql/resolvers"
 	"fantom-api-graphql/internal/handlers"
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/tracing"
 	"flag"
 	"fmt"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 )
@@ -29,7 +32,13 @@ type apiServer struct {
 	cfg          *config.Config
 	log          logger.Logger
 	api          resolvers.ApiResolver
+	alerts       *alerts.Monitor
 	srv          *http.Server
+	metrics      *http.Server
+	admin        *http.Server
+	traces       func()
+	unwatch      func()
+	reloadLock   sync.Mutex
 	closed       chan interface{}
 	isVersionReq bool
 }
@@ -84,18 +93,25 @@ func (app *apiServer) init() {
 	app.log = logger.New(app.cfg)
 	app.closed = make(chan interface{})
 
+	// setup distributed tracing before anything gets traced
+	app.traces = tracing.Setup(app.cfg, app.log)
+
 	// make sure to pass logger and config to internals
 	repository.SetConfig(app.cfg)
-	repository.SetLogger(app.log)
+	repository.SetLogger(logger.Module(app.log, "repository"))
 	resolvers.SetConfig(app.cfg)
-	resolvers.SetLogger(app.log)
+	resolvers.SetLogger(logger.Module(app.log, "resolvers"))
 	svc.SetConfig(app.cfg)
-	svc.SetLogger(app.log)
+	svc.SetLogger(logger.Module(app.log, "svc"))
 
+	// make validator alerts monitor; it's nil if no alert rules are configured
+	app.alerts = alerts.New(app.cfg, app.log)
 	go app.RunValidatorChecks()
 
 	// make the HTTP server
 	app.makeHttpServer()
+	app.makeMetricsServer()
+	app.makeAdminServer()
 
 }
 
@@ -113,6 +129,17 @@ func (app *apiServer) run() {
 	// run services
 	svc.Manager().Run()
 
+	// expose metrics and administration, if enabled
+	if app.metrics != nil {
+		go app.serveMetrics()
+	}
+	if app.admin != nil {
+		go app.serveAdmin()
+	}
+
+	// follow changes of the configuration files
+	app.watchConfig()
+
 	// start responding to requests
 	app.log.Infof("welcome to Fantom GraphQL API server")
 	app.log.Infof("listening for requests on %s", app.cfg.Server.BindAddress)
@@ -146,14 +173,100 @@ func (app *apiServer) makeHttpServer() {
 	app.setupHandlers(srvMux)
 }
 
+// makeMetricsServer creates the HTTP server exposing the collected metrics, if configured.
+func (app *apiServer) makeMetricsServer() {
+	if app.cfg.Server.MetricsBind == "" {
+		app.log.Notice("metrics server disabled")
+		return
+	}
+
+	mux := new(http.ServeMux)
+	mux.Handle("/metrics", handlers.Metrics(app.cfg, logger.Module(app.log, "handlers")))
+
+	app.metrics = &http.Server{
+		Addr:              app.cfg.Server.MetricsBind,
+		ReadHeaderTimeout: time.Second * time.Duration(app.cfg.Server.HeaderTimeout),
+		Handler:           mux,
+	}
+}
+
+// serveMetrics runs the metrics HTTP server until it's closed.
+func (app *apiServer) serveMetrics() {
+	app.log.Infof("serving metrics on %s", app.cfg.Server.MetricsBind)
+	if err := app.metrics.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		app.log.Errorf("metrics server failed; %s", err.Error())
+	}
+}
+
+// makeAdminServer creates the HTTP server exposing the administration API, if configured.
+func (app *apiServer) makeAdminServer() {
+	if app.cfg.Admin.BindAddress == "" || app.cfg.Admin.Password == "" {
+		app.log.Notice("administration API disabled")
+		return
+	}
+
+	mux := new(http.ServeMux)
+	mux.Handle("/admin/", handlers.Admin(app.cfg, logger.Module(app.log, "admin"), app.reload))
+
+	app.admin = &http.Server{
+		Addr:              app.cfg.Admin.BindAddress,
+		ReadHeaderTimeout: time.Second * time.Duration(app.cfg.Server.HeaderTimeout),
+		Handler:           mux,
+	}
+}
+
+// serveAdmin runs the administration HTTP server until it's closed.
+func (app *apiServer) serveAdmin() {
+	app.log.Infof("serving administration API on %s", app.cfg.Admin.BindAddress)
+	if err := app.admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		app.log.Errorf("administration server failed; %s", err.Error())
+	}
+}
+
+// watchConfig reloads the configuration when the configuration file, or the ERC20 tokens file changes.
+func (app *apiServer) watchConfig() {
+	if config.File() == "" {
+		return
+	}
+
+	var err error
+	app.unwatch, err = config.Watch(app.cfg, func() {
+		if _, err := app.reload(); err != nil {
+			app.log.Errorf("can not reload configuration; %s", err.Error())
+		}
+	})
+	if err != nil {
+		app.log.Errorf("can not watch configuration; %s", err.Error())
+	}
+}
+
+// reload applies the reloadable subset of the configuration file. The names of the changed
+// configuration sections, which need the server to be restarted, are returned.
+func (app *apiServer) reload() ([]string, error) {
+	app.reloadLock.Lock()
+	defer app.reloadLock.Unlock()
+
+	restart, err := config.Reload(app.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	app.log.Notice("configuration reloaded")
+	if len(restart) > 0 {
+		app.log.Warningf("changes of %v need the server to be restarted", restart)
+	}
+	return restart, nil
+}
+
 // setupHandlers initializes an array of handlers for our HTTP API end-points.
 func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 	// create root resolver
 	app.api = resolvers.New()
+	hlog := logger.Module(app.log, "handlers")
 
 	// setup GraphQL API handler
-	h := http.TimeoutHandler(
-		handlers.Api(app.cfg, app.log, app.api),
+	h := handlers.NewTimeoutHandler(
+		handlers.Api(app.cfg, hlog, app.api),
 		time.Second*time.Duration(app.cfg.Server.ResolverTimeout),
 		"Service timeout.",
 	)
@@ -179,17 +292,18 @@ func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 	mux.Handle("/api", h)
 	mux.Handle("/graphql", h)
 
-	mux.Handle("/health", handlers.Health(app.log, app.cfg))
+	mux.Handle("/health", handlers.Health(hlog, app.cfg))
 
 	// setup gas price estimator REST API resolver
-	mux.Handle("/json/gas", handlers.GasPrice(app.log))
-	mux.Handle("/json/validators/down", cacheClient.Middleware(handlers.ValidatorsDownJSONHandler(app.log)))
-	mux.Handle("/html/validators/down", cacheClient.Middleware(handlers.ValidatorsDownHandler(app.log)))
-	mux.Handle("/validators", cacheClient.Middleware(handlers.ValidatorsJSONHandler(app.api, app.log)))
-	mux.Handle("/validators/", cacheClient.Middleware(handlers.ValidatorJSONHandler(app.log)))
+	mux.Handle("/json/gas", handlers.GasPrice(hlog))
+	mux.Handle("/json/staking/report", handlers.StakingReport(hlog))
+	mux.Handle("/json/validators/down", cacheClient.Middleware(handlers.ValidatorsDownJSONHandler(hlog)))
+	mux.Handle("/html/validators/down", cacheClient.Middleware(handlers.ValidatorsDownHandler(hlog)))
+	mux.Handle("/validators", cacheClient.Middleware(handlers.ValidatorsJSONHandler(app.api, hlog)))
+	mux.Handle("/validators/", cacheClient.Middleware(handlers.ValidatorJSONHandler(hlog)))
 
 	// handle GraphiQL interface
-	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, app.log))
+	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, hlog))
 }
 
 // observeSignals setups terminate signals observation.
@@ -201,6 +315,17 @@ func (app *apiServer) observeSignals() {
 	ts := make(chan os.Signal, 1)
 	signal.Notify(ts, syscall.SIGINT, syscall.SIGTERM)
 
+	// reload the configuration on hang up
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if _, err := app.reload(); err != nil {
+				app.log.Errorf("can not reload configuration; %s", err.Error())
+			}
+		}
+	}()
+
 	// start monitoring
 	go func() {
 		defer func() {
@@ -219,6 +344,20 @@ func (app *apiServer) observeSignals() {
 			app.log.Errorf("could not terminate HTTP listener; %s", err.Error())
 		}
 
+		// terminate metrics responder
+		if app.metrics != nil {
+			if err := app.metrics.Shutdown(ct); err != nil {
+				app.log.Errorf("could not terminate metrics listener; %s", err.Error())
+			}
+		}
+
+		// terminate administration responder
+		if app.admin != nil {
+			if err := app.admin.Shutdown(ct); err != nil {
+				app.log.Errorf("could not terminate administration listener; %s", err.Error())
+			}
+		}
+
 		// we closed
 		cancel()
 		app.log.Notice("HTTP server closed")
@@ -231,8 +370,14 @@ func (app *apiServer) terminate() {
 	app.log.Notice("closing resolver")
 	app.api.Close()
 
+	// stop following the configuration changes
+	if app.unwatch != nil {
+		app.unwatch()
+	}
+
 	// terminate observers, scanners and dispatchers, etc.
 	app.log.Notice("closing services")
+	app.alerts.Close()
 	if mgr := svc.Manager(); mgr != nil {
 		mgr.Close()
 	}
@@ -243,6 +388,8 @@ func (app *apiServer) terminate() {
 		repo.Close()
 	}
 
+	// flush pending traces
+	app.traces()
 	app.log.Notice("terminated")
 }
 
@@ -255,7 +402,7 @@ func (app *apiServer) RunValidatorChecks() {
 		}
 
 		// get the list of validators
-		validatorStatuses, err := app.api.ValidatorStatuses()
+		validatorStatuses, err := app.api.ValidatorStatuses(context.Background())
 		if err != nil {
 			app.log.Errorf("can not get validators list; %s", err.Error())
 			time.Sleep(5 * time.Second)
@@ -288,6 +435,9 @@ func (app *apiServer) RunValidatorChecks() {
 		cheaterValidatorsGauge.Set(float64(cheaterVals))
 		totalValidatorsGauge.Set(float64(len(validatorStatuses)))
 
+		// evaluate alert rules and notify configured targets
+		app.alerts.Check(validatorStatuses)
+
 		// wait for the next check
 		time.Sleep(30 * time.Second)
 	}
@@ -2,8 +2,6 @@
 package main
 
 import (
-	"github.com/ethereum/go-ethereum/log"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"net/http"
 	"time"
 )
@@ -20,17 +18,7 @@ func init() {
 
 // main initializes the API server and starts it when ready.
 func main() {
-	go makeMetricsServer()
-
 	app := apiServer{}
 	app.init()
 	app.run()
 }
-
-func makeMetricsServer() {
-	http.Handle("/metrics", promhttp.Handler())
-	err := http.ListenAndServe(":2112", nil)
-	if err != nil {
-		log.Crit(err.Error())
-	}
-}
@@ -0,0 +1,288 @@
+// Package alerts implements validator alert rules evaluation and webhook notifications.
+package alerts
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+const (
+	// defaultLockExpiringThreshold represents the default time before a lock expiration
+	// the lock expiring rule is raised.
+	defaultLockExpiringThreshold = 7 * 24 * time.Hour
+
+	// tokenDecimalsUnit represents the number of WEI units in a token.
+	tokenDecimalsUnit = 1e18
+)
+
+// Monitor represents the validator alerts monitor evaluating configured rules
+// against validator statuses and notifying configured targets about changes.
+type Monitor struct {
+	cfg    *config.Alerts
+	log    logger.Logger
+	rules  []config.AlertRule
+	active map[string]*types.ValidatorAlert
+	loaded bool
+	out    *dispatcher
+}
+
+// New creates a new validator alerts monitor for the given configuration.
+// Nil is returned if no alert rules are configured.
+func New(cfg *config.Config, log logger.Logger) *Monitor {
+	m := &Monitor{
+		cfg:    &cfg.Alerts,
+		log:    log,
+		rules:  make([]config.AlertRule, 0, len(cfg.Alerts.Rules)),
+		active: make(map[string]*types.ValidatorAlert),
+	}
+
+	for _, r := range cfg.Alerts.Rules {
+		if !isKnownRuleType(r.Type) {
+			log.Warningf("unknown validator alert rule type %s of rule %s skipped", r.Type, r.Name)
+			continue
+		}
+		if r.Name == "" {
+			r.Name = r.Type
+		}
+		m.rules = append(m.rules, r)
+	}
+
+	if len(m.rules) == 0 {
+		return nil
+	}
+
+	m.out = newDispatcher(&cfg.Alerts, log)
+	log.Noticef("validator alerts monitor with %d rules and %d targets ready", len(m.rules), len(cfg.Alerts.Targets))
+	return m
+}
+
+// Close terminates the monitor and its notification delivery.
+func (m *Monitor) Close() {
+	if m != nil && m.out != nil {
+		m.out.close()
+	}
+}
+
+// Check evaluates all the configured rules against the given list of validator statuses.
+// Newly raised alerts and alerts no longer valid are stored and notified to targets.
+func (m *Monitor) Check(list []*types.ValidatorStatus) {
+	if m == nil {
+		return
+	}
+
+	// restore the active alerts so we don't notify them again after restart
+	if !m.loaded {
+		if err := m.load(); err != nil {
+			m.log.Errorf("can not load active validator alerts; %s", err.Error())
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	for _, vs := range list {
+		for i := range m.rules {
+			if !containsValidator(m.rules[i].Validators, vs.Id) {
+				continue
+			}
+
+			raised, msg, err := m.evaluate(&m.rules[i], vs, now)
+			if err != nil {
+				m.log.Errorf("can not evaluate rule %s on validator #%d; %s", m.rules[i].Name, vs.Id, err.Error())
+				continue
+			}
+			m.update(&m.rules[i], vs, raised, msg, now)
+		}
+	}
+}
+
+// load restores the list of active alerts from the persistent storage.
+// Alerts of rules removed from the configuration are resolved silently.
+func (m *Monitor) load() error {
+	list, err := repository.R().ValidatorAlerts(nil, true, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, va := range list {
+		if !m.hasRule(va.Rule) {
+			now := time.Now().UTC()
+			va.Resolved = &now
+			if err := repository.R().StoreValidatorAlert(va); err != nil {
+				m.log.Errorf("can not resolve alert %s; %s", va.Id, err.Error())
+			}
+			continue
+		}
+		m.active[va.AlertKey()] = va
+	}
+
+	m.loaded = true
+	return nil
+}
+
+// hasRule checks if a rule of the given name is configured.
+func (m *Monitor) hasRule(name string) bool {
+	for _, r := range m.rules {
+		if r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// update processes the result of a rule evaluation on the validator.
+func (m *Monitor) update(r *config.AlertRule, vs *types.ValidatorStatus, raised bool, msg string, now time.Time) {
+	key := types.ValidatorAlertKey(r.Name, vs.Id)
+	va, isActive := m.active[key]
+
+	switch {
+	case raised && !isActive:
+		va = &types.ValidatorAlert{
+			Id:          fmt.Sprintf("%s-%d", key, now.Unix()),
+			ValidatorId: vs.Id,
+			Address:     vs.Address,
+			Rule:        r.Name,
+			Type:        r.Type,
+			Message:     msg,
+			Raised:      now,
+			Notified:    now,
+		}
+		m.active[key] = va
+		m.store(va)
+		m.out.notify(EventRaised, va)
+
+	case raised && isActive && m.cfg.Repeat > 0 && now.Sub(va.Notified) >= m.cfg.Repeat:
+		va.Message = msg
+		va.Notified = now
+		m.store(va)
+		m.out.notify(EventRepeated, va)
+
+	case !raised && isActive:
+		va.Resolved = &now
+		delete(m.active, key)
+		m.store(va)
+		m.out.notify(EventResolved, va)
+	}
+}
+
+// store persists the given alert state.
+func (m *Monitor) store(va *types.ValidatorAlert) {
+	if err := repository.R().StoreValidatorAlert(va); err != nil {
+		m.log.Errorf("can not store validator alert %s; %s", va.Id, err.Error())
+	}
+}
+
+// evaluate checks the given rule on the validator status and provides the alert message if raised.
+func (m *Monitor) evaluate(r *config.AlertRule, vs *types.ValidatorStatus, now time.Time) (bool, string, error) {
+	// withdrawn validators are not expected to operate anymore
+	if vs.IsWithdrawn && r.Type != types.AlertTypeCheater {
+		return false, "", nil
+	}
+
+	switch r.Type {
+	case types.AlertTypeSelfStake:
+		return m.evaluateSelfStake(r, vs)
+	case types.AlertTypeLockExpiring:
+		return m.evaluateLock(r, vs, now)
+	default:
+		raised, msg := evaluateStatus(r, vs)
+		return raised, msg, nil
+	}
+}
+
+// evaluateStatus checks the given rule based on the validator status only.
+func evaluateStatus(r *config.AlertRule, vs *types.ValidatorStatus) (bool, string) {
+	switch r.Type {
+	case types.AlertTypeOffline:
+		return vs.IsOffline, fmt.Sprintf("validator #%d is offline", vs.Id)
+	case types.AlertTypeDowntime:
+		return vs.DownTime.Time > uint64(r.Threshold), fmt.Sprintf("validator #%d is down for %s (%d blocks)",
+			vs.Id, time.Duration(vs.DownTime.Time)*time.Second, vs.DownTime.Blocks)
+	case types.AlertTypeNotVoting:
+		return vs.IsActive && !vs.IsVoting, fmt.Sprintf("validator #%d stopped voting", vs.Id)
+	case types.AlertTypeCheater:
+		return vs.IsCheater, fmt.Sprintf("validator #%d has been marked as a cheater", vs.Id)
+	}
+	return false, ""
+}
+
+// evaluateSelfStake checks the validator self-stake against the minimum.
+// The minimal self-stake of the SFC contract is used if the rule does not specify any.
+func (m *Monitor) evaluateSelfStake(r *config.AlertRule, vs *types.ValidatorStatus) (bool, string, error) {
+	min := new(big.Int).Mul(big.NewInt(r.Threshold), big.NewInt(tokenDecimalsUnit))
+	if r.Threshold <= 0 {
+		sc, err := repository.R().SfcConfiguration()
+		if err != nil {
+			return false, "", err
+		}
+		min = sc.MinValidatorStake.ToInt()
+	}
+
+	addr := common.HexToAddress(vs.Address)
+	dlg, err := repository.R().Delegation(&addr, (*hexutil.Big)(new(big.Int).SetUint64(vs.Id)))
+	if err != nil {
+		return false, "", err
+	}
+
+	stake := new(big.Int)
+	if dlg.AmountDelegated != nil {
+		stake = dlg.AmountDelegated.ToInt()
+	}
+	return stake.Cmp(min) < 0, fmt.Sprintf("validator #%d self-stake of %s tokens is below the minimum of %s tokens",
+		vs.Id, tokenAmount(stake), tokenAmount(min)), nil
+}
+
+// evaluateLock checks the expiration of the validator self-stake lock.
+func (m *Monitor) evaluateLock(r *config.AlertRule, vs *types.ValidatorStatus, now time.Time) (bool, string, error) {
+	threshold := time.Duration(r.Threshold) * time.Second
+	if threshold <= 0 {
+		threshold = defaultLockExpiringThreshold
+	}
+
+	addr := common.HexToAddress(vs.Address)
+	lock, err := repository.R().DelegationLock(&addr, (*hexutil.Big)(new(big.Int).SetUint64(vs.Id)))
+	if err != nil {
+		return false, "", err
+	}
+
+	until := time.Unix(int64(lock.LockedUntil), 0).UTC()
+	if lock.LockedUntil == 0 || !until.After(now) {
+		return false, "", nil
+	}
+	return until.Sub(now) <= threshold, fmt.Sprintf("validator #%d self-stake lock expires at %s",
+		vs.Id, until.Format(time.RFC3339)), nil
+}
+
+// isKnownRuleType checks if the given rule type is supported.
+func isKnownRuleType(t string) bool {
+	switch t {
+	case types.AlertTypeOffline, types.AlertTypeDowntime, types.AlertTypeNotVoting,
+		types.AlertTypeCheater, types.AlertTypeSelfStake, types.AlertTypeLockExpiring:
+		return true
+	}
+	return false
+}
+
+// containsValidator checks if the validator is on the given list; an empty list contains all validators.
+func containsValidator(list []uint64, id uint64) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenAmount formats the given amount of WEI units as an amount of tokens.
+func tokenAmount(amo *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(amo), big.NewFloat(tokenDecimalsUnit)).Text('f', 2)
+}
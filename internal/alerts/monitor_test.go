@@ -0,0 +1,54 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEvaluateStatus(t *testing.T) {
+	vs := &types.ValidatorStatus{Id: 5, IsActive: true, DownTime: types.Dt{Time: 120, Blocks: 10}}
+
+	tests := []struct {
+		rule   config.AlertRule
+		raised bool
+	}{
+		{config.AlertRule{Type: types.AlertTypeOffline}, false},
+		{config.AlertRule{Type: types.AlertTypeDowntime, Threshold: 60}, true},
+		{config.AlertRule{Type: types.AlertTypeDowntime, Threshold: 300}, false},
+		{config.AlertRule{Type: types.AlertTypeNotVoting}, true},
+		{config.AlertRule{Type: types.AlertTypeCheater}, false},
+	}
+
+	for _, tc := range tests {
+		raised, msg := evaluateStatus(&tc.rule, vs)
+		if raised != tc.raised {
+			t.Errorf("rule %s threshold %d; expected %t, got %t", tc.rule.Type, tc.rule.Threshold, tc.raised, raised)
+		}
+		if !strings.Contains(msg, "#5") {
+			t.Errorf("rule %s; unexpected message %q", tc.rule.Type, msg)
+		}
+	}
+}
+
+func TestPayload(t *testing.T) {
+	va := &types.ValidatorAlert{Id: "offline-5-1", ValidatorId: 5, Rule: "offline", Type: types.AlertTypeOffline, Message: "validator #5 is offline", Raised: time.Unix(1, 0).UTC()}
+
+	for format, field := range map[string]string{FormatJSON: "event", FormatSlack: "text", FormatDiscord: "content"} {
+		data, err := payload(format, EventRaised, va)
+		if err != nil {
+			t.Fatalf("format %s; %s", format, err.Error())
+		}
+
+		var out map[string]interface{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("format %s; %s", format, err.Error())
+		}
+		if _, ok := out[field]; !ok {
+			t.Errorf("format %s; field %s missing in %s", format, field, string(data))
+		}
+	}
+}
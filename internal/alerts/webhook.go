@@ -0,0 +1,225 @@
+// Package alerts implements validator alert rules evaluation and webhook notifications.
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// EventRaised represents a notification of a newly raised alert.
+	EventRaised = "raised"
+
+	// EventRepeated represents a repeated notification of an alert still active.
+	EventRepeated = "repeated"
+
+	// EventResolved represents a recovery notification of an alert no longer valid.
+	EventResolved = "resolved"
+)
+
+const (
+	// FormatJSON represents a generic JSON webhook payload.
+	FormatJSON = "json"
+
+	// FormatSlack represents a Slack compatible webhook payload.
+	FormatSlack = "slack"
+
+	// FormatDiscord represents a Discord compatible webhook payload.
+	FormatDiscord = "discord"
+)
+
+const (
+	// deliveryQueueCapacity represents the capacity of the notification delivery queue.
+	deliveryQueueCapacity = 500
+
+	// deliveryTimeout represents the timeout of a single webhook request.
+	deliveryTimeout = 10 * time.Second
+)
+
+// delivery represents a single notification to be delivered to a target.
+type delivery struct {
+	target *config.AlertTarget
+	event  string
+	alert  types.ValidatorAlert
+}
+
+// dispatcher delivers alert notifications to configured webhook targets.
+type dispatcher struct {
+	cfg     *config.Alerts
+	log     logger.Logger
+	client  *http.Client
+	queue   chan *delivery
+	sigStop chan struct{}
+	wg      sync.WaitGroup
+}
+
+// jsonPayload represents the generic JSON webhook payload.
+type jsonPayload struct {
+	Event       string     `json:"event"`
+	Id          string     `json:"id"`
+	Rule        string     `json:"rule"`
+	Type        string     `json:"type"`
+	ValidatorId uint64     `json:"validatorId"`
+	Address     string     `json:"address"`
+	Message     string     `json:"message"`
+	Raised      time.Time  `json:"raised"`
+	Resolved    *time.Time `json:"resolved,omitempty"`
+}
+
+// newDispatcher creates a new notification dispatcher and starts its delivery loop.
+func newDispatcher(cfg *config.Alerts, log logger.Logger) *dispatcher {
+	d := &dispatcher{
+		cfg:     cfg,
+		log:     log,
+		client:  &http.Client{Timeout: deliveryTimeout},
+		queue:   make(chan *delivery, deliveryQueueCapacity),
+		sigStop: make(chan struct{}),
+	}
+
+	d.wg.Add(1)
+	go d.run()
+	return d
+}
+
+// close terminates the delivery loop; pending notifications are dropped.
+func (d *dispatcher) close() {
+	close(d.sigStop)
+	d.wg.Wait()
+}
+
+// notify queues the given alert event for delivery to all the targets subscribed to it.
+func (d *dispatcher) notify(event string, va *types.ValidatorAlert) {
+	for i := range d.cfg.Targets {
+		t := &d.cfg.Targets[i]
+		if !containsRule(t.Rules, va.Rule) || !containsValidator(t.Validators, va.ValidatorId) {
+			continue
+		}
+
+		select {
+		case d.queue <- &delivery{target: t, event: event, alert: *va}:
+		default:
+			d.log.Errorf("alert delivery queue full; %s of %s to %s dropped", event, va.Id, t.Name)
+		}
+	}
+}
+
+// run delivers queued notifications until terminated.
+func (d *dispatcher) run() {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.sigStop:
+			return
+		case dl := <-d.queue:
+			d.deliver(dl)
+		}
+	}
+}
+
+// deliver sends the notification to its target with retries on failure.
+// The delay between attempts doubles with each retry.
+func (d *dispatcher) deliver(dl *delivery) {
+	body, err := payload(dl.target.Format, dl.event, &dl.alert)
+	if err != nil {
+		d.log.Errorf("can not encode alert %s for %s; %s", dl.alert.Id, dl.target.Name, err.Error())
+		return
+	}
+
+	delay := d.cfg.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := d.send(dl.target.Url, body)
+		if err == nil {
+			d.log.Debugf("alert %s %s delivered to %s", dl.alert.Id, dl.event, dl.target.Name)
+			return
+		}
+
+		if !retry || attempt >= d.cfg.Retries {
+			d.log.Errorf("alert %s %s delivery to %s failed; %s", dl.alert.Id, dl.event, dl.target.Name, err.Error())
+			return
+		}
+
+		d.log.Warningf("alert %s delivery to %s failed, retrying in %s; %s", dl.alert.Id, dl.target.Name, delay, err.Error())
+		select {
+		case <-d.sigStop:
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// send posts the payload to the target URL. It signals if the failed request should be retried.
+func (d *dispatcher) send(url string, body []byte) (bool, error) {
+	res, err := d.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return true, err
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	// client errors will not be fixed by repeating the same request
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected response status %d", res.StatusCode)
+}
+
+// payload encodes the alert event in the given webhook format.
+func payload(format string, event string, va *types.ValidatorAlert) ([]byte, error) {
+	switch format {
+	case FormatSlack:
+		return json.Marshal(struct {
+			Text string `json:"text"`
+		}{Text: text(event, va)})
+	case FormatDiscord:
+		return json.Marshal(struct {
+			Content string `json:"content"`
+		}{Content: text(event, va)})
+	default:
+		return json.Marshal(jsonPayload{
+			Event:       event,
+			Id:          va.Id,
+			Rule:        va.Rule,
+			Type:        va.Type,
+			ValidatorId: va.ValidatorId,
+			Address:     va.Address,
+			Message:     va.Message,
+			Raised:      va.Raised,
+			Resolved:    va.Resolved,
+		})
+	}
+}
+
+// text builds a human-readable message of the alert event for chat webhooks.
+func text(event string, va *types.ValidatorAlert) string {
+	switch event {
+	case EventResolved:
+		return fmt.Sprintf("[RESOLVED] %s: %s; active since %s", va.Rule, va.Message, va.Raised.Format(time.RFC3339))
+	case EventRepeated:
+		return fmt.Sprintf("[STILL ACTIVE] %s: %s; active since %s", va.Rule, va.Message, va.Raised.Format(time.RFC3339))
+	default:
+		return fmt.Sprintf("[ALERT] %s: %s", va.Rule, va.Message)
+	}
+}
+
+// containsRule checks if the rule is on the given list; an empty list contains all rules.
+func containsRule(list []string, rule string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, r := range list {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -50,6 +50,24 @@ type Config struct {
 	// Governance configuration
 	Governance Governance `mapstructure:"governance"`
 
+	// Alerts configuration
+	Alerts Alerts `mapstructure:"alerts"`
+
+	// Limits represents the API queries cost and rate limiting configuration
+	Limits Limits `mapstructure:"limits"`
+
+	// Queries represents the persisted queries and HTTP caching configuration
+	Queries Queries `mapstructure:"queries"`
+
+	// Tracing represents the distributed tracing configuration
+	Tracing Tracing `mapstructure:"tracing"`
+
+	// Subscriptions represents the GraphQL subscriptions transports configuration
+	Subscriptions Subscriptions `mapstructure:"subscriptions"`
+
+	// Admin represents the runtime administration API configuration
+	Admin Admin `mapstructure:"admin"`
+
 	// TokenLogoFilePath contains the path to JSON file with the map
 	// of known ERC20 tokens to their logo URLs.
 	// The file will be loaded on configuration loading.
@@ -81,6 +99,11 @@ type Server struct {
 	IdleTimeout     int64    `mapstructure:"idle_timeout"`
 	HeaderTimeout   int64    `mapstructure:"header_timeout"`
 	ResolverTimeout int64    `mapstructure:"resolver_timeout"`
+
+	// metrics listener; empty bind address disables the metrics server
+	MetricsBind     string `mapstructure:"metrics_bind"`
+	MetricsUser     string `mapstructure:"metrics_user"`
+	MetricsPassword string `mapstructure:"metrics_password"`
 }
 
 // ServerSignature represents the signature used by this server
@@ -94,19 +117,59 @@ type ServerSignature struct {
 type Log struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// Encoding represents the records encoding, "text" or "json".
+	Encoding string `mapstructure:"encoding"`
+
+	// Output represents the records destination, "stderr", "file", or "syslog".
+	Output    string      `mapstructure:"output"`
+	File      LogFile     `mapstructure:"file"`
+	SyslogTag string      `mapstructure:"syslog_tag"`
+	Modules   []LogModule `mapstructure:"modules"`
+}
+
+// LogFile represents the configuration of the rotated log file output.
+type LogFile struct {
+	Path       string `mapstructure:"path"`
+	MaxSize    int    `mapstructure:"max_size"`
+	MaxBackups int    `mapstructure:"max_backups"`
+	MaxAge     int    `mapstructure:"max_age"`
+	Compress   bool   `mapstructure:"compress"`
+}
+
+// LogModule represents the logging level override of a single module,
+// i.e. "svc", "repository/rpc", "repository/db", or "handlers".
+type LogModule struct {
+	Name  string `mapstructure:"name"`
+	Level string `mapstructure:"level"`
 }
 
-// OperaNode represents the Opera network node access configuration
+// OperaNode represents the Opera network node access configuration.
+// If endpoints are configured, the API uses the pool of the endpoints instead of the single node URL;
+// the health interval is the time between health probes in seconds, the max block lag is the number
+// of blocks an endpoint can fall behind the best known head before it's considered unhealthy.
 type OperaNode struct {
-	ApiNodeUrl        string `mapstructure:"url"`
-	ApiHealthCheckUrl string `mapstructure:"health_check_url"`
-	BlockDiff         int64  `mapstructure:"block_diff"`
+	ApiNodeUrl        string          `mapstructure:"url"`
+	ApiHealthCheckUrl string          `mapstructure:"health_check_url"`
+	BlockDiff         int64           `mapstructure:"block_diff"`
+	Endpoints         []OperaEndpoint `mapstructure:"endpoints"`
+	HealthInterval    int64           `mapstructure:"health_interval"`
+	MaxBlockLag       uint64          `mapstructure:"max_block_lag"`
+}
+
+// OperaEndpoint represents a single node endpoint of the pool. Each endpoint serves regular calls;
+// the roles mark endpoints able to serve historical state ("archive"), tracing calls ("tracing"),
+// and new heads subscription ("head").
+type OperaEndpoint struct {
+	Url   string   `mapstructure:"url"`
+	Roles []string `mapstructure:"roles"`
 }
 
 // PeerNetworking defines configuration for Opera p2p protocol.
 type PeerNetworking struct {
 	DiscoveryUDP   string        `mapstructure:"bind_udp"`
 	GeoIPPath      string        `mapstructure:"geo_db"`
+	GeoASNPath     string        `mapstructure:"geo_asn_db"`
 	BootstrapNodes []*enode.Node `mapstructure:"bootstrap"`
 }
 
@@ -143,10 +206,11 @@ type Staking struct {
 
 // DeFi represents the DeFi and financial contracts configuration.
 type DeFi struct {
-	FMint        DeFiFMint   `mapstructure:"fmint"`
-	Uniswap      DeFiUniswap `mapstructure:"uniswap"`
-	FLend        DeFiFLend   `mapstructure:"flend"`
-	PriceSymbols []string    `mapstructure:"symbols"`
+	FMint        DeFiFMint     `mapstructure:"fmint"`
+	Uniswap      DeFiUniswap   `mapstructure:"uniswap"`
+	UniswapV3    DeFiUniswapV3 `mapstructure:"uniswap_v3"`
+	FLend        DeFiFLend     `mapstructure:"flend"`
+	PriceSymbols []string      `mapstructure:"symbols"`
 }
 
 // DeFiFMint represents the fMint DeFi module configuration.
@@ -161,6 +225,12 @@ type DeFiUniswap struct {
 	PairsWhiteList []common.Address `mapstructure:"whitelist"`
 }
 
+// DeFiUniswapV3 represents the concentrated liquidity (Uniswap v3 style) DeFi module configuration.
+type DeFiUniswapV3 struct {
+	Factory         common.Address `mapstructure:"factory"`
+	PositionManager common.Address `mapstructure:"positions"`
+}
+
 // Governance represents the governance module configuration.
 type Governance struct {
 	Contracts []GovernanceContract `mapstructure:"contracts"`
@@ -179,3 +249,115 @@ type GovernanceContract struct {
 type DeFiFLend struct {
 	LendingPool common.Address `mapstructure:"lending_pool"`
 }
+
+// Alerts represents the validator alerting configuration.
+type Alerts struct {
+	Rules      []AlertRule   `mapstructure:"rules"`
+	Targets    []AlertTarget `mapstructure:"targets"`
+	Retries    int           `mapstructure:"retries"`
+	RetryDelay time.Duration `mapstructure:"retry_delay"`
+	Repeat     time.Duration `mapstructure:"repeat"`
+}
+
+// AlertRule represents a single validator alert rule configuration.
+// The threshold is denominated in seconds for downtime and lock expiration rules
+// and in whole tokens for the self-stake rule.
+type AlertRule struct {
+	Name       string   `mapstructure:"name"`
+	Type       string   `mapstructure:"type"`
+	Threshold  int64    `mapstructure:"threshold"`
+	Validators []uint64 `mapstructure:"validators"`
+}
+
+// AlertTarget represents a webhook receiving validator alerts.
+// Empty list of rules, or validators means the target receives all of them.
+type AlertTarget struct {
+	Name       string   `mapstructure:"name"`
+	Url        string   `mapstructure:"url"`
+	Format     string   `mapstructure:"format"`
+	Rules      []string `mapstructure:"rules"`
+	Validators []uint64 `mapstructure:"validators"`
+}
+
+// Limits represents the API queries cost and rate limiting configuration.
+// The rate limiting is disabled if no tiers are configured.
+type Limits struct {
+	MaxDepth    int           `mapstructure:"max_depth"`
+	MaxCost     int           `mapstructure:"max_cost"`
+	ListSize    int           `mapstructure:"list_size"`
+	MaxListSize int           `mapstructure:"max_list_size"`
+	MaxNodes    int           `mapstructure:"max_nodes"`
+	Weights     []LimitWeight `mapstructure:"weights"`
+	KeyHeader   string        `mapstructure:"key_header"`
+	TrustProxy  bool          `mapstructure:"trust_proxy"`
+	DefaultTier string        `mapstructure:"default_tier"`
+	Tiers       []LimitTier   `mapstructure:"tiers"`
+	Clients     []LimitClient `mapstructure:"clients"`
+}
+
+// LimitWeight represents the cost of a field identified by "Type.field" name.
+type LimitWeight struct {
+	Field  string `mapstructure:"field"`
+	Weight int    `mapstructure:"weight"`
+}
+
+// LimitTier represents a rate limiting tier; the rate is denominated in query cost units per second
+// and the burst is the max cost the client can spend at once. Zero max cost or depth means the global limit applies.
+type LimitTier struct {
+	Name     string  `mapstructure:"name"`
+	Rate     float64 `mapstructure:"rate"`
+	Burst    int     `mapstructure:"burst"`
+	MaxCost  int     `mapstructure:"max_cost"`
+	MaxDepth int     `mapstructure:"max_depth"`
+}
+
+// LimitClient represents an API key assigned to a rate limiting tier.
+type LimitClient struct {
+	Key  string `mapstructure:"key"`
+	Tier string `mapstructure:"tier"`
+}
+
+// Queries represents the persisted queries and HTTP response caching configuration.
+// The allowlist file contains a JSON map of sha256 query hashes to the query documents.
+// The cache memory is the memory limit of the automatically persisted queries in MB.
+// The response cache size is the memory limit of the cached responses in MB, zero disables the cache.
+type Queries struct {
+	CacheSize          int    `mapstructure:"cache_size"`
+	CacheMemory        int    `mapstructure:"cache_memory"`
+	AllowlistOnly      bool   `mapstructure:"allowlist_only"`
+	AllowlistFile      string `mapstructure:"allowlist"`
+	HeadMaxAge         int64  `mapstructure:"head_max_age"`
+	FinalMaxAge        int64  `mapstructure:"final_max_age"`
+	ResponseCacheSize  int    `mapstructure:"response_cache_size"`
+	FinalConfirmations uint64 `mapstructure:"final_confirmations"`
+}
+
+// Tracing represents the OpenTelemetry distributed tracing configuration.
+// The endpoint is the host:port of an OTLP/HTTP traces collector; the sample ratio
+// is the share of new traces recorded, incoming traces follow the sampling decision of the caller.
+type Tracing struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Endpoint    string  `mapstructure:"endpoint"`
+	Insecure    bool    `mapstructure:"insecure"`
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// Subscriptions represents the configuration of GraphQL subscriptions over WebSocket and SSE transports.
+// The keep alive is the ping interval in seconds, the init timeout is the time in seconds a new WebSocket
+// connection has to be initialized in. If the key is required, clients have to present a known API key
+// in the connection parameters to subscribe.
+type Subscriptions struct {
+	KeepAlive     int64 `mapstructure:"keep_alive"`
+	InitTimeout   int64 `mapstructure:"init_timeout"`
+	MaxOperations int   `mapstructure:"max_operations"`
+	RequireKey    bool  `mapstructure:"require_key"`
+}
+
+// Admin represents the configuration of the runtime administration API. The API is served
+// on its own listener and it's disabled unless both the bind address and the password are set;
+// clients authenticate by HTTP basic auth.
+type Admin struct {
+	BindAddress string `mapstructure:"bind"`
+	User        string `mapstructure:"user"`
+	Password    string `mapstructure:"password"`
+}
@@ -30,6 +30,9 @@ const (
 	defHeaderTimeout   = 1
 	defResolverTimeout = 30
 
+	// defMetricsBind holds default metrics server binding address
+	defMetricsBind = ":2112"
+
 	// defServerDomain holds default API server domain address
 	defServerDomain = "localhost:16761"
 
@@ -41,9 +44,30 @@ const (
 	// defLoggingFormat holds default format of the Logger output
 	defLoggingFormat = "%{color}%{level:-8s} %{shortpkg}/%{shortfunc}%{color:reset}: %{message}"
 
+	// defLoggingEncoding holds default encoding of the Logger records
+	defLoggingEncoding = "text"
+
+	// defLoggingOutput holds default destination of the Logger records
+	defLoggingOutput = "stderr"
+
+	// defLoggingFilePath holds default path of the log file, if the file output is used
+	defLoggingFilePath = "/var/log/apiserver/apiserver.log"
+
+	// defLoggingFileMaxSize holds default size of the log file in MB before it's rotated
+	defLoggingFileMaxSize = 100
+
+	// defLoggingFileMaxAge holds default number of days the rotated log files are kept
+	defLoggingFileMaxAge = 30
+
 	// defLachesisUrl holds default Opera network connection string
 	defLachesisUrl = "~/.lachesis/data/lachesis.ipc"
 
+	// defNodeHealthInterval holds default time between health probes of node endpoints in seconds
+	defNodeHealthInterval = 5
+
+	// defNodeMaxBlockLag holds default number of blocks a node endpoint can fall behind the head
+	defNodeMaxBlockLag = 10
+
 	// defMongoUrl holds default MongoDB connection string
 	defMongoUrl = "mongodb://localhost:27017"
 
@@ -82,6 +106,70 @@ const (
 
 	// defBlockScanRescanDepth represents the amount of blocks re-scanned on server start
 	defBlockScanRescanDepth = 200
+
+	// defAlertsRetries represents the default number of alert delivery retries
+	defAlertsRetries = 3
+
+	// defAlertsRetryDelay represents the default delay before the first alert delivery retry
+	defAlertsRetryDelay = 10 * time.Second
+
+	// defLimitsMaxDepth represents the default max depth of an API query
+	defLimitsMaxDepth = 15
+
+	// defLimitsMaxCost represents the default max static cost of an API query
+	defLimitsMaxCost = 25000
+
+	// defLimitsListSize represents the expected size of lists without the count argument
+	defLimitsListSize = 10
+
+	// defLimitsMaxListSize represents the max number of list edges served per request
+	defLimitsMaxListSize = 250
+
+	// defLimitsMaxNodes represents the max number of selections and fragment spreads of an API query analyzed
+	defLimitsMaxNodes = 10000
+
+	// defLimitsKeyHeader represents the default name of the HTTP header carrying the client API key
+	defLimitsKeyHeader = "X-Api-Key"
+
+	// defQueriesCacheSize represents the default max number of automatically persisted queries kept
+	defQueriesCacheSize = 10000
+
+	// defQueriesCacheMemory represents the default memory limit of automatically persisted queries in MB
+	defQueriesCacheMemory = 16
+
+	// defQueriesHeadMaxAge represents the default max age in seconds of responses depending on the chain head
+	defQueriesHeadMaxAge = 2
+
+	// defQueriesFinalMaxAge represents the default max age in seconds of responses with finalized data only
+	defQueriesFinalMaxAge = 31536000
+
+	// defQueriesResponseCacheSize represents the default memory limit of the GraphQL response cache in MB
+	defQueriesResponseCacheSize = 64
+
+	// defQueriesFinalConfirmations represents the default number of blocks below the head
+	// for a block to be considered final
+	defQueriesFinalConfirmations = 5
+
+	// defTracingEndpoint represents the default address of the OTLP/HTTP traces collector
+	defTracingEndpoint = "localhost:4318"
+
+	// defTracingSampleRatio represents the default share of new traces being recorded
+	defTracingSampleRatio = 0.1
+
+	// defSubscriptionsKeepAlive represents the default ping interval of subscription connections in seconds
+	defSubscriptionsKeepAlive = 15
+
+	// defSubscriptionsInitTimeout represents the default time in seconds a WebSocket connection has to be initialized in
+	defSubscriptionsInitTimeout = 10
+
+	// defSubscriptionsMaxOperations represents the default max number of operations running on a single connection
+	defSubscriptionsMaxOperations = 50
+
+	// defAdminBind holds default administration API binding address, it's local only
+	defAdminBind = "127.0.0.1:2113"
+
+	// defAdminUser holds default administration API user name
+	defAdminUser = "admin"
 )
 
 // default list of API peers
@@ -104,11 +192,19 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyAppName, defApplicationName)
 	cfg.SetDefault(keyBindAddress, defServerBind)
 	cfg.SetDefault(keyDomainAddress, defServerDomain)
+	cfg.SetDefault(keyMetricsBind, defMetricsBind)
 	cfg.SetDefault(keySignatureAddress, defSelfAddress)
 	cfg.SetDefault(keySignaturePrivateKey, defSelfPrivateKey)
 	cfg.SetDefault(keyLoggingLevel, defLoggingLevel)
 	cfg.SetDefault(keyLoggingFormat, defLoggingFormat)
+	cfg.SetDefault(keyLoggingEncoding, defLoggingEncoding)
+	cfg.SetDefault(keyLoggingOutput, defLoggingOutput)
+	cfg.SetDefault(keyLoggingFilePath, defLoggingFilePath)
+	cfg.SetDefault(keyLoggingFileMaxSize, defLoggingFileMaxSize)
+	cfg.SetDefault(keyLoggingFileMaxAge, defLoggingFileMaxAge)
 	cfg.SetDefault(keyLachesisUrl, defLachesisUrl)
+	cfg.SetDefault(keyNodeHealthInterval, defNodeHealthInterval)
+	cfg.SetDefault(keyNodeMaxBlockLag, defNodeMaxBlockLag)
 	cfg.SetDefault(keyMongoUrl, defMongoUrl)
 	cfg.SetDefault(keyMongoDatabase, defMongoDatabase)
 	cfg.SetDefault(keySolCompilerPath, defSolCompilerPath)
@@ -144,7 +240,42 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyDefiFMintAddressProvider, defDefiFMintAddressProvider)
 	cfg.SetDefault(keyDefiUniswapCore, defDefiUniswapCore)
 	cfg.SetDefault(keyDefiUniswapRouter, defDefiUniswapRouter)
+	cfg.SetDefault(keyDefiUniswapV3Factory, EmptyAddress)
+	cfg.SetDefault(keyDefiUniswapV3Positions, EmptyAddress)
 
 	// P2P defaults
 	cfg.SetDefault(keyP2PBindUDP, "0.0.0.0:19173")
+
+	// validator alerts delivery
+	cfg.SetDefault(keyAlertsRetries, defAlertsRetries)
+	cfg.SetDefault(keyAlertsRetryDelay, defAlertsRetryDelay)
+
+	// query cost and rate limits
+	cfg.SetDefault(keyLimitsMaxDepth, defLimitsMaxDepth)
+	cfg.SetDefault(keyLimitsMaxCost, defLimitsMaxCost)
+	cfg.SetDefault(keyLimitsListSize, defLimitsListSize)
+	cfg.SetDefault(keyLimitsMaxListSize, defLimitsMaxListSize)
+	cfg.SetDefault(keyLimitsMaxNodes, defLimitsMaxNodes)
+	cfg.SetDefault(keyLimitsKeyHeader, defLimitsKeyHeader)
+
+	// persisted queries and response caching
+	cfg.SetDefault(keyQueriesCacheSize, defQueriesCacheSize)
+	cfg.SetDefault(keyQueriesCacheMemory, defQueriesCacheMemory)
+	cfg.SetDefault(keyQueriesHeadMaxAge, defQueriesHeadMaxAge)
+	cfg.SetDefault(keyQueriesFinalMaxAge, defQueriesFinalMaxAge)
+	cfg.SetDefault(keyQueriesResponseCacheSize, defQueriesResponseCacheSize)
+	cfg.SetDefault(keyQueriesFinalConfirmations, defQueriesFinalConfirmations)
+
+	// distributed tracing
+	cfg.SetDefault(keyTracingEndpoint, defTracingEndpoint)
+	cfg.SetDefault(keyTracingSampleRatio, defTracingSampleRatio)
+
+	// subscriptions transports
+	cfg.SetDefault(keySubscriptionsKeepAlive, defSubscriptionsKeepAlive)
+	cfg.SetDefault(keySubscriptionsInitTimeout, defSubscriptionsInitTimeout)
+	cfg.SetDefault(keySubscriptionsMaxOperations, defSubscriptionsMaxOperations)
+
+	// runtime administration
+	cfg.SetDefault(keyAdminBind, defAdminBind)
+	cfg.SetDefault(keyAdminUser, defAdminUser)
 }
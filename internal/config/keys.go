@@ -19,6 +19,7 @@ const (
 	keyApiPeers         = "server.peers"
 	keyApiStateOrigin   = "server.origin"
 	keyCorsAllowOrigins = "server.cors_origins"
+	keyMetricsBind      = "server.metrics_bind"
 
 	// server time out related keys
 	keyTimeoutRead     = "server.read_timeout"
@@ -32,12 +33,21 @@ const (
 	keySignaturePrivateKey = "me.pkey"
 
 	// logging related options
-	keyLoggingLevel  = "log.level"
-	keyLoggingFormat = "log.format"
+	keyLoggingLevel       = "log.level"
+	keyLoggingFormat      = "log.format"
+	keyLoggingEncoding    = "log.encoding"
+	keyLoggingOutput      = "log.output"
+	keyLoggingFilePath    = "log.file.path"
+	keyLoggingFileMaxSize = "log.file.max_size"
+	keyLoggingFileMaxAge  = "log.file.max_age"
 
 	// node connection related options
 	keyLachesisUrl = "lachesis.url"
 
+	// node endpoints pool options
+	keyNodeHealthInterval = "node.health_interval"
+	keyNodeMaxBlockLag    = "node.max_block_lag"
+
 	// off-chain database related options
 	keyMongoUrl      = "db.url"
 	keyMongoDatabase = "db.db"
@@ -64,6 +74,41 @@ const (
 	keyDefiFMintAddressProvider = "defi.fmint.address_provider"
 	keyDefiUniswapCore          = "defi.uniswap.core"
 	keyDefiUniswapRouter        = "defi.uniswap.router"
+	keyDefiUniswapV3Factory     = "defi.uniswap_v3.factory"
+	keyDefiUniswapV3Positions   = "defi.uniswap_v3.positions"
 
 	keyP2PBindUDP = "p2p.bind_udp"
+
+	// validator alerts
+	keyAlertsRetries    = "alerts.retries"
+	keyAlertsRetryDelay = "alerts.retry_delay"
+
+	// query cost and rate limits
+	keyLimitsMaxDepth    = "limits.max_depth"
+	keyLimitsMaxCost     = "limits.max_cost"
+	keyLimitsListSize    = "limits.list_size"
+	keyLimitsMaxListSize = "limits.max_list_size"
+	keyLimitsMaxNodes    = "limits.max_nodes"
+	keyLimitsKeyHeader   = "limits.key_header"
+
+	// persisted queries and response caching
+	keyQueriesCacheSize          = "queries.cache_size"
+	keyQueriesCacheMemory        = "queries.cache_memory"
+	keyQueriesHeadMaxAge         = "queries.head_max_age"
+	keyQueriesFinalMaxAge        = "queries.final_max_age"
+	keyQueriesResponseCacheSize  = "queries.response_cache_size"
+	keyQueriesFinalConfirmations = "queries.final_confirmations"
+
+	// distributed tracing
+	keyTracingEndpoint    = "tracing.endpoint"
+	keyTracingSampleRatio = "tracing.sample_ratio"
+
+	// subscriptions transports
+	keySubscriptionsKeepAlive     = "subscriptions.keep_alive"
+	keySubscriptionsInitTimeout   = "subscriptions.init_timeout"
+	keySubscriptionsMaxOperations = "subscriptions.max_operations"
+
+	// runtime administration
+	keyAdminBind = "admin.bind"
+	keyAdminUser = "admin.user"
 )
@@ -79,6 +79,8 @@ func readConfigFile() (*viper.Viper, error) {
 		log.Print("configuration file not found, using default values")
 	}
 
+	// remember the file for reloading
+	setConfigFile(cfg.ConfigFileUsed())
 	return cfg, nil
 }
 
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"net/url"
+)
+
+// redactedValue replaces secrets in the effective configuration.
+const redactedValue = "<redacted>"
+
+// Effective provides a copy of the configuration in effect with secrets redacted,
+// so it can be inspected by administrators. Credentials and paths of URLs are removed,
+// the URLs of node providers and webhooks often carry access tokens.
+func Effective(cfg *Config) Config {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+
+	c := *cfg
+	c.Signature.PrivateKey = nil
+	c.Server.MetricsPassword = redact(c.Server.MetricsPassword)
+	c.Admin.Password = redact(c.Admin.Password)
+	c.Db.Url = redactURL(c.Db.Url)
+	c.Opera.ApiNodeUrl = redactURL(c.Opera.ApiNodeUrl)
+	c.Opera.ApiHealthCheckUrl = redactURL(c.Opera.ApiHealthCheckUrl)
+
+	c.Opera.Endpoints = append([]OperaEndpoint(nil), cfg.Opera.Endpoints...)
+	for i := range c.Opera.Endpoints {
+		c.Opera.Endpoints[i].Url = redactURL(c.Opera.Endpoints[i].Url)
+	}
+
+	c.Alerts.Targets = append([]AlertTarget(nil), cfg.Alerts.Targets...)
+	for i := range c.Alerts.Targets {
+		c.Alerts.Targets[i].Url = redactURL(c.Alerts.Targets[i].Url)
+	}
+
+	c.Limits.Clients = append([]LimitClient(nil), cfg.Limits.Clients...)
+	for i := range c.Limits.Clients {
+		c.Limits.Clients[i].Key = redact(c.Limits.Clients[i].Key)
+	}
+	return c
+}
+
+// redact hides the given secret, if any.
+func redact(s string) string {
+	if s == "" {
+		return s
+	}
+	return redactedValue
+}
+
+// redactURL removes credentials, path and query of the given URL; local paths are kept.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return redact(s)
+	}
+	if u.Host == "" {
+		return s
+	}
+	return u.Scheme + "://" + u.Host
+}
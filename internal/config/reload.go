@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"log"
+	"reflect"
+	"sync"
+)
+
+var (
+	// reloadLock guards the reloadable subset of the configuration.
+	reloadLock sync.RWMutex
+
+	// configFile holds the path of the loaded configuration file, if any.
+	configFile string
+
+	// listeners hold the functions notified about reloaded configuration.
+	listeners   []func(*Config)
+	listenersMu sync.Mutex
+)
+
+// setConfigFile remembers the path of the loaded configuration file.
+func setConfigFile(path string) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	configFile = path
+}
+
+// File provides the path of the loaded configuration file; it's empty
+// if the configuration was not loaded from a file.
+func File() string {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+	return configFile
+}
+
+// OnReload registers a function to be called after the configuration is reloaded.
+// The function is expected to pick the new values of the reloadable options it uses.
+func OnReload(fn func(*Config)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// Reload reads the configuration file again and applies the reloadable subset of options
+// to the given configuration; these are the CORS origins, log levels, ERC20 token logos,
+// governance contracts and Uniswap pairs whitelist. Other options need the server to be
+// restarted, names of the changed sections are returned so the caller can report them.
+func Reload(cfg *Config) ([]string, error) {
+	path := File()
+	if path == "" {
+		return nil, fmt.Errorf("configuration was not loaded from a file")
+	}
+
+	// read the file the same way it was loaded
+	v := viper.New()
+	v.SetConfigFile(path)
+	applyDefaults(v)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	var loaded Config
+	if err := v.Unmarshal(&loaded, setupConfigUnmarshaler); err != nil {
+		return nil, err
+	}
+	loadErc20LogMap(&loaded)
+
+	restart := apply(cfg, &loaded)
+
+	// notify the listeners outside the lock, so they can read the new values
+	listenersMu.Lock()
+	ls := append([]func(*Config){}, listeners...)
+	listenersMu.Unlock()
+
+	for _, fn := range ls {
+		fn(cfg)
+	}
+
+	log.Printf("configuration reloaded from %s", path)
+	return restart, nil
+}
+
+// apply copies the reloadable options of the loaded configuration into the live one.
+// It returns the names of the other sections which differ between the two.
+func apply(cfg *Config, loaded *Config) []string {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+
+	cfg.Server.CorsOrigin = loaded.Server.CorsOrigin
+	cfg.Log.Level = loaded.Log.Level
+	cfg.Log.Modules = loaded.Log.Modules
+	cfg.TokenLogoFilePath = loaded.TokenLogoFilePath
+	cfg.TokenLogo = loaded.TokenLogo
+	cfg.Governance.Contracts = loaded.Governance.Contracts
+	cfg.DeFi.Uniswap.PairsWhiteList = loaded.DeFi.Uniswap.PairsWhiteList
+
+	// command line options are not part of the file
+	loaded.RepoCommand = cfg.RepoCommand
+
+	restart := make([]string, 0)
+	cv, lv := reflect.ValueOf(cfg).Elem(), reflect.ValueOf(loaded).Elem()
+	for i := 0; i < cv.NumField(); i++ {
+		if !reflect.DeepEqual(cv.Field(i).Interface(), lv.Field(i).Interface()) {
+			name := cv.Type().Field(i).Tag.Get("mapstructure")
+			if name == "" {
+				name = cv.Type().Field(i).Name
+			}
+			restart = append(restart, name)
+		}
+	}
+	return restart
+}
+
+// AllowedOrigins provides the list of CORS origins allowed to access the API.
+func (s *Server) AllowedOrigins() []string {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+	return s.CorsOrigin
+}
+
+// Levels provides the default log level and the list of module level overrides.
+func (l *Log) Levels() (string, []LogModule) {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+	return l.Level, l.Modules
+}
+
+// TokenLogoOf provides the logo URL of the given ERC20 token, if known.
+func (cfg *Config) TokenLogoOf(addr *common.Address) (string, bool) {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+
+	logo, ok := cfg.TokenLogo[*addr]
+	return logo, ok
+}
+
+// ContractList provides the list of configured governance contracts.
+func (g *Governance) ContractList() []GovernanceContract {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+	return g.Contracts
+}
+
+// WhiteList provides the list of whitelisted Uniswap pairs.
+func (u *DeFiUniswap) WhiteList() []common.Address {
+	reloadLock.RLock()
+	defer reloadLock.RUnlock()
+	return u.PairsWhiteList
+}
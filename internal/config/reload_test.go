@@ -0,0 +1,55 @@
+package config
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	path := filepath.Join(t.TempDir(), "apiserver.json")
+	g.Expect(os.WriteFile(path, []byte(`{
+		"server": {"cors_origins": ["https://new.example.com"]},
+		"log": {"level": "DEBUG", "modules": [{"name": "svc", "level": "ERROR"}]},
+		"db": {"url": "mongodb://db.example.com:27017"}
+	}`), 0600)).To(gomega.Succeed())
+	setConfigFile(path)
+
+	cfg := Config{
+		Server:      Server{CorsOrigin: []string{"https://old.example.com"}},
+		Log:         Log{Level: "INFO"},
+		Db:          Database{Url: "mongodb://localhost:27017"},
+		RepoCommand: RepoCmd{BlockScanReScan: 10},
+	}
+
+	// load the defaults the same way the file does, so only the intended sections differ
+	v := viper.New()
+	applyDefaults(v)
+	g.Expect(v.Unmarshal(&cfg, setupConfigUnmarshaler)).To(gomega.Succeed())
+	cfg.Server.CorsOrigin = []string{"https://old.example.com"}
+	cfg.Db.Url = "mongodb://localhost:27017"
+
+	var notified *Config
+	OnReload(func(c *Config) { notified = c })
+
+	restart, err := Reload(&cfg)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(restart).To(gomega.ConsistOf("db"))
+	g.Expect(notified).To(gomega.BeIdenticalTo(&cfg))
+
+	// reloadable options are applied, others are kept until restart
+	g.Expect(cfg.Server.AllowedOrigins()).To(gomega.Equal([]string{"https://new.example.com"}))
+	lvl, modules := cfg.Log.Levels()
+	g.Expect(lvl).To(gomega.Equal("DEBUG"))
+	g.Expect(modules).To(gomega.Equal([]LogModule{{Name: "svc", Level: "ERROR"}}))
+	g.Expect(cfg.Db.Url).To(gomega.Equal("mongodb://localhost:27017"))
+	g.Expect(cfg.RepoCommand.BlockScanReScan).To(gomega.Equal(uint64(10)))
+
+	// secrets are not exposed
+	cfg.Admin.Password = "secret"
+	g.Expect(Effective(&cfg).Admin.Password).To(gomega.Equal(redactedValue))
+}
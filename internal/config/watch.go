@@ -0,0 +1,93 @@
+package config
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"log"
+	"path/filepath"
+	"time"
+)
+
+// watchSettleDelay represents the time the watched files have to stay unchanged before
+// the change is reported; editors and deployment tools often write files in several steps.
+const watchSettleDelay = time.Second
+
+// Watch observes the loaded configuration file and the ERC20 token logos file and calls
+// the given function when any of them changes. The returned function stops the observation.
+// Directories of the files are watched instead of the files, so replaced files are detected, too.
+func Watch(cfg *Config, onChange func()) (func(), error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]bool)
+	watch := func(path string) {
+		if path == "" {
+			return
+		}
+
+		path = filepath.Clean(path)
+		if files[path] {
+			return
+		}
+		if err := w.Add(filepath.Dir(path)); err != nil {
+			log.Printf("can not watch %s; %s", path, err.Error())
+			return
+		}
+		files[path] = true
+	}
+
+	watch(File())
+	reloadLock.RLock()
+	watch(cfg.TokenLogoFilePath)
+	reloadLock.RUnlock()
+
+	// the tokens file may move with the reloaded configuration
+	paths := make(chan string, 1)
+	OnReload(func(c *Config) {
+		reloadLock.RLock()
+		defer reloadLock.RUnlock()
+
+		select {
+		case paths <- c.TokenLogoFilePath:
+		default:
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		settle := time.NewTimer(watchSettleDelay)
+		settle.Stop()
+
+		for {
+			select {
+			case <-done:
+				settle.Stop()
+				return
+			case p := <-paths:
+				watch(p)
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if files[filepath.Clean(ev.Name)] && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+					settle.Reset(watchSettleDelay)
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("configuration watcher failed; %s", err.Error())
+			case <-settle.C:
+				onChange()
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		if err := w.Close(); err != nil {
+			log.Printf("can not close configuration watcher; %s", err.Error())
+		}
+	}, nil
+}
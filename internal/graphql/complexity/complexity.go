@@ -0,0 +1,420 @@
+// Package complexity implements static cost and depth analysis of incoming GraphQL queries.
+package complexity
+
+import (
+	"errors"
+	"fmt"
+	"github.com/graph-gophers/graphql-go/types"
+	"math"
+	"strings"
+)
+
+// CostDirective represents the name of the schema directive used to set the cost of a field,
+// i.e. `txList: [Transaction!]! @cost(weight: 10)`.
+const CostDirective = "cost"
+
+// listSizeArgument represents the name of the argument limiting the size of a list.
+const listSizeArgument = "count"
+
+// defaultMaxNodes represents the max number of selections and fragment spreads
+// visited by the analysis, if not configured.
+const defaultMaxNodes = 10000
+
+// maxCost represents the cost the analysis saturates at, so excessive queries don't overflow.
+const maxCost = math.MaxInt32
+
+// ErrTooManyNodes is returned if the query selects more fields and fragments than the analysis visits.
+var ErrTooManyNodes = errors.New("too many selections in the query")
+
+// Config represents the configuration of the query analyzer.
+type Config struct {
+	// Weights represent the cost of fields by "Type.field" key; overrides the schema directive.
+	Weights map[string]int
+
+	// ListSize represents the expected size of lists without the size argument.
+	ListSize int
+
+	// MaxListSize represents the max size of a list the API provides.
+	MaxListSize int
+
+	// MaxNodes represents the max number of selections and fragment spreads visited
+	// by the analysis; queries over the limit are rejected with ErrTooManyNodes.
+	MaxNodes int
+}
+
+// Result represents the result of a query analysis.
+type Result struct {
+	Cost  int
+	Depth int
+
+	// Operation represents the kind of the operation, i.e. query, mutation, or subscription.
+	Operation string
+
+	// Name represents the name of the operation, if any.
+	Name string
+
+	// Fields represents the set of "Type.field" coordinates selected by the operation.
+	Fields map[string]bool
+
+	// Roots represents the root fields selected by the operation.
+	Roots []Root
+}
+
+// Root represents a root field selected by an operation along with the names of its arguments.
+type Root struct {
+	Name string
+	Args map[string]bool
+}
+
+// Analyzer calculates the static cost and depth of GraphQL queries against the API schema.
+//
+// Each object resolved costs one unit, scalar fields are free, unless the weight is set
+// by the configuration, or by the cost directive in the schema. The cost of a list field is
+// multiplied by the number of items requested by the count argument; the count of a paginated
+// list applies to the first list found under it.
+type Analyzer struct {
+	schema  *types.Schema
+	cfg     Config
+	weights map[string]int
+}
+
+// New creates a new query analyzer for the given schema.
+func New(schema *types.Schema, cfg Config) *Analyzer {
+	an := Analyzer{
+		schema:  schema,
+		cfg:     cfg,
+		weights: make(map[string]int),
+	}
+	if an.cfg.MaxNodes <= 0 {
+		an.cfg.MaxNodes = defaultMaxNodes
+	}
+
+	// collect weights from the schema directives first, the configuration has a priority
+	for name, nt := range schema.Types {
+		for _, fd := range typeFields(nt) {
+			if w, ok := directiveWeight(fd); ok {
+				an.weights[name+"."+fd.Name] = w
+			}
+		}
+	}
+	for key, w := range cfg.Weights {
+		an.weights[key] = w
+	}
+	return &an
+}
+
+// Analyze calculates the cost and the depth of the given query operation.
+func (an *Analyzer) Analyze(query string, operationName string, variables map[string]interface{}) (*Result, error) {
+	doc, err := parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := findOperation(doc, operationName)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := an.schema.EntryPoints[op.kind]
+	if !ok {
+		return nil, fmt.Errorf("%s operation not supported", op.kind)
+	}
+
+	w := walker{
+		an:        an,
+		doc:       doc,
+		variables: variables,
+		defaults:  op.defaults,
+		visiting:  make(map[string]bool),
+		fragments: make(map[fragmentKey]fragmentCost),
+		res:       &Result{Operation: op.kind, Name: op.name, Fields: make(map[string]bool)},
+	}
+	w.res.Cost, w.res.Depth = w.selections(op.selections, root, 0, 0)
+	if w.err != nil {
+		return nil, w.err
+	}
+	return w.res, nil
+}
+
+// findOperation finds the operation to be executed in the document.
+func findOperation(doc *document, name string) (*operation, error) {
+	if len(doc.operations) == 0 {
+		return nil, fmt.Errorf("no operation found")
+	}
+
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, fmt.Errorf("operation name required")
+		}
+		return doc.operations[0], nil
+	}
+
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("operation %s not found", name)
+}
+
+// walker keeps the state of a single query analysis.
+type walker struct {
+	an        *Analyzer
+	doc       *document
+	variables map[string]interface{}
+	defaults  map[string]interface{}
+	visiting  map[string]bool
+	fragments map[fragmentKey]fragmentCost
+	nodes     int
+	err       error
+	res       *Result
+}
+
+// fragmentKey represents the key of a fragment analysis; the cost of a fragment depends
+// on the pending list size and fields on the root level are also collected as roots.
+type fragmentKey struct {
+	name    string
+	pending int
+	root    bool
+}
+
+// fragmentCost represents the cost and the depth of a fragment analysis;
+// the depth is relative to the depth of the fragment spread.
+type fragmentCost struct {
+	cost  int
+	depth int
+}
+
+// selections calculates the cost and the depth of the given selection set on the given type.
+// The pending size is the count of a paginated list waiting for the list to be found.
+func (w *walker) selections(list []*selection, on types.NamedType, pending int, depth int) (int, int) {
+	var cost, maxDepth int
+	for _, sel := range list {
+		// reject queries over the limit of visited nodes
+		w.nodes++
+		if w.nodes > w.an.cfg.MaxNodes {
+			w.err = ErrTooManyNodes
+		}
+		if w.err != nil {
+			return cost, maxDepth
+		}
+
+		var c, d int
+		switch {
+		case sel.spread != "":
+			c, d = w.fragment(sel.spread, pending, depth)
+		case sel.inline:
+			nt := on
+			if sel.typeCond != "" {
+				if t, ok := w.an.schema.Types[sel.typeCond]; ok {
+					nt = t
+				}
+			}
+			c, d = w.selections(sel.selections, nt, pending, depth)
+		default:
+			c, d = w.field(sel, on, pending, depth+1)
+		}
+
+		cost = addCost(cost, c)
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+	return cost, maxDepth
+}
+
+// fragment calculates the cost and the depth of the given fragment spread.
+// Each fragment is analyzed only once for the pending list size, so fragments
+// spread repeatedly can not make the analysis itself expensive.
+func (w *walker) fragment(name string, pending int, depth int) (int, int) {
+	fr, ok := w.doc.fragments[name]
+	if !ok || w.visiting[name] {
+		return 0, depth
+	}
+
+	nt, ok := w.an.schema.Types[fr.typeCond]
+	if !ok {
+		return 0, depth
+	}
+
+	key := fragmentKey{name: name, pending: pending, root: depth == 0}
+	if fc, ok := w.fragments[key]; ok {
+		return fc.cost, depth + fc.depth
+	}
+
+	w.visiting[name] = true
+	defer delete(w.visiting, name)
+
+	cost, d := w.selections(fr.selections, nt, pending, depth)
+	if d < depth {
+		d = depth
+	}
+	w.fragments[key] = fragmentCost{cost: cost, depth: d - depth}
+	return cost, d
+}
+
+// field calculates the cost and the depth of the given field selection.
+func (w *walker) field(sel *selection, on types.NamedType, pending int, depth int) (int, int) {
+	// introspection is not charged
+	if strings.HasPrefix(sel.name, "__") {
+		return 0, 0
+	}
+
+	fd := typeFields(on).Get(sel.name)
+	if fd == nil {
+		return 0, depth
+	}
+
+	// keep track of the fields selected
+	w.res.Fields[on.TypeName()+"."+fd.Name] = true
+	if depth == 1 {
+		root := Root{Name: fd.Name, Args: make(map[string]bool, len(sel.args))}
+		for arg := range sel.args {
+			root.Args[arg] = true
+		}
+		w.res.Roots = append(w.res.Roots, root)
+	}
+
+	nt, isList := unwrapType(fd.Type)
+	weight, ok := w.an.weights[on.TypeName()+"."+fd.Name]
+	if !ok && isComposite(nt) {
+		weight = 1
+	}
+
+	// no sub-selection, we are done here
+	size := w.listSize(sel, fd)
+	if len(sel.selections) == 0 {
+		return weight, depth
+	}
+
+	// the size applies to the list, or waits for the list to be found under a paginated field
+	multiplier, childPending := 1, size
+	if isList {
+		multiplier, childPending = size, 0
+		if multiplier == 0 {
+			multiplier = pending
+		}
+		if multiplier == 0 {
+			multiplier = w.an.cfg.ListSize
+		}
+	}
+
+	cost, d := w.selections(sel.selections, nt, childPending, depth)
+	return mulCost(multiplier, addCost(weight, cost)), d
+}
+
+// addCost adds the given costs, saturating at the max cost.
+func addCost(a int, b int) int {
+	if a > maxCost-b {
+		return maxCost
+	}
+	return a + b
+}
+
+// mulCost multiplies the given costs, saturating at the max cost.
+func mulCost(a int, b int) int {
+	if a != 0 && b > maxCost/a {
+		return maxCost
+	}
+	return a * b
+}
+
+// listSize provides the size of the list requested by the field count argument, if any.
+func (w *walker) listSize(sel *selection, fd *types.FieldDefinition) int {
+	def := fd.Arguments.Get(listSizeArgument)
+	if def == nil {
+		return 0
+	}
+
+	val, ok := sel.args[listSizeArgument]
+	if !ok {
+		if def.Default == nil {
+			return 0
+		}
+		val = def.Default.Deserialize(nil)
+	}
+	if v, ok := val.(variable); ok {
+		val, ok = w.variables[string(v)]
+		if !ok {
+			val = w.defaults[string(v)]
+		}
+	}
+
+	size := toInt(val)
+	if size < 0 {
+		size = -size
+	}
+
+	// the API replaces missing, or excessive count with the max size of the list
+	if size == 0 || (w.an.cfg.MaxListSize > 0 && size > w.an.cfg.MaxListSize) {
+		size = w.an.cfg.MaxListSize
+	}
+	return size
+}
+
+// typeFields provides the list of fields of the given type, if any.
+func typeFields(nt types.NamedType) types.FieldsDefinition {
+	switch t := nt.(type) {
+	case *types.ObjectTypeDefinition:
+		return t.Fields
+	case *types.InterfaceTypeDefinition:
+		return t.Fields
+	}
+	return nil
+}
+
+// unwrapType provides the named type of the given type and signals if it is a list.
+func unwrapType(t types.Type) (types.NamedType, bool) {
+	var isList bool
+	for {
+		switch tt := t.(type) {
+		case *types.NonNull:
+			t = tt.OfType
+		case *types.List:
+			isList = true
+			t = tt.OfType
+		case types.NamedType:
+			return tt, isList
+		default:
+			return nil, isList
+		}
+	}
+}
+
+// isComposite checks if the given type has fields to be selected.
+func isComposite(nt types.NamedType) bool {
+	switch nt.(type) {
+	case *types.ObjectTypeDefinition, *types.InterfaceTypeDefinition, *types.Union:
+		return true
+	}
+	return false
+}
+
+// directiveWeight provides the weight of the field set by the cost directive, if any.
+func directiveWeight(fd *types.FieldDefinition) (int, bool) {
+	dir := fd.Directives.Get(CostDirective)
+	if dir == nil {
+		return 0, false
+	}
+
+	val, ok := dir.Arguments.Get("weight")
+	if !ok {
+		return 0, false
+	}
+	return toInt(val.Deserialize(nil)), true
+}
+
+// toInt converts the given decoded value into an integer.
+func toInt(val interface{}) int {
+	switch v := val.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package complexity
+
+import (
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"testing"
+)
+
+const testSchema = `
+directive @cost(weight: Int!) on FIELD_DEFINITION
+
+schema {
+    query: Query
+}
+
+type Query {
+    block(number: Long): Block
+    blocks(cursor: String, count: Int = 25): BlockList!
+    version: String!
+}
+
+type BlockList {
+    totalCount: Int!
+    edges: [BlockListEdge!]!
+}
+
+type BlockListEdge {
+    cursor: String!
+    block: Block!
+}
+
+type Block {
+    number: Long!
+    hash: String!
+    txList: [Transaction!]! @cost(weight: 2)
+}
+
+type Transaction {
+    hash: String!
+    sender: Account!
+}
+
+type Account {
+    address: String!
+    balance: String!
+}
+
+scalar Long
+`
+
+func TestAnalyze(t *testing.T) {
+	schema := graphql.MustParseSchema(testSchema, nil)
+	an := New(schema.ASTSchema(), Config{
+		Weights:     map[string]int{"Transaction.sender": 2},
+		ListSize:    10,
+		MaxListSize: 100,
+	})
+
+	tests := []struct {
+		query string
+		vars  map[string]interface{}
+		cost  int
+		depth int
+	}{
+		{`{ version }`, nil, 0, 1},
+		{`query { block { number hash } }`, nil, 1, 2},
+		{`{ block(number: 5) { txList { hash } } }`, nil, 1 + 10*2, 3},
+		{`{ blocks(count: 10) { edges { block { number } } } }`, nil, 1 + 10*(1+1), 4},
+		{`{ blocks { totalCount } }`, nil, 1, 2},
+		{`{ blocks(count: -500) { edges { cursor } } }`, nil, 1 + 100, 3},
+		{`query q($c: Int) { blocks(count: $c) { edges { cursor } } }`, map[string]interface{}{"c": float64(3)}, 1 + 3, 3},
+		{`query q($c: Int = 4) { blocks(count: $c) { edges { cursor } } }`, nil, 1 + 4, 3},
+		{`{ block { ...tx } } fragment tx on Block { txList { sender { address } } }`, nil, 1 + 10*(2+2), 4},
+		{`{ block { ... on Block { hash } __typename } __schema { types { name } } }`, nil, 1, 2},
+		{"# comment\n{ a: block(number: 1) { hash }, b: block { hash } }", nil, 2, 2},
+	}
+
+	for _, tc := range tests {
+		res, err := an.Analyze(tc.query, "", tc.vars)
+		if err != nil {
+			t.Errorf("query %q failed; %s", tc.query, err.Error())
+			continue
+		}
+		if res.Cost != tc.cost || res.Depth != tc.depth {
+			t.Errorf("query %q; expected cost %d depth %d, got cost %d depth %d", tc.query, tc.cost, tc.depth, res.Cost, res.Depth)
+		}
+	}
+}
+
+func TestAnalyzeFields(t *testing.T) {
+	schema := graphql.MustParseSchema(testSchema, nil)
+	an := New(schema.ASTSchema(), Config{ListSize: 10, MaxListSize: 100})
+
+	res, err := an.Analyze(`{ block(number: 1) { ...tx } version } fragment tx on Block { txList { sender { address } } }`, "", nil)
+	if err != nil {
+		t.Fatalf("query failed; %s", err.Error())
+	}
+	if res.Operation != "query" || len(res.Roots) != 2 || res.Roots[0].Name != "block" || !res.Roots[0].Args["number"] {
+		t.Errorf("unexpected operation %s, roots %v", res.Operation, res.Roots)
+	}
+	for _, f := range []string{"Query.block", "Query.version", "Block.txList", "Transaction.sender", "Account.address"} {
+		if !res.Fields[f] {
+			t.Errorf("field %s not found in %v", f, res.Fields)
+		}
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	schema := graphql.MustParseSchema(testSchema, nil)
+	an := New(schema.ASTSchema(), Config{ListSize: 10, MaxListSize: 100})
+
+	for _, q := range []string{
+		`{ block { hash }`,
+		`{ block(number: ) { hash } }`,
+		`query a { version } query b { version }`,
+		`{ version "x }`,
+		`mutation { version }`,
+	} {
+		if _, err := an.Analyze(q, "", nil); err == nil {
+			t.Errorf("query %q; error expected", q)
+		}
+	}
+}
+
+func TestAnalyzeFragmentsDoubling(t *testing.T) {
+	schema := graphql.MustParseSchema(testSchema, nil)
+	an := New(schema.ASTSchema(), Config{ListSize: 10, MaxListSize: 100})
+
+	// each fragment spreads the previous one twice; 2^60 walks without memoization
+	q := "{ block { ...f60 } } fragment f0 on Block { txList { hash } }"
+	for i := 1; i <= 60; i++ {
+		q += fmt.Sprintf(" fragment f%d on Block { ...f%d ...f%d }", i, i-1, i-1)
+	}
+
+	res, err := an.Analyze(q, "", nil)
+	if err != nil {
+		t.Fatalf("query failed; %s", err.Error())
+	}
+	if res.Cost != maxCost || res.Depth != 3 {
+		t.Errorf("expected cost %d depth 3, got cost %d depth %d", maxCost, res.Cost, res.Depth)
+	}
+
+	// the small doubling query is still charged in full
+	res, err = an.Analyze(`{ block { ...f2 } } fragment f0 on Block { txList { hash } } fragment f1 on Block { ...f0 ...f0 } fragment f2 on Block { ...f1 ...f1 }`, "", nil)
+	if err != nil {
+		t.Fatalf("query failed; %s", err.Error())
+	}
+	if res.Cost != 1+4*10*2 {
+		t.Errorf("expected cost %d, got %d", 1+4*10*2, res.Cost)
+	}
+}
+
+func TestAnalyzeMaxNodes(t *testing.T) {
+	schema := graphql.MustParseSchema(testSchema, nil)
+	an := New(schema.ASTSchema(), Config{ListSize: 10, MaxListSize: 100, MaxNodes: 5})
+
+	if _, err := an.Analyze(`{ block { number hash txList { hash } } }`, "", nil); err != nil {
+		t.Errorf("query within the limit failed; %s", err.Error())
+	}
+	if _, err := an.Analyze(`{ block { number hash txList { hash sender { address } } } }`, "", nil); err != ErrTooManyNodes {
+		t.Errorf("expected %v, got %v", ErrTooManyNodes, err)
+	}
+}
@@ -0,0 +1,458 @@
+// Package complexity implements static cost and depth analysis of incoming GraphQL queries.
+package complexity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenKind represents the kind of lexical token of a GraphQL document.
+type tokenKind int
+
+const (
+	tkEOF tokenKind = iota
+	tkPunct
+	tkName
+	tkInt
+	tkFloat
+	tkString
+)
+
+// token represents a lexical token of a GraphQL document.
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+}
+
+// variable represents a reference to a query variable in an argument value.
+type variable string
+
+// selection represents a field, a fragment spread, or an inline fragment of a selection set.
+type selection struct {
+	name       string
+	args       map[string]interface{}
+	spread     string
+	inline     bool
+	typeCond   string
+	selections []*selection
+}
+
+// operation represents an operation definition of a GraphQL document.
+type operation struct {
+	kind       string
+	name       string
+	defaults   map[string]interface{}
+	selections []*selection
+}
+
+// fragment represents a fragment definition of a GraphQL document.
+type fragment struct {
+	typeCond   string
+	selections []*selection
+}
+
+// document represents a parsed executable GraphQL document.
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+// syntaxError represents a syntax error found while parsing a GraphQL document.
+type syntaxError struct {
+	msg string
+	pos int
+}
+
+// Error returns the text of the syntax error.
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("syntax error at %d: %s", e.pos, e.msg)
+}
+
+// parser implements a recursive descent parser of executable GraphQL documents.
+// Only the parts relevant to the cost analysis are kept; the full validation
+// is left to the GraphQL server.
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// parse parses the given GraphQL query document.
+func parse(src string) (doc *document, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			se, ok := r.(*syntaxError)
+			if !ok {
+				panic(r)
+			}
+			doc, err = nil, se
+		}
+	}()
+
+	p := parser{src: src}
+	p.next()
+
+	doc = &document{fragments: make(map[string]*fragment)}
+	for p.tok.kind != tkEOF {
+		switch {
+		case p.is(tkPunct, "{"):
+			doc.operations = append(doc.operations, &operation{kind: "query", selections: p.selectionSet()})
+		case p.is(tkName, "fragment"):
+			p.next()
+			name := p.name()
+			p.expectName("on")
+			fr := fragment{typeCond: p.name()}
+			p.directives()
+			fr.selections = p.selectionSet()
+			doc.fragments[name] = &fr
+		case p.is(tkName, "query") || p.is(tkName, "mutation") || p.is(tkName, "subscription"):
+			doc.operations = append(doc.operations, p.operation())
+		default:
+			p.fail("unexpected %q", p.tok.val)
+		}
+	}
+	return doc, nil
+}
+
+// fail terminates the parsing with a syntax error.
+func (p *parser) fail(format string, args ...interface{}) {
+	panic(&syntaxError{msg: fmt.Sprintf(format, args...), pos: p.tok.pos})
+}
+
+// is checks if the current token is of the given kind and value.
+func (p *parser) is(kind tokenKind, val string) bool {
+	return p.tok.kind == kind && p.tok.val == val
+}
+
+// expect consumes the given punctuator.
+func (p *parser) expect(val string) {
+	if !p.is(tkPunct, val) {
+		p.fail("expected %q, found %q", val, p.tok.val)
+	}
+	p.next()
+}
+
+// expectName consumes the given keyword.
+func (p *parser) expectName(val string) {
+	if !p.is(tkName, val) {
+		p.fail("expected %q, found %q", val, p.tok.val)
+	}
+	p.next()
+}
+
+// name consumes a name token and returns its value.
+func (p *parser) name() string {
+	if p.tok.kind != tkName {
+		p.fail("expected name, found %q", p.tok.val)
+	}
+	val := p.tok.val
+	p.next()
+	return val
+}
+
+// operation parses an operation definition.
+func (p *parser) operation() *operation {
+	op := operation{kind: p.name(), defaults: make(map[string]interface{})}
+	if p.tok.kind == tkName {
+		op.name = p.name()
+	}
+
+	// variable definitions; we keep the default values only
+	if p.is(tkPunct, "(") {
+		p.next()
+		for !p.is(tkPunct, ")") {
+			p.expect("$")
+			name := p.name()
+			p.expect(":")
+			p.typeRef()
+			if p.is(tkPunct, "=") {
+				p.next()
+				op.defaults[name] = p.value()
+			}
+			p.directives()
+		}
+		p.next()
+	}
+
+	p.directives()
+	op.selections = p.selectionSet()
+	return &op
+}
+
+// typeRef parses a type reference of a variable definition.
+func (p *parser) typeRef() {
+	if p.is(tkPunct, "[") {
+		p.next()
+		p.typeRef()
+		p.expect("]")
+	} else {
+		p.name()
+	}
+	if p.is(tkPunct, "!") {
+		p.next()
+	}
+}
+
+// directives parses a list of directives; the directives are not relevant to the cost.
+func (p *parser) directives() {
+	for p.is(tkPunct, "@") {
+		p.next()
+		p.name()
+		if p.is(tkPunct, "(") {
+			p.arguments()
+		}
+	}
+}
+
+// selectionSet parses a selection set.
+func (p *parser) selectionSet() []*selection {
+	p.expect("{")
+	list := make([]*selection, 0)
+	for !p.is(tkPunct, "}") {
+		if p.tok.kind == tkEOF {
+			p.fail("unexpected end of document")
+		}
+		list = append(list, p.selection())
+	}
+	p.next()
+	return list
+}
+
+// selection parses a single selection of a selection set.
+func (p *parser) selection() *selection {
+	if p.is(tkPunct, "...") {
+		p.next()
+
+		// fragment spread
+		if p.tok.kind == tkName && p.tok.val != "on" {
+			sel := selection{spread: p.name()}
+			p.directives()
+			return &sel
+		}
+
+		// inline fragment
+		sel := selection{inline: true}
+		if p.is(tkName, "on") {
+			p.next()
+			sel.typeCond = p.name()
+		}
+		p.directives()
+		sel.selections = p.selectionSet()
+		return &sel
+	}
+
+	// field with optional alias
+	sel := selection{name: p.name()}
+	if p.is(tkPunct, ":") {
+		p.next()
+		sel.name = p.name()
+	}
+	if p.is(tkPunct, "(") {
+		sel.args = p.arguments()
+	}
+	p.directives()
+	if p.is(tkPunct, "{") {
+		sel.selections = p.selectionSet()
+	}
+	return &sel
+}
+
+// arguments parses a list of arguments.
+func (p *parser) arguments() map[string]interface{} {
+	p.expect("(")
+	args := make(map[string]interface{})
+	for !p.is(tkPunct, ")") {
+		name := p.name()
+		p.expect(":")
+		args[name] = p.value()
+	}
+	p.next()
+	return args
+}
+
+// value parses an input value. Integers and variable references are kept,
+// other values are parsed, but not retained.
+func (p *parser) value() interface{} {
+	switch p.tok.kind {
+	case tkInt:
+		val, err := strconv.ParseInt(p.tok.val, 10, 64)
+		if err != nil {
+			p.fail("invalid integer %s", p.tok.val)
+		}
+		p.next()
+		return val
+	case tkFloat, tkString, tkName:
+		p.next()
+		return nil
+	}
+
+	switch {
+	case p.is(tkPunct, "$"):
+		p.next()
+		return variable(p.name())
+	case p.is(tkPunct, "["):
+		p.next()
+		for !p.is(tkPunct, "]") {
+			p.value()
+		}
+		p.next()
+		return nil
+	case p.is(tkPunct, "{"):
+		p.next()
+		for !p.is(tkPunct, "}") {
+			p.name()
+			p.expect(":")
+			p.value()
+		}
+		p.next()
+		return nil
+	}
+
+	p.fail("unexpected %q", p.tok.val)
+	return nil
+}
+
+// next reads the next token from the source.
+func (p *parser) next() {
+	p.skipIgnored()
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tkEOF, pos: p.pos}
+		return
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c == '.':
+		if !strings.HasPrefix(p.src[p.pos:], "...") {
+			p.tok.pos = start
+			p.fail("unexpected character %q", c)
+		}
+		p.pos += 3
+		p.tok = token{kind: tkPunct, val: "...", pos: start}
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		p.pos++
+		p.tok = token{kind: tkPunct, val: string(c), pos: start}
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tkName, val: p.src[start:p.pos], pos: start}
+	case c == '-' || isDigit(c):
+		p.number()
+	case c == '"':
+		p.string()
+	default:
+		p.tok.pos = start
+		p.fail("unexpected character %q", c)
+	}
+}
+
+// skipIgnored skips white space, commas and comments.
+func (p *parser) skipIgnored() {
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			p.pos++
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' && p.src[p.pos] != '\r' {
+				p.pos++
+			}
+		case strings.HasPrefix(p.src[p.pos:], "\ufeff"):
+			p.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+// number reads an integer, or a float number token.
+func (p *parser) number() {
+	start := p.pos
+	kind := tkInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	p.digits()
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		kind = tkFloat
+		p.pos++
+		p.digits()
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		kind = tkFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		p.digits()
+	}
+	p.tok = token{kind: kind, val: p.src[start:p.pos], pos: start}
+}
+
+// digits reads a non-empty sequence of digits.
+func (p *parser) digits() {
+	start := p.pos
+	for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		p.tok.pos = start
+		p.fail("invalid number")
+	}
+}
+
+// string reads a string, or a block string token.
+func (p *parser) string() {
+	start := p.pos
+
+	// block string ends with the first non-escaped triple quote
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		p.pos += 3
+		for p.pos < len(p.src) {
+			if strings.HasPrefix(p.src[p.pos:], `\"""`) {
+				p.pos += 4
+				continue
+			}
+			if strings.HasPrefix(p.src[p.pos:], `"""`) {
+				p.pos += 3
+				p.tok = token{kind: tkString, val: p.src[start:p.pos], pos: start}
+				return
+			}
+			p.pos++
+		}
+		p.tok.pos = start
+		p.fail("unterminated string")
+	}
+
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+		case '"':
+			p.pos++
+			p.tok = token{kind: tkString, val: p.src[start:p.pos], pos: start}
+			return
+		case '\n', '\r':
+			p.tok.pos = start
+			p.fail("unterminated string")
+		default:
+			p.pos++
+		}
+	}
+	p.tok.pos = start
+	p.fail("unterminated string")
+}
+
+// isLetter checks if the given character is an ASCII letter.
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isDigit checks if the given character is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,11 +28,11 @@ func NewAccount(acc *types.Account) *Account {
 }
 
 // Account resolves blockchain account by address.
-func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account, error) {
+func (rs *rootResolver) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
 	// simply pull the block by hash
 	acc, err := repository.R().Account(&args.Address)
 	if err != nil {
-		log.Errorf("could not get the specified account")
+		logFor(ctx).Errorf("could not get the specified account")
 		return nil, err
 	}
 	return NewAccount(acc), nil
@@ -227,15 +228,15 @@ func (acc *Account) Delegations(args *struct {
 
 // Contract resolves the account smart contract detail,
 // if the account is a smart contract address.
-func (acc *Account) Contract() (*Contract, error) {
+func (acc *Account) Contract(ctx context.Context) (*Contract, error) {
 	// is this actually a contract account?
 	if acc.ContractTx == nil {
 		return nil, nil
 	}
 
 	// get new contract
-	con, err := repository.R().Contract(&acc.Address)
-	if err != nil {
+	con, err := loadContract(ctx, &acc.Address)
+	if err != nil || con == nil {
 		return nil, err
 	}
 	return NewContract(con), nil
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,18 +23,24 @@ func NewBlock(blk *types.Block) *Block {
 }
 
 // Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-func (rs *rootResolver) Block(args *struct {
+func (rs *rootResolver) Block(ctx context.Context, args *struct {
 	Number *hexutil.Uint64
 	Hash   *common.Hash
 }) (*Block, error) {
+	var b *types.Block
+	var err error
+
 	// do we have the number, or hash is not given?
 	if args.Number != nil || args.Hash == nil {
-		b, err := repository.R().BlockByNumber(args.Number)
-		return NewBlock(b), err
+		b, err = repository.R().BlockByNumber(args.Number)
+	} else {
+		b, err = repository.R().BlockByHash(args.Hash)
 	}
 
-	// simply pull the block by hash
-	b, err := repository.R().BlockByHash(args.Hash)
+	// blocks close to the head may still change
+	if b != nil && !rs.isFinalBlock(uint64(b.Number)) {
+		markVolatile(ctx)
+	}
 	return NewBlock(b), err
 }
 
@@ -55,21 +62,18 @@ func (blk *Block) TxHashList() []common.Hash {
 }
 
 // TxList resolves list of transaction details of the transactions bundled in the block.
-func (blk *Block) TxList() ([]*Transaction, error) {
-	// make the container
-	txs := make([]*Transaction, len(blk.Txs))
-
-	// loop the hashes and extract transactions
-	for i, hash := range blk.Txs {
-		trx, err := repository.R().Transaction(hash)
-		if err != nil {
-			return nil, err
-		}
+func (blk *Block) TxList(ctx context.Context) ([]*Transaction, error) {
+	// load all the transactions in one batch
+	list, err := loadTransactions(ctx, blk.Txs)
+	if err != nil {
+		return nil, err
+	}
 
-		// make a resolvable transaction
+	// make resolvable transactions
+	txs := make([]*Transaction, len(list))
+	for i, trx := range list {
 		txs[i] = NewTransaction(trx)
 	}
-
 	return txs, nil
 }
 
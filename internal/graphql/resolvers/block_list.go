@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -28,7 +29,7 @@ func NewBlockList(blocks *types.BlockList, totalCount *hexutil.Big) *BlockList {
 }
 
 // Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-func (rs *rootResolver) Blocks(args *struct {
+func (rs *rootResolver) Blocks(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*BlockList, error) {
@@ -37,7 +38,7 @@ func (rs *rootResolver) Blocks(args *struct {
 	if args.Cursor != nil {
 		val, err := hexutil.DecodeUint64(string(*args.Cursor))
 		if err != nil {
-			log.Errorf("invalid block cursor [%s]; %s", args.Cursor, err.Error())
+			logFor(ctx).Errorf("invalid block cursor [%s]; %s", args.Cursor, err.Error())
 		}
 		num = &val
 	}
@@ -55,7 +56,7 @@ func (rs *rootResolver) Blocks(args *struct {
 	// get the block list from repository
 	bl, err := repository.R().Blocks(num, args.Count)
 	if err != nil {
-		log.Errorf("can not get blocks list; %s", err.Error())
+		logFor(ctx).Errorf("can not get blocks list; %s", err.Error())
 		return nil, err
 	}
 
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -9,20 +10,20 @@ import (
 )
 
 // FtmBurnedTotal resolves total amount of burned FTM tokens in WEI units.
-func (rs *rootResolver) FtmBurnedTotal() hexutil.Big {
+func (rs *rootResolver) FtmBurnedTotal(ctx context.Context) hexutil.Big {
 	val, err := repository.R().FtmBurnTotal()
 	if err != nil {
-		log.Criticalf("failed to load burned total; %s", err.Error())
+		logFor(ctx).Criticalf("failed to load burned total; %s", err.Error())
 		return hexutil.Big{}
 	}
 	return hexutil.Big(*new(big.Int).Mul(big.NewInt(val), types.BurnDecimalsCorrection))
 }
 
 // FtmBurnedTotalAmount resolves total amount of burned FTM tokens in FTM units.
-func (rs *rootResolver) FtmBurnedTotalAmount() float64 {
+func (rs *rootResolver) FtmBurnedTotalAmount(ctx context.Context) float64 {
 	val, err := repository.R().FtmBurnTotal()
 	if err != nil {
-		log.Criticalf("failed to load burned total; %s", err.Error())
+		logFor(ctx).Criticalf("failed to load burned total; %s", err.Error())
 		return 0
 	}
 	return float64(val) / types.BurnFTMDecimalsCorrection
@@ -0,0 +1,64 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"sync/atomic"
+)
+
+// cacheHintKey represents the context key of the response cache hint.
+type cacheHintKey struct{}
+
+// CacheHint collects information about the finality of the data resolved for a response.
+// Resolvers mark the response volatile if they provide data which may still change,
+// i.e. a pending transaction.
+type CacheHint struct {
+	volatile atomic.Bool
+}
+
+// WithCacheHint attaches a new response cache hint to the given request context.
+func WithCacheHint(ctx context.Context) (context.Context, *CacheHint) {
+	ch := new(CacheHint)
+	return context.WithValue(ctx, cacheHintKey{}, ch), ch
+}
+
+// CacheHintOf provides the response cache hint of the given request context, if any.
+func CacheHintOf(ctx context.Context) *CacheHint {
+	if ctx == nil {
+		return nil
+	}
+	ch, _ := ctx.Value(cacheHintKey{}).(*CacheHint)
+	return ch
+}
+
+// MarkVolatile marks the response as containing data which may still change.
+func (ch *CacheHint) MarkVolatile() {
+	ch.volatile.Store(true)
+}
+
+// IsVolatile signals if the response contains data which may still change.
+func (ch *CacheHint) IsVolatile() bool {
+	return ch.volatile.Load()
+}
+
+// markVolatile marks the response of the given request context volatile.
+func markVolatile(ctx context.Context) {
+	if ch := CacheHintOf(ctx); ch != nil {
+		ch.MarkVolatile()
+	}
+}
+
+// isFinalBlock checks if the block of the given number is deep enough below the chain head
+// to be considered final.
+func (rs *rootResolver) isFinalBlock(num uint64) bool {
+	head := rs.head.Load()
+	if head == 0 {
+		h, err := repository.R().BlockHeight()
+		if err != nil {
+			return false
+		}
+		head = h.ToInt().Uint64()
+	}
+	return num+cfg.Queries.FinalConfirmations <= head
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/sha256"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -78,8 +79,8 @@ func NewContract(con *types.Contract) *Contract {
 }
 
 // DeployedBy resolves the deployment transaction of the contract.
-func (con *Contract) DeployedBy() (*Transaction, error) {
-	tr, err := repository.R().Transaction(&con.TransactionHash)
+func (con *Contract) DeployedBy(ctx context.Context) (*Transaction, error) {
+	tr, err := loadTransaction(ctx, &con.TransactionHash)
 	return NewTransaction(tr), err
 }
 
@@ -178,24 +179,24 @@ func updateContractFromInput(con *ContractValidationInput, sc *types.Contract) {
 // ValidateContract resolves smart contract source code vs. deployed byte code and marks
 // the contract as validated if the match is found. Peer API points are ringed on success
 // to notify them about the change.
-func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidationInput }) (*Contract, error) {
+func (rs *rootResolver) ValidateContract(ctx context.Context, args *struct{ Contract ContractValidationInput }) (*Contract, error) {
 	// validate the input
 	if err := isValidationValid(&args.Contract); err != nil {
-		log.Errorf("can not validate contract, validation request is not valid; %s", err.Error())
+		logFor(ctx).Errorf("can not validate contract, validation request is not valid; %s", err.Error())
 		return nil, err
 	}
 
 	// get a contract to be validated if any
 	sc, err := repository.R().Contract(&args.Contract.Address)
 	if err != nil {
-		log.Errorf("contract [%s] not found", args.Contract.Address.String())
+		logFor(ctx).Errorf("contract [%s] not found", args.Contract.Address.String())
 		return nil, err
 	}
 
 	// if we already have this source code, no need to do any updates
 	hash := sourceHash(args.Contract.SourceCode)
 	if sc.SourceCodeHash != nil && hash.String() == sc.SourceCodeHash.String() {
-		log.Debugf("contract [%s] source code is already known", sc.Address.String())
+		logFor(ctx).Debugf("contract [%s] source code is already known", sc.Address.String())
 		return NewContract(sc), nil
 	}
 
@@ -205,7 +206,7 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 
 	// do the validation
 	if err := repository.R().ValidateContract(sc); err != nil {
-		log.Errorf("contract validation failed; %s", err.Error())
+		logFor(ctx).Errorf("contract validation failed; %s", err.Error())
 		return nil, err
 	}
 
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -26,7 +27,7 @@ func NewContractList(cl *types.ContractList) *ContractList {
 }
 
 // Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-func (rs *rootResolver) Contracts(args *struct {
+func (rs *rootResolver) Contracts(ctx context.Context, args *struct {
 	ValidatedOnly bool
 	Cursor        *Cursor
 	Count         int32
@@ -38,7 +39,7 @@ func (rs *rootResolver) Contracts(args *struct {
 	// get the contract list from repository
 	cl, err := repository.R().Contracts(args.ValidatedOnly, (*string)(args.Cursor), args.Count)
 	if err != nil {
-		log.Errorf("can not get contracts list; %s", err.Error())
+		logFor(ctx).Errorf("can not get contracts list; %s", err.Error())
 		return nil, err
 	}
 	return NewContractList(cl), nil
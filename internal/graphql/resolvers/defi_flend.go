@@ -58,3 +58,11 @@ func (lp *LendingPool) UserDepositHistory(args *struct {
 }) ([]*types.FLendDeposit, error) {
 	return repository.R().FLendGetUserDepositHistory(args.Address, args.Asset)
 }
+
+// UserBorrowHistory resolves user account borrow history data from lending pool
+func (lp *LendingPool) UserBorrowHistory(args *struct {
+	Address *common.Address
+	Asset   *common.Address
+}) ([]*types.FLendBorrow, error) {
+	return repository.R().FLendGetUserBorrowHistory(args.Address, args.Asset)
+}
@@ -0,0 +1,138 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// FLendAction represents resolvable fLend lending pool action.
+type FLendAction struct {
+	types.FLendAction
+}
+
+// FLendActionList represents resolvable list of lending pool action edges structure.
+type FLendActionList struct {
+	types.FLendActionList
+}
+
+// FLendActionListEdge represents a single edge of a lending pool action list structure.
+type FLendActionListEdge struct {
+	Action *FLendAction
+}
+
+// NewFLendAction builds new resolvable lending pool action structure.
+func NewFLendAction(fa *types.FLendAction) *FLendAction {
+	return &FLendAction{FLendAction: *fa}
+}
+
+// NewFLendActionList builds new resolvable list of lending pool actions.
+func NewFLendActionList(fal *types.FLendActionList) *FLendActionList {
+	return &FLendActionList{*fal}
+}
+
+// UserHistory resolves list of lending pool actions of the given user
+// optionally filtered by the asset and action type.
+func (lp *LendingPool) UserHistory(ctx context.Context, args *struct {
+	Address    common.Address
+	Asset      *common.Address
+	ActionType *int32
+	Cursor     *Cursor
+	Count      int32
+}) (*FLendActionList, error) {
+	return fLendActions(ctx, &args.Address, args.Asset, args.ActionType, args.Cursor, args.Count)
+}
+
+// BorrowHistory resolves list of borrow actions on the lending pool
+// optionally filtered by the user and asset.
+func (lp *LendingPool) BorrowHistory(ctx context.Context, args *struct {
+	Address *common.Address
+	Asset   *common.Address
+	Cursor  *Cursor
+	Count   int32
+}) (*FLendActionList, error) {
+	tp := int32(types.FLendActionBorrow)
+	return fLendActions(ctx, args.Address, args.Asset, &tp, args.Cursor, args.Count)
+}
+
+// Liquidations resolves list of liquidations on the lending pool
+// optionally filtered by the liquidated user.
+func (lp *LendingPool) Liquidations(ctx context.Context, args *struct {
+	Address *common.Address
+	Cursor  *Cursor
+	Count   int32
+}) (*FLendActionList, error) {
+	tp := int32(types.FLendActionLiquidationCall)
+	return fLendActions(ctx, args.Address, nil, &tp, args.Cursor, args.Count)
+}
+
+// fLendActions loads a resolvable list of lending pool actions for the given filter.
+func fLendActions(ctx context.Context, user *common.Address, asset *common.Address, actionType *int32, cursor *Cursor, count int32) (*FLendActionList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	count = listLimitCount(count, listMaxEdgesPerRequest)
+
+	al, err := repository.R().FLendActions(user, asset, actionType, (*string)(cursor), count)
+	if err != nil {
+		logFor(ctx).Errorf("can not get fLend action list; %s", err.Error())
+		return nil, err
+	}
+	return NewFLendActionList(al), nil
+}
+
+// Id resolves the identifier of the action.
+func (fa *FLendAction) Id() Cursor {
+	return Cursor(fa.Pk())
+}
+
+// AssetToken resolves the token of the action asset.
+func (fa *FLendAction) AssetToken() *ERC20Token {
+	return NewErc20Token(&fa.Asset)
+}
+
+// TransactionHash resolves the hash of the action transaction.
+func (fa *FLendAction) TransactionHash() common.Hash {
+	return fa.TrxHash
+}
+
+// TotalCount resolves the total number of lending pool actions in the list.
+func (fl *FLendActionList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(fl.Total))
+}
+
+// PageInfo resolves the current page information for the lending pool action list.
+func (fl *FLendActionList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if fl.Collection == nil || len(fl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(fl.Collection[0].Pk())
+	last := Cursor(fl.Collection[len(fl.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !fl.IsEnd, !fl.IsStart)
+}
+
+// Edges resolves list of edges for the linked lending pool action list.
+func (fl *FLendActionList) Edges() []*FLendActionListEdge {
+	// do we have any items? return empty list if not
+	if fl.Collection == nil || len(fl.Collection) == 0 {
+		return make([]*FLendActionListEdge, 0)
+	}
+
+	// make the list
+	edges := make([]*FLendActionListEdge, len(fl.Collection))
+	for i, c := range fl.Collection {
+		edges[i] = &FLendActionListEdge{Action: NewFLendAction(c)}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (fe *FLendActionListEdge) Cursor() Cursor {
+	return Cursor(fe.Action.Pk())
+}
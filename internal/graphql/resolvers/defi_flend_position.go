@@ -0,0 +1,115 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// fLendAtRiskMaxCount represents the max number of fLend positions at risk provided in one request.
+const fLendAtRiskMaxCount = 500
+
+// FLendPosition represents resolvable fLend position with its health factor.
+type FLendPosition struct {
+	types.FLendPosition
+}
+
+// FLendHealthPoint represents resolvable point of fLend position health factor time series.
+type FLendHealthPoint struct {
+	types.FLendHealthPoint
+}
+
+// NewFLendPosition builds new resolvable fLend position structure.
+func NewFLendPosition(pos *types.FLendPosition) *FLendPosition {
+	return &FLendPosition{FLendPosition: *pos}
+}
+
+// FLendPosition resolves the latest known state of the fLend position of the given user.
+func (rs *rootResolver) FLendPosition(ctx context.Context, args *struct{ Address common.Address }) (*FLendPosition, error) {
+	pos, err := repository.R().FLendPosition(&args.Address)
+	if err != nil {
+		logFor(ctx).Errorf("can not get fLend position of %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	if pos == nil {
+		return nil, nil
+	}
+	return NewFLendPosition(pos), nil
+}
+
+// FLendAtRiskPositions resolves the list of fLend positions with health factor below the threshold,
+// the riskiest positions first.
+func (rs *rootResolver) FLendAtRiskPositions(ctx context.Context, args *struct {
+	Threshold float64
+	Count     int32
+}) ([]*FLendPosition, error) {
+	// limit query size
+	if args.Count <= 0 || args.Count > fLendAtRiskMaxCount {
+		args.Count = fLendAtRiskMaxCount
+	}
+
+	pl, err := repository.R().FLendAtRiskPositions(args.Threshold, args.Count)
+	if err != nil {
+		logFor(ctx).Errorf("can not get fLend positions at risk; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]*FLendPosition, len(pl))
+	for i, pos := range pl {
+		list[i] = NewFLendPosition(pos)
+	}
+	return list, nil
+}
+
+// TotalCollateralFUSD resolves the total value of the position collateral in fUSD.
+func (fp *FLendPosition) TotalCollateralFUSD() hexutil.Big {
+	return fp.TotalCollateral
+}
+
+// TotalDebtFUSD resolves the total value of the position debt in fUSD.
+func (fp *FLendPosition) TotalDebtFUSD() hexutil.Big {
+	return fp.TotalDebt
+}
+
+// Updated resolves the time stamp of the last health factor refresh.
+func (fp *FLendPosition) Updated() hexutil.Uint64 {
+	return hexutil.Uint64(fp.FLendPosition.Updated.Unix())
+}
+
+// HealthHistory resolves the health factor time series of the position
+// for the time resolution and interval. If dates are not given, the last month is provided.
+func (fp *FLendPosition) HealthHistory(ctx context.Context, args *struct {
+	Resolution *string
+	FromDate   *int32
+	ToDate     *int32
+}) ([]*FLendHealthPoint, error) {
+	// check date values
+	var fDate int64
+	if args.FromDate != nil {
+		fDate = (int64)(*args.FromDate)
+	} else {
+		fDate = time.Now().UTC().AddDate(0, -1, 0).Unix()
+	}
+
+	// check resolution value
+	resolution := ""
+	if args.Resolution != nil {
+		resolution = *args.Resolution
+	}
+
+	hh, err := repository.R().FLendHealthHistory(&fp.User, resolution, fDate, checkDate(args.ToDate))
+	if err != nil {
+		logFor(ctx).Errorf("can not get fLend health history of %s; %s", fp.User.String(), err.Error())
+		return nil, err
+	}
+
+	list := make([]*FLendHealthPoint, len(hh))
+	for i := range hh {
+		list[i] = &FLendHealthPoint{FLendHealthPoint: hh[i]}
+	}
+	return list, nil
+}
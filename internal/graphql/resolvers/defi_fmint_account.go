@@ -2,10 +2,13 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
 )
 
 // FMintAccount represents resolvable DeFi account information.
@@ -119,3 +122,135 @@ func (mb *FMintTokenBalance) Balance() (hexutil.Big, error) {
 func (mb *FMintTokenBalance) Value() (hexutil.Big, error) {
 	return repository.R().FMintTokenValue(&mb.OwnerAddress, &mb.TokenAddress, mb.Type)
 }
+
+// History resolves the list of fMint transactions of the account.
+func (fac *FMintAccount) History(ctx context.Context, args *struct {
+	Cursor *Cursor
+	Count  int32
+	Type   *int32
+}) (*FMintTransactionList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	fl, err := repository.R().FMintTransactions(&fac.Address, nil, args.Type, 0, 0, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		logFor(ctx).Errorf("can not get fMint history of %s; %s", fac.Address.String(), err.Error())
+		return nil, err
+	}
+	return NewFMintTransactionList(fl), nil
+}
+
+// CollateralRatio4 resolves the current collateral to debt ratio of the account
+// in the same decimals as the DeFi configuration ratios.
+// Nil is returned if the account does not have any debt.
+func (fac *FMintAccount) CollateralRatio4() (*hexutil.Big, error) {
+	if fac.DebtValue.ToInt().Sign() == 0 {
+		return nil, nil
+	}
+
+	dc, err := repository.R().DefiConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	r := fMintRatioDecimals(dc)
+	r.Mul(r, fac.CollateralValue.ToInt())
+	r.Div(r, fac.DebtValue.ToInt())
+	return (*hexutil.Big)(r), nil
+}
+
+// LiquidationThreshold4 resolves the minimal collateral to debt ratio the account
+// needs to keep to stay safe from liquidation.
+func (fac *FMintAccount) LiquidationThreshold4() (hexutil.Big, error) {
+	dc, err := repository.R().DefiConfiguration()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return dc.MinCollateralRatio4, nil
+}
+
+// LiquidationDistance4 resolves the difference between the current collateral to debt ratio
+// and the liquidation threshold. Negative value means the account is below the threshold.
+// Nil is returned if the account does not have any debt.
+func (fac *FMintAccount) LiquidationDistance4() (*hexutil.Big, error) {
+	ratio, err := fac.CollateralRatio4()
+	if err != nil || ratio == nil {
+		return nil, err
+	}
+
+	dc, err := repository.R().DefiConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(new(big.Int).Sub(ratio.ToInt(), dc.MinCollateralRatio4.ToInt())), nil
+}
+
+// CollateralBuffer resolves the collateral value in fUSD the account can lose
+// before reaching the liquidation threshold. Negative value means the account
+// is already below the threshold.
+func (fac *FMintAccount) CollateralBuffer() (hexutil.Big, error) {
+	dc, err := repository.R().DefiConfiguration()
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	// the minimal collateral value required by the current debt
+	req := new(big.Int).Mul(fac.DebtValue.ToInt(), dc.MinCollateralRatio4.ToInt())
+	req.Div(req, fMintRatioDecimals(dc))
+	return hexutil.Big(*new(big.Int).Sub(fac.CollateralValue.ToInt(), req)), nil
+}
+
+// RiskHistory resolves the collateral to debt ratio time series of the account
+// for the time resolution and interval. If dates are not given, the last month is provided.
+func (fac *FMintAccount) RiskHistory(ctx context.Context, args *struct {
+	Resolution *string
+	FromDate   *int32
+	ToDate     *int32
+}) ([]*FMintRiskPoint, error) {
+	// check date values
+	var fDate int64
+	if args.FromDate != nil {
+		fDate = (int64)(*args.FromDate)
+	} else {
+		fDate = time.Now().UTC().AddDate(0, -1, 0).Unix()
+	}
+
+	// check resolution value
+	resolution := ""
+	if args.Resolution != nil {
+		resolution = *args.Resolution
+	}
+
+	rh, err := repository.R().FMintRiskHistory(&fac.Address, resolution, fDate, checkDate(args.ToDate))
+	if err != nil {
+		logFor(ctx).Errorf("can not get fMint risk history of %s; %s", fac.Address.String(), err.Error())
+		return nil, err
+	}
+
+	list := make([]*FMintRiskPoint, len(rh))
+	for i := range rh {
+		list[i] = &FMintRiskPoint{FMintRiskPoint: rh[i]}
+	}
+	return list, nil
+}
+
+// fMintRatioDecimals provides the ratio decimals correction of the DeFi configuration.
+func fMintRatioDecimals(dc *types.DefiSettings) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(dc.Decimals)), nil)
+}
+
+// FMintRiskPoint represents resolvable point of fMint account risk time series.
+type FMintRiskPoint struct {
+	types.FMintRiskPoint
+}
+
+// CollateralValue resolves the collateral value of the account in fUSD.
+func (rp *FMintRiskPoint) CollateralValue() hexutil.Big {
+	return hexutil.Big(*new(big.Int).Mul(big.NewInt(rp.FMintRiskPoint.CollateralValue), types.FMintAmountDecimalsCorrection))
+}
+
+// DebtValue resolves the debt value of the account in fUSD.
+func (rp *FMintRiskPoint) DebtValue() hexutil.Big {
+	return hexutil.Big(*new(big.Int).Mul(big.NewInt(rp.FMintRiskPoint.DebtValue), types.FMintAmountDecimalsCorrection))
+}
@@ -0,0 +1,118 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// FMintTransaction represents resolvable fMint protocol transaction.
+type FMintTransaction struct {
+	types.FMintTransaction
+}
+
+// FMintTransactionList represents resolvable list of fMint transaction edges structure.
+type FMintTransactionList struct {
+	types.FMintTransactionList
+}
+
+// FMintTransactionListEdge represents a single edge of an fMint transaction list structure.
+type FMintTransactionListEdge struct {
+	Transaction *FMintTransaction
+}
+
+// FMintTransactionFilter represents an input structure used
+// to filter the list of fMint transactions.
+type FMintTransactionFilter struct {
+	User     *common.Address
+	Token    *common.Address
+	Type     *int32
+	FromDate *int32
+	ToDate   *int32
+}
+
+// NewFMintTransaction builds new resolvable fMint transaction structure.
+func NewFMintTransaction(ft *types.FMintTransaction) *FMintTransaction {
+	return &FMintTransaction{FMintTransaction: *ft}
+}
+
+// NewFMintTransactionList builds new resolvable list of fMint transactions.
+func NewFMintTransactionList(fl *types.FMintTransactionList) *FMintTransactionList {
+	return &FMintTransactionList{*fl}
+}
+
+// FMintTransactions resolves list of fMint transactions encapsulated in a listable structure.
+func (rs *rootResolver) FMintTransactions(ctx context.Context, args *struct {
+	Filter *FMintTransactionFilter
+	Cursor *Cursor
+	Count  int32
+}) (*FMintTransactionList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// no filter means all the transactions
+	fi := args.Filter
+	if fi == nil {
+		fi = &FMintTransactionFilter{}
+	}
+
+	fl, err := repository.R().FMintTransactions(fi.User, fi.Token, fi.Type, checkDate(fi.FromDate), checkDate(fi.ToDate), (*string)(args.Cursor), args.Count)
+	if err != nil {
+		logFor(ctx).Errorf("can not get fMint transaction list; %s", err.Error())
+		return nil, err
+	}
+	return NewFMintTransactionList(fl), nil
+}
+
+// User resolves the address of the fMint account of the transaction.
+func (ft *FMintTransaction) User() common.Address {
+	return ft.UserAddress
+}
+
+// Token resolves the token of the transaction.
+func (ft *FMintTransaction) Token() *ERC20Token {
+	return NewErc20Token(&ft.TokenAddress)
+}
+
+// TotalCount resolves the total number of fMint transactions in the list.
+func (fl *FMintTransactionList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(fl.Total))
+}
+
+// PageInfo resolves the current page information for the fMint transaction list.
+func (fl *FMintTransactionList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if fl.Collection == nil || len(fl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(fl.Collection[0].Pk())
+	last := Cursor(fl.Collection[len(fl.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !fl.IsEnd, !fl.IsStart)
+}
+
+// Edges resolves list of edges for the linked fMint transaction list.
+func (fl *FMintTransactionList) Edges() []*FMintTransactionListEdge {
+	// do we have any items? return empty list if not
+	if fl.Collection == nil || len(fl.Collection) == 0 {
+		return make([]*FMintTransactionListEdge, 0)
+	}
+
+	// make the list
+	edges := make([]*FMintTransactionListEdge, len(fl.Collection))
+	for i, c := range fl.Collection {
+		edges[i] = &FMintTransactionListEdge{Transaction: NewFMintTransaction(c)}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (fe *FMintTransactionListEdge) Cursor() Cursor {
+	return Cursor(fe.Transaction.Pk())
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,12 +15,18 @@ type Epoch struct {
 }
 
 // Epoch resolves information about epoch of the given id.
-func (rs *rootResolver) Epoch(args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
+func (rs *rootResolver) Epoch(ctx context.Context, args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
 	epo, err := repository.R().Epoch(args.Id)
 	if err != nil {
 		return Epoch{}, err
 	}
-	return Epoch{*epo}, nil
+
+	// only sealed and processed epochs are final
+	ep := Epoch{*epo}
+	if cur, err := repository.R().CurrentEpoch(); err != nil || epo.Id >= cur || ep.detail().FirstBlock == nil {
+		markVolatile(ctx)
+	}
+	return ep, nil
 }
 
 // Duration resolves the time length of the given epoch
@@ -43,21 +50,116 @@ func (ep Epoch) Duration() hexutil.Uint64 {
 	return ep.EndTime - prev.EndTime
 }
 
+// detail provides the epoch with its block range, transactions and validators detail, if known.
+// The epoch may come from the node, so we need to check the stored one.
+func (ep Epoch) detail() *types.Epoch {
+	if ep.Epoch.FirstBlock != nil {
+		return &ep.Epoch
+	}
+
+	se, err := repository.R().StoredEpoch(uint64(ep.Id))
+	if err != nil || se == nil {
+		return &ep.Epoch
+	}
+	return se
+}
+
+// FirstBlock resolves the number of the first block of the epoch.
+func (ep Epoch) FirstBlock() *hexutil.Uint64 {
+	return ep.detail().FirstBlock
+}
+
+// LastBlock resolves the number of the last block of the epoch.
+func (ep Epoch) LastBlock() *hexutil.Uint64 {
+	return ep.detail().LastBlock
+}
+
+// TransactionCount resolves the number of transactions included in the epoch.
+func (ep Epoch) TransactionCount() *hexutil.Uint64 {
+	return ep.detail().TransactionCount
+}
+
+// GasUsed resolves the total gas used by transactions of the epoch.
+func (ep Epoch) GasUsed() *hexutil.Uint64 {
+	return ep.detail().GasUsed
+}
+
+// ValidatorsCount resolves the number of validators active in the epoch.
+func (ep Epoch) ValidatorsCount() *hexutil.Uint64 {
+	return ep.detail().ValidatorsCount
+}
+
+// Validators resolves the list of validators active in the epoch with their stake.
+func (ep Epoch) Validators() *[]EpochValidator {
+	de := ep.detail()
+	if de.Validators == nil {
+		return nil
+	}
+
+	list := make([]EpochValidator, len(de.Validators))
+	for i, v := range de.Validators {
+		list[i] = EpochValidator{v}
+	}
+	return &list
+}
+
+// Blocks resolves list of blocks of the epoch, the newest block first.
+func (ep Epoch) Blocks(ctx context.Context, args *struct {
+	Cursor *Cursor
+	Count  int32
+}) (*BlockList, error) {
+	var num *uint64
+	if args.Cursor != nil {
+		val, err := hexutil.DecodeUint64(string(*args.Cursor))
+		if err != nil {
+			logFor(ctx).Errorf("invalid block cursor [%s]; %s", *args.Cursor, err.Error())
+			return nil, err
+		}
+		num = &val
+	}
+
+	de := ep.detail()
+	bl, err := repository.R().EpochBlocks(de, num, listLimitCount(args.Count, listMaxEdgesPerRequest))
+	if err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	if de.FirstBlock != nil && de.LastBlock != nil {
+		total.SetUint64(uint64(*de.LastBlock) - uint64(*de.FirstBlock) + 1)
+	}
+	return NewBlockList(bl, (*hexutil.Big)(total)), nil
+}
+
+// EpochValidator represents a resolvable validator active in an epoch.
+type EpochValidator struct {
+	types.EpochValidator
+}
+
+// Staker resolves the current detail of the validator.
+func (ev EpochValidator) Staker(ctx context.Context) (*Staker, error) {
+	st, err := loadValidator(ctx, &ev.Id)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(st), nil
+}
+
 // FtmTreasuryTotal resolves total amount of FTM tokens in WEI units sent into treasury.
-func (rs *rootResolver) FtmTreasuryTotal() hexutil.Big {
+func (rs *rootResolver) FtmTreasuryTotal(ctx context.Context) hexutil.Big {
 	val, err := repository.R().FtmTreasuryTotal()
 	if err != nil {
-		log.Criticalf("failed to load treasury total; %s", err.Error())
+		logFor(ctx).Criticalf("failed to load treasury total; %s", err.Error())
 		return hexutil.Big{}
 	}
 	return hexutil.Big(*new(big.Int).Mul(big.NewInt(val), types.BurnDecimalsCorrection))
 }
 
 // FtmTreasuryTotalAmount resolves total amount of FTM tokens in FTM units sent into treasury.
-func (rs *rootResolver) FtmTreasuryTotalAmount() float64 {
+func (rs *rootResolver) FtmTreasuryTotalAmount(ctx context.Context) float64 {
 	val, err := repository.R().FtmTreasuryTotal()
 	if err != nil {
-		log.Criticalf("failed to load treasury total; %s", err.Error())
+		logFor(ctx).Criticalf("failed to load treasury total; %s", err.Error())
 		return 0
 	}
 	return float64(val) / types.BurnFTMDecimalsCorrection
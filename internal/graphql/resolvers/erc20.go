@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,23 +34,23 @@ func (rs *rootResolver) Erc20Token(args *struct{ Token common.Address }) *ERC20T
 
 // FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 // by the token owner for DeFi operations.
-func (rs *rootResolver) FMintTokenAllowance(args *struct {
+func (rs *rootResolver) FMintTokenAllowance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) hexutil.Big {
 	a, err := repository.R().Erc20Allowance(&args.Token, &args.Owner, nil)
 	if err != nil {
-		log.Errorf("allowance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
+		logFor(ctx).Errorf("allowance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return a
 }
 
 // ErcTotalSupply resolves the current total supply of the specified token.
-func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) hexutil.Big {
+func (rs *rootResolver) ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) hexutil.Big {
 	s, err := repository.R().Erc20TotalSupply(&args.Token)
 	if err != nil {
-		log.Errorf("total supply of %s not known; %s", args.Token.String(), err.Error())
+		logFor(ctx).Errorf("total supply of %s not known; %s", args.Token.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return s
@@ -57,13 +58,13 @@ func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) hex
 
 // ErcTokenBalance resolves the current available balance of the specified token
 // for the specified owner.
-func (rs *rootResolver) ErcTokenBalance(args *struct {
+func (rs *rootResolver) ErcTokenBalance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) hexutil.Big {
 	b, err := repository.R().Erc20BalanceOf(&args.Token, &args.Owner)
 	if err != nil {
-		log.Errorf("balance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
+		logFor(ctx).Errorf("balance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return b
@@ -71,47 +72,47 @@ func (rs *rootResolver) ErcTokenBalance(args *struct {
 
 // ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 // by the token owner for the spender to be manipulated with.
-func (rs *rootResolver) ErcTokenAllowance(args *struct {
+func (rs *rootResolver) ErcTokenAllowance(ctx context.Context, args *struct {
 	Token   common.Address
 	Owner   common.Address
 	Spender common.Address
 }) hexutil.Big {
 	a, err := repository.R().Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
 	if err != nil {
-		log.Errorf("allowance of %s for %s -> %s not known; %s", args.Token.String(), args.Owner.String(), args.Spender.String(), err.Error())
+		logFor(ctx).Errorf("allowance of %s for %s -> %s not known; %s", args.Token.String(), args.Owner.String(), args.Spender.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return a
 }
 
 // TotalSupply resolves the total supply of the given ERC20 token.
-func (token *ERC20Token) TotalSupply() hexutil.Big {
+func (token *ERC20Token) TotalSupply(ctx context.Context) hexutil.Big {
 	s, err := repository.R().Erc20TotalSupply(&token.Address)
 	if err != nil {
-		log.Errorf("total supply of %s not known; %s", token.Address.String(), err.Error())
+		logFor(ctx).Errorf("total supply of %s not known; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return s
 }
 
 // BalanceOf resolves the available balance of the given ERC20 token to a user.
-func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) hexutil.Big {
+func (token *ERC20Token) BalanceOf(ctx context.Context, args *struct{ Owner common.Address }) hexutil.Big {
 	b, err := repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
 	if err != nil {
-		log.Errorf("balance of %s for %s not known; %s", token.Address.String(), args.Owner.String(), err.Error())
+		logFor(ctx).Errorf("balance of %s for %s not known; %s", token.Address.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return b
 }
 
 // Allowance resolves the unlocked allowance of the given ERC20 token from the owner to spender.
-func (token *ERC20Token) Allowance(args *struct {
+func (token *ERC20Token) Allowance(ctx context.Context, args *struct {
 	Owner   common.Address
 	Spender common.Address
 }) hexutil.Big {
 	a, err := repository.R().Erc20Allowance(&token.Address, &args.Owner, &args.Spender)
 	if err != nil {
-		log.Errorf("allowance of %s for %s -> %s not known; %s", token.Address.String(), args.Owner.String(), args.Spender.String(), err.Error())
+		logFor(ctx).Errorf("allowance of %s for %s -> %s not known; %s", token.Address.String(), args.Owner.String(), args.Spender.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return a
@@ -123,20 +124,20 @@ func (token *ERC20Token) LogoURL() string {
 }
 
 // TotalDeposit represents the total amount of tokens deposited to fMint as collateral.
-func (token *ERC20Token) TotalDeposit() hexutil.Big {
+func (token *ERC20Token) TotalDeposit(ctx context.Context) hexutil.Big {
 	d, err := repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeCollateral)
 	if err != nil {
-		log.Errorf("unknown deposit of %s; %s", token.Address.String(), err.Error())
+		logFor(ctx).Errorf("unknown deposit of %s; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return d
 }
 
 // TotalDebt represents the total amount of tokens borrowed/minted on fMint.
-func (token *ERC20Token) TotalDebt() hexutil.Big {
+func (token *ERC20Token) TotalDebt(ctx context.Context) hexutil.Big {
 	d, err := repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeDebt)
 	if err != nil {
-		log.Errorf("unknown debt of %s; %s", token.Address.String(), err.Error())
+		logFor(ctx).Errorf("unknown debt of %s; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return d
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -37,21 +38,21 @@ func NewEstimatedRewards(ep *types.Epoch, amount *hexutil.Uint64, total *hexutil
 }
 
 // estimateRewardsByAddress instantiates the estimated rewards for specified address if possible.
-func (rs *rootResolver) estimateRewardsByAddress(addr *common.Address, ep *types.Epoch, total *hexutil.Big) (EstimatedRewards, error) {
+func (rs *rootResolver) estimateRewardsByAddress(ctx context.Context, addr *common.Address, ep *types.Epoch, total *hexutil.Big) (EstimatedRewards, error) {
 	// try to get the address involved
 	acc, err := repository.R().Account(addr)
 	if err != nil {
-		log.Error("invalid address or address not found")
+		logFor(ctx).Error("invalid address or address not found")
 		return EstimatedRewards{}, fmt.Errorf("address not found")
 	}
 
 	// inform to debug
-	log.Debugf("calculating rewards estimation for address [%s]", acc.Address.String())
+	logFor(ctx).Debugf("calculating rewards estimation for address [%s]", acc.Address.String())
 
 	// get the address balance
 	balance, err := repository.R().AccountBalance(&acc.Address)
 	if err != nil {
-		log.Errorf("can not get balance for address [%s]", acc.Address.String())
+		logFor(ctx).Errorf("can not get balance for address [%s]", acc.Address.String())
 		return EstimatedRewards{}, fmt.Errorf("address balance not found")
 	}
 
@@ -61,13 +62,13 @@ func (rs *rootResolver) estimateRewardsByAddress(addr *common.Address, ep *types
 }
 
 // EstimateRewards resolves reward estimation for the given address or amount staked.
-func (rs *rootResolver) EstimateRewards(args *struct {
+func (rs *rootResolver) EstimateRewards(ctx context.Context, args *struct {
 	Address *common.Address
 	Amount  *hexutil.Uint64
 }) (EstimatedRewards, error) {
 	// at least one of the parameters must be present
 	if args == nil || (args.Address == nil && args.Amount == nil) {
-		log.Error("can not calculate estimated rewards without parameters")
+		logFor(ctx).Error("can not calculate estimated rewards without parameters")
 		return EstimatedRewards{}, fmt.Errorf("missing both address and amount")
 	}
 
@@ -76,20 +77,20 @@ func (rs *rootResolver) EstimateRewards(args *struct {
 	// but we don't need that precise reflection here
 	ep, err := repository.R().CurrentSealedEpoch()
 	if err != nil {
-		log.Errorf("can not get the current sealed epoch information; %s", err.Error())
+		logFor(ctx).Errorf("can not get the current sealed epoch information; %s", err.Error())
 		return EstimatedRewards{}, fmt.Errorf("current sealed epoch not found")
 	}
 
 	// get the current total staked amount
 	total, err := repository.R().TotalStaked()
 	if err != nil {
-		log.Errorf("can not get the current total staked amount; %s", err.Error())
+		logFor(ctx).Errorf("can not get the current total staked amount; %s", err.Error())
 		return EstimatedRewards{}, fmt.Errorf("current total staked amount not found")
 	}
 
 	// if address is specified, pull the estimation from it
 	if args.Address != nil {
-		return rs.estimateRewardsByAddress(args.Address, ep, total)
+		return rs.estimateRewardsByAddress(ctx, args.Address, ep, total)
 	}
 	return NewEstimatedRewards(ep, args.Amount, total), nil
 }
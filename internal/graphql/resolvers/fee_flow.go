@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,7 +17,7 @@ const defaultFeeFlowListStartingRange = -30 * 24 * time.Hour
 type FeeFlowDaily types.FtmDailyBurn
 
 // DailyFeeFlow resolves the flow of transaction fees over time.
-func (rs *rootResolver) DailyFeeFlow(args struct {
+func (rs *rootResolver) DailyFeeFlow(ctx context.Context, args struct {
 	From *graphql.Time
 	To   *graphql.Time
 }) ([]*FeeFlowDaily, error) {
@@ -36,7 +37,7 @@ func (rs *rootResolver) DailyFeeFlow(args struct {
 	// load the data
 	list, err := repository.R().FeeFlow(args.From.Time, args.To.Time)
 	if err != nil {
-		log.Criticalf("failed to load fee flow list; %s", err.Error())
+		logFor(ctx).Criticalf("failed to load fee flow list; %s", err.Error())
 		return nil, err
 	}
 
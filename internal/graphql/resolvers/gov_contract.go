@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -40,13 +41,14 @@ func (rs *rootResolver) GovContract(args struct{ Address common.Address }) (*Gov
 // GovContracts resolves list of governance contracts details recognized by the API.
 func (rs *rootResolver) GovContracts() ([]*GovernanceContract, error) {
 	// do we know any contracts?
-	if nil == cfg.Governance.Contracts || 0 == len(cfg.Governance.Contracts) {
+	contracts := cfg.Governance.ContractList()
+	if 0 == len(contracts) {
 		return nil, fmt.Errorf("no governance contracts recognized")
 	}
 
 	// make the output array
-	res := make([]*GovernanceContract, len(cfg.Governance.Contracts))
-	for i, gc := range cfg.Governance.Contracts {
+	res := make([]*GovernanceContract, len(contracts))
+	for i, gc := range contracts {
 		// add to the structure
 		res[i] = &GovernanceContract{
 			Name:    gc.Name,
@@ -97,20 +99,20 @@ func (gc *GovernanceContract) Proposals(args *struct {
 
 // DelegationsBy resolves list of delegations an address has in context of the given
 // governance contract.
-func (gc *GovernanceContract) DelegationsBy(args struct{ From common.Address }) ([]common.Address, error) {
+func (gc *GovernanceContract) DelegationsBy(ctx context.Context, args struct{ From common.Address }) ([]common.Address, error) {
 	// decide by the contract type
 	switch gc.Type {
 	case "sfc":
-		return gc.sfcDelegationsBy(args.From)
+		return gc.sfcDelegationsBy(ctx, args.From)
 	}
 
 	// no delegations by default
-	log.Debugf("unknown governance type of %s", gc.Address.Hex())
+	logFor(ctx).Debugf("unknown governance type of %s", gc.Address.Hex())
 	return []common.Address{}, nil
 }
 
 // CanVote resolves if the given address can post votes in context of the given governance contract.
-func (gc *GovernanceContract) CanVote(args struct{ From common.Address }) (bool, error) {
+func (gc *GovernanceContract) CanVote(ctx context.Context, args struct{ From common.Address }) (bool, error) {
 	// decide by the contract type
 	switch gc.Type {
 	case "sfc":
@@ -118,12 +120,12 @@ func (gc *GovernanceContract) CanVote(args struct{ From common.Address }) (bool,
 	}
 
 	// voting disabled by default
-	log.Debugf("unknown governance type of %s", gc.Address.Hex())
+	logFor(ctx).Debugf("unknown governance type of %s", gc.Address.Hex())
 	return false, nil
 }
 
 // sfcDelegationsBy resolves delegations of the SFC type.
-func (gc *GovernanceContract) sfcDelegationsBy(addr common.Address) ([]common.Address, error) {
+func (gc *GovernanceContract) sfcDelegationsBy(ctx context.Context, addr common.Address) ([]common.Address, error) {
 	// get SFC delegations list
 	dl, err := repository.R().DelegationsByAddressAll(&addr)
 	if err != nil {
@@ -135,14 +137,14 @@ func (gc *GovernanceContract) sfcDelegationsBy(addr common.Address) ([]common.Ad
 	for _, d := range dl {
 		// is the delegation ok for voting?
 		if 0 == d.AmountDelegated.ToInt().Uint64() {
-			log.Debugf("delegation to %d from address %s is deactivated", d.ToStakerId, addr.String())
+			logFor(ctx).Debugf("delegation to %d from address %s is deactivated", d.ToStakerId, addr.String())
 			continue
 		}
 		res = append(res, d.ToStakerAddress)
 	}
 
 	// log delegations found
-	log.Debugf("%d delegations on %s", len(res), addr.String())
+	logFor(ctx).Debugf("%d delegations on %s", len(res), addr.String())
 	return res, nil
 }
 
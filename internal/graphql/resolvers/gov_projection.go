@@ -0,0 +1,68 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// GovernanceVoteInput represents an input structure of a hypothetical vote
+// used to project the outcome of a Governance Proposal.
+type GovernanceVoteInput struct {
+	Weight  hexutil.Big
+	Choices []hexutil.Uint64
+}
+
+// GovernanceVotingPower represents resolvable voting power of an address.
+type GovernanceVotingPower struct {
+	types.GovernanceVotingPower
+}
+
+// Projection resolves the projected outcome of the Governance Proposal.
+func (gp *GovernanceProposal) Projection(args *struct{ WhatIf *[]GovernanceVoteInput }) (*types.GovernanceProjection, error) {
+	var whatIf []*types.GovernanceVote
+	if args.WhatIf != nil {
+		whatIf = make([]*types.GovernanceVote, len(*args.WhatIf))
+		for i, v := range *args.WhatIf {
+			whatIf[i] = &types.GovernanceVote{
+				GovernanceId: gp.GovernanceId,
+				ProposalId:   gp.Id,
+				Weight:       v.Weight,
+				Choices:      v.Choices,
+			}
+		}
+	}
+	return repository.R().GovernanceProjection(gp.GovernanceId, &gp.Id, whatIf)
+}
+
+// VotingPowerOf resolves the voting power of the given address in context of the Governance contract.
+func (gc *GovernanceContract) VotingPowerOf(ctx context.Context, args struct{ Address common.Address }) (*GovernanceVotingPower, error) {
+	// decide by the contract type
+	switch gc.Type {
+	case "sfc":
+		vp, err := repository.R().GovernanceVotingPower(args.Address)
+		if err != nil {
+			return nil, err
+		}
+		return &GovernanceVotingPower{GovernanceVotingPower: *vp}, nil
+	}
+
+	// no voting power by default
+	logFor(ctx).Debugf("unknown governance type of %s", gc.Address.Hex())
+	return &GovernanceVotingPower{GovernanceVotingPower: types.GovernanceVotingPower{
+		Delegations: make([]*types.GovernanceDelegatedPower, 0),
+	}}, nil
+}
+
+// Total resolves the total voting power of the address.
+func (vp *GovernanceVotingPower) Total() hexutil.Big {
+	total := new(big.Int).Add(vp.Own.ToInt(), vp.Received.ToInt())
+	for _, d := range vp.Delegations {
+		total.Add(total, d.Weight.ToInt())
+	}
+	return hexutil.Big(*total)
+}
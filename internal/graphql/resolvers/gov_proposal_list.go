@@ -38,8 +38,9 @@ func (rs *rootResolver) GovProposals(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// prep list of governance contracts we are interested in
-	gcl := make([]common.Address, len(cfg.Governance.Contracts))
-	for i, gc := range cfg.Governance.Contracts {
+	contracts := cfg.Governance.ContractList()
+	gcl := make([]common.Address, len(contracts))
+	for i, gc := range contracts {
 		gcl[i] = gc.Address
 	}
 
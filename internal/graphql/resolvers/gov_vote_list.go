@@ -0,0 +1,160 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"math/big"
+)
+
+// GovernanceVoteList represents resolvable list of indexed Governance votes.
+type GovernanceVoteList struct {
+	types.GovernanceVoteList
+}
+
+// GovernanceVoteListEdge represents a single edge of the Governance votes list.
+type GovernanceVoteListEdge struct {
+	*types.GovernanceVoteRecord
+}
+
+// GovernanceProposalEvent represents resolvable life cycle event of a Governance Proposal.
+type GovernanceProposalEvent struct {
+	types.GovernanceProposalEvent
+}
+
+// Votes resolves the list of votes placed on the Governance Proposal.
+func (gp *GovernanceProposal) Votes(args *struct {
+	Cursor *Cursor
+	Count  int32
+}) (*GovernanceVoteList, error) {
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	cursor, err := governanceVoteCursor(args.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	vl, err := repository.R().GovernanceProposalVotes(gp.GovernanceId, &gp.Id, cursor, args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &GovernanceVoteList{GovernanceVoteList: *vl}, nil
+}
+
+// History resolves the life cycle events of the Governance Proposal.
+func (gp *GovernanceProposal) History() ([]*GovernanceProposalEvent, error) {
+	list, err := repository.R().GovernanceProposalEvents(gp.GovernanceId, &gp.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*GovernanceProposalEvent, len(list))
+	for i, ge := range list {
+		out[i] = &GovernanceProposalEvent{GovernanceProposalEvent: *ge}
+	}
+	return out, nil
+}
+
+// GovernanceVotes resolves the list of Governance votes placed by the account.
+func (acc *Account) GovernanceVotes(args *struct {
+	Cursor *Cursor
+	Count  int32
+}) (*GovernanceVoteList, error) {
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	cursor, err := governanceVoteCursor(args.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	vl, err := repository.R().GovernanceVotesBy(acc.Address, cursor, args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &GovernanceVoteList{GovernanceVoteList: *vl}, nil
+}
+
+// governanceVoteCursor decodes the ordinal index of the Governance votes list cursor.
+func governanceVoteCursor(c *Cursor) (*int64, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	orx, err := hexutil.DecodeUint64(string(*c))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %s", *c)
+	}
+
+	val := int64(orx)
+	return &val, nil
+}
+
+// TotalCount resolves the total number of votes in the list.
+func (vl *GovernanceVoteList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(vl.Total))
+}
+
+// PageInfo resolves the current page information for the Governance votes list.
+func (vl *GovernanceVoteList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(vl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(hexutil.EncodeUint64(uint64(vl.Collection[0].Ordinal)))
+	last := Cursor(hexutil.EncodeUint64(uint64(vl.Collection[len(vl.Collection)-1].Ordinal)))
+	return NewListPageInfo(&first, &last, !vl.IsEnd, !vl.IsStart)
+}
+
+// Edges resolves list of edges for the Governance votes list.
+func (vl *GovernanceVoteList) Edges() []*GovernanceVoteListEdge {
+	edges := make([]*GovernanceVoteListEdge, len(vl.Collection))
+	for i, gv := range vl.Collection {
+		edges[i] = &GovernanceVoteListEdge{GovernanceVoteRecord: gv}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (ve *GovernanceVoteListEdge) Cursor() Cursor {
+	return Cursor(hexutil.EncodeUint64(uint64(ve.Ordinal)))
+}
+
+// Vote resolves the vote of the edge.
+func (ve *GovernanceVoteListEdge) Vote() *types.GovernanceVote {
+	return &ve.GovernanceVote
+}
+
+// VotedAt resolves the time the vote was placed.
+func (ve *GovernanceVoteListEdge) VotedAt() graphql.Time {
+	return graphql.Time{Time: ve.TimeStamp}
+}
+
+// TrxHash resolves the hash of the transaction placing the vote.
+func (ve *GovernanceVoteListEdge) TrxHash() common.Hash {
+	return ve.Trx
+}
+
+// Proposal resolves the Governance Proposal the vote was placed on.
+func (ve *GovernanceVoteListEdge) Proposal() (*GovernanceProposal, error) {
+	gp, err := repository.R().GovernanceProposal(ve.GovernanceId, &ve.ProposalId)
+	if err != nil {
+		return nil, err
+	}
+	return NewGovernanceProposal(gp), nil
+}
+
+// TrxHash resolves the hash of the transaction emitting the event.
+func (ge *GovernanceProposalEvent) TrxHash() common.Hash {
+	return ge.Trx
+}
+
+// TimeStamp resolves the time of the event.
+func (ge *GovernanceProposalEvent) TimeStamp() graphql.Time {
+	return graphql.Time{Time: ge.GovernanceProposalEvent.TimeStamp}
+}
@@ -20,16 +20,16 @@ type ApiResolver interface {
 	Version() string
 
 	// Epochs resolves a list of epochs for the given cursor and count.
-	Epochs(args struct {
+	Epochs(ctx context.Context, args struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*EpochList, error)
 
 	// Account resolves blockchain account by address.
-	Account(struct{ Address common.Address }) (*Account, error)
+	Account(context.Context, struct{ Address common.Address }) (*Account, error)
 
 	// Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-	Contracts(*struct {
+	Contracts(context.Context, *struct {
 		ValidatedOnly bool
 		Cursor        *Cursor
 		Count         int32
@@ -38,25 +38,25 @@ type ApiResolver interface {
 	// ValidateContract resolves smart contract source code vs. deployed byte code and marks
 	// the contract as validated if the match is found. Peer API points are ringed on success
 	// to notify them about the change.
-	ValidateContract(*struct{ Contract ContractValidationInput }) (*Contract, error)
+	ValidateContract(context.Context, *struct{ Contract ContractValidationInput }) (*Contract, error)
 
 	// Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-	Block(*struct {
+	Block(context.Context, *struct {
 		Number *hexutil.Uint64
 		Hash   *common.Hash
 	}) (*Block, error)
 
 	// Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-	Blocks(*struct {
+	Blocks(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*BlockList, error)
 
 	// Transaction resolves blockchain transaction by hash.
-	Transaction(*struct{ Hash common.Hash }) (*Transaction, error)
+	Transaction(context.Context, *struct{ Hash common.Hash }) (*Transaction, error)
 
 	// Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-	Transactions(*struct {
+	Transactions(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*TransactionList, error)
@@ -71,7 +71,7 @@ type ApiResolver interface {
 	CurrentEpoch() (hexutil.Uint64, error)
 
 	// Epoch resolves information about epoch of the given id.
-	Epoch(*struct{ Id *hexutil.Uint64 }) (Epoch, error)
+	Epoch(context.Context, *struct{ Id *hexutil.Uint64 }) (Epoch, error)
 
 	// LastStakerId resolves the last staker id in Opera blockchain.
 	LastStakerId() (hexutil.Uint64, error)
@@ -86,13 +86,13 @@ type ApiResolver interface {
 	}) (*Staker, error)
 
 	// Stakers resolves a list of staker information from SFC smart contract.
-	Stakers() ([]*Staker, error)
+	Stakers(context.Context) ([]*Staker, error)
 
 	// StakersWithFlag resolves a list of stakers for the given type of flag.
-	StakersWithFlag(struct{ Flag string }) ([]*Staker, error)
+	StakersWithFlag(context.Context, struct{ Flag string }) ([]*Staker, error)
 
 	// ValidatorStatuses resolves a list of staker statuses from SFC smart contract.
-	ValidatorStatuses() ([]*types.ValidatorStatus, error)
+	ValidatorStatuses(context.Context) ([]*types.ValidatorStatus, error)
 
 	// Delegation resolves details of a delegator by its address.
 	Delegation(*struct {
@@ -118,7 +118,7 @@ type ApiResolver interface {
 	Price(*struct{ To string }) (types.Price, error)
 
 	// GasPrice resolves the current amount of WEI for single Gas.
-	GasPrice() (hexutil.Uint64, error)
+	GasPrice(context.Context) (hexutil.Uint64, error)
 
 	// EstimateGas resolves the estimated amount of Gas required to perform
 	// transaction described by the input params.
@@ -130,7 +130,7 @@ type ApiResolver interface {
 	}) (*hexutil.Uint64, error)
 
 	// EstimateRewards resolves reward estimation for the given address or amount staked.
-	EstimateRewards(*struct {
+	EstimateRewards(context.Context, *struct {
 		Address *common.Address
 		Amount  *hexutil.Uint64
 	}) (EstimatedRewards, error)
@@ -145,7 +145,7 @@ type ApiResolver interface {
 	}) (hexutil.Big, error)
 
 	// SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
-	SendTransaction(*struct{ Tx hexutil.Bytes }) (*Transaction, error)
+	SendTransaction(context.Context, *struct{ Tx hexutil.Bytes }) (*Transaction, error)
 
 	// DefiConfiguration resolves the current DeFi contract settings.
 	DefiConfiguration() (*DefiConfiguration, error)
@@ -182,7 +182,7 @@ type ApiResolver interface {
 
 	// FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 	// by the token owner for DeFi/fMint protocol operations.
-	FMintTokenAllowance(args *struct {
+	FMintTokenAllowance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) hexutil.Big
@@ -201,17 +201,17 @@ type ApiResolver interface {
 
 	// ErcTokenBalance resolves the current available balance of the specified token
 	// for the specified owner.
-	ErcTokenBalance(args *struct {
+	ErcTokenBalance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) hexutil.Big
 
 	// ErcTotalSupply resolves the current total supply of the specified token.
-	ErcTotalSupply(args *struct{ Token common.Address }) hexutil.Big
+	ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) hexutil.Big
 
 	// ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 	// by the token owner for the spender to be manipulated with.
-	ErcTokenAllowance(args *struct {
+	ErcTokenAllowance(ctx context.Context, args *struct {
 		Token   common.Address
 		Owner   common.Address
 		Spender common.Address
@@ -244,7 +244,7 @@ type ApiResolver interface {
 
 	// TrxGasSpeed resolves the gas consumption speed
 	// of the network in transactions processed per second.
-	TrxGasSpeed(args struct {
+	TrxGasSpeed(ctx context.Context, args struct {
 		Range int32
 		To    *string
 	}) (float64, error)
@@ -0,0 +1,307 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
+	"time"
+)
+
+const (
+	// loaderWait represents the time a loader collects keys before the batch is loaded.
+	loaderWait = 2 * time.Millisecond
+
+	// loaderMaxBatch represents the max number of keys loaded in a single batch.
+	loaderMaxBatch = 100
+)
+
+// loadersKey represents the context key of the request scoped loaders.
+type loadersKey struct{}
+
+// loaders represents a set of request scoped batch loaders. The loaders collect keys
+// requested by resolvers running in parallel, load them in batches, and keep the results
+// for the rest of the request, so the same entity is never loaded twice.
+type loaders struct {
+	blocks       *loader[hexutil.Uint64, *types.Block]
+	transactions *loader[common.Hash, *types.Transaction]
+	accounts     *loader[common.Address, *types.Account]
+	contracts    *loader[common.Address, *types.Contract]
+	erc20Tokens  *loader[common.Address, *types.Erc20Token]
+	validators   *loader[uint64, *types.Validator]
+}
+
+// WithLoaders attaches a new set of batch loaders to the given request context.
+// The loaders use the request context, so the batches are traced as a part of the request.
+func WithLoaders(ctx context.Context) context.Context {
+	repo := repository.R()
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		blocks: newLoader(func(nums []hexutil.Uint64) ([]*types.Block, error) {
+			return repo.BlocksByNumber(ctx, nums)
+		}),
+		transactions: newLoader(func(hashes []common.Hash) ([]*types.Transaction, error) {
+			return repo.TransactionsByHash(ctx, hashes)
+		}),
+		accounts: newLoader(func(addr []common.Address) ([]*types.Account, error) {
+			return repo.AccountsByAddress(ctx, addr)
+		}),
+		contracts: newLoader(func(addr []common.Address) ([]*types.Contract, error) {
+			return repo.ContractsByAddress(ctx, addr)
+		}),
+		erc20Tokens: newLoader(func(addr []common.Address) ([]*types.Erc20Token, error) {
+			return repo.Erc20TokensByAddress(ctx, addr)
+		}),
+		validators: newLoader(func(ids []uint64) ([]*types.Validator, error) {
+			vid := make([]hexutil.Big, len(ids))
+			for i, id := range ids {
+				vid[i] = (hexutil.Big)(*new(big.Int).SetUint64(id))
+			}
+			return repo.ValidatorsById(ctx, vid)
+		}),
+	})
+}
+
+// loadersOf provides the batch loaders of the given context, if any.
+func loadersOf(ctx context.Context) *loaders {
+	if ctx == nil {
+		return nil
+	}
+	ld, _ := ctx.Value(loadersKey{}).(*loaders)
+	return ld
+}
+
+// loadBlock loads the block of the given number.
+func loadBlock(ctx context.Context, num *hexutil.Uint64) (*types.Block, error) {
+	ld := loadersOf(ctx)
+	if ld == nil || num == nil {
+		return repository.R().BlockByNumber(num)
+	}
+
+	blk, err := ld.blocks.load(*num)
+	if err == nil && blk == nil {
+		err = repository.ErrBlockNotFound
+	}
+	return blk, err
+}
+
+// loadTransaction loads the transaction of the given hash.
+func loadTransaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().Transaction(hash)
+	}
+
+	trx, err := ld.transactions.load(*hash)
+	if err == nil && trx == nil {
+		err = repository.ErrTransactionNotFound
+	}
+	return trx, err
+}
+
+// loadTransactions loads the transactions of the given hashes in a single batch.
+func loadTransactions(ctx context.Context, hashes []*common.Hash) ([]*types.Transaction, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		list := make([]*types.Transaction, len(hashes))
+		for i, h := range hashes {
+			trx, err := repository.R().Transaction(h)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = trx
+		}
+		return list, nil
+	}
+
+	keys := make([]common.Hash, len(hashes))
+	for i, h := range hashes {
+		keys[i] = *h
+	}
+	list, err := ld.transactions.loadMany(keys)
+	if err != nil {
+		return nil, err
+	}
+	for _, trx := range list {
+		if trx == nil {
+			return nil, repository.ErrTransactionNotFound
+		}
+	}
+	return list, nil
+}
+
+// loadAccount loads the account of the given address.
+func loadAccount(ctx context.Context, addr *common.Address) (*types.Account, error) {
+	ld := loadersOf(ctx)
+	if ld == nil || addr == nil {
+		return repository.R().Account(addr)
+	}
+	return ld.accounts.load(*addr)
+}
+
+// loadContract loads the smart contract of the given address, nil if not found.
+func loadContract(ctx context.Context, addr *common.Address) (*types.Contract, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().Contract(addr)
+	}
+	return ld.contracts.load(*addr)
+}
+
+// loadErc20Token loads the ERC20 token of the given address.
+func loadErc20Token(ctx context.Context, addr *common.Address) (*types.Erc20Token, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().Erc20Token(addr)
+	}
+	return ld.erc20Tokens.load(*addr)
+}
+
+// loadValidator loads the validator of the given ID.
+func loadValidator(ctx context.Context, id *hexutil.Big) (*types.Validator, error) {
+	ld := loadersOf(ctx)
+	if ld == nil || id == nil || !id.ToInt().IsUint64() {
+		return repository.R().Validator(id)
+	}
+
+	val, err := ld.validators.load(id.ToInt().Uint64())
+	if err == nil && val == nil {
+		err = fmt.Errorf("validator #%d not found", id.ToInt().Uint64())
+	}
+	return val, err
+}
+
+// loader implements a batch loader of values identified by keys.
+type loader[K comparable, V any] struct {
+	fetch func([]K) ([]V, error)
+	mu    sync.Mutex
+	known map[K]*loaderResult[V]
+	batch *loaderBatch[K, V]
+}
+
+// loaderResult represents a value being loaded by the loader.
+type loaderResult[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// loaderBatch represents a list of keys waiting to be loaded together.
+type loaderBatch[K comparable, V any] struct {
+	keys    []K
+	results []*loaderResult[V]
+}
+
+// newLoader creates a new batch loader using the given fetch function.
+// The fetch function provides values in the order of the keys.
+func newLoader[K comparable, V any](fetch func([]K) ([]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch: fetch,
+		known: make(map[K]*loaderResult[V]),
+	}
+}
+
+// load provides the value of the given key; the key is loaded with other keys
+// requested in the meantime.
+func (l *loader[K, V]) load(key K) (V, error) {
+	l.mu.Lock()
+	res := l.enqueue(key)
+	l.mu.Unlock()
+
+	<-res.done
+	return res.val, res.err
+}
+
+// loadMany provides the values of the given keys; the keys are loaded right away.
+func (l *loader[K, V]) loadMany(keys []K) ([]V, error) {
+	l.mu.Lock()
+	res := make([]*loaderResult[V], len(keys))
+	for i, key := range keys {
+		res[i] = l.enqueue(key)
+	}
+	if b := l.batch; b != nil {
+		l.batch = nil
+		go l.exec(b)
+	}
+	l.mu.Unlock()
+
+	list := make([]V, len(keys))
+	for i, r := range res {
+		<-r.done
+		if r.err != nil {
+			return nil, r.err
+		}
+		list[i] = r.val
+	}
+	return list, nil
+}
+
+// enqueue adds the key to the pending batch, unless it's already known.
+// The caller is expected to hold the loader lock.
+func (l *loader[K, V]) enqueue(key K) *loaderResult[V] {
+	if res, ok := l.known[key]; ok {
+		return res
+	}
+
+	res := &loaderResult[V]{done: make(chan struct{})}
+	l.known[key] = res
+
+	// start a new batch and schedule its loading
+	if l.batch == nil {
+		b := &loaderBatch[K, V]{}
+		l.batch = b
+		time.AfterFunc(loaderWait, func() {
+			l.mu.Lock()
+			if l.batch != b {
+				l.mu.Unlock()
+				return
+			}
+			l.batch = nil
+			l.mu.Unlock()
+			l.exec(b)
+		})
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, res)
+
+	// the batch is full; load it right away
+	if len(l.batch.keys) >= loaderMaxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.exec(b)
+	}
+	return res
+}
+
+// exec loads the given batch and releases the resolvers waiting for the values.
+func (l *loader[K, V]) exec(b *loaderBatch[K, V]) {
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			log.Criticalf("batch loader crashed; %v", r)
+			err = fmt.Errorf("failed to load data")
+		}
+		for _, res := range b.results {
+			if err != nil {
+				res.err = err
+			}
+			close(res.done)
+		}
+	}()
+
+	vals, err := l.fetch(b.keys)
+	if err == nil && len(vals) != len(b.keys) {
+		err = fmt.Errorf("batch loader expected %d values, got %d", len(b.keys), len(vals))
+	}
+	if err != nil {
+		return
+	}
+	for i, res := range b.results {
+		res.val = vals[i]
+	}
+}
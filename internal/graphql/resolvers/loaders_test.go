@@ -0,0 +1,66 @@
+package resolvers
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestLoaderBatching(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+	ld := newLoader(func(keys []int) ([]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+
+		res := make([]string, len(keys))
+		for i, k := range keys {
+			res[i] = fmt.Sprintf("v%d", k)
+		}
+		return res, nil
+	})
+
+	// parallel loads of 10 keys, each requested twice
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			v, err := ld.load(k)
+			if err != nil || v != fmt.Sprintf("v%d", k) {
+				t.Errorf("key %d; unexpected value %q, error %v", k, v, err)
+			}
+		}(i % 10)
+	}
+	wg.Wait()
+
+	var total int
+	for _, b := range batches {
+		total += len(b)
+	}
+	if total != 10 {
+		t.Errorf("expected 10 keys loaded, got %d in %d batches", total, len(batches))
+	}
+
+	// known keys are not loaded again
+	list, err := ld.loadMany([]int{1, 2, 3, 42})
+	if err != nil || len(list) != 4 || list[3] != "v42" {
+		t.Errorf("unexpected list %v, error %v", list, err)
+	}
+	if last := batches[len(batches)-1]; len(last) != 1 || last[0] != 42 {
+		t.Errorf("expected only the new key to be loaded, got %v", last)
+	}
+}
+
+func TestLoaderError(t *testing.T) {
+	ld := newLoader(func(keys []int) ([]int, error) {
+		return nil, fmt.Errorf("failed")
+	})
+	if _, err := ld.load(1); err == nil {
+		t.Errorf("error expected")
+	}
+	if _, err := ld.loadMany([]int{2, 3}); err == nil {
+		t.Errorf("error expected")
+	}
+}
@@ -0,0 +1,329 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/graph-gophers/graphql-go"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// NetworkNodeFilter represents an input structure used
+// to filter the list of Opera network nodes.
+type NetworkNodeFilter struct {
+	IsOpera       *bool
+	IsSynced      *bool
+	IsResponsive  *bool
+	Client        *string
+	ClientVersion *string
+	Country       *string
+}
+
+// NetworkNode represents resolvable Opera network node.
+type NetworkNode struct {
+	types.OperaNode
+}
+
+// NetworkNodeList represents resolvable list of Opera network nodes.
+type NetworkNodeList struct {
+	types.OperaNodeList
+}
+
+// NetworkNodeListEdge represents a single edge of the Opera network nodes list.
+type NetworkNodeListEdge struct {
+	Node *NetworkNode
+}
+
+// NetworkNodeVersionGroup represents an aggregated Opera nodes group with a common client version.
+type NetworkNodeVersionGroup struct {
+	list *NetworkNodeVersionGroupList
+	types.OperaNodeVersionAggregate
+}
+
+// NetworkNodeVersionGroupList represents a list of Opera nodes aggregated by client version.
+type NetworkNodeVersionGroupList struct {
+	TotalCount int32
+	Groups     []*NetworkNodeVersionGroup
+}
+
+// NetworkNodes resolves a page of the Opera network nodes matching the filter, the most recently seen first.
+func (rs *rootResolver) NetworkNodes(ctx context.Context, args *struct {
+	Filter *NetworkNodeFilter
+	Cursor *Cursor
+	Count  int32
+}) (*NetworkNodeList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	var cursor *types.OperaNodeListCursor
+	if args.Cursor != nil {
+		var err error
+		cursor, err = decodeNodeCursor(*args.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list, err := repository.R().NetworkNodes(args.Filter.nodeFilter(), cursor, int64(args.Count))
+	if err != nil {
+		logFor(ctx).Errorf("can not get network nodes; %s", err.Error())
+		return nil, err
+	}
+	return &NetworkNodeList{OperaNodeList: *list}, nil
+}
+
+// nodeFilter converts the input filter to the Opera network nodes filter.
+func (nf *NetworkNodeFilter) nodeFilter() *types.OperaNodeFilter {
+	if nf == nil {
+		return nil
+	}
+	return &types.OperaNodeFilter{
+		IsOpera:       nf.IsOpera,
+		IsSynced:      nf.IsSynced,
+		IsResponsive:  nf.IsResponsive,
+		Client:        nf.Client,
+		ClientVersion: nf.ClientVersion,
+		Country:       nf.Country,
+	}
+}
+
+// TotalCount resolves the total number of network nodes matching the filter.
+func (nl *NetworkNodeList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(nl.Total))
+}
+
+// PageInfo resolves the current page information for the network nodes list.
+func (nl *NetworkNodeList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(nl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := nodeCursor(nl.Collection[0])
+	last := nodeCursor(nl.Collection[len(nl.Collection)-1])
+	return NewListPageInfo(&first, &last, !nl.IsEnd, !nl.IsStart)
+}
+
+// Edges resolves list of edges for the network nodes list.
+func (nl *NetworkNodeList) Edges() []*NetworkNodeListEdge {
+	edges := make([]*NetworkNodeListEdge, len(nl.Collection))
+	for i, nn := range nl.Collection {
+		edges[i] = &NetworkNodeListEdge{Node: &NetworkNode{OperaNode: *nn}}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (ne *NetworkNodeListEdge) Cursor() Cursor {
+	return nodeCursor(&ne.Node.OperaNode)
+}
+
+// nodeCursor encodes the position of the given node in the list of nodes;
+// the time the node was last seen in milliseconds and its network identifier.
+func nodeCursor(nn *types.OperaNode) Cursor {
+	return Cursor(fmt.Sprintf("%s.%s", hexutil.EncodeUint64(uint64(nn.LastSeen.UnixMilli())), nn.Node.ID().String()))
+}
+
+// decodeNodeCursor decodes the position of a node in the list of nodes.
+func decodeNodeCursor(c Cursor) (*types.OperaNodeListCursor, error) {
+	parts := strings.Split(string(c), ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	ms, err := hexutil.DecodeUint64(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %s", c)
+	}
+
+	id, err := enode.ParseID(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %s", c)
+	}
+	return &types.OperaNodeListCursor{LastSeen: time.UnixMilli(int64(ms)).UTC(), Id: id}, nil
+}
+
+// Id resolves the network identifier of the node.
+func (nn *NetworkNode) Id() string {
+	return nn.Node.ID().String()
+}
+
+// Enode resolves the enode URL of the node.
+func (nn *NetworkNode) Enode() string {
+	return nn.Node.URLv4()
+}
+
+// Ip resolves the IP address of the node.
+func (nn *NetworkNode) Ip() *string {
+	if nn.Node.IP() == nil {
+		return nil
+	}
+	ip := nn.Node.IP().String()
+	return &ip
+}
+
+// Port resolves the TCP port of the node.
+func (nn *NetworkNode) Port() int32 {
+	return int32(nn.Node.TCP())
+}
+
+// Name resolves the node name advertised in the p2p communication, if known.
+func (nn *NetworkNode) Name() *string {
+	if nn.NodeInformation == nil {
+		return nil
+	}
+	return &nn.NodeInformation.Name
+}
+
+// Client resolves the name of the client software of the node, if known.
+func (nn *NetworkNode) Client() *string {
+	if nn.NodeInformation == nil || nn.NodeInformation.Client == "" {
+		return nil
+	}
+	return &nn.NodeInformation.Client
+}
+
+// ClientVersion resolves the version of the client software of the node, if known.
+func (nn *NetworkNode) ClientVersion() *string {
+	if nn.NodeInformation == nil || nn.NodeInformation.ClientVersion == "" {
+		return nil
+	}
+	return &nn.NodeInformation.ClientVersion
+}
+
+// ProtocolVersion resolves the p2p protocol version of the node, if known.
+func (nn *NetworkNode) ProtocolVersion() *string {
+	if nn.NodeInformation == nil {
+		return nil
+	}
+	return &nn.NodeInformation.Version
+}
+
+// Protocols resolves the list of protocol capabilities of the node, if known.
+func (nn *NetworkNode) Protocols() *string {
+	if nn.NodeInformation == nil {
+		return nil
+	}
+	return &nn.NodeInformation.Protocols
+}
+
+// IsOpera resolves the flag of the node being confirmed as an Opera node.
+func (nn *NetworkNode) IsOpera() bool {
+	return nn.NodeInformation != nil && nn.NodeInformation.IsOperaConfirmed
+}
+
+// IsSynced resolves the flag of the node being in sync with the network.
+func (nn *NetworkNode) IsSynced() bool {
+	return nn.NodeInformation != nil && nn.NodeInformation.IsSynced
+}
+
+// Epoch resolves the epoch the node reported, if known.
+func (nn *NetworkNode) Epoch() *hexutil.Uint64 {
+	if nn.NodeInformation == nil {
+		return nil
+	}
+	val := hexutil.Uint64(nn.NodeInformation.Epoch)
+	return &val
+}
+
+// BlockHeight resolves the block height the node reported, if known.
+func (nn *NetworkNode) BlockHeight() *hexutil.Uint64 {
+	if nn.NodeInformation == nil {
+		return nil
+	}
+	val := hexutil.Uint64(nn.NodeInformation.BlockHeight)
+	return &val
+}
+
+// InfoUpdated resolves the time the node information was obtained, if known.
+func (nn *NetworkNode) InfoUpdated() *graphql.Time {
+	if nn.NodeInformation == nil || nn.NodeInformation.Updated.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: nn.NodeInformation.Updated}
+}
+
+// FirstSeen resolves the time the node was discovered.
+func (nn *NetworkNode) FirstSeen() graphql.Time {
+	return graphql.Time{Time: nn.Found}
+}
+
+// LastSeen resolves the time of the last successful contact with the node.
+func (nn *NetworkNode) LastSeen() graphql.Time {
+	return graphql.Time{Time: nn.OperaNode.LastSeen}
+}
+
+// LastCheck resolves the time of the last attempt to contact the node.
+func (nn *NetworkNode) LastCheck() graphql.Time {
+	return graphql.Time{Time: nn.OperaNode.LastCheck}
+}
+
+// Fails resolves the number of failed checks of the node since the last successful contact.
+func (nn *NetworkNode) Fails() int32 {
+	return int32(nn.OperaNode.Fails)
+}
+
+// Score resolves the liveliness score of the node.
+func (nn *NetworkNode) Score() int32 {
+	return int32(nn.OperaNode.Score)
+}
+
+// Location resolves the geographic location of the node.
+func (nn *NetworkNode) Location() *types.GeoLocation {
+	return &nn.OperaNode.Location
+}
+
+// NetworkNodesByVersion resolves a list of active Opera nodes aggregated by their client version.
+func (rs *rootResolver) NetworkNodesByVersion() (*NetworkNodeVersionGroupList, error) {
+	list, err := repository.R().NetworkNodesVersionAggregated()
+	if err != nil {
+		return nil, err
+	}
+
+	nl := NetworkNodeVersionGroupList{
+		TotalCount: 0,
+		Groups:     make([]*NetworkNodeVersionGroup, len(list)),
+	}
+	for i, ag := range list {
+		nl.Groups[i] = &NetworkNodeVersionGroup{
+			list:                      &nl,
+			OperaNodeVersionAggregate: *ag,
+		}
+		nl.TotalCount += ag.Count
+	}
+	return &nl, nil
+}
+
+// Client resolves the name of the client software of the group, if known.
+func (vg *NetworkNodeVersionGroup) Client() *string {
+	if vg.OperaNodeVersionAggregate.Client == "" {
+		return nil
+	}
+	return &vg.OperaNodeVersionAggregate.Client
+}
+
+// Version resolves the version of the client software of the group, if known.
+func (vg *NetworkNodeVersionGroup) Version() *string {
+	if vg.OperaNodeVersionAggregate.Version == "" {
+		return nil
+	}
+	return &vg.OperaNodeVersionAggregate.Version
+}
+
+// Pct provides percentage of the group in the list.
+// The number is provided as fixed point integer with 1 decimal precision (i.e. 258 = 25.8%, 1000 = 100%)
+func (vg *NetworkNodeVersionGroup) Pct() int32 {
+	if vg.list == nil || vg.list.TotalCount == 0 {
+		return 0
+	}
+	return int32(math.Round(float64(vg.Count) * 1000 / float64(vg.list.TotalCount)))
+}
@@ -0,0 +1,98 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+// networkStatsMaxPoints represents the max number of network stats points provided in one request.
+const networkStatsMaxPoints = 1000
+
+// networkStatsDefaultRange represents the default time range of the network stats.
+const networkStatsDefaultRange = 30 * 24 * time.Hour
+
+// networkStatsResolutions maps the network stats resolution options to their time periods.
+var networkStatsResolutions = map[string]time.Duration{
+	"HOUR": time.Hour,
+	"DAY":  24 * time.Hour,
+	"WEEK": 7 * 24 * time.Hour,
+}
+
+// networkStatsGroupings maps the network stats grouping options to the snapshot groups.
+var networkStatsGroupings = map[string]string{
+	"COUNTRY":        types.NetworkStatsByCountry,
+	"CONTINENT":      types.NetworkStatsByContinent,
+	"CLIENT_VERSION": types.NetworkStatsByVersion,
+	"HOSTING":        types.NetworkStatsByHost,
+	"SYNCED":         types.NetworkStatsBySynced,
+}
+
+// NetworkStatsPoint represents resolvable snapshot of the network topology.
+type NetworkStatsPoint struct {
+	types.NetworkStats
+	groupBy string
+}
+
+// NetworkStats resolves the network topology snapshots in the given time range
+// grouped by the given property of the nodes.
+func (rs *rootResolver) NetworkStats(args *struct {
+	From       *hexutil.Uint64
+	To         *hexutil.Uint64
+	Resolution string
+	GroupBy    string
+}) ([]*NetworkStatsPoint, error) {
+	res, ok := networkStatsResolutions[args.Resolution]
+	if !ok {
+		return nil, fmt.Errorf("unknown resolution %s", args.Resolution)
+	}
+	groupBy, ok := networkStatsGroupings[args.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown grouping %s", args.GroupBy)
+	}
+
+	// decode the time range
+	to := time.Now().UTC()
+	if args.To != nil {
+		to = time.Unix(int64(*args.To), 0).UTC()
+	}
+	from := to.Add(-networkStatsDefaultRange)
+	if args.From != nil {
+		from = time.Unix(int64(*args.From), 0).UTC()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("invalid time range")
+	}
+	if to.Sub(from)/res > networkStatsMaxPoints {
+		return nil, fmt.Errorf("too many data points requested, use lower resolution")
+	}
+
+	list, err := repository.R().NetworkStats(from, to, res)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]*NetworkStatsPoint, len(list))
+	for i, ns := range list {
+		points[i] = &NetworkStatsPoint{NetworkStats: *ns, groupBy: groupBy}
+	}
+	return points, nil
+}
+
+// Time resolves the time the snapshot was taken.
+func (nsp *NetworkStatsPoint) Time() graphql.Time {
+	return graphql.Time{Time: nsp.Stamp}
+}
+
+// Groups resolves the active nodes grouped by the requested property.
+func (nsp *NetworkStatsPoint) Groups() []types.NetworkStatsGroup {
+	list := nsp.NetworkStats.Groups(nsp.groupBy)
+	if list == nil {
+		return make([]types.NetworkStatsGroup, 0)
+	}
+	return list
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/cmd/apiserver/build"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
@@ -10,6 +11,7 @@ import (
 	"fmt"
 	"golang.org/x/sync/singleflight"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -35,12 +37,19 @@ type rootResolver struct {
 	unsubscribeOnBlock chan string
 	blockSubscribers   map[string]*subscriptOnBlock
 	onBlockEvents      chan *types.Block
+	head               atomic.Uint64
 
 	// transaction subscriptions management
 	subscribeOnTrx   chan *subscriptOnTrx
 	unsubscribeOnTrx chan string
 	trxSubscribers   map[string]*subscriptOnTrx
 	onTrxEvents      chan *types.Transaction
+
+	// fLend health factor subscriptions management
+	subscribeOnHealth   chan *subscriptOnHealth
+	unsubscribeOnHealth chan string
+	healthSubscribers   map[string]*subscriptOnHealth
+	onHealthEvents      chan *types.FLendPosition
 }
 
 // log represents the logger to be used by the repository.
@@ -56,6 +65,13 @@ func SetLogger(l logger.Logger) {
 	log = l
 }
 
+// logFor provides the logger tagged with the request identifier of the given context.
+// Resolvers logging on behalf of a request take the request context for this purpose;
+// the background work, i.e. the subscriptions dispatch, uses the package logger.
+func logFor(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, log)
+}
+
 // SetConfig sets the repository configuration to be used to establish
 // and maintain external repository connections.
 func SetConfig(c *config.Config) {
@@ -87,6 +103,12 @@ func New() ApiResolver {
 		unsubscribeOnTrx: make(chan string, subscriptionQueueCapacity),
 		trxSubscribers:   make(map[string]*subscriptOnTrx, subscriptionInitialCapacity),
 		onTrxEvents:      make(chan *types.Transaction, onBlockChannelCapacity),
+
+		// fLend health factor subscription basics
+		subscribeOnHealth:   make(chan *subscriptOnHealth, subscriptionQueueCapacity),
+		unsubscribeOnHealth: make(chan string, subscriptionQueueCapacity),
+		healthSubscribers:   make(map[string]*subscriptOnHealth, subscriptionInitialCapacity),
+		onHealthEvents:      make(chan *types.FLendPosition, onHealthChannelCapacity),
 	}
 
 	// pass subscription data source channels to the service manager
@@ -94,6 +116,7 @@ func New() ApiResolver {
 	sm := svc.Manager()
 	sm.SetBlockChannel(rs.onBlockEvents)
 	sm.SetTrxChannel(rs.onTrxEvents)
+	sm.SetFLendPositionChannel(rs.onHealthEvents)
 
 	// handle broadcast and subscriptions in a separate routine
 	rs.wg.Add(1)
@@ -136,17 +159,27 @@ func (rs *rootResolver) run() {
 		case id := <-rs.unsubscribeOnTrx:
 			delete(rs.trxSubscribers, id)
 
+		case id := <-rs.unsubscribeOnHealth:
+			delete(rs.healthSubscribers, id)
+
 		case sub := <-rs.subscribeOnBlock:
 			rs.addBlockSubscriber(sub)
 
 		case sub := <-rs.subscribeOnTrx:
 			rs.addTrxSubscriber(sub)
 
+		case sub := <-rs.subscribeOnHealth:
+			rs.addHealthSubscriber(sub)
+
 		case evt := <-rs.onBlockEvents:
+			rs.head.Store(uint64(evt.Number))
 			rs.dispatchOnBlock(evt)
 
 		case evt := <-rs.onTrxEvents:
 			rs.dispatchOnTransaction(evt)
+
+		case evt := <-rs.onHealthEvents:
+			rs.dispatchOnHealth(evt)
 		}
 	}
 }
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,7 +23,7 @@ func NewEpochList(el *types.EpochList) *EpochList {
 }
 
 // Epochs resolves a list of epochs for the given cursor and count.
-func (rs *rootResolver) Epochs(args struct {
+func (rs *rootResolver) Epochs(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*EpochList, error) {
@@ -33,7 +34,7 @@ func (rs *rootResolver) Epochs(args struct {
 	// get the transaction hash list from repository
 	epl, err := repository.R().Epochs((*string)(args.Cursor), args.Count)
 	if err != nil {
-		log.Errorf("can not get epoch list; %s", err.Error())
+		logFor(ctx).Errorf("can not get epoch list; %s", err.Error())
 		return nil, err
 	}
 	return NewEpochList(epl), nil
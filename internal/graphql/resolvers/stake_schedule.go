@@ -0,0 +1,107 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+const (
+	// defaultStakeScheduleRange represents the default time span of the stake unlock schedule.
+	defaultStakeScheduleRange = 90 * 24 * time.Hour
+
+	// maxStakeScheduleRange represents the max time span of the stake unlock schedule.
+	maxStakeScheduleRange = 2 * 366 * 24 * time.Hour
+)
+
+// StakeScheduleItem represents resolvable upcoming unlock, or withdrawal of a delegation.
+type StakeScheduleItem struct {
+	types.StakeScheduleItem
+}
+
+// StakeScheduleTick represents resolvable aggregated stake unlock schedule tick.
+type StakeScheduleTick struct {
+	types.StakeScheduleTick
+}
+
+// StakingSchedule resolves the list of upcoming lock expirations and withdrawals of the account.
+func (acc *Account) StakingSchedule() ([]*StakeScheduleItem, error) {
+	sch, err := repository.R().StakingSchedule(&acc.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*StakeScheduleItem, len(sch))
+	for i, it := range sch {
+		list[i] = &StakeScheduleItem{StakeScheduleItem: *it}
+	}
+	return list, nil
+}
+
+// StakeUnlockSchedule resolves the network-wide schedule of stake unlocking
+// and becoming withdrawable in the given time range.
+func (rs *rootResolver) StakeUnlockSchedule(args struct {
+	From       *time.Time
+	To         *time.Time
+	Resolution *string
+}) ([]*StakeScheduleTick, error) {
+	from := time.Now().UTC()
+	if args.From != nil {
+		from = args.From.UTC()
+	}
+
+	to := from.Add(defaultStakeScheduleRange)
+	if args.To != nil {
+		to = args.To.UTC()
+	}
+
+	if !to.After(from) || to.Sub(from) > maxStakeScheduleRange {
+		return nil, fmt.Errorf("invalid schedule time range")
+	}
+
+	resolution := repository.StakeScheduleDay
+	if args.Resolution != nil {
+		resolution = *args.Resolution
+	}
+	if resolution != repository.StakeScheduleDay && resolution != repository.StakeScheduleWeek && resolution != repository.StakeScheduleMonth {
+		return nil, fmt.Errorf("unknown schedule resolution %s", resolution)
+	}
+
+	sch, err := repository.R().StakeUnlockSchedule(from, to, resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*StakeScheduleTick, len(sch))
+	for i, t := range sch {
+		list[i] = &StakeScheduleTick{StakeScheduleTick: *t}
+	}
+	return list, nil
+}
+
+// Date resolves the date of the scheduled event.
+func (si *StakeScheduleItem) Date() graphql.Time {
+	return graphql.Time{Time: si.StakeScheduleItem.Date}
+}
+
+// IsClaimable resolves the flag of a withdrawal being claimable already.
+func (si *StakeScheduleItem) IsClaimable() bool {
+	return si.Type == types.StakeScheduleWithdrawal && !si.StakeScheduleItem.Date.After(time.Now())
+}
+
+// Date resolves the start of the schedule tick.
+func (st *StakeScheduleTick) Date() graphql.Time {
+	return graphql.Time{Time: st.StakeScheduleTick.Date}
+}
+
+// UnlockCount resolves the number of lock expirations in the tick.
+func (st *StakeScheduleTick) UnlockCount() int32 {
+	return int32(st.StakeScheduleTick.UnlockCount)
+}
+
+// WithdrawCount resolves the number of withdrawals becoming claimable in the tick.
+func (st *StakeScheduleTick) WithdrawCount() int32 {
+	return int32(st.StakeScheduleTick.WithdrawCount)
+}
@@ -0,0 +1,67 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// stakerEpochHistoryDefaultLength represents the number of epochs provided
+// by the staker epoch history if the range start is not specified.
+const stakerEpochHistoryDefaultLength = 100
+
+// StakerEpoch represents resolvable snapshot of a staker taken at the epoch seal.
+type StakerEpoch struct {
+	types.ValidatorEpoch
+}
+
+// EpochHistory resolves the per-epoch performance history of the staker
+// for the given range of epochs. The most recent epochs are provided if the range is not given.
+func (st Staker) EpochHistory(ctx context.Context, args struct {
+	From *hexutil.Uint64
+	To   *hexutil.Uint64
+}) ([]*StakerEpoch, error) {
+	// the range ends at the last sealed epoch by default
+	var to uint64
+	if args.To != nil {
+		to = uint64(*args.To)
+	} else {
+		ep, err := repository.R().CurrentSealedEpoch()
+		if err != nil {
+			return nil, err
+		}
+		to = uint64(ep.Id)
+	}
+
+	// the range starts at the default length from the end
+	var from uint64
+	if args.From != nil {
+		from = uint64(*args.From)
+	} else if to > stakerEpochHistoryDefaultLength {
+		from = to - stakerEpochHistoryDefaultLength + 1
+	}
+
+	vl, err := repository.R().ValidatorEpochs(&st.Id, from, to)
+	if err != nil {
+		logFor(ctx).Errorf("can not get epoch history of validator #%d; %s", st.Id.ToInt().Uint64(), err.Error())
+		return nil, err
+	}
+
+	list := make([]*StakerEpoch, len(vl))
+	for i, ve := range vl {
+		list[i] = &StakerEpoch{ValidatorEpoch: *ve}
+	}
+	return list, nil
+}
+
+// MissedBlocks resolves the number of blocks the staker missed as recorded at the epoch seal.
+func (se *StakerEpoch) MissedBlocks() hexutil.Uint64 {
+	return se.OfflineBlocks
+}
+
+// Downtime resolves the number of seconds the staker was offline as recorded at the epoch seal.
+func (se *StakerEpoch) Downtime() hexutil.Uint64 {
+	return se.OfflineTime
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -9,13 +10,13 @@ import (
 )
 
 // Stakers resolves a list of staker information from SFC smart contract.
-func (rs *rootResolver) Stakers() ([]*Staker, error) {
-	return loadStakersFiltered(func(v *types.Validator) bool { return v != nil })
+func (rs *rootResolver) Stakers(ctx context.Context) ([]*Staker, error) {
+	return loadStakersFiltered(ctx, func(v *types.Validator) bool { return v != nil })
 }
 
 // StakersWithFlag resolves a list of stakers for the given type of flag.
-func (rs *rootResolver) StakersWithFlag(args struct{ Flag string }) ([]*Staker, error) {
-	return loadStakersFiltered(func(v *types.Validator) bool {
+func (rs *rootResolver) StakersWithFlag(ctx context.Context, args struct{ Flag string }) ([]*Staker, error) {
+	return loadStakersFiltered(ctx, func(v *types.Validator) bool {
 		if v == nil {
 			return false
 		}
@@ -30,7 +31,7 @@ func (rs *rootResolver) StakersWithFlag(args struct{ Flag string }) ([]*Staker,
 		case "IS_CHEATER":
 			return uint64(v.Status)&SfcStatusDoubleSign > 0
 		default:
-			log.Errorf("unknown flag filter %s", args.Flag)
+			logFor(ctx).Errorf("unknown flag filter %s", args.Flag)
 		}
 		return false
 	})
@@ -38,11 +39,11 @@ func (rs *rootResolver) StakersWithFlag(args struct{ Flag string }) ([]*Staker,
 
 // loadStakersFiltered loads list of validators check each one if it can be added to the output list
 // using a provided callback check.
-func loadStakersFiltered(check func(*types.Validator) bool) ([]*Staker, error) {
+func loadStakersFiltered(ctx context.Context, check func(*types.Validator) bool) ([]*Staker, error) {
 	// get the number
 	num, err := repository.R().LastValidatorId()
 	if err != nil {
-		log.Errorf("can not get the highest staker id; %s", err.Error())
+		logFor(ctx).Errorf("can not get the highest staker id; %s", err.Error())
 		return nil, err
 	}
 
@@ -52,13 +53,13 @@ func loadStakersFiltered(check func(*types.Validator) bool) ([]*Staker, error) {
 		// extract the staker info
 		st, err := repository.R().Validator((*hexutil.Big)(new(big.Int).SetUint64(i)))
 		if err != nil {
-			log.Criticalf("can not extract staker #%d information; %s", i, err.Error())
+			logFor(ctx).Criticalf("can not extract staker #%d information; %s", i, err.Error())
 			continue
 		}
 
 		// staker not valid?
 		if st.Id.ToInt().Uint64() == 0 {
-			log.Debugf("staker #%d has invalid ID", i)
+			logFor(ctx).Debugf("staker #%d has invalid ID", i)
 			continue
 		}
 
@@ -69,7 +70,7 @@ func loadStakersFiltered(check func(*types.Validator) bool) ([]*Staker, error) {
 	}
 
 	// inform
-	log.Debugf("found %d stakers", len(list))
+	logFor(ctx).Debugf("found %d stakers", len(list))
 
 	// sort the list by total amount delegated and return the result
 	sort.Sort(StakesByTotalStaked(list))
@@ -96,8 +97,8 @@ func (s StakesByTotalStaked) Swap(i, j int) {
 }
 
 // ValidatorStatuses resolves a list of staker statuses from SFC smart contract.
-func (rs *rootResolver) ValidatorStatuses() ([]*types.ValidatorStatus, error) {
-	stakers, err := rs.Stakers()
+func (rs *rootResolver) ValidatorStatuses(ctx context.Context) ([]*types.ValidatorStatus, error) {
+	stakers, err := rs.Stakers(ctx)
 	if err != nil {
 		return nil, err
 	}
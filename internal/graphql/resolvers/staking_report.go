@@ -0,0 +1,119 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"time"
+)
+
+// StakingReport represents resolvable staking rewards report.
+type StakingReport struct {
+	types.StakingReport
+}
+
+// StakingReportEntry represents resolvable staking report event.
+type StakingReportEntry struct {
+	types.StakingReportEntry
+}
+
+// StakingReportTotal represents resolvable staking report total.
+type StakingReportTotal struct {
+	types.StakingReportTotal
+}
+
+// StakingReport resolves the staking rewards report of the given account.
+func (rs *rootResolver) StakingReport(args struct {
+	Address  common.Address
+	Currency string
+	From     *time.Time
+	To       *time.Time
+}) (*StakingReport, error) {
+	rep, err := repository.R().StakingReport(&args.Address, args.Currency, stakingReportFrom(args.From, args.To), stakingReportTo(args.To))
+	if err != nil {
+		return nil, err
+	}
+	return &StakingReport{StakingReport: *rep}, nil
+}
+
+// stakingReportTo provides the end of the staking report period.
+func stakingReportTo(to *time.Time) time.Time {
+	if to == nil {
+		return time.Now().UTC()
+	}
+	return to.UTC()
+}
+
+// stakingReportFrom provides the start of the staking report period;
+// the start of the year of the period end is used by default.
+func stakingReportFrom(from *time.Time, to *time.Time) time.Time {
+	if from != nil {
+		return from.UTC()
+	}
+	return time.Date(stakingReportTo(to).Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// FromTime resolves the start of the reported period.
+func (sr *StakingReport) FromTime() graphql.Time {
+	return graphql.Time{Time: sr.From}
+}
+
+// ToTime resolves the end of the reported period.
+func (sr *StakingReport) ToTime() graphql.Time {
+	return graphql.Time{Time: sr.To}
+}
+
+// Entries resolves the list of reported events.
+func (sr *StakingReport) Entries() []*StakingReportEntry {
+	list := make([]*StakingReportEntry, len(sr.StakingReport.Entries))
+	for i, e := range sr.StakingReport.Entries {
+		list[i] = &StakingReportEntry{StakingReportEntry: *e}
+	}
+	return list
+}
+
+// Totals resolves the list of totals per year and event type.
+func (sr *StakingReport) Totals() []*StakingReportTotal {
+	list := make([]*StakingReportTotal, len(sr.StakingReport.Totals))
+	for i, t := range sr.StakingReport.Totals {
+		list[i] = &StakingReportTotal{StakingReportTotal: *t}
+	}
+	return list
+}
+
+// TimeStamp resolves the time of the event.
+func (se *StakingReportEntry) TimeStamp() graphql.Time {
+	return graphql.Time{Time: se.StakingReportEntry.TimeStamp}
+}
+
+// Epoch resolves the id of the epoch of the event.
+func (se *StakingReportEntry) Epoch() hexutil.Uint64 {
+	return hexutil.Uint64(se.StakingReportEntry.Epoch)
+}
+
+// TrxHash resolves the hash of the transaction of the event.
+func (se *StakingReportEntry) TrxHash() common.Hash {
+	return se.Trx
+}
+
+// HasPrice resolves the availability of the price history for the time of the event.
+func (se *StakingReportEntry) HasPrice() bool {
+	return se.Price != nil
+}
+
+// Year resolves the calendar year of the total.
+func (st *StakingReportTotal) Year() int32 {
+	return int32(st.StakingReportTotal.Year)
+}
+
+// Count resolves the number of events of the total.
+func (st *StakingReportTotal) Count() int32 {
+	return int32(st.StakingReportTotal.Count)
+}
+
+// Unvalued resolves the number of events without known fiat value.
+func (st *StakingReportTotal) Unvalued() int32 {
+	return int32(st.StakingReportTotal.Unvalued)
+}
@@ -0,0 +1,99 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// onHealthChannelCapacity is the number of fLend position events held in memory for being broadcast to subscriber.
+const onHealthChannelCapacity = 100
+
+// subscriptOnHealth represents reference to a subscriber to onHealthFactorBelow events broadcast.
+type subscriptOnHealth struct {
+	address   common.Address
+	threshold float64
+	stop      <-chan struct{}
+	events    chan<- *FLendPosition
+}
+
+// OnHealthFactorBelow resolves subscription to fLend position health factor refresh broadcast
+// of the given account. Only positions with the health factor below the threshold are sent.
+func (rs *rootResolver) OnHealthFactorBelow(ctx context.Context, args *struct {
+	Address   common.Address
+	Threshold float64
+}) <-chan *FLendPosition {
+	// make the stream
+	c := make(chan *FLendPosition, onHealthChannelCapacity)
+
+	// subscribe to event dispatch
+	rs.subscribeOnHealth <- &subscriptOnHealth{
+		address:   args.Address,
+		threshold: args.Threshold,
+		stop:      ctx.Done(),
+		events:    c,
+	}
+	return c
+}
+
+// addHealthSubscriber adds a new subscription to onHealthFactorBelow events.
+func (rs *rootResolver) addHealthSubscriber(sub *subscriptOnHealth) {
+	id, err := uuid()
+	if err == nil {
+		// add the subscriber to the map
+		rs.healthSubscribers[id] = sub
+	} else {
+		// log critical issue
+		log.Critical("can not generate UUID for new onHealthFactorBelow subscriber")
+		log.Critical(err)
+	}
+}
+
+// dispatchOnHealth dispatches fLend position refresh to registered subscribers.
+func (rs *rootResolver) dispatchOnHealth(fp *types.FLendPosition) {
+	// prep the position
+	pos := NewFLendPosition(fp)
+	hf := fp.HealthFactorValue()
+
+	// broadcast the event in separate go routines so we don't block here
+	for id, sub := range rs.healthSubscribers {
+		// drop closed subscriptions, most of them never receive a matching position
+		select {
+		case <-sub.stop:
+			delete(rs.healthSubscribers, id)
+			continue
+		default:
+		}
+
+		if sub.address == fp.User && hf < sub.threshold {
+			go rs.notifyOnHealth(pos, sub, id)
+		}
+	}
+}
+
+// notifyOnHealth broadcasts onHealthFactorBelow event to given subscriber.
+func (rs *rootResolver) notifyOnHealth(pos *FLendPosition, sub *subscriptOnHealth, id string) {
+	// check if the context isn't already closed in which case we just unsub and leave
+	select {
+	case <-sub.stop:
+		rs.unsubscribeOnHealth <- id
+		return
+	default:
+	}
+
+	// broadcast
+	select {
+	case <-sub.stop:
+		// just unsub on broken context
+		rs.unsubscribeOnHealth <- id
+
+	case sub.events <- pos:
+		// push the position to subscriber
+
+	case <-time.After(time.Second):
+		// timeout reached without response? just remove the subscriber
+		rs.unsubscribeOnHealth <- id
+	}
+}
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,10 +34,13 @@ func (ttx *TokenTransaction) Type() string {
 }
 
 // TokenName resolves the name of the ERC token contract, if available.
-func (ttx *TokenTransaction) TokenName() (name string, err error) {
+func (ttx *TokenTransaction) TokenName(ctx context.Context) (name string, err error) {
 	switch ttx.TokenTransaction.TokenType {
 	case types.AccountTypeERC20Token:
-		name, err = repository.R().Erc20Name(&ttx.TokenTransaction.TokenAddress)
+		var tk *types.Erc20Token
+		if tk, err = loadErc20Token(ctx, &ttx.TokenTransaction.TokenAddress); err == nil {
+			name = tk.Name
+		}
 	case types.AccountTypeERC721Contract:
 		name, err = repository.R().Erc721Name(&ttx.TokenTransaction.TokenAddress)
 	default:
@@ -46,10 +50,13 @@ func (ttx *TokenTransaction) TokenName() (name string, err error) {
 }
 
 // TokenSymbol resolves the symbol of the ERC token contract, if available.
-func (ttx *TokenTransaction) TokenSymbol() (sym string, err error) {
+func (ttx *TokenTransaction) TokenSymbol(ctx context.Context) (sym string, err error) {
 	switch ttx.TokenTransaction.TokenType {
 	case types.AccountTypeERC20Token:
-		sym, err = repository.R().Erc20Symbol(&ttx.TokenTransaction.TokenAddress)
+		var tk *types.Erc20Token
+		if tk, err = loadErc20Token(ctx, &ttx.TokenTransaction.TokenAddress); err == nil {
+			sym = tk.Symbol
+		}
 	case types.AccountTypeERC721Contract:
 		sym, err = repository.R().Erc721Symbol(&ttx.TokenTransaction.TokenAddress)
 	default:
@@ -59,10 +66,13 @@ func (ttx *TokenTransaction) TokenSymbol() (sym string, err error) {
 }
 
 // TokenDecimals resolves the amount of decimals of the ERC token contract, if available.
-func (ttx *TokenTransaction) TokenDecimals() (decimals int32, err error) {
+func (ttx *TokenTransaction) TokenDecimals(ctx context.Context) (decimals int32, err error) {
 	switch ttx.TokenTransaction.TokenType {
 	case types.AccountTypeERC20Token:
-		decimals, err = repository.R().Erc20Decimals(&ttx.TokenTransaction.TokenAddress)
+		var tk *types.Erc20Token
+		if tk, err = loadErc20Token(ctx, &ttx.TokenTransaction.TokenAddress); err == nil {
+			decimals = tk.Decimals
+		}
 	case types.AccountTypeERC721Contract:
 		decimals = 0
 	default:
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -25,10 +26,10 @@ func NewTransaction(trx *types.Transaction) *Transaction {
 }
 
 // Transaction resolves blockchain transaction by transaction hash.
-func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (tx *Transaction, err error) {
+func (rs *rootResolver) Transaction(ctx context.Context, args *struct{ Hash common.Hash }) (tx *Transaction, err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			log.Criticalf("transaction loader crashed on %s", args.Hash.String())
+			logFor(ctx).Criticalf("transaction loader crashed on %s", args.Hash.String())
 			err = fmt.Errorf("failed to load transaction %s", args.Hash.String())
 			tx = nil
 		}
@@ -37,24 +38,29 @@ func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (tx *Trans
 	// get the transaction from repository
 	trx, err := repository.R().Transaction(&args.Hash)
 	if err != nil {
-		log.Warningf("can not get transaction %s", args.Hash)
+		logFor(ctx).Warningf("can not get transaction %s", args.Hash)
 		return nil, err
 	}
 
 	// transaction not found, yet no error?
 	if trx == nil {
-		log.Errorf("transaction %s not found", args.Hash.String())
+		logFor(ctx).Errorf("transaction %s not found", args.Hash.String())
 		return nil, fmt.Errorf("transaction %s not found", args.Hash.String())
 	}
+
+	// pending transactions and transactions close to the head may still change
+	if trx.BlockHash == nil || trx.BlockNumber == nil || !rs.isFinalBlock(uint64(*trx.BlockNumber)) {
+		markVolatile(ctx)
+	}
 	return NewTransaction(trx), nil
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
-func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+func (rs *rootResolver) SendTransaction(ctx context.Context, args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
 	// get the transaction from repository
 	trx, err := repository.R().SendTransaction(args.Tx)
 	if err != nil {
-		log.Warningf("can not send transaction; %s", err.Error())
+		logFor(ctx).Warningf("can not send transaction; %s", err.Error())
 		return nil, err
 	}
 
@@ -62,9 +68,9 @@ func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Tran
 }
 
 // Sender resolves sender's account of the transaction.
-func (trx *Transaction) Sender() (*Account, error) {
+func (trx *Transaction) Sender(ctx context.Context) (*Account, error) {
 	// get the sender by address
-	acc, err := repository.R().Account(&trx.From)
+	acc, err := loadAccount(ctx, &trx.From)
 	if err != nil {
 		return nil, err
 	}
@@ -73,14 +79,14 @@ func (trx *Transaction) Sender() (*Account, error) {
 }
 
 // Recipient resolves recipient's account of the transaction.
-func (trx *Transaction) Recipient() (*Account, error) {
+func (trx *Transaction) Recipient(ctx context.Context) (*Account, error) {
 	// no recipient available
 	if trx.To == nil {
 		return nil, nil
 	}
 
 	// get the recipient by address
-	acc, err := repository.R().Account(trx.To)
+	acc, err := loadAccount(ctx, trx.To)
 	if err != nil {
 		return nil, err
 	}
@@ -89,14 +95,14 @@ func (trx *Transaction) Recipient() (*Account, error) {
 }
 
 // Block resolves block the transaction is bundled in, nil if it's pending and not added to a block yet.
-func (trx *Transaction) Block() (*Block, error) {
+func (trx *Transaction) Block(ctx context.Context) (*Block, error) {
 	// no recipient available
 	if trx.BlockNumber == nil {
 		return nil, nil
 	}
 
 	// get the sender by address
-	blk, err := repository.R().BlockByNumber(trx.BlockNumber)
+	blk, err := loadBlock(ctx, trx.BlockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -105,10 +111,10 @@ func (trx *Transaction) Block() (*Block, error) {
 }
 
 // tokenTransactions loads list of all token transaction related to this transaction call.
-func (trx *Transaction) tokenTransactions() ([]*types.TokenTransaction, error) {
+func (trx *Transaction) tokenTransactions(ctx context.Context) ([]*types.TokenTransaction, error) {
 	// call for it only once
 	val, err, _ := trx.cg.Do("erc", func() (interface{}, error) {
-		log.Noticef("Loading ERC list for %s", trx.Hash.String())
+		logFor(ctx).Noticef("Loading ERC list for %s", trx.Hash.String())
 		return repository.R().TokenTransactionsByCall(&trx.Hash)
 	})
 	if err != nil {
@@ -119,9 +125,9 @@ func (trx *Transaction) tokenTransactions() ([]*types.TokenTransaction, error) {
 
 // TokenTransactions resolves list of all generic token transactions involved
 // with the base transaction call.
-func (trx *Transaction) TokenTransactions() ([]*TokenTransaction, error) {
+func (trx *Transaction) TokenTransactions(ctx context.Context) ([]*TokenTransaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -136,9 +142,9 @@ func (trx *Transaction) TokenTransactions() ([]*TokenTransaction, error) {
 
 // Erc20Transactions resolves list of ERC-20 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc20Transactions() ([]*ERC20Transaction, error) {
+func (trx *Transaction) Erc20Transactions(ctx context.Context) ([]*ERC20Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +160,9 @@ func (trx *Transaction) Erc20Transactions() ([]*ERC20Transaction, error) {
 
 // Erc721Transactions resolves list of ERC-721 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc721Transactions() ([]*ERC721Transaction, error) {
+func (trx *Transaction) Erc721Transactions(ctx context.Context) ([]*ERC721Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -172,9 +178,9 @@ func (trx *Transaction) Erc721Transactions() ([]*ERC721Transaction, error) {
 
 // Erc1155Transactions resolves list of ERC-155 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc1155Transactions() ([]*ERC1155Transaction, error) {
+func (trx *Transaction) Erc1155Transactions(ctx context.Context) ([]*ERC1155Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
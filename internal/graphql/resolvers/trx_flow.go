@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -45,7 +46,7 @@ func (rs *rootResolver) TrxVolume(args struct {
 
 // TrxGasSpeed resolves the gas consumption speed speed
 // of the network in transactions processed per second.
-func (rs *rootResolver) TrxGasSpeed(args struct {
+func (rs *rootResolver) TrxGasSpeed(ctx context.Context, args struct {
 	Range int32
 	To    *string
 }) (val float64, err error) {
@@ -67,7 +68,7 @@ func (rs *rootResolver) TrxGasSpeed(args struct {
 	from := to.Add(time.Duration(-args.Range) * time.Second)
 
 	// log what we do
-	log.Noticef("calculating gas speed from %s to %s", from.String(), to.String())
+	logFor(ctx).Noticef("calculating gas speed from %s to %s", from.String(), to.String())
 	return repository.R().TrxGasSpeed(&from, &to)
 }
 
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -27,7 +28,7 @@ func NewTransactionList(txs *types.TransactionList) *TransactionList {
 }
 
 // Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-func (rs *rootResolver) Transactions(args *struct {
+func (rs *rootResolver) Transactions(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*TransactionList, error) {
@@ -38,7 +39,7 @@ func (rs *rootResolver) Transactions(args *struct {
 	// get the transaction hash list from repository
 	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count)
 	if err != nil {
-		log.Errorf("can not get transactions list; %s", err.Error())
+		logFor(ctx).Errorf("can not get transactions list; %s", err.Error())
 		return nil, err
 	}
 	return NewTransactionList(txs), nil
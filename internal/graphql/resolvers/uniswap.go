@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -278,7 +279,7 @@ func (upv *UniswapPairVolume) IsInFUSD() (bool, error) {
 
 // DefiTimeVolumes resolves swap volumes for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeVolumes(args *struct {
+func (rs *rootResolver) DefiTimeVolumes(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -302,7 +303,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 	// get volumes from DB repository
 	swapVolumes, err := repository.R().UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
 	if err != nil {
-		log.Errorf("Can not get swap volumes from DB repository: %s", err.Error())
+		logFor(ctx).Errorf("Can not get swap volumes from DB repository: %s", err.Error())
 		return make([]*DefiTimeVolume, 0)
 	}
 
@@ -320,7 +321,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 
 // DefiTimePrices resolves swap prices for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimePrices(args *struct {
+func (rs *rootResolver) DefiTimePrices(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -351,7 +352,7 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 	// get prices from DB repository
 	swapPrices, err := repository.R().UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
 	if err != nil {
-		log.Errorf("Can not get uniswap prices from DB repository: %s", err.Error())
+		logFor(ctx).Errorf("Can not get uniswap prices from DB repository: %s", err.Error())
 		return make([]types.DefiTimePrice, 0)
 	}
 	return swapPrices
@@ -398,7 +399,7 @@ func checkDate(td *int32) int64 {
 
 // DefiTimeReserves resolves uniswap reserves for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeReserves(args *struct {
+func (rs *rootResolver) DefiTimeReserves(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -422,7 +423,7 @@ func (rs *rootResolver) DefiTimeReserves(args *struct {
 	// get reserves from DB repository
 	timeReserves, err := repository.R().UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
 	if err != nil {
-		log.Errorf("Can not get uniswap reserves from DB repository: %s", err.Error())
+		logFor(ctx).Errorf("Can not get uniswap reserves from DB repository: %s", err.Error())
 		return make([]DefiTimeReserve, 0)
 	}
 
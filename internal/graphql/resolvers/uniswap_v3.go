@@ -0,0 +1,200 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// UniswapV3Pool represents a resolvable concentrated liquidity pool.
+type UniswapV3Pool struct {
+	types.UniswapV3Pool
+}
+
+// UniswapV3PoolState represents a resolvable state of a concentrated liquidity pool.
+type UniswapV3PoolState struct {
+	types.UniswapV3PoolState
+}
+
+// UniswapV3Position represents a resolvable liquidity position.
+type UniswapV3Position struct {
+	types.UniswapV3Position
+}
+
+// NewUniswapV3Pool creates a new resolvable concentrated liquidity pool.
+func NewUniswapV3Pool(pool *types.UniswapV3Pool) *UniswapV3Pool {
+	return &UniswapV3Pool{UniswapV3Pool: *pool}
+}
+
+// NewUniswapV3Position creates a new resolvable liquidity position.
+func NewUniswapV3Position(pos *types.UniswapV3Position) *UniswapV3Position {
+	return &UniswapV3Position{UniswapV3Position: *pos}
+}
+
+// DefiUniswapV3Pools resolves the list of all known concentrated liquidity pools.
+func (rs *rootResolver) DefiUniswapV3Pools() ([]*UniswapV3Pool, error) {
+	pools, err := repository.R().UniswapV3Pools()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*UniswapV3Pool, len(pools))
+	for i, p := range pools {
+		list[i] = NewUniswapV3Pool(p)
+	}
+	return list, nil
+}
+
+// DefiUniswapV3Pool resolves a concentrated liquidity pool by its address.
+func (rs *rootResolver) DefiUniswapV3Pool(args *struct{ Address common.Address }) (*UniswapV3Pool, error) {
+	pool, err := repository.R().UniswapV3Pool(&args.Address)
+	if err != nil || pool == nil {
+		return nil, err
+	}
+	return NewUniswapV3Pool(pool), nil
+}
+
+// DefiUniswapV3Position resolves a liquidity position by its NFT token id.
+func (rs *rootResolver) DefiUniswapV3Position(args *struct{ TokenId hexutil.Big }) (*UniswapV3Position, error) {
+	pos, err := repository.R().UniswapV3Position(&args.TokenId)
+	if err != nil || pos == nil {
+		return nil, err
+	}
+	return NewUniswapV3Position(pos), nil
+}
+
+// DefiUniswapV3Positions resolves a list of liquidity positions of the given owner and/or pool.
+func (rs *rootResolver) DefiUniswapV3Positions(args *struct {
+	Owner *common.Address
+	Pool  *common.Address
+}) ([]*UniswapV3Position, error) {
+	return uniswapV3Positions(args.Owner, args.Pool)
+}
+
+// DefiUniswapV3TimePrices resolves OHLC prices of the given pool for the time resolution and interval.
+func (rs *rootResolver) DefiUniswapV3TimePrices(ctx context.Context, args *struct {
+	Address    common.Address
+	Resolution *string
+	FromDate   *int32
+	ToDate     *int32
+	Direction  *int32
+}) []types.DefiTimePrice {
+	// check date values
+	var fDate int64
+	if args.FromDate != nil {
+		fDate = (int64)(*args.FromDate)
+	} else {
+		fDate = time.Now().UTC().AddDate(0, -1, 0).Unix()
+	}
+	tDate := checkDate(args.ToDate)
+
+	// check resolution value
+	resolution := ""
+	if args.Resolution != nil {
+		resolution = *args.Resolution
+	}
+
+	// check direction value
+	var dir int32
+	if args.Direction != nil {
+		dir = *args.Direction
+	}
+
+	prices, err := repository.R().UniswapV3TimePrices(&args.Address, resolution, fDate, tDate, dir)
+	if err != nil {
+		logFor(ctx).Errorf("can not get uniswap v3 prices; %s", err.Error())
+		return make([]types.DefiTimePrice, 0)
+	}
+	return prices
+}
+
+// uniswapV3Positions loads a resolvable list of liquidity positions of the given owner and/or pool.
+func uniswapV3Positions(owner *common.Address, pool *common.Address) ([]*UniswapV3Position, error) {
+	pl, err := repository.R().UniswapV3Positions(owner, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*UniswapV3Position, len(pl))
+	for i, p := range pl {
+		list[i] = NewUniswapV3Position(p)
+	}
+	return list, nil
+}
+
+// Token0 resolves the first token of the pool.
+func (up *UniswapV3Pool) Token0() *ERC20Token {
+	return NewErc20Token(&up.UniswapV3Pool.Token0)
+}
+
+// Token1 resolves the second token of the pool.
+func (up *UniswapV3Pool) Token1() *ERC20Token {
+	return NewErc20Token(&up.UniswapV3Pool.Token1)
+}
+
+// CreatedBlock resolves the number of the block in which the pool was created.
+func (up *UniswapV3Pool) CreatedBlock() hexutil.Uint64 {
+	return up.Block
+}
+
+// Created resolves the time stamp of the pool creation.
+func (up *UniswapV3Pool) Created() hexutil.Uint64 {
+	return hexutil.Uint64(up.UniswapV3Pool.Created.Unix())
+}
+
+// State resolves the current on-chain state of the pool.
+func (up *UniswapV3Pool) State() (*UniswapV3PoolState, error) {
+	st, err := repository.R().UniswapV3PoolState(&up.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &UniswapV3PoolState{UniswapV3PoolState: *st}, nil
+}
+
+// Ticks resolves the list of initialized ticks of the pool.
+func (up *UniswapV3Pool) Ticks() ([]*types.UniswapV3Tick, error) {
+	return repository.R().UniswapV3PoolTicks(&up.Address)
+}
+
+// Positions resolves the list of liquidity positions on the pool.
+func (up *UniswapV3Pool) Positions() ([]*UniswapV3Position, error) {
+	return uniswapV3Positions(nil, &up.Address)
+}
+
+// Price resolves the raw price of token0 denominated in token1.
+func (ps *UniswapV3PoolState) Price() float64 {
+	return ps.UniswapV3PoolState.Price()
+}
+
+// FeeGrowthGlobal0X128 resolves the fee growth of token0 per unit of liquidity.
+func (ps *UniswapV3PoolState) FeeGrowthGlobal0X128() hexutil.Big {
+	return ps.FeeGrowth0
+}
+
+// FeeGrowthGlobal1X128 resolves the fee growth of token1 per unit of liquidity.
+func (ps *UniswapV3PoolState) FeeGrowthGlobal1X128() hexutil.Big {
+	return ps.FeeGrowth1
+}
+
+// Pool resolves the pool the position provides liquidity to.
+func (pos *UniswapV3Position) Pool() (*UniswapV3Pool, error) {
+	pool, err := repository.R().UniswapV3Pool(&pos.UniswapV3Position.Pool)
+	if err != nil || pool == nil {
+		return nil, err
+	}
+	return NewUniswapV3Pool(pool), nil
+}
+
+// Token0 resolves the first token of the position.
+func (pos *UniswapV3Position) Token0() *ERC20Token {
+	return NewErc20Token(&pos.UniswapV3Position.Token0)
+}
+
+// Token1 resolves the second token of the position.
+func (pos *UniswapV3Position) Token1() *ERC20Token {
+	return NewErc20Token(&pos.UniswapV3Position.Token1)
+}
@@ -0,0 +1,123 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// UniswapV3Action represents resolvable concentrated liquidity pool action.
+type UniswapV3Action struct {
+	types.UniswapV3Action
+}
+
+// UniswapV3ActionList represents resolvable list of pool action edges structure.
+type UniswapV3ActionList struct {
+	types.UniswapV3ActionList
+}
+
+// UniswapV3ActionListEdge represents a single edge of a pool action list structure.
+type UniswapV3ActionListEdge struct {
+	Action *UniswapV3Action
+}
+
+// NewUniswapV3Action builds new resolvable pool action structure.
+func NewUniswapV3Action(ua *types.UniswapV3Action) *UniswapV3Action {
+	return &UniswapV3Action{UniswapV3Action: *ua}
+}
+
+// NewUniswapV3ActionList builds new resolvable list of pool actions.
+func NewUniswapV3ActionList(ual *types.UniswapV3ActionList) *UniswapV3ActionList {
+	return &UniswapV3ActionList{*ual}
+}
+
+// DefiUniswapV3Actions resolves list of concentrated liquidity pool actions encapsulated in a listable structure.
+func (rs *rootResolver) DefiUniswapV3Actions(ctx context.Context, args *struct {
+	Pool       *common.Address
+	Owner      *common.Address
+	Cursor     *Cursor
+	Count      int32
+	ActionType *int32
+}) (*UniswapV3ActionList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	al, err := repository.R().UniswapV3Actions(args.Pool, args.Owner, args.ActionType, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		logFor(ctx).Errorf("can not get uniswap v3 action list; %s", err.Error())
+		return nil, err
+	}
+	return NewUniswapV3ActionList(al), nil
+}
+
+// Id resolves the identifier of the action.
+func (ua *UniswapV3Action) Id() Cursor {
+	return Cursor(ua.Pk())
+}
+
+// Pool resolves the pool of the action.
+func (ua *UniswapV3Action) Pool() (*UniswapV3Pool, error) {
+	pool, err := repository.R().UniswapV3Pool(&ua.UniswapV3Action.Pool)
+	if err != nil || pool == nil {
+		return nil, err
+	}
+	return NewUniswapV3Pool(pool), nil
+}
+
+// PoolAddress resolves the address of the pool of the action.
+func (ua *UniswapV3Action) PoolAddress() common.Address {
+	return ua.UniswapV3Action.Pool
+}
+
+// Price resolves the raw price of token0 denominated in token1 after a swap.
+func (ua *UniswapV3Action) Price() float64 {
+	return ua.UniswapV3Action.Price()
+}
+
+// TransactionHash resolves the hash of the action transaction.
+func (ua *UniswapV3Action) TransactionHash() common.Hash {
+	return ua.TrxHash
+}
+
+// TotalCount resolves the total number of pool actions in the list.
+func (cl *UniswapV3ActionList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(cl.Total))
+}
+
+// PageInfo resolves the current page information for the pool action list.
+func (cl *UniswapV3ActionList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if cl.Collection == nil || len(cl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(cl.Collection[0].Pk())
+	last := Cursor(cl.Collection[len(cl.Collection)-1].Pk())
+	return NewListPageInfo(&first, &last, !cl.IsEnd, !cl.IsStart)
+}
+
+// Edges resolves list of edges for the linked pool action list.
+func (cl *UniswapV3ActionList) Edges() []*UniswapV3ActionListEdge {
+	// do we have any items? return empty list if not
+	if cl.Collection == nil || len(cl.Collection) == 0 {
+		return make([]*UniswapV3ActionListEdge, 0)
+	}
+
+	// make the list
+	edges := make([]*UniswapV3ActionListEdge, len(cl.Collection))
+	for i, c := range cl.Collection {
+		edges[i] = &UniswapV3ActionListEdge{Action: NewUniswapV3Action(c)}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (ue *UniswapV3ActionListEdge) Cursor() Cursor {
+	return Cursor(ue.Action.Pk())
+}
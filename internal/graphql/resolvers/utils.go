@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/rand"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -25,7 +26,7 @@ func (rs *rootResolver) Price(args *struct{ To string }) (types.Price, error) {
 }
 
 // GasPrice resolves the current amount of WEI for single Gas.
-func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
+func (rs *rootResolver) GasPrice(ctx context.Context) (hexutil.Uint64, error) {
 	// get the actual value
 	price, err := repository.R().GasPrice()
 	if err != nil {
@@ -34,12 +35,12 @@ func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
 
 	// if the price safely within the range
 	if !price.ToInt().IsUint64() {
-		log.Error("current gas price is too high and can not be extracted")
+		logFor(ctx).Error("current gas price is too high and can not be extracted")
 		return hexutil.Uint64(0), err
 	}
 
 	// inform and return
-	log.Debugf("current gas price is %d", price.ToInt().Uint64())
+	logFor(ctx).Debugf("current gas price is %d", price.ToInt().Uint64())
 	return hexutil.Uint64(price.ToInt().Uint64()), nil
 }
 
@@ -0,0 +1,68 @@
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"math/big"
+)
+
+// maxValidatorAlertsCount represents the max number of validator alerts loaded at once.
+const maxValidatorAlertsCount = 500
+
+// ValidatorAlert represents resolvable validator alert.
+type ValidatorAlert struct {
+	types.ValidatorAlert
+}
+
+// ValidatorAlerts resolves the history of validator alerts, the latest first.
+func (rs *rootResolver) ValidatorAlerts(args struct {
+	ValidatorId *hexutil.Uint64
+	ActiveOnly  bool
+	Count       int32
+}) ([]*ValidatorAlert, error) {
+	if args.Count <= 0 || args.Count > maxValidatorAlertsCount {
+		args.Count = maxValidatorAlertsCount
+	}
+
+	list, err := repository.R().ValidatorAlerts((*uint64)(args.ValidatorId), args.ActiveOnly, int64(args.Count))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*ValidatorAlert, len(list))
+	for i, va := range list {
+		out[i] = &ValidatorAlert{ValidatorAlert: *va}
+	}
+	return out, nil
+}
+
+// ValidatorId resolves the ID of the validator of the alert.
+func (va *ValidatorAlert) ValidatorId() hexutil.Big {
+	return (hexutil.Big)(*new(big.Int).SetUint64(va.ValidatorAlert.ValidatorId))
+}
+
+// Address resolves the address of the validator of the alert.
+func (va *ValidatorAlert) Address() common.Address {
+	return common.HexToAddress(va.ValidatorAlert.Address)
+}
+
+// Raised resolves the time the alert was raised.
+func (va *ValidatorAlert) Raised() graphql.Time {
+	return graphql.Time{Time: va.ValidatorAlert.Raised}
+}
+
+// Resolved resolves the time the alert was resolved, if any.
+func (va *ValidatorAlert) Resolved() *graphql.Time {
+	if va.ValidatorAlert.Resolved == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *va.ValidatorAlert.Resolved}
+}
+
+// IsActive resolves the flag of the alert not being resolved yet.
+func (va *ValidatorAlert) IsActive() bool {
+	return va.ValidatorAlert.Resolved == nil
+}
@@ -0,0 +1,203 @@
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"math/big"
+)
+
+// validatorDirectorySortFields maps the validators directory sorting options to the snapshot fields.
+var validatorDirectorySortFields = map[string]string{
+	"TOTAL_STAKE":    types.FiValidatorSnapshotStake,
+	"DELEGATORS":     types.FiValidatorSnapshotDelegators,
+	"UPTIME":         types.FiValidatorSnapshotUptime,
+	"LOCK_REMAINING": types.FiValidatorSnapshotLocked,
+	"APR":            types.FiValidatorSnapshotApr,
+}
+
+// ValidatorFilter represents an input structure used
+// to filter the validators directory.
+type ValidatorFilter struct {
+	IsActive      *bool
+	IsOffline     *bool
+	IsLocked      *bool
+	HasInfo       *bool
+	MinCommission *float64
+	MaxCommission *float64
+	MinStake      *hexutil.Big
+}
+
+// ValidatorList represents resolvable list of validators directory entries.
+type ValidatorList struct {
+	types.ValidatorSnapshotList
+}
+
+// ValidatorListEdge represents a single edge of the validators directory list.
+type ValidatorListEdge struct {
+	Validator *ValidatorDirectoryEntry
+	Position  uint64
+}
+
+// ValidatorDirectoryEntry represents resolvable validators directory entry.
+type ValidatorDirectoryEntry struct {
+	types.ValidatorSnapshot
+}
+
+// Validators resolves a page of the validators directory filtered and sorted by the given criteria.
+func (rs *rootResolver) Validators(ctx context.Context, args *struct {
+	Filter   *ValidatorFilter
+	SortBy   string
+	SortDesc bool
+	Cursor   *Cursor
+	Count    int32
+}) (*ValidatorList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	sortBy, ok := validatorDirectorySortFields[args.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown sorting %s", args.SortBy)
+	}
+
+	var cursor *uint64
+	if args.Cursor != nil {
+		pos, err := hexutil.DecodeUint64(string(*args.Cursor))
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %s", *args.Cursor)
+		}
+		cursor = &pos
+	}
+
+	list, err := repository.R().ValidatorDirectory(args.Filter.snapshotFilter(), sortBy, args.SortDesc, cursor, int64(args.Count))
+	if err != nil {
+		logFor(ctx).Errorf("can not get validators directory; %s", err.Error())
+		return nil, err
+	}
+	return &ValidatorList{ValidatorSnapshotList: *list}, nil
+}
+
+// snapshotFilter converts the input filter to the validators snapshot filter.
+func (vf *ValidatorFilter) snapshotFilter() *types.ValidatorSnapshotFilter {
+	if vf == nil {
+		return nil
+	}
+	return &types.ValidatorSnapshotFilter{
+		IsActive:      vf.IsActive,
+		IsOffline:     vf.IsOffline,
+		IsLocked:      vf.IsLocked,
+		HasInfo:       vf.HasInfo,
+		MinCommission: vf.MinCommission,
+		MaxCommission: vf.MaxCommission,
+		MinStake:      (*big.Int)(vf.MinStake),
+	}
+}
+
+// TotalCount resolves the total number of validators matching the filter.
+func (vl *ValidatorList) TotalCount() hexutil.Big {
+	return hexutil.Big(*new(big.Int).SetUint64(vl.Total))
+}
+
+// PageInfo resolves the current page information for the validators directory list.
+func (vl *ValidatorList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(vl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, false)
+	}
+
+	// get the first and last elements
+	first := Cursor(hexutil.EncodeUint64(vl.Offset))
+	last := Cursor(hexutil.EncodeUint64(vl.Offset + uint64(len(vl.Collection)) - 1))
+	return NewListPageInfo(&first, &last, !vl.IsEnd, !vl.IsStart)
+}
+
+// Edges resolves list of edges for the validators directory list.
+func (vl *ValidatorList) Edges() []*ValidatorListEdge {
+	edges := make([]*ValidatorListEdge, len(vl.Collection))
+	for i, vs := range vl.Collection {
+		edges[i] = &ValidatorListEdge{
+			Validator: &ValidatorDirectoryEntry{ValidatorSnapshot: *vs},
+			Position:  vl.Offset + uint64(i),
+		}
+	}
+	return edges
+}
+
+// Cursor generates the list edge cursor.
+func (ve *ValidatorListEdge) Cursor() Cursor {
+	return Cursor(hexutil.EncodeUint64(ve.Position))
+}
+
+// Id resolves the ID of the validator.
+func (ve *ValidatorDirectoryEntry) Id() hexutil.Uint64 {
+	return hexutil.Uint64(ve.ValidatorSnapshot.Id)
+}
+
+// Address resolves the address of the validator.
+func (ve *ValidatorDirectoryEntry) Address() common.Address {
+	return common.HexToAddress(ve.ValidatorSnapshot.Address)
+}
+
+// Status resolves the SFC status of the validator.
+func (ve *ValidatorDirectoryEntry) Status() hexutil.Uint64 {
+	return hexutil.Uint64(ve.ValidatorSnapshot.Status)
+}
+
+// Name resolves the name of the validator from the staker info, if any.
+func (ve *ValidatorDirectoryEntry) Name() *string {
+	if !ve.HasInfo || ve.ValidatorSnapshot.Name == "" {
+		return nil
+	}
+	return &ve.ValidatorSnapshot.Name
+}
+
+// TotalStake resolves the total amount staked to the validator.
+func (ve *ValidatorDirectoryEntry) TotalStake() hexutil.Big {
+	return snapshotAmount(ve.ValidatorSnapshot.TotalStake)
+}
+
+// SelfStake resolves the amount staked by the validator itself.
+func (ve *ValidatorDirectoryEntry) SelfStake() hexutil.Big {
+	return snapshotAmount(ve.ValidatorSnapshot.SelfStake)
+}
+
+// DelegatorsCount resolves the number of active delegations of the validator.
+func (ve *ValidatorDirectoryEntry) DelegatorsCount() int32 {
+	return int32(ve.DelegatorCount)
+}
+
+// LockedUntil resolves the time the validator self stake lock expires, if locked.
+func (ve *ValidatorDirectoryEntry) LockedUntil() *graphql.Time {
+	if ve.ValidatorSnapshot.LockedUntil.IsZero() {
+		return nil
+	}
+	return &graphql.Time{Time: ve.ValidatorSnapshot.LockedUntil}
+}
+
+// Snapshot resolves the time the directory entry was taken.
+func (ve *ValidatorDirectoryEntry) Snapshot() graphql.Time {
+	return graphql.Time{Time: ve.ValidatorSnapshot.Snapshot}
+}
+
+// Staker resolves the full validator details.
+func (ve *ValidatorDirectoryEntry) Staker(ctx context.Context) (*Staker, error) {
+	st, err := loadValidator(ctx, (*hexutil.Big)(new(big.Int).SetUint64(ve.ValidatorSnapshot.Id)))
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(st), nil
+}
+
+// snapshotAmount decodes hex encoded amount of the validator snapshot.
+func snapshotAmount(val string) hexutil.Big {
+	amo, err := hexutil.DecodeBig(val)
+	if err != nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*amo)
+}
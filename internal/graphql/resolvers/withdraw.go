@@ -2,7 +2,7 @@
 package resolvers
 
 import (
-	"fantom-api-graphql/internal/repository"
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -49,9 +49,9 @@ func (wr WithdrawRequest) Amount() hexutil.Big {
 }
 
 // Account resolves the account detail of the partial withdraw request.
-func (wr WithdrawRequest) Account() (*Account, error) {
+func (wr WithdrawRequest) Account(ctx context.Context) (*Account, error) {
 	// get the account detail by address
-	acc, err := repository.R().Account(&wr.Address)
+	acc, err := loadAccount(ctx, &wr.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +61,9 @@ func (wr WithdrawRequest) Account() (*Account, error) {
 }
 
 // Staker resolves the withdraw request staker detail, if available.
-func (wr WithdrawRequest) Staker() (*Staker, error) {
+func (wr WithdrawRequest) Staker(ctx context.Context) (*Staker, error) {
 	// get staker detail by the staker id
-	st, err := repository.R().Validator(wr.WithdrawRequest.StakerID)
+	st, err := loadValidator(ctx, wr.WithdrawRequest.StakerID)
 	if err != nil {
 		return nil, err
 	}
@@ -71,3 +71,8 @@ func (wr WithdrawRequest) Staker() (*Staker, error) {
 	// return the staker information
 	return NewStaker(st), nil
 }
+
+// IsWithdrawn resolves the finalization status of the withdraw request.
+func (wr WithdrawRequest) IsWithdrawn() bool {
+	return wr.WithdrawRequest.IsClosed()
+}
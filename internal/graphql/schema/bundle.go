@@ -497,6 +497,193 @@ type NetworkNodeGroupList {
     groups: [NetworkNodeGroup!]!
 }
 
+# NetworkNodeFilter represents a filter of the list of Opera network nodes.
+input NetworkNodeFilter {
+    # isOpera filters nodes confirmed to run the Opera protocol.
+    isOpera: Boolean
+
+    # isSynced filters nodes in sync with the network.
+    isSynced: Boolean
+
+    # isResponsive filters nodes without failed checks since the last contact.
+    isResponsive: Boolean
+
+    # client filters nodes by the name of the client software, i.e. "go-x1".
+    client: String
+
+    # clientVersion filters nodes by the version of the client software.
+    clientVersion: String
+
+    # country filters nodes by the name of the country of their location.
+    country: String
+}
+
+# NetworkNodeLocation represents geographic location of a network node.
+type NetworkNodeLocation {
+    continent: String!
+    country: String!
+    region: String!
+    city: String!
+    timeZone: String!
+    latitude: Float!
+    longitude: Float!
+}
+
+# NetworkNode represents a node of the Opera network found by the network crawler.
+type NetworkNode {
+    # id represents the network identifier of the node.
+    id: String!
+
+    # enode represents the enode URL of the node.
+    enode: String!
+
+    # ip represents the IP address of the node.
+    ip: String
+
+    # port represents the TCP port of the node.
+    port: Int!
+
+    # name represents the node name advertised in the p2p communication.
+    name: String
+
+    # client represents the name of the client software of the node.
+    client: String
+
+    # clientVersion represents the version of the client software of the node.
+    clientVersion: String
+
+    # protocolVersion represents the version of the p2p protocol of the node.
+    protocolVersion: String
+
+    # protocols represents the list of protocol capabilities of the node.
+    protocols: String
+
+    # isOpera signals the node has been confirmed to run the Opera protocol.
+    isOpera: Boolean!
+
+    # isSynced signals the node is in sync with the network.
+    isSynced: Boolean!
+
+    # epoch represents the epoch reported by the node.
+    epoch: Long
+
+    # blockHeight represents the block height reported by the node.
+    blockHeight: Long
+
+    # infoUpdated represents the time the node information was obtained.
+    infoUpdated: Time
+
+    # firstSeen represents the time the node was discovered.
+    firstSeen: Time!
+
+    # lastSeen represents the time of the last successful contact with the node.
+    lastSeen: Time!
+
+    # lastCheck represents the time of the last attempt to contact the node.
+    lastCheck: Time!
+
+    # fails represents the number of failed checks since the last successful contact.
+    fails: Int!
+
+    # score represents the liveliness score of the node.
+    score: Int!
+
+    # location represents the geographic location of the node.
+    location: NetworkNodeLocation!
+}
+
+# NetworkNodeListEdge represents a single edge of the network nodes list.
+type NetworkNodeListEdge {
+    cursor: Cursor!
+    node: NetworkNode!
+}
+
+# NetworkNodeList represents a list of network nodes.
+type NetworkNodeList {
+    # totalCount represents the total number of nodes matching the filter.
+    totalCount: BigInt!
+
+    # pageInfo represents the information about the current page of the list.
+    pageInfo: ListPageInfo!
+
+    # edges represents the list of nodes in the current page.
+    edges: [NetworkNodeListEdge!]!
+}
+
+# NetworkNodeVersionGroup represents an aggregated group of Opera network nodes
+# running the same version of the client software.
+type NetworkNodeVersionGroup {
+    # client represents the name of the client software.
+    client: String
+
+    # version represents the version of the client software.
+    version: String
+
+    # count represents the number of nodes in the aggregation group.
+    count: Int!
+
+    # synced represents the number of synced nodes in the aggregation group.
+    synced: Int!
+
+    # pct represents the percentage share of the aggregation group
+    # compared to the number of all known active nodes. The number is provided
+    # as fixed point integer with 1 decimal precision (i.e. 258 = 25.8%, 1000 = 100%)
+    pct: Int!
+}
+
+# NetworkNodeVersionGroupList represents a list of network node groups by client version.
+type NetworkNodeVersionGroupList {
+    # totalCount represents the total number of nodes in the list.
+    totalCount: Int!
+
+    # groups represents an array of groups in the list.
+    groups: [NetworkNodeVersionGroup!]!
+}
+
+# NetworkStatsResolution represents the time resolution of the network statistics.
+enum NetworkStatsResolution {
+    HOUR
+    DAY
+    WEEK
+}
+
+# NetworkStatsGroupBy represents the property of network nodes used to group the network statistics.
+enum NetworkStatsGroupBy {
+    COUNTRY
+    CONTINENT
+    CLIENT_VERSION
+    HOSTING
+    SYNCED
+}
+
+# NetworkStatsGroup represents the number of active network nodes sharing the same property.
+type NetworkStatsGroup {
+    # key represents the value of the property shared by the group,
+    # i.e. the name of the country, or the hosting network "AS<number> <organization>".
+    key: String!
+
+    # count represents the number of nodes in the group.
+    count: Int!
+
+    # synced represents the number of synced nodes in the group.
+    synced: Int!
+}
+
+# NetworkStatsPoint represents a snapshot of the Opera network topology.
+type NetworkStatsPoint {
+    # time represents the time the snapshot was taken.
+    time: Time!
+
+    # total represents the number of active nodes.
+    total: Int!
+
+    # synced represents the number of active nodes in sync with the network.
+    synced: Int!
+
+    # groups represents the active nodes grouped by the requested property.
+    groups: [NetworkStatsGroup!]!
+}
+
 # Block is an Opera block chain block.
 type Block {
     # Number is the number of this block, starting at 0 for the genesis block.
@@ -525,7 +712,7 @@ type Block {
     txHashList: [Bytes32!]!
 
     # txList is a list of transactions assigned to the block.
-    txList: [Transaction!]!
+    txList: [Transaction!]! @cost(weight: 3)
 }
 
 # ERC721Contract represents a generic ERC721 non-fungible tokens (NFT) contract.
@@ -664,7 +851,8 @@ type Delegation {
     # of the validator.
     isSelfStake: Boolean!
 
-    # Time stamp of the delegation creation.
+    # Time stamp of the delegation creation; zero if the delegation
+    # was recovered from the SFC state and its creation is not known.
     createdTime: Long!
 
     # Amount delegated in WEI. The value includes all the pending un-delegations.
@@ -782,6 +970,42 @@ type Epoch {
 
     # Total supply amount.
     totalSupply: BigInt!
+
+    # Number of the first block of the epoch.
+    # The block range, transactions and validators of the epoch
+    # are available only after the epoch has been processed.
+    firstBlock: Long
+
+    # Number of the last block of the epoch.
+    lastBlock: Long
+
+    # Number of transactions included in the epoch.
+    transactionCount: Long
+
+    # Total gas used by transactions of the epoch.
+    gasUsed: Long
+
+    # Number of validators active in the epoch.
+    validatorsCount: Long
+
+    # List of validators active in the epoch with their stake.
+    validators: [EpochValidator!]
+
+    # Get list of blocks of the epoch with at most <count> edges.
+    # The newest block is on top of the list.
+    blocks(cursor:Cursor, count:Int = 25):BlockList!
+}
+
+# Represents a validator active in an epoch.
+type EpochValidator {
+    # Identifier of the validator.
+    id: BigInt!
+
+    # Total stake of the validator in the epoch.
+    stake: BigInt!
+
+    # Current detail of the validator.
+    staker: Staker
 }
 
 # ERC721TransactionList is a list of ERC721 transaction edges provided by sequential access request.
@@ -1104,6 +1328,11 @@ type Staker {
     # are provided if cursor is omitted.
     delegations(cursor: Cursor, count: Int = 25):DelegationList!
 
+    # epochHistory represents the per-epoch performance history of the staker
+    # for the given range of epochs. The last 100 sealed epochs are provided
+    # if the range is not specified.
+    epochHistory(from: Long, to: Long): [StakerEpoch!]!
+
     # Status is a binary encoded status of the staker.
     # Ok = 0, bin 1 = Fork Detected, bin 256 = Validator Offline
     status: Long!
@@ -1112,6 +1341,51 @@ type Staker {
     stakerInfo: StakerInfo
 }
 
+# StakerEpoch represents a snapshot of a staker taken at the epoch seal.
+type StakerEpoch {
+    # epoch represents the identifier of the epoch.
+    epoch: Long!
+
+    # endTime represents the time stamp of the epoch seal.
+    endTime: Long!
+
+    # duration represents the length of the epoch in seconds.
+    duration: Long!
+
+    # selfStake represents the self stake of the staker. The value is not available
+    # for epochs processed after they have been sealed.
+    selfStake: BigInt
+
+    # delegatedAmount represents the amount delegated to the staker by other accounts.
+    # The value is not available if the self stake is not known.
+    delegatedAmount: BigInt
+
+    # receivedStake represents the total stake of the staker in the epoch.
+    receivedStake: BigInt!
+
+    # uptime represents the number of seconds the staker was online in the epoch.
+    uptime: Long!
+
+    # missedBlocks represents the number of blocks the staker missed as recorded at the epoch seal.
+    missedBlocks: Long!
+
+    # downtime represents the number of seconds the staker was offline as recorded at the epoch seal.
+    downtime: Long!
+
+    # originatedFee represents the fee of transactions originated by the staker in the epoch.
+    originatedFee: BigInt!
+
+    # accumulatedRewardPerToken represents the reward per staked token accumulated up to the epoch.
+    accumulatedRewardPerToken: BigInt!
+
+    # rewardPerToken represents the reward per staked token received in the epoch.
+    rewardPerToken: BigInt!
+
+    # apr represents the realized annual percentage rate of a stake delegated
+    # to the staker calculated from the rewards of the epoch.
+    apr: Float!
+}
+
 # StakerFlagFilter represents a filter type for stakers with the given flag.
 enum StakerFlagFilter {
     IS_ACTIVE
@@ -1190,6 +1464,33 @@ type FMintAccount {
     # inside the reward distribution and can be pushed into
     # the system to distribute them among eligible accounts.
     canPushNewRewards: Boolean!
+
+    # collateralRatio4 represents the current collateral to debt ratio
+    # of the account. Value is represented in the same decimals as
+    # the DeFi configuration ratios. NULL if the account has no debt.
+    collateralRatio4: BigInt
+
+    # liquidationThreshold4 represents the minimal collateral to debt ratio
+    # the account needs to keep as configured on the protocol.
+    liquidationThreshold4: BigInt!
+
+    # liquidationDistance4 represents the difference between the current
+    # collateral to debt ratio and the liquidation threshold. Negative value
+    # means the account is below the threshold. NULL if the account has no debt.
+    liquidationDistance4: BigInt
+
+    # collateralBuffer represents the collateral value in ref. denomination (fUSD)
+    # the account can lose before reaching the liquidation threshold.
+    collateralBuffer: BigInt!
+
+    # history represents the list of fMint transactions of the account,
+    # optionally filtered by the transaction type.
+    history(cursor: Cursor, count: Int!, type: Int): FMintTransactionList!
+
+    # riskHistory represents the collateral to debt ratio time series
+    # of the account sampled periodically by the API server.
+    # If dates are not given, the last month is provided.
+    riskHistory(resolution: String, fromDate: Int, toDate: Int): [FMintRiskPoint!]!
 }
 
 # FMintTokenBalance represents a balance of a specific DeFi token
@@ -1358,6 +1659,10 @@ type WithdrawRequest {
     # WithdrawTime represents the time stamp of the request finalization.
     # If the request is pending, the withdrawTime will be NULL.
     withdrawTime: Long
+
+    # IsWithdrawn signals the request has been finalized. The withdrawTime
+    # is NULL for a finalized request if the withdrawal transaction is not known.
+    isWithdrawn: Boolean!
 }
 
 # UniswapPair represents the information about single
@@ -1501,8 +1806,23 @@ type LendingPool {
     # User account data for specified user address
     userAccountData(address: Address!): FLendUserData!
 
-    # User account deposit event history data
+    # User account deposit event history data,
+    # the most recent 1000 deposits are provided.
     userDepositHistory(address: Address, asset: Address): [FLendDeposit!]!
+
+    # User account borrow event history data,
+    # the most recent 1000 borrows are provided.
+    userBorrowHistory(address: Address, asset: Address): [FLendBorrow!]!
+
+    # List of all the actions of the user account,
+    # optionally filtered by the asset and action type.
+    userHistory(address: Address!, asset: Address, actionType: Int, cursor: Cursor, count: Int!): FLendActionList!
+
+    # List of borrow actions, optionally filtered by the user account and asset.
+    borrowHistory(address: Address, asset: Address, cursor: Cursor, count: Int!): FLendActionList!
+
+    # List of liquidations, optionally filtered by the liquidated user account.
+    liquidations(address: Address, cursor: Cursor, count: Int!): FLendActionList!
 }
 
 # ReserveData represents a lendingpool asset data.
@@ -1614,15 +1934,144 @@ type FLendBorrow {
     # interest rate mode
     interestRateMode: Int!
 
-    # borrow rate
+    # borrow rate in basis points
     borrowRate: Int!
 
+    # borrow rate in ray
+    borrowRateRay: BigInt!
+
 	# referral code
 	referralCode: Int!
 
     # time of deposit
     timestamp: Long!
 }
+
+# FLendActionList is a list of lending pool action edges
+# provided by sequential access request.
+type FLendActionList {
+    # Edges contains provided edges of the sequential list.
+    edges: [FLendActionListEdge!]!
+
+    # TotalCount is the maximum number of lending pool actions available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of lending pool action edges.
+    pageInfo: ListPageInfo!
+}
+
+# FLendActionListEdge is a single edge in a sequential list of lending pool actions.
+type FLendActionListEdge {
+    cursor: Cursor!
+    action: FLendAction!
+}
+
+# FLendAction represents an action on the lending pool.
+type FLendAction {
+    # id of the action in the persistent db
+    id: Cursor!
+
+    # type represents action type:
+    # 0 - deposit
+    # 1 - withdraw
+    # 2 - borrow
+    # 3 - repay
+    # 4 - borrow rate mode swap
+    # 5 - stable borrow rate rebalance
+    # 6 - flash loan
+    # 7 - liquidation call
+    type: Int!
+
+    # asset is the address of the reserve asset; the debt asset
+    # for liquidations, or the borrowed asset for flash loans.
+    asset: Address!
+
+    # assetToken represents the detail of the asset token.
+    assetToken: ERC20Token!
+
+    # user is the owner of the affected position, i.e. the on behalf of address
+    # for deposits and borrows, the liquidated user for liquidations,
+    # or the initiator for flash loans.
+    user: Address!
+
+    # counterparty is the caller for deposits and borrows, the recipient
+    # for withdrawals, the repayer for repays, the liquidator for liquidations,
+    # or the receiver contract for flash loans.
+    counterparty: Address!
+
+    # amount is the amount of the action, or the debt covered by a liquidation.
+    amount: BigInt!
+
+    # interestRateMode is the borrow rate mode of borrows and swaps.
+    interestRateMode: Int!
+
+    # borrowRate is the borrow rate of borrows in ray.
+    borrowRate: BigInt!
+
+    # premium is the fee paid for a flash loan.
+    premium: BigInt!
+
+    # collateralAsset is the address of the collateral asset of a liquidation.
+    collateralAsset: Address!
+
+    # collateralAmount is the amount of collateral liquidated.
+    collateralAmount: BigInt!
+
+    # receiveAToken signals the liquidator received aTokens instead of the collateral.
+    receiveAToken: Boolean!
+
+    # referralCode is the referral code of the action.
+    referralCode: Int!
+
+    # transactionHash represents the hash of the action transaction.
+    transactionHash: Bytes32!
+
+    # blockNumber is the number of the block of the action.
+    blockNumber: Long!
+
+    # timeStamp represents the time stamp of the action.
+    timeStamp: Long!
+}
+
+# FLendPosition represents the state of an fLend account position
+# as observed by the fLend position monitor of the API server.
+type FLendPosition {
+    # user represents the address of the position owner.
+    user: Address!
+
+    # totalCollateralFUSD represents the total value of the collateral in fUSD.
+    totalCollateralFUSD: BigInt!
+
+    # totalDebtFUSD represents the total value of the debt in fUSD.
+    totalDebtFUSD: BigInt!
+
+    # healthFactor represents the health factor of the position in 18 decimals.
+    # Positions with health factor below 1.0 can be liquidated.
+    healthFactor: BigInt!
+
+    # healthFactorValue represents the health factor as a floating point value.
+    healthFactorValue: Float!
+
+    # updated represents the time stamp of the last health factor refresh.
+    updated: Long!
+
+    # healthHistory represents the health factor time series of the position.
+    # If dates are not given, the last month is provided.
+    healthHistory(resolution: String, fromDate: Int, toDate: Int): [FLendHealthPoint!]!
+}
+
+# FLendHealthPoint represents a point of fLend position health factor time series.
+type FLendHealthPoint {
+    # time represents ISO time tag of the point.
+    time: String!
+
+    # healthFactor represents the health factor at the end of the period.
+    healthFactor: Float!
+
+    # low represents the lowest health factor observed in the period.
+    low: Float!
+}
+
 # RewardClaim represents
 type RewardClaim {
     # address represents the address of the delegator
@@ -1863,6 +2312,14 @@ type Account {
     # List of delegations of the account, if the account is a delegator.
     delegations(cursor:Cursor, count:Int = 25): DelegationList!
 
+    # stakingSchedule provides the list of upcoming lock expirations
+    # and pending withdrawals of the account sorted by the date.
+    stakingSchedule: [StakeScheduleItem!]!
+
+    # governanceVotes represents the list of Governance votes placed
+    # by the account, the latest first. Canceled votes are not listed.
+    governanceVotes(cursor: Cursor, count: Int = 25): GovernanceVoteList!
+
     # Details about smart contract, if the account is a smart contract.
     contract: Contract
 }
@@ -1909,6 +2366,11 @@ type GovernanceContract {
     # on the Governance contract in the form of votes
     # weight.
     totalVotingPower: BigInt!
+
+    # votingPowerOf represents the voting power of the given address
+    # broken down by its own stake, the stake delegated to it
+    # and the stake it delegated to validators.
+    votingPowerOf(address: Address!): GovernanceVotingPower!
 }
 
 # GovernanceProposalList is a list of governance proposal edges
@@ -2041,6 +2503,19 @@ type GovernanceProposal {
     # subject contract, the <delegatedTo> may be left empty, or set to the same address
     # as the <from> address.
     vote(from: Address!, delegatedTo: Address): GovernanceVote
+
+    # votes represents the list of votes placed on the Proposal, the latest first.
+    # Canceled votes are not listed.
+    votes(cursor: Cursor, count: Int = 25): GovernanceVoteList!
+
+    # history represents the list of life cycle events of the Proposal,
+    # the oldest first.
+    history: [GovernanceProposalEvent!]!
+
+    # projection calculates the expected outcome of the Proposal from the votes
+    # placed so far and the current stake of the voters. The optional whatIf votes
+    # are added to the votes placed so far in a separate "what_if" scenario.
+    projection(whatIf: [GovernanceVoteInput!]): GovernanceProjection!
 }
 
 # ProposalState represents the state of the whole proposal.
@@ -2103,6 +2578,691 @@ type GovernanceVote {
     # presented.
     choices: [Long!]!
 }
+# GovernanceVoteList is a list of votes placed on Governance Proposals.
+type GovernanceVoteList {
+    # Edges contains provided edges of the sequential list.
+    edges: [GovernanceVoteListEdge!]!
+
+    # TotalCount is the maximum number of votes available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of vote edges.
+    pageInfo: ListPageInfo!
+}
+
+# GovernanceVoteListEdge is a single edge in a sequential list of votes.
+type GovernanceVoteListEdge {
+    cursor: Cursor!
+
+    # vote represents the vote placed.
+    vote: GovernanceVote!
+
+    # votedAt is the time the vote was placed.
+    votedAt: Time!
+
+    # trxHash is the hash of the transaction placing the vote.
+    trxHash: Bytes32!
+
+    # proposal represents the Proposal the vote was placed on.
+    proposal: GovernanceProposal!
+}
+
+# GovernanceProposalEvent represents a life cycle event of a Governance Proposal.
+type GovernanceProposalEvent {
+    # type is the type of the event;
+    # one of "created", "resolved", "rejected" and "canceled"
+    type: String!
+
+    # trxHash is the hash of the transaction emitting the event.
+    trxHash: Bytes32!
+
+    # timeStamp is the time of the event.
+    timeStamp: Time!
+}
+
+# GovernanceVoteInput represents a hypothetical vote
+# used to project the outcome of a Governance Proposal.
+input GovernanceVoteInput {
+    # weight is the weight of the vote.
+    weight: BigInt!
+
+    # choices is the list of opinions on the Proposal options.
+    choices: [Long!]!
+}
+
+# GovernanceProjection represents the projected outcome of a Governance Proposal.
+type GovernanceProjection {
+    # totalWeight is the total voting weight available.
+    totalWeight: BigInt!
+
+    # votedWeight is the current weight of the votes placed.
+    votedWeight: BigInt!
+
+    # minVotes is the absolute weight of votes required to settle the Proposal.
+    minVotes: BigInt!
+
+    # overridable is the list of voting power of delegators who did not vote
+    # and are still able to override the vote of their validator.
+    overridable: [GovernanceOverridableWeight!]!
+
+    # scenarios is the list of projected outcomes; "current" for the votes
+    # placed so far, "overrides_oppose" and "overrides_favor" if all the overridable
+    # power voted with the lowest, or the highest opinion on all options,
+    # and "what_if" for the votes placed so far extended with the hypothetical votes.
+    scenarios: [GovernanceScenario!]!
+}
+
+# GovernanceOverridableWeight represents the weight of delegators of a voting validator
+# still able to override the validator vote.
+type GovernanceOverridableWeight {
+    # validator is the address of the validator.
+    validator: Address!
+
+    # validatorId is the ID of the validator.
+    validatorId: BigInt!
+
+    # weight is the total weight of the delegators not voting on their own.
+    weight: BigInt!
+
+    # delegators is the number of the delegators not voting on their own.
+    delegators: Int!
+}
+
+# GovernanceScenario represents a projected outcome of a Governance Proposal.
+type GovernanceScenario {
+    # name is the name of the scenario.
+    name: String!
+
+    # votes is the total weight of votes in the scenario.
+    votes: BigInt!
+
+    # minVotesReached signals the weight of votes reached the minimum.
+    minVotesReached: Boolean!
+
+    # minAgreementReached signals at least one option reached the minimal agreement.
+    minAgreementReached: Boolean!
+
+    # winnerId is the identifier of the expected winning option, if any.
+    winnerId: BigInt
+
+    # options is the list of projected states of the Proposal options.
+    options: [OptionState!]!
+}
+
+# GovernanceVotingPower represents the voting power of an address.
+type GovernanceVotingPower {
+    # total is the total voting power of the address.
+    total: BigInt!
+
+    # own is the weight of the address own stake, if the address is a validator.
+    own: BigInt!
+
+    # received is the weight delegated to the address, if the address is a validator.
+    received: BigInt!
+
+    # delegations is the list of weights the address delegated to validators.
+    delegations: [GovernanceDelegatedPower!]!
+}
+
+# GovernanceDelegatedPower represents the voting weight delegated to a validator.
+type GovernanceDelegatedPower {
+    # delegatedTo is the address of the validator.
+    delegatedTo: Address!
+
+    # validatorId is the ID of the validator.
+    validatorId: BigInt!
+
+    # weight is the delegated voting weight.
+    weight: BigInt!
+}
+
+# UniswapV3Pool represents a concentrated liquidity pool
+# deployed by the configured Uniswap v3 factory.
+type UniswapV3Pool {
+    # address represents the address of the pool contract.
+    address: Address!
+
+    # token0 represents the first token of the pool.
+    token0: ERC20Token!
+
+    # token1 represents the second token of the pool.
+    token1: ERC20Token!
+
+    # fee represents the swap fee of the pool in hundredths of a bip.
+    fee: Int!
+
+    # tickSpacing represents the minimal distance between usable ticks.
+    tickSpacing: Int!
+
+    # createdBlock represents the number of the block in which the pool was created.
+    createdBlock: Long!
+
+    # created represents the time stamp of the pool creation.
+    created: Long!
+
+    # state represents the current on-chain state of the pool.
+    state: UniswapV3PoolState!
+
+    # ticks represents the list of initialized ticks of the pool
+    # with their liquidity, ordered from the lowest tick up.
+    ticks: [UniswapV3Tick!]!
+
+    # positions represents the list of position manager
+    # liquidity positions on the pool.
+    positions: [UniswapV3Position!]!
+}
+
+# UniswapV3PoolState represents the current state of a concentrated liquidity pool.
+type UniswapV3PoolState {
+    # sqrtPriceX96 is the square root of the current price as a Q64.96 value.
+    sqrtPriceX96: BigInt!
+
+    # tick is the current tick of the pool.
+    tick: Int!
+
+    # liquidity is the in-range liquidity available to the pool.
+    liquidity: BigInt!
+
+    # price is the raw price of token0 denominated in token1,
+    # token decimals are not applied.
+    price: Float!
+
+    # feeGrowthGlobal0X128 is the fee growth of token0 per unit of liquidity.
+    feeGrowthGlobal0X128: BigInt!
+
+    # feeGrowthGlobal1X128 is the fee growth of token1 per unit of liquidity.
+    feeGrowthGlobal1X128: BigInt!
+}
+
+# UniswapV3Tick represents liquidity details of an initialized tick.
+type UniswapV3Tick {
+    # index is the tick index.
+    index: Int!
+
+    # liquidityGross is the total position liquidity referencing the tick.
+    liquidityGross: BigInt!
+
+    # liquidityNet is the amount of liquidity added (or removed, if negative)
+    # when the tick is crossed from left to right.
+    liquidityNet: BigInt!
+}
+
+# UniswapV3Position represents a liquidity position NFT
+# of the configured Uniswap v3 position manager.
+type UniswapV3Position {
+    # tokenId is the identifier of the position NFT.
+    tokenId: BigInt!
+
+    # owner is the current owner of the position NFT.
+    owner: Address!
+
+    # pool is the pool the position provides liquidity to.
+    pool: UniswapV3Pool
+
+    # token0 represents the first token of the position.
+    token0: ERC20Token!
+
+    # token1 represents the second token of the position.
+    token1: ERC20Token!
+
+    # fee represents the fee tier of the position pool.
+    fee: Int!
+
+    # tickLower is the lower tick boundary of the position.
+    tickLower: Int!
+
+    # tickUpper is the upper tick boundary of the position.
+    tickUpper: Int!
+
+    # liquidity is the liquidity of the position.
+    liquidity: BigInt!
+
+    # tokensOwed0 is the amount of token0 owed to the position
+    # as of the last position update.
+    tokensOwed0: BigInt!
+
+    # tokensOwed1 is the amount of token1 owed to the position
+    # as of the last position update.
+    tokensOwed1: BigInt!
+
+    # updated is the time stamp of the last position update.
+    updated: Long!
+}
+
+# UniswapV3ActionList is a list of concentrated liquidity pool action edges
+# provided by sequential access request.
+type UniswapV3ActionList {
+    # Edges contains provided edges of the sequential list.
+    edges: [UniswapV3ActionListEdge!]!
+
+    # TotalCount is the maximum number of pool actions available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of pool action edges.
+    pageInfo: ListPageInfo!
+}
+
+# UniswapV3ActionListEdge is a single edge in a sequential list of pool actions.
+type UniswapV3ActionListEdge {
+    cursor: Cursor!
+    action: UniswapV3Action!
+}
+
+# UniswapV3Action represents an action on a concentrated liquidity pool.
+type UniswapV3Action {
+    # id of the action in the persistent db
+    id: Cursor!
+
+    # type represents action type:
+    # 0 - swap
+    # 1 - mint
+    # 2 - burn
+    # 3 - collect
+    type: Int!
+
+    # pool is the pool of the action.
+    pool: UniswapV3Pool
+
+    # poolAddress is the address of the pool of the action.
+    poolAddress: Address!
+
+    # sender is the address initiating the action.
+    sender: Address!
+
+    # owner is the swap recipient, or the owner of the position for liquidity actions.
+    owner: Address!
+
+    # tickLower is the lower tick of the position for liquidity actions.
+    tickLower: Int!
+
+    # tickUpper is the upper tick of the position for liquidity actions.
+    tickUpper: Int!
+
+    # tick is the pool tick after a swap.
+    tick: Int!
+
+    # amount0 is the amount of token0; swap amounts are signed
+    # and negative value represents tokens leaving the pool.
+    amount0: BigInt!
+
+    # amount1 is the amount of token1; swap amounts are signed
+    # and negative value represents tokens leaving the pool.
+    amount1: BigInt!
+
+    # liquidity is the pool liquidity after a swap,
+    # or the position liquidity change for mint and burn.
+    liquidity: BigInt!
+
+    # sqrtPriceX96 is the square root of the pool price after a swap.
+    sqrtPriceX96: BigInt!
+
+    # price is the raw price of token0 denominated in token1 after a swap.
+    price: Float!
+
+    # transactionHash represents the hash of the action transaction.
+    transactionHash: Bytes32!
+
+    # blockNumber is the number of the block of the action.
+    blockNumber: Long!
+
+    # timeStamp represents the time stamp of the action.
+    timeStamp: Long!
+}
+
+# FMintTransaction represents a core transaction on the fMint protocol.
+type FMintTransaction {
+    # user represents the address of the fMint account.
+    user: Address!
+
+    # tokenAddress represents the address of the token involved.
+    tokenAddress: Address!
+
+    # token represents the detail of the token involved.
+    token: ERC20Token!
+
+    # type represents the transaction type:
+    # 0 - deposit
+    # 1 - withdraw
+    # 2 - mint
+    # 3 - repay
+    # 4 - reward
+    type: Int!
+
+    # amount represents the amount of tokens involved.
+    amount: BigInt!
+
+    # fee represents the fee paid on the transaction.
+    fee: BigInt!
+
+    # trxHash represents the hash of the transaction.
+    trxHash: Bytes32!
+
+    # timeStamp represents the time stamp of the transaction.
+    timeStamp: Long!
+}
+
+# FMintTransactionList is a list of fMint transaction edges
+# provided by sequential access request.
+type FMintTransactionList {
+    # Edges contains provided edges of the sequential list.
+    edges: [FMintTransactionListEdge!]!
+
+    # TotalCount is the maximum number of fMint transactions available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of fMint transaction edges.
+    pageInfo: ListPageInfo!
+}
+
+# FMintTransactionListEdge is a single edge in a sequential list of fMint transactions.
+type FMintTransactionListEdge {
+    cursor: Cursor!
+    transaction: FMintTransaction!
+}
+
+# FMintTransactionFilter represents a filter of the fMint transactions list.
+input FMintTransactionFilter {
+    # user limits the list to the given fMint account.
+    user: Address
+
+    # token limits the list to the given token.
+    token: Address
+
+    # type limits the list to the given transaction type.
+    type: Int
+
+    # fromDate limits the list to transactions made at or after the time stamp.
+    fromDate: Int
+
+    # toDate limits the list to transactions made at or before the time stamp.
+    toDate: Int
+}
+
+# FMintRiskPoint represents a point of fMint account
+# collateral to debt ratio time series.
+type FMintRiskPoint {
+    # time represents ISO time tag of the point.
+    time: String!
+
+    # collateralValue represents the collateral value in ref. denomination (fUSD).
+    collateralValue: BigInt!
+
+    # debtValue represents the debt value in ref. denomination (fUSD).
+    debtValue: BigInt!
+
+    # ratio represents the collateral to debt ratio; zero if the account has no debt.
+    ratio: Float!
+}
+
+# StakingReport represents a report of staking rewards and related staking events
+# of an account with the fiat value of each event at the time of the event.
+type StakingReport {
+    # address represents the address of the delegator
+    address: Address!
+
+    # currency represents the fiat currency symbol of the valuation
+    currency: String!
+
+    # fromTime is the start of the reported period
+    fromTime: Time!
+
+    # toTime is the end of the reported period
+    toTime: Time!
+
+    # entries is the list of reported events sorted by time
+    entries: [StakingReportEntry!]!
+
+    # totals is the list of totals per year and event type
+    totals: [StakingReportTotal!]!
+}
+
+# StakingReportEntry represents a single event of the staking report.
+type StakingReportEntry {
+    # type is the type of the event; one of "claim", "restake",
+    # "lock_penalty" and "withdrawal"
+    type: String!
+
+    # timeStamp is the time of the event
+    timeStamp: Time!
+
+    # epoch is the id of the epoch of the event
+    epoch: Long!
+
+    # validatorId is the ID of the validator of the delegation
+    validatorId: BigInt!
+
+    # amount is the amount of tokens of the event in WEI units
+    amount: BigInt!
+
+    # trxHash is the hash of the transaction of the event
+    trxHash: Bytes32!
+
+    # hasPrice signals the price history covers the time of the event
+    # and the event is valued. The price history is recorded hourly and older
+    # history is backfilled from an external source in the background;
+    # events outside of the covered history are not valued.
+    hasPrice: Boolean!
+
+    # price is the price of the native token in the report currency
+    # at the time of the event; null if hasPrice is false
+    price: Float
+
+    # fiatValue is the value of the amount in the report currency
+    # at the time of the event; null if hasPrice is false
+    fiatValue: Float
+}
+
+# StakingReportTotal represents a total of an event type in a calendar year.
+type StakingReportTotal {
+    # year is the calendar year of the total
+    year: Int!
+
+    # type is the type of the events
+    type: String!
+
+    # count is the number of events
+    count: Int!
+
+    # amount is the total amount of tokens in WEI units
+    amount: BigInt!
+
+    # fiatValue is the total value of the valued events in the report currency
+    fiatValue: Float!
+
+    # unvalued is the number of events without known fiat value
+    unvalued: Int!
+}
+
+# StakeScheduleItem represents an upcoming lock expiration,
+# or a pending withdrawal of a delegation.
+type StakeScheduleItem {
+    # type is the type of the event; "unlock" for a lock expiration,
+    # "withdrawal" for a withdraw request becoming claimable
+    type: String!
+
+    # validatorId is the ID of the validator of the delegation
+    validatorId: BigInt!
+
+    # requestId is the ID of the withdraw request; null for unlocks
+    requestId: BigInt
+
+    # date is the time of the lock expiration, or the time
+    # the withdraw request becomes claimable
+    date: Time!
+
+    # amount is the expected amount of tokens in WEI units
+    amount: BigInt!
+
+    # isClaimable signals the withdrawal can already be claimed
+    isClaimable: Boolean!
+}
+
+# StakeScheduleTick represents network-wide amounts of stake unlocking
+# and becoming withdrawable in a time period.
+type StakeScheduleTick {
+    # date is the start of the time period
+    date: Time!
+
+    # unlocking is the amount of locked stake expiring in the period in WEI units
+    unlocking: BigInt!
+
+    # unlockCount is the number of lock expirations in the period
+    unlockCount: Int!
+
+    # withdrawable is the amount of pending withdrawals becoming claimable
+    # in the period in WEI units
+    withdrawable: BigInt!
+
+    # withdrawCount is the number of withdrawals becoming claimable in the period
+    withdrawCount: Int!
+}
+
+# ValidatorAlert represents an alert raised by a validator alert rule.
+type ValidatorAlert {
+    # id is the unique identifier of the alert
+    id: String!
+
+    # validatorId is the ID of the validator
+    validatorId: BigInt!
+
+    # address is the address of the validator
+    address: Address!
+
+    # rule is the name of the alert rule raising the alert
+    rule: String!
+
+    # type is the type of the alert rule; one of "offline", "downtime",
+    # "not_voting", "cheater", "self_stake" and "lock_expiring"
+    type: String!
+
+    # message is the description of the alert
+    message: String!
+
+    # raised is the time the alert was raised
+    raised: Time!
+
+    # resolved is the time the alert was resolved; null if still active
+    resolved: Time
+
+    # isActive signals the alert has not been resolved yet
+    isActive: Boolean!
+}
+
+# ValidatorFilter represents a filter of the validators directory.
+input ValidatorFilter {
+    # isActive limits the list to active, or not active validators.
+    isActive: Boolean
+
+    # isOffline limits the list to offline, or online validators.
+    isOffline: Boolean
+
+    # isLocked limits the list to validators with locked, or unlocked self stake.
+    isLocked: Boolean
+
+    # hasInfo limits the list to validators with, or without the staker information.
+    hasInfo: Boolean
+
+    # minCommission limits the list to validators with commission
+    # at or above the value in percent.
+    minCommission: Float
+
+    # maxCommission limits the list to validators with commission
+    # at or below the value in percent.
+    maxCommission: Float
+
+    # minStake limits the list to validators with total stake
+    # at or above the amount in WEI.
+    minStake: BigInt
+}
+
+# ValidatorSortField represents the sorting of the validators directory.
+enum ValidatorSortField {
+    TOTAL_STAKE
+    DELEGATORS
+    UPTIME
+    LOCK_REMAINING
+    APR
+}
+
+# ValidatorList is a list of validators directory entries.
+type ValidatorList {
+    # Edges contains provided edges of the sequential list.
+    edges: [ValidatorListEdge!]!
+
+    # TotalCount is the total number of validators matching the filter.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of validators.
+    pageInfo: ListPageInfo!
+}
+
+# ValidatorListEdge is a single edge in a sequential list of validators.
+type ValidatorListEdge {
+    cursor: Cursor!
+    validator: ValidatorDirectoryEntry!
+}
+
+# ValidatorDirectoryEntry represents a periodically refreshed snapshot of a validator.
+type ValidatorDirectoryEntry {
+    # id is the ID of the validator
+    id: Long!
+
+    # address is the address of the validator
+    address: Address!
+
+    # name is the name of the validator from the staker information, if available
+    name: String
+
+    # status is the SFC status of the validator
+    status: Long!
+
+    # isActive signals the validator is active
+    isActive: Boolean!
+
+    # isOffline signals the validator is offline
+    isOffline: Boolean!
+
+    # isWithdrawn signals the validator has been withdrawn
+    isWithdrawn: Boolean!
+
+    # isCheater signals the validator has been flagged as a cheater
+    isCheater: Boolean!
+
+    # totalStake is the total amount staked to the validator in WEI
+    totalStake: BigInt!
+
+    # selfStake is the amount staked by the validator itself in WEI
+    selfStake: BigInt!
+
+    # delegatorsCount is the number of active delegations of the validator
+    delegatorsCount: Int!
+
+    # lockedUntil is the time the self stake lock expires; null if not locked
+    lockedUntil: Time
+
+    # uptime is the percentage of time the validator was online in recent epochs
+    uptime: Float!
+
+    # commission is the validator commission in percent
+    commission: Float!
+
+    # apr is the annual percentage rate realized in the last sealed epoch
+    apr: Float!
+
+    # hasInfo signals the validator provided the staker information
+    hasInfo: Boolean!
+
+    # snapshot is the time the entry was refreshed
+    snapshot: Time!
+
+    # staker provides the full details of the validator
+    staker: Staker!
+}
+
+# cost sets the static cost of a field used to limit complexity of incoming queries;
+# list fields multiply the cost by the number of items requested.
+directive @cost(weight: Int!) on FIELD_DEFINITION
+
 # Root schema definition
 schema {
     query: Query
@@ -2242,6 +3402,10 @@ type Query {
     # fMintAccount provides DeFi/fMint information about an account on fMint protocol.
     fMintAccount(owner: Address!):FMintAccount!
 
+    # fMintTransactions provides list of fMint protocol transactions
+    # optionally filtered by the account, token, type and time range.
+    fMintTransactions(filter: FMintTransactionFilter, cursor: Cursor, count: Int!):FMintTransactionList!
+
     # fMintTokenAllowance resolves the amount of ERC20 tokens unlocked
     # by the token owner for DeFi/fMint operations.
     fMintTokenAllowance(owner: Address!, token: Address!):BigInt!
@@ -2314,6 +3478,43 @@ type Query {
     # 2 - burn,
     defiUniswapActions(pairAddress:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapActionList!
 
+    # defiUniswapV3Pools represents a list of all concentrated liquidity pools
+    # deployed by the configured Uniswap v3 factory.
+    defiUniswapV3Pools: [UniswapV3Pool!]!
+
+    # defiUniswapV3Pool provides a concentrated liquidity pool by its address.
+    # The resolver returns NULL if the pool is not known.
+    defiUniswapV3Pool(address: Address!): UniswapV3Pool
+
+    # defiUniswapV3Position provides a liquidity position by its NFT token id.
+    # The resolver returns NULL if the position is not known.
+    defiUniswapV3Position(tokenId: BigInt!): UniswapV3Position
+
+    # defiUniswapV3Positions provides a list of liquidity positions
+    # of the given owner and/or on the given pool.
+    defiUniswapV3Positions(owner: Address, pool: Address): [UniswapV3Position!]!
+
+    # Get list of concentrated liquidity pool actions with at most <count> edges.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    # For undefined cursor, positive <count> starts the list from top,
+    # negative <count> starts the list from bottom.
+    # Pool and owner can be used to narrow the list down.
+    # ActionType represents action type:
+    # 0 - swap,
+    # 1 - mint,
+    # 2 - burn,
+    # 3 - collect
+    defiUniswapV3Actions(pool:Address, owner:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapV3ActionList!
+
+    # defiUniswapV3TimePrices returns OHLC prices for specified pool, time resolution and interval.
+    # Address is pool address and is mandatory.
+    # Resolution can be {month, day, 4h, 1h, 30m 15m, 5m, 1m}, is optional, default is a day.
+    # Direction specifies price calculation, default 0 is for Token0/Token1 otherwise Token1/Token0
+    # Dates are in unix UTC number and are optional. When not provided
+    # then it takes period for last month till now.
+    defiUniswapV3TimePrices(address:Address!, resolution:String, fromDate:Int, toDate:Int, direction:Int):[DefiTimePrice!]!
+
     # erc20Token provides the information about an ERC20 token specified by it's
     # address, if available. The resolver returns NULL if the token does not exist.
     erc20Token(token: Address!):ERC20Token
@@ -2362,6 +3563,15 @@ type Query {
     # fLendLendingPool represents an instance of an fLend Lending pool
     fLendLendingPool: LendingPool!
 
+    # fLendPosition provides the latest known state of the fLend position
+    # of the given account as observed by the fLend position monitor.
+    fLendPosition(address: Address!): FLendPosition
+
+    # fLendAtRiskPositions provides the list of fLend positions with debt
+    # and the health factor below the given threshold, the riskiest positions first.
+    # Positions with health factor below 1.0 can be liquidated.
+    fLendAtRiskPositions(threshold: Float!, count: Int = 100): [FLendPosition!]!
+
     # trxVolume provides a list of daily aggregations of the network transaction flow.
     # If boundaries are not defined, last 90 days of aggregated trx flow is provided.
     # Boundaries are defined in format YYYY-MM-DD, i.e. 2021-01-23 for January 23rd, 2021.
@@ -2380,6 +3590,28 @@ type Query {
     # we use to calculate the average gas consumption.
     trxGasSpeed(range: Int = 1200, to: String): Float!
 
+    # stakingReport provides a report of staking rewards, restakes, lock penalties
+    # and withdrawals of the given account with fiat values in the given currency
+    # at the time of each event. If the end time is not specified, the current time is used.
+    # If the start time is not specified, the report starts at the beginning of the end time year.
+    stakingReport(address: Address!, currency: String = "USD", from: Time, to: Time): StakingReport!
+
+    # validators provides a page of the validators directory matching the filter
+    # and sorted by the given field; the directory is refreshed periodically.
+    # Negative count loads the page preceding the cursor.
+    validators(filter: ValidatorFilter, sortBy: ValidatorSortField = TOTAL_STAKE, sortDesc: Boolean = true, cursor: Cursor, count: Int = 25): ValidatorList!
+
+    # validatorAlerts provides the history of validator alerts, the latest first,
+    # optionally limited to the given validator and to alerts not resolved yet.
+    # The max number of alerts provided is 500.
+    validatorAlerts(validatorId: Long, activeOnly: Boolean = false, count: Int = 50): [ValidatorAlert!]!
+
+    # stakeUnlockSchedule provides network-wide amounts of locked stake expiring
+    # and pending withdrawals becoming claimable in the given time span,
+    # aggregated by the resolution; "day" (default), "week", or "month".
+    # If not specified, the schedule covers the next 90 days; the max time span is 2 years.
+    stakeUnlockSchedule(from: Time, to: Time, resolution: String): [StakeScheduleTick!]!
+
     # gasPriceList provides a list of gas price ticks for the given date/time span.
     # If the end time is not specified, the list is provided up to the current date/time.
     # The maximal date/time span of the list is 30 days.
@@ -2409,6 +3641,19 @@ type Query {
 
     # networkNodesAggregated provides an aggregated list of network nodes on the Opera network.
     networkNodesAggregated(level: NetworkNodeGroupLevel = COUNTRY): NetworkNodeGroupList!
+
+    # networkNodes provides a list of network nodes found on the Opera network
+    # matching the filter, the most recently seen nodes first.
+    networkNodes(filter: NetworkNodeFilter, cursor: Cursor, count: Int = 25): NetworkNodeList!
+
+    # networkNodesByVersion provides a list of active network nodes
+    # aggregated by the version of their client software.
+    networkNodesByVersion: NetworkNodeVersionGroupList!
+
+    # networkStats provides historical snapshots of the Opera network topology
+    # in the given time range (unix time stamps, the last 30 days by default)
+    # with active nodes grouped by the given property.
+    networkStats(from: Long, to: Long, resolution: NetworkStatsResolution = DAY, groupBy: NetworkStatsGroupBy = COUNTRY): [NetworkStatsPoint!]!
 }
 
 # Mutation endpoints for modifying the data
@@ -2432,6 +3677,10 @@ type Subscription {
 
     # Subscribe to receive information about new transactions in the blockchain.
     onTransaction: Transaction!
+
+    # Subscribe to receive fLend position updates of the given account
+    # whenever its refreshed health factor is below the threshold.
+    onHealthFactorBelow(address: Address!, threshold: Float!): FLendPosition!
 }
 
 `
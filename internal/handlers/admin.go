@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/svc"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"io"
+	"net/http"
+)
+
+// adminMaxBodySize represents the max size of an administration API request body.
+const adminMaxBodySize = 64 << 10
+
+// adminLogLevel represents a log level change request; empty module changes the default level.
+type adminLogLevel struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+// adminEviction represents a request to evict keys from the in-memory cache.
+type adminEviction struct {
+	Keys []string `json:"keys"`
+}
+
+// adminReScan represents a request to re-scan the given number of blocks.
+type adminReScan struct {
+	Depth uint64 `json:"depth"`
+}
+
+// adminRestoreStake represents a request to restore the stake of the given owner.
+type adminRestoreStake struct {
+	Owner common.Address `json:"owner"`
+}
+
+// Admin constructs the HTTP handler of the runtime administration API. The API allows to inspect
+// the effective configuration, reload it, change log levels, evict the in-memory cache,
+// and trigger the re-scan and stake reconciliation jobs. All the requests need the basic auth
+// credentials of the configuration; the API is not available without the password.
+func Admin(cfg *config.Config, log logger.Logger, reload func() ([]string, error)) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/config", adminMethod(http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+		adminRespond(w, log, config.Effective(cfg))
+	}))
+
+	mux.HandleFunc("/admin/config/reload", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		restart, err := reload()
+		if err != nil {
+			adminFail(w, log, http.StatusInternalServerError, err)
+			return
+		}
+		adminRespond(w, log, map[string]interface{}{"restartRequired": restart})
+	}))
+
+	mux.HandleFunc("/admin/log", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var req adminLogLevel
+			if !adminDecode(w, r, log, &req) {
+				return
+			}
+			if err := logger.SetLevel(req.Module, req.Level); err != nil {
+				adminFail(w, log, http.StatusBadRequest, err)
+				return
+			}
+			log.Noticef("log level of module %q set to %s", req.Module, req.Level)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		def, modules := logger.Levels()
+		adminRespond(w, log, map[string]interface{}{"level": def, "modules": modules})
+	})
+
+	mux.HandleFunc("/admin/cache/evict", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		var req adminEviction
+		if !adminDecode(w, r, log, &req) {
+			return
+		}
+
+		evicted := make([]string, 0, len(req.Keys))
+		for _, key := range req.Keys {
+			ok, err := repository.R().CacheEvict(key)
+			if err != nil {
+				adminFail(w, log, http.StatusInternalServerError, err)
+				return
+			}
+			if ok {
+				evicted = append(evicted, key)
+			}
+		}
+		adminRespond(w, log, map[string]interface{}{"evicted": evicted})
+	}))
+
+	mux.HandleFunc("/admin/cache/reset", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		if err := repository.R().CacheReset(); err != nil {
+			adminFail(w, log, http.StatusInternalServerError, err)
+			return
+		}
+		adminRespond(w, log, map[string]interface{}{"reset": true})
+	}))
+
+	mux.HandleFunc("/admin/jobs/rescan", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		var req adminReScan
+		if !adminDecode(w, r, log, &req) {
+			return
+		}
+		adminJob(w, log, fmt.Sprintf("re-scan of %d blocks", req.Depth), svc.Manager().ReScan(req.Depth))
+	}))
+
+	mux.HandleFunc("/admin/jobs/stake", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		var req adminRestoreStake
+		if !adminDecode(w, r, log, &req) {
+			return
+		}
+		adminJob(w, log, fmt.Sprintf("stake restore of %s", req.Owner.String()), svc.Manager().RestoreStake(req.Owner))
+	}))
+
+	mux.HandleFunc("/admin/jobs/reconcile", adminMethod(http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+		adminJob(w, log, "stake reconciliation", svc.Manager().ReconcileStakes())
+	}))
+
+	return basicAuth(cfg.Admin.User, cfg.Admin.Password, "admin", log, mux)
+}
+
+// adminMethod restricts the handler to the given HTTP method.
+func adminMethod(method string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// adminDecode decodes the JSON body of the request; the request is failed if the body is not valid.
+func adminDecode(w http.ResponseWriter, r *http.Request, log logger.Logger, req interface{}) bool {
+	err := json.NewDecoder(io.LimitReader(r.Body, adminMaxBodySize)).Decode(req)
+	if err != nil {
+		adminFail(w, log, http.StatusBadRequest, fmt.Errorf("invalid request; %s", err.Error()))
+		return false
+	}
+	return true
+}
+
+// adminJob responds to the job trigger request.
+func adminJob(w http.ResponseWriter, log logger.Logger, job string, err error) {
+	if err != nil {
+		adminFail(w, log, http.StatusConflict, err)
+		return
+	}
+
+	log.Noticef("%s requested by administrator", job)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	adminRespond(w, log, map[string]interface{}{"accepted": job})
+}
+
+// adminFail responds with the given error.
+func adminFail(w http.ResponseWriter, log logger.Logger, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": err.Error()}); err != nil {
+		log.Debugf("can not write admin response; %s", err.Error())
+	}
+}
+
+// adminRespond responds with the given JSON encoded value.
+func adminRespond(w http.ResponseWriter, log logger.Logger, val interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(val); err != nil {
+		log.Errorf("can not encode admin response; %s", err.Error())
+	}
+}
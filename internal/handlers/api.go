@@ -8,36 +8,97 @@ import (
 	"fantom-api-graphql/internal/logger"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
-	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
+	otelgraphql "github.com/graph-gophers/graphql-go/trace/otel"
 	"github.com/rs/cors"
 	"net/http"
+	"sync/atomic"
 )
 
 // Api constructs and return the API HTTP handlers chain for serving GraphQL API calls.
 func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver) http.Handler {
-	// Create a new CORS handler and attach the logger into it, so we get information on Debug level if needed
-	corsHandler := cors.New(corsOptions(cfg))
-	corsHandler.Log = log
-
 	// we don't want to write a method for each type field if it could be matched directly
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers(), graphql.Logger(&panicLogger{logger: log})}
+	if depth := maxQueryDepth(&cfg.Limits); depth > 0 {
+		opts = append(opts, graphql.MaxDepth(depth))
+	}
+	if cfg.Tracing.Enabled {
+		opts = append(opts, graphql.Tracer(otelgraphql.DefaultTracer()))
+	}
 
 	// create new parsed GraphQL schema
 	schema := graphql.MustParseSchema(gqlSchema.Schema(), rs, opts...)
 
+	// queries are analyzed for the cost and the cache policy
+	an := newQueryAnalyzer(cfg, schema)
+	rm := NewRequestMetricsHandler(NewResponseCacheHandler(cfg, log, rs, withLoaders(&relay.Handler{Schema: schema})))
+	lh := NewLimitsHandler(cfg, log, an, rm)
+
+	// streaming operations bypass the regular chain, they share the persisted queries and limits checks
+	ph := NewPersistedQueryHandler(cfg, log, an, lh)
+
 	// return the constructed API handler chain
 	return &LoggingHandler{
-		logger:  log,
-		handler: corsHandler.Handler(graphqlws.NewHandlerFunc(schema, &relay.Handler{Schema: schema})),
+		logger: log,
+		handler: &TracingHandler{
+			handler: newCorsHandler(cfg, log, NewSubscriptionHandler(cfg, log, schema, lh, rm, ph)),
+		},
 	}
 }
 
+// withLoaders attaches request scoped batch loaders to the context of incoming API requests,
+// so resolvers of the same request can share the lookups of blocks, transactions, accounts, etc.
+func withLoaders(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(resolvers.WithLoaders(r.Context())))
+	})
+}
+
+// corsHandler implements the CORS middleware following the allowed origins
+// of the reloaded configuration.
+type corsHandler struct {
+	handler atomic.Pointer[http.Handler]
+}
+
+// newCorsHandler creates a new CORS middleware in front of the given handler.
+func newCorsHandler(cfg *config.Config, log logger.Logger, h http.Handler) http.Handler {
+	var ch corsHandler
+	update := func(c *config.Config) {
+		// attach the logger, so we get information on Debug level if needed
+		cr := cors.New(corsOptions(c))
+		cr.Log = log
+
+		wh := cr.Handler(h)
+		ch.handler.Store(&wh)
+	}
+
+	update(cfg)
+	config.OnReload(update)
+	return &ch
+}
+
+// ServeHTTP passes the request to the CORS handler of the current configuration.
+func (ch *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*ch.handler.Load()).ServeHTTP(w, r)
+}
+
 // corsOptions constructs a new set of options for the CORS handler based on the provided configuration.
 func corsOptions(cfg *config.Config) cors.Options {
 	return cors.Options{
-		AllowedOrigins: cfg.Server.CorsOrigin,
+		AllowedOrigins: cfg.Server.AllowedOrigins(),
 		AllowedMethods: []string{"HEAD", "GET", "POST"},
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", requestIDHeader, cfg.Limits.KeyHeader},
 		MaxAge:         300,
 	}
 }
+
+// maxQueryDepth provides the max depth of a query allowed to any client; it is enforced
+// by the GraphQL executor on top of the limits checked by the limits handler.
+func maxQueryDepth(cfg *config.Limits) int {
+	depth := cfg.MaxDepth
+	for _, t := range cfg.Tiers {
+		if depth > 0 && t.MaxDepth > depth {
+			depth = t.MaxDepth
+		}
+	}
+	return depth
+}
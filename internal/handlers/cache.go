@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/complexity"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+var (
+	responseCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "graphql_response_cache_hits_total",
+		Help: "The total number of GraphQL responses served from the response cache",
+	})
+	responseCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "graphql_response_cache_misses_total",
+		Help: "The total number of cacheable GraphQL queries not found in the response cache",
+	})
+	responseCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "graphql_response_cache_evictions_total",
+		Help: "The total number of GraphQL responses evicted from the response cache to keep the memory limit",
+	})
+	responseCacheSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "graphql_response_cache_bytes",
+		Help: "The total size of GraphQL responses kept in the response cache",
+	})
+	responseCacheEntries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "graphql_response_cache_entries",
+		Help: "The number of GraphQL responses kept in the response cache",
+	}, []string{
+		"finality",
+	})
+)
+
+// responseCacheMaxEntryShare represents the max share of the cache memory a single response can take.
+const responseCacheMaxEntryShare = 16
+
+// ResponseCacheHandler defines HTTP handler middleware keeping GraphQL responses in memory.
+// Responses with finalized chain data only are kept until evicted by the memory limit,
+// responses depending on the chain head are dropped on each new block.
+type ResponseCacheHandler struct {
+	logger  flogger.Logger
+	handler http.Handler
+
+	mu    sync.Mutex
+	limit int
+	gen   uint64
+
+	// final and head dependent responses are kept apart,
+	// so the head dependent ones can be dropped at once
+	final *responseCacheSet
+	head  *responseCacheSet
+}
+
+// responseCacheSet represents a set of cached responses in LRU order.
+type responseCacheSet struct {
+	lru     *list.List
+	entries map[string]*list.Element
+	size    int
+}
+
+// responseCacheEntry represents a single cached response.
+type responseCacheEntry struct {
+	key   string
+	body  []byte
+	final bool
+}
+
+// newResponseCacheSet creates a new empty set of cached responses.
+func newResponseCacheSet() *responseCacheSet {
+	return &responseCacheSet{lru: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// remove drops the given element from the set.
+func (cs *responseCacheSet) remove(el *list.Element) {
+	e := cs.lru.Remove(el).(*responseCacheEntry)
+	delete(cs.entries, e.key)
+	cs.size -= len(e.key) + len(e.body)
+}
+
+// NewResponseCacheHandler creates a new response cache middleware. The head dependent responses
+// are invalidated by the new block events of the given API resolver.
+func NewResponseCacheHandler(cfg *config.Config, log flogger.Logger, rs resolvers.ApiResolver, h http.Handler) http.Handler {
+	if cfg.Queries.ResponseCacheSize <= 0 {
+		log.Notice("GraphQL response cache disabled")
+		return h
+	}
+
+	rc := ResponseCacheHandler{
+		logger:  log,
+		handler: h,
+		limit:   cfg.Queries.ResponseCacheSize << 20,
+		final:   newResponseCacheSet(),
+		head:    newResponseCacheSet(),
+	}
+	go rc.observeBlocks(rs.OnBlock(context.Background()))
+	return &rc
+}
+
+// observeBlocks invalidates head dependent responses on new blocks.
+func (rc *ResponseCacheHandler) observeBlocks(blocks <-chan *resolvers.Block) {
+	for range blocks {
+		rc.invalidate()
+	}
+}
+
+// ServeHTTP serves the incoming GraphQL query from the cache, if possible. Otherwise, the query
+// is passed to the next handler in the chain and the response is cached, if it's cacheable.
+func (rc *ResponseCacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res, ok := r.Context().Value(queryAnalysisKey{}).(*complexity.Result)
+	if !ok || res.Operation != "query" {
+		rc.handler.ServeHTTP(w, r)
+		return
+	}
+
+	key, err := rc.key(r)
+	if err != nil {
+		rc.handler.ServeHTTP(w, r)
+		return
+	}
+
+	hint := resolvers.CacheHintOf(r.Context())
+	if hint == nil {
+		var ctx context.Context
+		ctx, hint = resolvers.WithCacheHint(r.Context())
+		r = r.WithContext(ctx)
+	}
+
+	if body, final, ok := rc.get(key); ok {
+		responseCacheHits.Inc()
+
+		// replay the finality of the cached data for the cache headers
+		if !final {
+			hint.MarkVolatile()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			rc.logger.Debugf("can not write cached response; %s", err.Error())
+		}
+		return
+	}
+	responseCacheMisses.Inc()
+
+	// responses started before a new block may already be outdated
+	rc.mu.Lock()
+	gen := rc.gen
+	rc.mu.Unlock()
+
+	rec := responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	rc.handler.ServeHTTP(&rec, r)
+
+	if rec.status == http.StatusOK && !hasErrors(rec.body.Bytes()) {
+		rc.put(key, rec.body.Bytes(), isFinal(res, hint), gen)
+	}
+
+	w.WriteHeader(rec.status)
+	if _, err := w.Write(rec.body.Bytes()); err != nil {
+		rc.logger.Debugf("can not write response; %s", err.Error())
+	}
+}
+
+// key provides the cache key of the query request.
+func (rc *ResponseCacheHandler) key(r *http.Request) (string, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var req limitsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", err
+	}
+
+	// variables are encoded with sorted keys, so the same variables always give the same key
+	vars, err := json.Marshal(req.Variables)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(req.Query))
+	h.Write([]byte{0})
+	h.Write([]byte(req.OperationName))
+	h.Write([]byte{0})
+	h.Write(vars)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// get provides the cached response of the given key, if any.
+func (rc *ResponseCacheHandler) get(key string) ([]byte, bool, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, cs := range []*responseCacheSet{rc.final, rc.head} {
+		if el, ok := cs.entries[key]; ok {
+			cs.lru.MoveToFront(el)
+
+			e := el.Value.(*responseCacheEntry)
+			return e.body, e.final, true
+		}
+	}
+	return nil, false, false
+}
+
+// put adds the response to the cache. Head dependent responses are not added
+// if a new block arrived while the response was being resolved.
+func (rc *ResponseCacheHandler) put(key string, body []byte, final bool, gen uint64) {
+	size := len(key) + len(body)
+	if size > rc.limit/responseCacheMaxEntryShare {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if !final && gen != rc.gen {
+		return
+	}
+	for _, cs := range []*responseCacheSet{rc.final, rc.head} {
+		if el, ok := cs.entries[key]; ok {
+			cs.remove(el)
+		}
+	}
+
+	// make space for the new response; the short-lived head dependent responses go first
+	for rc.final.size+rc.head.size+size > rc.limit {
+		cs := rc.head
+		if cs.lru.Len() == 0 {
+			cs = rc.final
+		}
+		if cs.lru.Len() == 0 {
+			break
+		}
+		cs.remove(cs.lru.Back())
+		responseCacheEvictions.Inc()
+	}
+
+	cs := rc.head
+	if final {
+		cs = rc.final
+	}
+	e := responseCacheEntry{key: key, body: append([]byte(nil), body...), final: final}
+	cs.entries[key] = cs.lru.PushFront(&e)
+	cs.size += size
+	rc.updateMetrics()
+}
+
+// invalidate drops all the head dependent responses.
+func (rc *ResponseCacheHandler) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.gen++
+	rc.head = newResponseCacheSet()
+	rc.updateMetrics()
+}
+
+// updateMetrics updates the cache size metrics. The caller is expected to hold the cache lock.
+func (rc *ResponseCacheHandler) updateMetrics() {
+	responseCacheSize.Set(float64(rc.final.size + rc.head.size))
+	responseCacheEntries.WithLabelValues("final").Set(float64(rc.final.lru.Len()))
+	responseCacheEntries.WithLabelValues("head").Set(float64(rc.head.lru.Len()))
+}
+
+// hasErrors checks if the GraphQL response contains any errors.
+func hasErrors(body []byte) bool {
+	var errs struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	return json.Unmarshal(body, &errs) != nil || len(errs.Errors) > 0
+}
@@ -11,37 +11,21 @@ const graphiqlTemplate = `
 <!DOCTYPE html>
 <html>
    <head>
-		   <link rel="stylesheet" href="https://cdnjs.cloudflare.com/ajax/libs/graphiql/0.11.10/graphiql.css" />
-		   <script src="https://cdnjs.cloudflare.com/ajax/libs/fetch/1.1.0/fetch.min.js"></script>
-		   <script src="https://cdnjs.cloudflare.com/ajax/libs/react/15.5.4/react.min.js"></script>
-		   <script src="https://cdnjs.cloudflare.com/ajax/libs/react/15.5.4/react-dom.min.js"></script>
-		   <script src="https://cdnjs.cloudflare.com/ajax/libs/graphiql/0.11.10/graphiql.js"></script>
-		   <script src="//unpkg.com/subscriptions-transport-ws@0.8.3/browser/client.js"></script>
-		   <script src="//unpkg.com/graphiql-subscriptions-fetcher@0.0.2/browser/client.js"></script>
+		   <link rel="stylesheet" href="https://unpkg.com/graphiql@3.0.10/graphiql.min.css" />
+		   <script crossorigin src="https://unpkg.com/react@18.2.0/umd/react.production.min.js"></script>
+		   <script crossorigin src="https://unpkg.com/react-dom@18.2.0/umd/react-dom.production.min.js"></script>
+		   <script crossorigin src="https://unpkg.com/graphql-ws@5.14.3/umd/graphql-ws.min.js"></script>
+		   <script crossorigin src="https://unpkg.com/graphiql@3.0.10/graphiql.min.js"></script>
    </head>
    <body style="width: 100%; height: 100%; margin: 0; overflow: hidden;">
 		   <div id="graphiql" style="height: 100vh;">Loading...</div>
 		   <script>
-				   function graphQLFetcher(graphQLParams) {
-						   return fetch("/graphql", {
-								   method: "post",
-								   body: JSON.stringify(graphQLParams),
-								   credentials: "include",
-						   }).then(function (response) {
-								   return response.text();
-						   }).then(function (responseBody) {
-								   try {
-										   return JSON.parse(responseBody);
-								   } catch (error) {
-										   return responseBody;
-								   }
-						   });
-				   }
-				   var subscriptionsClient = new window.SubscriptionsTransportWs.SubscriptionClient('wss://{{ . }}/graphql', { reconnect: true });
-				   var subscriptionsFetcher = window.GraphiQLSubscriptionsFetcher.graphQLFetcher(subscriptionsClient, graphQLFetcher);
-				   ReactDOM.render(
-						   React.createElement(GraphiQL, {fetcher: subscriptionsFetcher}),
-						   document.getElementById("graphiql")
+				   var fetcher = GraphiQL.createFetcher({
+						   url: "/graphql",
+						   wsClient: graphqlWs.createClient({url: "wss://{{ . }}/graphql", lazy: true}),
+				   });
+				   ReactDOM.createRoot(document.getElementById("graphiql")).render(
+						   React.createElement(GraphiQL, {fetcher: fetcher})
 				   );
 		   </script>
    </body>
@@ -0,0 +1,315 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/complexity"
+	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// limitsCodeTooComplex is the error code of queries rejected for the excessive cost.
+	limitsCodeTooComplex = "QUERY_TOO_COMPLEX"
+
+	// limitsCodeTooDeep is the error code of queries rejected for the excessive depth.
+	limitsCodeTooDeep = "QUERY_TOO_DEEP"
+
+	// limitsCodeRateLimited is the error code of queries rejected by the client rate limit.
+	limitsCodeRateLimited = "RATE_LIMITED"
+
+	// limitsCodeInvalidQuery is the error code of requests which can not be analyzed.
+	limitsCodeInvalidQuery = "GRAPHQL_PARSE_FAILED"
+
+	// limitsMaxBodySize represents the max size of a query request body we accept.
+	limitsMaxBodySize = 1 << 20
+
+	// limitsBucketsCleanup represents the period of idle rate limit buckets clean up.
+	limitsBucketsCleanup = 10 * time.Minute
+)
+
+// LimitsHandler defines HTTP handler middleware rejecting GraphQL queries over the configured
+// depth and cost limits, and rate limiting clients by the cost of their queries.
+type LimitsHandler struct {
+	logger   flogger.Logger
+	handler  http.Handler
+	cfg      *config.Limits
+	analyzer *complexity.Analyzer
+	tiers    map[string]*config.LimitTier
+	clients  map[string]*config.LimitClient
+
+	mu          sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+// limitsRequest represents the part of a GraphQL request relevant to the limits.
+type limitsRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// queryError represents a structured GraphQL error of a rejected query.
+type queryError struct {
+	Message    string                 `json:"message"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+// limitsRejection represents the reason of a query rejected by the limits.
+type limitsRejection struct {
+	status int
+	retry  int
+	err    queryError
+}
+
+// tokenBucket represents the rate limit state of a single client.
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// queryAnalysisKey represents the context key of the incoming query analysis result.
+type queryAnalysisKey struct{}
+
+// newQueryAnalyzer creates a new analyzer of incoming queries for the given schema.
+func newQueryAnalyzer(cfg *config.Config, schema *graphql.Schema) *complexity.Analyzer {
+	weights := make(map[string]int, len(cfg.Limits.Weights))
+	for _, w := range cfg.Limits.Weights {
+		weights[w.Field] = w.Weight
+	}
+
+	return complexity.New(schema.ASTSchema(), complexity.Config{
+		Weights:     weights,
+		ListSize:    cfg.Limits.ListSize,
+		MaxListSize: cfg.Limits.MaxListSize,
+		MaxNodes:    cfg.Limits.MaxNodes,
+	})
+}
+
+// NewLimitsHandler creates a new query limits middleware using the given query analyzer.
+func NewLimitsHandler(cfg *config.Config, log flogger.Logger, an *complexity.Analyzer, h http.Handler) *LimitsHandler {
+	lh := LimitsHandler{
+		logger:      log,
+		handler:     h,
+		cfg:         &cfg.Limits,
+		analyzer:    an,
+		tiers:       make(map[string]*config.LimitTier, len(cfg.Limits.Tiers)),
+		clients:     make(map[string]*config.LimitClient, len(cfg.Limits.Clients)),
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+
+	for i := range cfg.Limits.Tiers {
+		lh.tiers[cfg.Limits.Tiers[i].Name] = &cfg.Limits.Tiers[i]
+	}
+	for i := range cfg.Limits.Clients {
+		if _, ok := lh.tiers[cfg.Limits.Clients[i].Tier]; !ok {
+			log.Errorf("unknown rate limit tier %s of API client", cfg.Limits.Clients[i].Tier)
+			continue
+		}
+		lh.clients[cfg.Limits.Clients[i].Key] = &cfg.Limits.Clients[i]
+	}
+	return &lh
+}
+
+// ServeHTTP checks the incoming GraphQL query against the limits and passes it
+// to the next handler in the chain, if the query is allowed.
+func (lh *LimitsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, limitsMaxBodySize))
+	if err != nil {
+		lh.logger.Debugf("can not read query request; %s", err.Error())
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// requests we can not analyze can not be checked against the limits; they are rejected
+	var req limitsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		lh.reject(w, http.StatusBadRequest, invalidQueryError(fmt.Errorf("invalid request; %s", err.Error())))
+		return
+	}
+	res, err := lh.analyze(r, &req)
+	if err != nil {
+		lh.reject(w, http.StatusBadRequest, invalidQueryError(err))
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), queryAnalysisKey{}, res))
+
+	client, tier := lh.client(r)
+	if rej := lh.admit(client, tier, res); rej != nil {
+		if rej.retry > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(rej.retry))
+		}
+		lh.reject(w, rej.status, rej.err)
+		return
+	}
+
+	lh.handler.ServeHTTP(w, r)
+}
+
+// invalidQueryError provides the GraphQL error of a query rejected for the given analysis error.
+func invalidQueryError(err error) queryError {
+	code := limitsCodeInvalidQuery
+	if err == complexity.ErrTooManyNodes {
+		code = limitsCodeTooComplex
+	}
+	return queryError{Message: err.Error(), Extensions: map[string]interface{}{"code": code}}
+}
+
+// admit checks the analyzed query of the client against the depth and cost limits
+// and consumes the cost from the client rate limit. Nil is returned if the query is allowed.
+func (lh *LimitsHandler) admit(client string, tier *config.LimitTier, res *complexity.Result) *limitsRejection {
+	maxDepth, maxCost := lh.cfg.MaxDepth, lh.cfg.MaxCost
+	if tier != nil && tier.MaxDepth > 0 {
+		maxDepth = tier.MaxDepth
+	}
+	if tier != nil && tier.MaxCost > 0 {
+		maxCost = tier.MaxCost
+	}
+
+	if maxDepth > 0 && res.Depth > maxDepth {
+		return &limitsRejection{status: http.StatusBadRequest, err: queryError{
+			Message: fmt.Sprintf("query depth %d exceeds the limit of %d", res.Depth, maxDepth),
+			Extensions: map[string]interface{}{
+				"code":  limitsCodeTooDeep,
+				"depth": res.Depth,
+				"limit": maxDepth,
+				"cost":  res.Cost,
+			},
+		}}
+	}
+
+	if maxCost > 0 && res.Cost > maxCost {
+		lh.logger.Debugf("query of %s rejected; cost %d over %d", client, res.Cost, maxCost)
+		return &limitsRejection{status: http.StatusBadRequest, err: queryError{
+			Message: fmt.Sprintf("query cost %d exceeds the limit of %d", res.Cost, maxCost),
+			Extensions: map[string]interface{}{
+				"code":  limitsCodeTooComplex,
+				"cost":  res.Cost,
+				"limit": maxCost,
+			},
+		}}
+	}
+
+	if tier != nil {
+		if wait := lh.take(client, tier, res.Cost); wait > 0 {
+			retry := int(math.Ceil(wait.Seconds()))
+			return &limitsRejection{status: http.StatusTooManyRequests, retry: retry, err: queryError{
+				Message: fmt.Sprintf("rate limit exceeded; query cost %d, retry in %d seconds", res.Cost, retry),
+				Extensions: map[string]interface{}{
+					"code":       limitsCodeRateLimited,
+					"cost":       res.Cost,
+					"tier":       tier.Name,
+					"retryAfter": retry,
+				},
+			}}
+		}
+	}
+	return nil
+}
+
+// analyze provides the analysis of the incoming query; the analysis may already be available
+// in the request context, if the query was analyzed earlier in the handlers chain.
+func (lh *LimitsHandler) analyze(r *http.Request, req *limitsRequest) (*complexity.Result, error) {
+	if res, ok := r.Context().Value(queryAnalysisKey{}).(*complexity.Result); ok {
+		return res, nil
+	}
+	return lh.analyzer.Analyze(req.Query, req.OperationName, req.Variables)
+}
+
+// client identifies the client of the request and provides the rate limit tier of the client, if any.
+// Clients with a known API key get their configured tier, everybody else is identified by the IP address.
+func (lh *LimitsHandler) client(r *http.Request) (string, *config.LimitTier) {
+	client, tier, _ := lh.clientOfKey(r, r.Header.Get(lh.cfg.KeyHeader))
+	return client, tier
+}
+
+// clientOfKey identifies the client of the request by the given API key. The client is identified
+// by the IP address if the key is empty or unknown; the flag signals if the key is known.
+func (lh *LimitsHandler) clientOfKey(r *http.Request, key string) (string, *config.LimitTier, bool) {
+	if key != "" {
+		if cl, ok := lh.clients[key]; ok {
+			return "key:" + key, lh.tiers[cl.Tier], true
+		}
+	}
+	return "ip:" + lh.clientIP(r), lh.tiers[lh.cfg.DefaultTier], false
+}
+
+// clientIP provides the IP address of the remote client.
+func (lh *LimitsHandler) clientIP(r *http.Request) string {
+	if lh.cfg.TrustProxy {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			return strings.TrimSpace(strings.Split(fwd, ",")[0])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// take consumes the given cost from the client token bucket. If there is not enough tokens,
+// nothing is consumed and the time to wait for the tokens to be available is provided.
+func (lh *LimitsHandler) take(client string, tier *config.LimitTier, cost int) time.Duration {
+	lh.mu.Lock()
+	defer lh.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(lh.lastCleanup) > limitsBucketsCleanup {
+		lh.cleanup(now)
+	}
+
+	// a query over the burst size drains the full bucket
+	need := math.Min(float64(cost), float64(tier.Burst))
+	tb, ok := lh.buckets[client]
+	if !ok {
+		tb = &tokenBucket{tokens: float64(tier.Burst), updated: now}
+		lh.buckets[client] = tb
+	}
+
+	tb.tokens = math.Min(float64(tier.Burst), tb.tokens+now.Sub(tb.updated).Seconds()*tier.Rate)
+	tb.updated = now
+	if tb.tokens >= need {
+		tb.tokens -= need
+		return 0
+	}
+
+	if tier.Rate <= 0 {
+		return time.Hour
+	}
+	return time.Duration((need - tb.tokens) / tier.Rate * float64(time.Second))
+}
+
+// cleanup removes buckets of clients idle long enough to have their bucket refilled.
+func (lh *LimitsHandler) cleanup(now time.Time) {
+	for key, tb := range lh.buckets {
+		if now.Sub(tb.updated) > limitsBucketsCleanup {
+			delete(lh.buckets, key)
+		}
+	}
+	lh.lastCleanup = now
+}
+
+// reject responds with the given GraphQL error.
+func (lh *LimitsHandler) reject(w http.ResponseWriter, status int, e queryError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"errors": []queryError{e}}); err != nil {
+		lh.logger.Errorf("can not encode limits error; %s", err.Error())
+	}
+}
@@ -1,11 +1,23 @@
 package handlers
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	flogger "fantom-api-graphql/internal/logger"
 	"net/http"
+	"runtime/debug"
 )
 
+// requestIDHeader represents the HTTP header carrying the request identifier.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDMaxLength represents the max length of a request identifier accepted from the client.
+const requestIDMaxLength = 64
+
 // LoggingHandler defines HTTP handler middleware for logging incoming communication through provided Logger.
+// Each request is assigned an identifier, which is passed down the chain in the request context,
+// so all the records logged while processing the request can be correlated.
 type LoggingHandler struct {
 	logger  flogger.Logger
 	handler http.Handler
@@ -14,9 +26,50 @@ type LoggingHandler struct {
 // ServeHTTP handles incoming request by creating a log record with predefined request details
 // and passing it to the next handler in the chain.
 func (h *LoggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := requestID(r)
+	w.Header().Set(requestIDHeader, id)
+	r = r.WithContext(flogger.WithRequestID(r.Context(), id))
+
 	// We log incoming requests on Debug level since in production the actual incoming traffic is not very important.
-	h.logger.Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
+	flogger.FromContext(r.Context(), h.logger).Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
 
 	// Pass request down the chain
 	h.handler.ServeHTTP(w, r)
 }
+
+// requestID provides the identifier of the request; a valid identifier sent by the client,
+// or a proxy in front of the server, is used. A new random identifier is made otherwise.
+func requestID(r *http.Request) string {
+	if id := r.Header.Get(requestIDHeader); isValidRequestID(id) {
+		return id
+	}
+
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf[:])
+}
+
+// isValidRequestID checks if the request identifier is safe to be logged.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > requestIDMaxLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.' || c == ':') {
+			return false
+		}
+	}
+	return true
+}
+
+// panicLogger implements GraphQL resolvers panic logging with the request identifier attached.
+type panicLogger struct {
+	logger flogger.Logger
+}
+
+// LogPanic logs the panic of a GraphQL resolver.
+func (pl *panicLogger) LogPanic(ctx context.Context, value interface{}) {
+	flogger.FromContext(ctx, pl.logger).Criticalf("graphql resolver panic; %v\n%s", value, debug.Stack())
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/complexity"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "graphql_request_duration_seconds",
+	Help:    "The duration of GraphQL requests by the operation name",
+	Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+}, []string{
+	"operation",
+})
+
+// requestMetricsMaxOperations represents the max number of distinct operation names tracked
+// by the request metrics; names above the limit are reported as "other".
+const requestMetricsMaxOperations = 256
+
+// RequestMetricsHandler defines HTTP handler middleware recording the duration
+// of analyzed GraphQL requests by the operation name.
+type RequestMetricsHandler struct {
+	handler http.Handler
+
+	mu    sync.RWMutex
+	names map[string]bool
+}
+
+// NewRequestMetricsHandler creates a new request metrics middleware.
+func NewRequestMetricsHandler(h http.Handler) *RequestMetricsHandler {
+	return &RequestMetricsHandler{
+		handler: h,
+		names:   make(map[string]bool),
+	}
+}
+
+// ServeHTTP passes the request to the next handler in the chain and records its duration.
+func (rm *RequestMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rm.handler.ServeHTTP(w, r)
+
+	res, _ := r.Context().Value(queryAnalysisKey{}).(*complexity.Result)
+	requestDuration.WithLabelValues(rm.label(res)).Observe(time.Since(start).Seconds())
+}
+
+// label provides the operation label of the analyzed request keeping the number
+// of distinct labels limited, so clients can not flood the metrics with random names.
+func (rm *RequestMetricsHandler) label(res *complexity.Result) string {
+	if res == nil {
+		return "unknown"
+	}
+	if res.Name == "" {
+		return "anonymous"
+	}
+
+	rm.mu.RLock()
+	known := rm.names[res.Name]
+	rm.mu.RUnlock()
+	if known {
+		return res.Name
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	if len(rm.names) >= requestMetricsMaxOperations {
+		return "other"
+	}
+	rm.names[res.Name] = true
+	return res.Name
+}
+
+// Metrics constructs the HTTP handler exposing the collected metrics. The access is protected
+// by the basic authentication, if the metrics user is configured.
+func Metrics(cfg *config.Config, log logger.Logger) http.Handler {
+	h := promhttp.Handler()
+	if cfg.Server.MetricsUser == "" {
+		return h
+	}
+
+	return basicAuth(cfg.Server.MetricsUser, cfg.Server.MetricsPassword, "metrics", log, h)
+}
+
+// basicAuth restricts access to the handler to the clients with the given basic auth credentials.
+func basicAuth(user string, pass string, realm string, log logger.Logger, h http.Handler) http.Handler {
+	u, p := []byte(user), []byte(pass)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ru, rp, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(ru), u) != 1 || subtle.ConstantTimeCompare([]byte(rp), p) != 1 {
+			log.Debugf("%s access denied to %s", realm, r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf("Basic realm=%q", realm))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
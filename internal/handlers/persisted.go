@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/complexity"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// persistedCodeNotFound is the error code of an unknown persisted query hash.
+	persistedCodeNotFound = "PERSISTED_QUERY_NOT_FOUND"
+
+	// persistedCodeNotAllowed is the error code of a query not registered in the allowlist.
+	persistedCodeNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+
+	// persistedCodeBadRequest is the error code of an invalid persisted query request.
+	persistedCodeBadRequest = "BAD_REQUEST"
+
+	// persistedQueryVersion is the version of the automatic persisted queries protocol supported.
+	persistedQueryVersion = 1
+)
+
+// finalRoots lists the root fields providing finalized chain data, if identified by any of the arguments.
+var finalRoots = map[string][]string{
+	"block":       {"number", "hash"},
+	"transaction": {"hash"},
+	"epoch":       {"id"},
+}
+
+// finalTypes lists the types of finalized chain data; their fields never change once the data are final.
+var finalTypes = map[string]bool{
+	"Query":              true,
+	"Block":              true,
+	"Transaction":        true,
+	"TokenTransaction":   true,
+	"ERC20Transaction":   true,
+	"ERC721Transaction":  true,
+	"ERC1155Transaction": true,
+	"Epoch":              true,
+	"EpochValidator":     true,
+}
+
+// PersistedQueryHandler defines HTTP handler middleware implementing automatic persisted queries,
+// the operator supplied queries allowlist, and cacheable GET requests. Responses to GET requests
+// get Cache-Control and ETag headers derived from the finality of the data provided.
+type PersistedQueryHandler struct {
+	logger    flogger.Logger
+	handler   http.Handler
+	cfg       *config.Queries
+	analyzer  *complexity.Analyzer
+	allowlist map[string]string
+
+	mu      sync.RWMutex
+	queries map[string]string
+	order   []string
+	size    int
+}
+
+// persistedRequest represents an incoming GraphQL request with the persisted query extension.
+type persistedRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    persistedExtensions    `json:"extensions"`
+}
+
+// persistedExtensions represents the extensions of a GraphQL request relevant to the persisted queries.
+type persistedExtensions struct {
+	PersistedQuery *struct {
+		Version    int    `json:"version"`
+		Sha256Hash string `json:"sha256Hash"`
+	} `json:"persistedQuery,omitempty"`
+}
+
+// NewPersistedQueryHandler creates a new persisted queries middleware using the given query analyzer.
+func NewPersistedQueryHandler(cfg *config.Config, log flogger.Logger, an *complexity.Analyzer, h http.Handler) *PersistedQueryHandler {
+	ph := PersistedQueryHandler{
+		logger:    log,
+		handler:   h,
+		cfg:       &cfg.Queries,
+		analyzer:  an,
+		allowlist: loadQueryAllowlist(cfg.Queries.AllowlistFile, log),
+		queries:   make(map[string]string),
+		order:     make([]string, 0),
+	}
+
+	if ph.cfg.AllowlistOnly {
+		log.Noticef("only %d allowlisted queries are accepted", len(ph.allowlist))
+	}
+	return &ph
+}
+
+// loadQueryAllowlist loads the map of allowed query hashes to the query documents.
+func loadQueryAllowlist(path string, log flogger.Logger) map[string]string {
+	list := make(map[string]string)
+	if path == "" {
+		return list
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Criticalf("can not read queries allowlist %s; %s", path, err.Error())
+		return list
+	}
+
+	var queries map[string]string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		log.Criticalf("can not decode queries allowlist %s; %s", path, err.Error())
+		return list
+	}
+
+	// verify the hashes so a wrong entry can not slip in
+	for hash, q := range queries {
+		if queryHash(q) != strings.ToLower(hash) {
+			log.Errorf("allowlisted query %s does not match the hash", hash)
+			continue
+		}
+		list[strings.ToLower(hash)] = q
+	}
+	return list
+}
+
+// queryHash calculates the sha256 hash of the given query document.
+func queryHash(q string) string {
+	h := sha256.Sum256([]byte(q))
+	return hex.EncodeToString(h[:])
+}
+
+// ServeHTTP resolves the query of the incoming request and passes it to the next
+// handler in the chain as a regular GraphQL request.
+func (ph *PersistedQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := ph.request(w, r)
+	if err != nil {
+		ph.reject(w, http.StatusBadRequest, queryError{
+			Message:    err.Error(),
+			Extensions: map[string]interface{}{"code": persistedCodeBadRequest},
+		})
+		return
+	}
+
+	// unknown request format is left for the GraphQL handler to be reported
+	if req == nil {
+		ph.handler.ServeHTTP(w, r)
+		return
+	}
+
+	pending, qe := ph.resolve(req)
+	if qe != nil {
+		ph.reject(w, http.StatusOK, *qe)
+		return
+	}
+
+	// pass the resolved query down the chain as a regular request
+	body, err := json.Marshal(req)
+	if err != nil {
+		ph.logger.Errorf("can not encode query request; %s", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	res, err := ph.analyzer.Analyze(req.Query, req.OperationName, req.Variables)
+	if err == nil {
+		ctx = context.WithValue(ctx, queryAnalysisKey{}, res)
+	}
+
+	// only queries can be executed by GET requests
+	if r.Method == http.MethodGet && res != nil && res.Operation != "query" {
+		w.Header().Set("Allow", http.MethodPost)
+		ph.reject(w, http.StatusMethodNotAllowed, queryError{
+			Message:    fmt.Sprintf("%s operation can not be executed by GET request", res.Operation),
+			Extensions: map[string]interface{}{"code": persistedCodeBadRequest},
+		})
+		return
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	// POST responses are not cached
+	if r.Method != http.MethodGet {
+		sw := statusWriter{ResponseWriter: w, status: http.StatusOK}
+		ph.handler.ServeHTTP(&sw, r.WithContext(ctx))
+		ph.registerAdmitted(pending, req.Query, res, sw.status)
+		return
+	}
+
+	ctx, hint := resolvers.WithCacheHint(ctx)
+	rec := responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	ph.handler.ServeHTTP(&rec, r.WithContext(ctx))
+	ph.registerAdmitted(pending, req.Query, res, rec.status)
+	ph.respond(w, r, &rec, ph.maxAge(res, hint))
+}
+
+// request decodes the incoming GraphQL request from the body of POST request,
+// or from the URL parameters of GET request. Nil is returned for unknown format of the request.
+func (ph *PersistedQueryHandler) request(w http.ResponseWriter, r *http.Request) (*persistedRequest, error) {
+	var req persistedRequest
+
+	if r.Method != http.MethodGet {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, limitsMaxBodySize))
+		if err != nil {
+			return nil, fmt.Errorf("can not read request; %s", err.Error())
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, nil
+		}
+		return &req, nil
+	}
+
+	params := r.URL.Query()
+	req.Query = params.Get("query")
+	req.OperationName = params.Get("operationName")
+	if v := params.Get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			return nil, fmt.Errorf("invalid variables; %s", err.Error())
+		}
+	}
+	if ext := params.Get("extensions"); ext != "" {
+		if err := json.Unmarshal([]byte(ext), &req.Extensions); err != nil {
+			return nil, fmt.Errorf("invalid extensions; %s", err.Error())
+		}
+	}
+	return &req, nil
+}
+
+// resolve provides the query document of the request using the persisted query hash.
+// The hash of a new query to be persisted is provided, if any; the query is registered
+// only after it passes the analysis and the limits. An error is provided if the query can not be used.
+func (ph *PersistedQueryHandler) resolve(req *persistedRequest) (string, *queryError) {
+	pq := req.Extensions.PersistedQuery
+	if pq == nil {
+		if ph.cfg.AllowlistOnly {
+			if _, ok := ph.allowlist[queryHash(req.Query)]; !ok {
+				return "", &queryError{Message: "PersistedQueryNotAllowed", Extensions: map[string]interface{}{"code": persistedCodeNotAllowed}}
+			}
+		}
+		return "", nil
+	}
+
+	if pq.Version != persistedQueryVersion {
+		return "", &queryError{Message: "PersistedQueryNotSupported", Extensions: map[string]interface{}{"code": persistedCodeBadRequest}}
+	}
+
+	hash := strings.ToLower(pq.Sha256Hash)
+	if req.Query != "" && queryHash(req.Query) != hash {
+		return "", &queryError{Message: "provided sha does not match query", Extensions: map[string]interface{}{"code": persistedCodeBadRequest}}
+	}
+
+	// allowlisted queries are always known
+	if q, ok := ph.allowlist[hash]; ok {
+		req.Query = q
+		return "", nil
+	}
+	if ph.cfg.AllowlistOnly {
+		return "", &queryError{Message: "PersistedQueryNotAllowed", Extensions: map[string]interface{}{"code": persistedCodeNotAllowed}}
+	}
+
+	// a new query to be registered
+	if req.Query != "" {
+		return hash, nil
+	}
+
+	ph.mu.RLock()
+	q, ok := ph.queries[hash]
+	ph.mu.RUnlock()
+	if !ok {
+		return "", &queryError{Message: "PersistedQueryNotFound", Extensions: map[string]interface{}{"code": persistedCodeNotFound}}
+	}
+	req.Query = q
+	return "", nil
+}
+
+// registerAdmitted registers the new persisted query of the given hash, if the query
+// has been analyzed and admitted by the limits; the limits reject queries by an error status.
+func (ph *PersistedQueryHandler) registerAdmitted(hash string, q string, res *complexity.Result, status int) {
+	if hash == "" || res == nil || status != http.StatusOK {
+		return
+	}
+	ph.register(hash, q)
+}
+
+// register adds the given query to the persisted queries; the oldest queries are dropped
+// if the max number of queries, or the memory limit, is reached.
+func (ph *PersistedQueryHandler) register(hash string, q string) {
+	size := len(hash) + len(q)
+	limit := ph.cfg.CacheMemory << 20
+	if limit > 0 && size > limit {
+		return
+	}
+
+	ph.mu.Lock()
+	defer ph.mu.Unlock()
+
+	if _, ok := ph.queries[hash]; ok {
+		return
+	}
+	for len(ph.order) > 0 && ((ph.cfg.CacheSize > 0 && len(ph.order) >= ph.cfg.CacheSize) || (limit > 0 && ph.size+size > limit)) {
+		ph.size -= len(ph.order[0]) + len(ph.queries[ph.order[0]])
+		delete(ph.queries, ph.order[0])
+		ph.order = ph.order[1:]
+	}
+
+	ph.queries[hash] = q
+	ph.order = append(ph.order, hash)
+	ph.size += size
+}
+
+// maxAge provides the max age of the response in seconds based on the finality of the data.
+// Responses with finalized chain data only are immutable, anything depending on the chain head
+// gets a short max age.
+func (ph *PersistedQueryHandler) maxAge(res *complexity.Result, hint *resolvers.CacheHint) int64 {
+	if !isFinal(res, hint) {
+		return ph.cfg.HeadMaxAge
+	}
+	return ph.cfg.FinalMaxAge
+}
+
+// isFinal checks if the response of the analyzed query provides finalized chain data only.
+// The query has to select final data by its structure, and resolvers must not find any data
+// which may still change.
+func isFinal(res *complexity.Result, hint *resolvers.CacheHint) bool {
+	if res == nil || hint == nil || hint.IsVolatile() || len(res.Roots) == 0 {
+		return false
+	}
+
+	for _, root := range res.Roots {
+		var final bool
+		for _, arg := range finalRoots[root.Name] {
+			final = final || root.Args[arg]
+		}
+		if !final {
+			return false
+		}
+	}
+
+	for f := range res.Fields {
+		if !finalTypes[f[:strings.IndexByte(f, '.')]] {
+			return false
+		}
+	}
+	return true
+}
+
+// respond sends the recorded response with the cache headers. Failed responses are not cached.
+func (ph *PersistedQueryHandler) respond(w http.ResponseWriter, r *http.Request, rec *responseRecorder, maxAge int64) {
+	if rec.status != http.StatusOK || hasErrors(rec.body.Bytes()) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(rec.status)
+		_, _ = w.Write(rec.body.Bytes())
+		return
+	}
+
+	hash := sha256.Sum256(rec.body.Bytes())
+	etag := fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:16]))
+	w.Header().Set("ETag", etag)
+	if maxAge >= ph.cfg.FinalMaxAge {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", maxAge))
+	} else {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	}
+
+	if match := r.Header.Get("If-None-Match"); match != "" && (match == etag || match == "*") {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.WriteHeader(rec.status)
+	if _, err := w.Write(rec.body.Bytes()); err != nil {
+		ph.logger.Debugf("can not write response; %s", err.Error())
+	}
+}
+
+// reject responds with the given GraphQL error.
+func (ph *PersistedQueryHandler) reject(w http.ResponseWriter, status int, e queryError) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"errors": []queryError{e}}); err != nil {
+		ph.logger.Errorf("can not encode query error; %s", err.Error())
+	}
+}
+
+// responseRecorder keeps the response of the next handler in the chain,
+// so the cache headers can be derived from it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code of the response.
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+// Write records the body of the response.
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
@@ -102,7 +102,7 @@ func ValidatorsJSONHandler(api resolvers.ApiResolver, log logger.Logger) http.Ha
 			_ = Body.Close()
 		}(r.Body)
 
-		list, err := api.ValidatorStatuses()
+		list, err := api.ValidatorStatuses(r.Context())
 		w.Header().Set("Content-Type", "application/json")
 		if err != nil {
 			log.Criticalf("can not get list of offline server; %s", err.Error())
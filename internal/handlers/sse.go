@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/graph-gophers/graphql-go"
+	"io"
+	"net/http"
+	"time"
+)
+
+// serveEventStream serves the GraphQL subscription by Server-Sent Events in the distinct connections mode
+// of the GraphQL over SSE protocol. The operation is requested by the URL parameters of GET request,
+// or by the JSON body of POST request; the results are streamed as "next" events followed by
+// a "complete" event. Comments are sent periodically to keep the connection open.
+// Queries and mutations are refused; they are served by regular requests with the response cache.
+func (sh *SubscriptionHandler) serveEventStream(w http.ResponseWriter, r *http.Request) {
+	req, err := eventStreamRequest(w, r)
+	if err != nil {
+		sh.refuse(w, http.StatusBadRequest, queryError{Message: err.Error()})
+		return
+	}
+
+	cl, err := sh.authorize(r, nil)
+	if err != nil {
+		sh.refuse(w, http.StatusUnauthorized, queryError{Message: err.Error()})
+		return
+	}
+	if rej := sh.admit(cl, req, true); rej != nil {
+		sh.refuse(w, rej.status, rej.err)
+		return
+	}
+
+	// the stream lives for the whole subscription, the server write timeout does not apply
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		sh.logger.Debugf("can not clear event stream write deadline; %s", err.Error())
+	}
+
+	results, err := sh.schema.Subscribe(r.Context(), req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		sh.refuse(w, http.StatusBadRequest, queryError{Message: err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		sh.logger.Errorf("event stream not supported; %s", err.Error())
+		return
+	}
+
+	ping := time.NewTicker(sh.keepAlive())
+	defer ping.Stop()
+
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				_ = writeEvent(w, rc, "complete", nil)
+				return
+			}
+			if err := sh.sendResult(w, rc, res); err != nil {
+				sh.logger.Debugf("event stream closed; %s", err.Error())
+				drain(results)
+				return
+			}
+		case <-ping.C:
+			if _, err := io.WriteString(w, ":\n\n"); err != nil || rc.Flush() != nil {
+				drain(results)
+				return
+			}
+		case <-r.Context().Done():
+			drain(results)
+			return
+		}
+	}
+}
+
+// sendResult sends the operation result to the event stream.
+func (sh *SubscriptionHandler) sendResult(w io.Writer, rc *http.ResponseController, res interface{}) error {
+	resp, ok := res.(*graphql.Response)
+	if !ok {
+		return nil
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		sh.logger.Errorf("can not encode subscription response; %s", err.Error())
+		return nil
+	}
+	return writeEvent(w, rc, "next", data)
+}
+
+// drain consumes the remaining results, so the executor of the operation can finish.
+func drain(results <-chan interface{}) {
+	go func() {
+		for range results {
+		}
+	}()
+}
+
+// refuse responds with the given GraphQL error before the event stream is started.
+func (sh *SubscriptionHandler) refuse(w http.ResponseWriter, status int, e queryError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"errors": []queryError{e}}); err != nil {
+		sh.logger.Debugf("can not encode subscription error; %s", err.Error())
+	}
+}
+
+// writeEvent writes a single event to the stream and flushes it to the client.
+func writeEvent(w io.Writer, rc *http.ResponseController, event string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// eventStreamRequest decodes the requested operation from the URL parameters of GET request,
+// or from the JSON body of POST request. The operation may be identified by the persisted query hash.
+func eventStreamRequest(w http.ResponseWriter, r *http.Request) (*subscriptionRequest, error) {
+	var req subscriptionRequest
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, limitsMaxBodySize)).Decode(&req); err != nil {
+			return nil, fmt.Errorf("invalid request; %s", err.Error())
+		}
+		return &req, nil
+	}
+
+	q := r.URL.Query()
+	req.Query = q.Get("query")
+	req.OperationName = q.Get("operationName")
+	if vars := q.Get("variables"); vars != "" {
+		if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+			return nil, fmt.Errorf("invalid variables; %s", err.Error())
+		}
+	}
+	if ext := q.Get("extensions"); ext != "" {
+		if err := json.Unmarshal([]byte(ext), &req.Extensions); err != nil {
+			return nil, fmt.Errorf("invalid extensions; %s", err.Error())
+		}
+	}
+	if req.Query == "" && req.Extensions.PersistedQuery == nil {
+		return nil, fmt.Errorf("query not found")
+	}
+	return &req, nil
+}
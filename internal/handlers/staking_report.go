@@ -0,0 +1,218 @@
+// Package handlers hold an HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stakingReportDecimals represents the number of decimals of the native token.
+const stakingReportDecimals = 18
+
+// stakingReportJSON represents the JSON output of the staking report.
+type stakingReportJSON struct {
+	Address  string                   `json:"address"`
+	Currency string                   `json:"currency"`
+	From     time.Time                `json:"from"`
+	To       time.Time                `json:"to"`
+	Entries  []stakingReportEntryJSON `json:"entries"`
+	Totals   []stakingReportTotalJSON `json:"totals"`
+}
+
+// stakingReportEntryJSON represents the JSON output of a staking report event.
+type stakingReportEntryJSON struct {
+	Type      string    `json:"type"`
+	TimeStamp time.Time `json:"timestamp"`
+	Epoch     uint64    `json:"epoch"`
+	Validator uint64    `json:"validator"`
+	Amount    string    `json:"amount"`
+	Price     *float64  `json:"price"`
+	FiatValue *float64  `json:"fiatValue"`
+	Trx       string    `json:"trx"`
+}
+
+// stakingReportTotalJSON represents the JSON output of a staking report total.
+type stakingReportTotalJSON struct {
+	Year      int     `json:"year"`
+	Type      string  `json:"type"`
+	Count     int     `json:"count"`
+	Amount    string  `json:"amount"`
+	FiatValue float64 `json:"fiatValue"`
+	Unvalued  int     `json:"unvalued"`
+}
+
+// StakingReport constructs and return the REST API HTTP handler for the staking rewards report.
+// The report is requested by the address, currency, from and to query parameters;
+// the format parameter selects between JSON (default) and CSV output.
+func StakingReport(log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		addr := q.Get("address")
+		if !common.IsHexAddress(addr) {
+			http.Error(w, "invalid address", http.StatusBadRequest)
+			return
+		}
+
+		// the end is exclusive; a date only end includes the whole day
+		to, err := stakingReportTime(q.Get("to"), time.Now().UTC(), true)
+		if err != nil {
+			http.Error(w, "invalid end time; "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the report starts with the year of the last reported day by default
+		last := to.Add(-time.Nanosecond)
+		from, err := stakingReportTime(q.Get("from"), time.Date(last.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), false)
+		if err != nil {
+			http.Error(w, "invalid start time; "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		currency := q.Get("currency")
+		if currency == "" {
+			currency = "USD"
+		}
+
+		adr := common.HexToAddress(addr)
+		rep, err := repository.R().StakingReport(&adr, currency, from, to)
+		if err != nil {
+			log.Errorf("can not build staking report of %s; %s", adr.String(), err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch strings.ToLower(q.Get("format")) {
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"staking-%s.csv\"", strings.ToLower(adr.String())))
+			err = writeStakingReportCSV(w, rep)
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			err = json.NewEncoder(w).Encode(stakingReportOutput(rep))
+		default:
+			http.Error(w, "unknown report format", http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			log.Criticalf("can not encode staking report; %s", err.Error())
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// stakingReportTime parses the given time parameter in RFC3339, or YYYY-MM-DD format.
+// The default value is used if the parameter is empty. If the dayEnd is set,
+// a date only value represents the end of the day, i.e. the midnight of the next day.
+func stakingReportTime(val string, def time.Time, dayEnd bool) (time.Time, error) {
+	if val == "" {
+		return def, nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t.UTC(), nil
+	}
+
+	t, err := time.Parse("2006-01-02", val)
+	if err != nil || !dayEnd {
+		return t, err
+	}
+	return t.Add(24 * time.Hour), nil
+}
+
+// stakingReportOutput converts the staking report into its JSON output structure.
+func stakingReportOutput(rep *types.StakingReport) stakingReportJSON {
+	out := stakingReportJSON{
+		Address:  rep.Address.String(),
+		Currency: rep.Currency,
+		From:     rep.From,
+		To:       rep.To,
+		Entries:  make([]stakingReportEntryJSON, len(rep.Entries)),
+		Totals:   make([]stakingReportTotalJSON, len(rep.Totals)),
+	}
+
+	for i, e := range rep.Entries {
+		out.Entries[i] = stakingReportEntryJSON{
+			Type:      e.Type,
+			TimeStamp: e.TimeStamp,
+			Epoch:     e.Epoch,
+			Validator: e.ValidatorId.ToInt().Uint64(),
+			Amount:    tokenAmountString(e.Amount.ToInt()),
+			Price:     e.Price,
+			FiatValue: e.FiatValue,
+			Trx:       e.Trx.String(),
+		}
+	}
+
+	for i, t := range rep.Totals {
+		out.Totals[i] = stakingReportTotalJSON{
+			Year:      t.Year,
+			Type:      t.Type,
+			Count:     t.Count,
+			Amount:    tokenAmountString(t.Amount.ToInt()),
+			FiatValue: t.FiatValue,
+			Unvalued:  t.Unvalued,
+		}
+	}
+	return out
+}
+
+// writeStakingReportCSV writes the staking report in CSV format; the list of events
+// is followed by an empty line and the list of per-year totals.
+func writeStakingReportCSV(w http.ResponseWriter, rep *types.StakingReport) error {
+	cw := csv.NewWriter(w)
+
+	rows := [][]string{{"type", "timestamp", "epoch", "validator", "amount", "currency", "price", "fiat_value", "trx"}}
+	for _, e := range rep.Entries {
+		rows = append(rows, []string{
+			e.Type,
+			e.TimeStamp.Format(time.RFC3339),
+			strconv.FormatUint(e.Epoch, 10),
+			e.ValidatorId.ToInt().String(),
+			tokenAmountString(e.Amount.ToInt()),
+			rep.Currency,
+			optionalFloatString(e.Price),
+			optionalFloatString(e.FiatValue),
+			e.Trx.String(),
+		})
+	}
+
+	rows = append(rows, []string{}, []string{"year", "type", "count", "amount", "currency", "fiat_value", "unvalued"})
+	for _, t := range rep.Totals {
+		rows = append(rows, []string{
+			strconv.Itoa(t.Year),
+			t.Type,
+			strconv.Itoa(t.Count),
+			tokenAmountString(t.Amount.ToInt()),
+			rep.Currency,
+			strconv.FormatFloat(t.FiatValue, 'f', 2, 64),
+			strconv.Itoa(t.Unvalued),
+		})
+	}
+	return cw.WriteAll(rows)
+}
+
+// tokenAmountString formats the given amount of WEI units as a decimal amount of tokens.
+func tokenAmountString(amo *big.Int) string {
+	val := new(big.Rat).SetFrac(amo, new(big.Int).Exp(big.NewInt(10), big.NewInt(stakingReportDecimals), nil)).FloatString(stakingReportDecimals)
+	val = strings.TrimRight(val, "0")
+	return strings.TrimSuffix(val, ".")
+}
+
+// optionalFloatString formats the given optional float value; empty string is used for nil.
+func optionalFloatString(val *float64) string {
+	if val == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*val, 'f', -1, 64)
+}
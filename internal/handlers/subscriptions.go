@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// protocolTransportWS is the WebSocket subprotocol of the graphql-transport-ws protocol.
+	protocolTransportWS = "graphql-transport-ws"
+
+	// subscriptionKeyParam is the name of the connection parameter carrying the API key.
+	// Browsers can not send custom headers with WebSocket and EventSource requests,
+	// so the key is accepted in the connection parameters, too.
+	subscriptionKeyParam = "apiKey"
+
+	// subscriptionKeepAlive represents the ping interval used if none is configured.
+	subscriptionKeepAlive = 15 * time.Second
+)
+
+var (
+	errKeyRequired = errors.New("API key required")
+	errKeyUnknown  = errors.New("unknown API key")
+)
+
+var streamingOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "graphql_streaming_operations_total",
+	Help: "The total number of GraphQL operations started over streaming transports by the operation name",
+}, []string{
+	"operation",
+})
+
+// SubscriptionHandler defines HTTP handler middleware serving GraphQL operations over streaming
+// transports. WebSocket connections are served by the graphql-transport-ws protocol, or the legacy
+// subscriptions-transport-ws protocol, as negotiated by the subprotocol header. Requests accepting
+// the text/event-stream content are served by Server-Sent Events. Other requests are passed
+// to the next handler in the chain. Streaming operations are not passed through the regular
+// chain, so they are checked against the persisted queries allowlist and the limits here,
+// and counted by the streaming operations metric.
+type SubscriptionHandler struct {
+	logger    flogger.Logger
+	cfg       *config.Subscriptions
+	server    *config.Server
+	schema    *graphql.Schema
+	limits    *LimitsHandler
+	persisted *PersistedQueryHandler
+	metrics   *RequestMetricsHandler
+	legacy    http.Handler
+
+	upgrader websocket.Upgrader
+}
+
+// subscriptionRequest represents a GraphQL operation requested over a streaming transport.
+type subscriptionRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	Extensions    persistedExtensions    `json:"extensions"`
+}
+
+// subscriptionClient represents the authorized client of a streaming connection.
+type subscriptionClient struct {
+	id   string
+	tier *config.LimitTier
+}
+
+// subscriptionClientKey represents the context key of the client of a legacy protocol connection.
+type subscriptionClientKey struct{}
+
+// legacyService implements the GraphQL service of the legacy protocol connections;
+// operations are checked against the query limits before they are executed.
+type legacyService struct {
+	sh *SubscriptionHandler
+}
+
+// NewSubscriptionHandler creates a new streaming transports middleware. The limits handler
+// is used to authorize clients by their API keys and to check the cost of their operations,
+// the persisted queries handler resolves the operations and enforces the queries allowlist.
+// Other requests are passed to the persisted queries handler.
+func NewSubscriptionHandler(cfg *config.Config, log flogger.Logger, schema *graphql.Schema, lh *LimitsHandler, rm *RequestMetricsHandler, ph *PersistedQueryHandler) *SubscriptionHandler {
+	sh := SubscriptionHandler{
+		logger:    log,
+		cfg:       &cfg.Subscriptions,
+		server:    &cfg.Server,
+		schema:    schema,
+		limits:    lh,
+		persisted: ph,
+		metrics:   rm,
+	}
+	sh.upgrader = websocket.Upgrader{
+		Subprotocols: []string{protocolTransportWS},
+		CheckOrigin:  sh.checkOrigin,
+	}
+
+	// the legacy protocol gets the connection authorized by the upgrade request
+	// and the client is checked against the limits on each operation
+	lg := graphqlws.NewHandler()
+	lg.Upgrader.CheckOrigin = sh.checkOrigin
+	sh.legacy = lg.NewHandlerFunc(&legacyService{sh: &sh}, ph, graphqlws.WithContextGenerator(
+		graphqlws.ContextGeneratorFunc(func(ctx context.Context, r *http.Request) (context.Context, error) {
+			cl, err := sh.authorize(r, nil)
+			if err != nil {
+				return nil, err
+			}
+			return context.WithValue(ctx, subscriptionClientKey{}, cl), nil
+		}),
+	))
+	return &sh
+}
+
+// Subscribe checks the operation of the legacy protocol client against the query limits
+// and executes it. Refused operations get the limits error as their only result.
+func (ls *legacyService) Subscribe(ctx context.Context, document string, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	cl, ok := ctx.Value(subscriptionClientKey{}).(*subscriptionClient)
+	if !ok {
+		return nil, errKeyRequired
+	}
+
+	req := subscriptionRequest{Query: document, OperationName: operationName, Variables: variables}
+	if rej := ls.sh.admit(cl, &req, false); rej != nil {
+		out := make(chan interface{}, 1)
+		out <- &graphql.Response{Errors: []*gqlerrors.QueryError{{Message: rej.err.Message, Extensions: rej.err.Extensions}}}
+		close(out)
+		return out, nil
+	}
+	return ls.sh.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+// checkOrigin checks the origin of the WebSocket upgrade request against the CORS origins
+// allowed to access the API. Requests without the origin don't come from a browser and are allowed.
+func (sh *SubscriptionHandler) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range sh.server.AllowedOrigins() {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+
+		// a single wildcard is accepted, i.e. https://*.example.com
+		if i := strings.IndexByte(allowed, '*'); i >= 0 && len(origin) >= len(allowed)-1 &&
+			strings.HasPrefix(strings.ToLower(origin), strings.ToLower(allowed[:i])) &&
+			strings.HasSuffix(strings.ToLower(origin), strings.ToLower(allowed[i+1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeHTTP routes the request to the transport it asks for.
+func (sh *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case websocket.IsWebSocketUpgrade(r) && hasSubprotocol(r, protocolTransportWS):
+		sh.serveTransportWS(w, r)
+	case isEventStream(r):
+		sh.serveEventStream(w, r)
+	default:
+		sh.legacy.ServeHTTP(w, r)
+	}
+}
+
+// authorize identifies the client of the connection by the API key found in the connection
+// parameters, or in the request. Unknown keys are refused, and so are the clients without a key,
+// if the key is required by the configuration.
+func (sh *SubscriptionHandler) authorize(r *http.Request, params map[string]interface{}) (*subscriptionClient, error) {
+	key := connectionKey(params, sh.limits.cfg.KeyHeader)
+	if key == "" {
+		key = r.Header.Get(sh.limits.cfg.KeyHeader)
+	}
+	if key == "" {
+		key = r.URL.Query().Get(subscriptionKeyParam)
+	}
+
+	id, tier, known := sh.limits.clientOfKey(r, key)
+	switch {
+	case key != "" && !known:
+		return nil, errKeyUnknown
+	case key == "" && sh.cfg.RequireKey:
+		return nil, errKeyRequired
+	}
+	return &subscriptionClient{id: id, tier: tier}, nil
+}
+
+// admit resolves the requested operation of the client by the persisted queries handler,
+// the same way the regular requests are resolved, and checks it against the query limits.
+// Operations the analyzer can not process can not be checked, so they are refused.
+// If subscriptionOnly is set, other operations than subscriptions are refused.
+func (sh *SubscriptionHandler) admit(cl *subscriptionClient, req *subscriptionRequest, subscriptionOnly bool) *limitsRejection {
+	pr := persistedRequest{Query: req.Query, OperationName: req.OperationName, Variables: req.Variables, Extensions: req.Extensions}
+	pending, qe := sh.persisted.resolve(&pr)
+	if qe != nil {
+		return &limitsRejection{status: http.StatusOK, err: *qe}
+	}
+	req.Query = pr.Query
+
+	res, err := sh.limits.analyzer.Analyze(req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		return &limitsRejection{status: http.StatusBadRequest, err: invalidQueryError(err)}
+	}
+	if subscriptionOnly && res.Operation != "subscription" {
+		return &limitsRejection{status: http.StatusBadRequest, err: queryError{
+			Message:    fmt.Sprintf("%s operation can not be executed by event stream", res.Operation),
+			Extensions: map[string]interface{}{"code": persistedCodeBadRequest},
+		}}
+	}
+	if rej := sh.limits.admit(cl.id, cl.tier, res); rej != nil {
+		return rej
+	}
+
+	sh.persisted.registerAdmitted(pending, req.Query, res, http.StatusOK)
+	streamingOperations.WithLabelValues(sh.metrics.label(res)).Inc()
+	return nil
+}
+
+// keepAlive provides the ping interval of the streaming connections.
+func (sh *SubscriptionHandler) keepAlive() time.Duration {
+	if sh.cfg.KeepAlive <= 0 {
+		return subscriptionKeepAlive
+	}
+	return time.Duration(sh.cfg.KeepAlive) * time.Second
+}
+
+// connectionKey finds the API key in the connection parameters; the key is accepted
+// under the apiKey name, or under the name of the configured API key header.
+func connectionKey(params map[string]interface{}, header string) string {
+	for name, val := range params {
+		if !strings.EqualFold(name, subscriptionKeyParam) && !strings.EqualFold(name, header) {
+			continue
+		}
+		if key, ok := val.(string); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// hasSubprotocol checks if the WebSocket upgrade request offers the given subprotocol.
+func hasSubprotocol(r *http.Request, proto string) bool {
+	for _, p := range websocket.Subprotocols(r) {
+		if p == proto {
+			return true
+		}
+	}
+	return false
+}
+
+// isEventStream checks if the request asks for the Server-Sent Events stream.
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// isStreaming checks if the request is served by a long-lived streaming transport.
+func isStreaming(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r) || isEventStream(r)
+}
+
+// NewTimeoutHandler creates a handler running the given handler with the time limit, the same way
+// http.TimeoutHandler does. Streaming requests live for the whole subscription, and need direct access
+// to the connection, so they are passed to the handler without the time limit.
+func NewTimeoutHandler(h http.Handler, dt time.Duration, msg string) http.Handler {
+	th := http.TimeoutHandler(h, dt, msg)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStreaming(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		th.ServeHTTP(w, r)
+	})
+}
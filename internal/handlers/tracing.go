@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/tracing"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"net/http"
+)
+
+// TracingHandler defines HTTP handler middleware recording a trace span of each API request.
+// The W3C trace context of the incoming request is honored, so the span joins the trace of the caller.
+type TracingHandler struct {
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by starting a new span and passing the request
+// to the next handler in the chain.
+func (h *TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	// subscriptions live too long to be traced as a single request
+	if isStreaming(r) {
+		h.handler.ServeHTTP(w, r.WithContext(ctx))
+		return
+	}
+
+	ctx, span := tracing.Start(ctx, "handlers.Api",
+		attribute.String("http.request.method", r.Method),
+		attribute.String("url.path", r.URL.Path),
+		attribute.String("user_agent.original", r.UserAgent()),
+		attribute.String("client.address", r.RemoteAddr),
+	)
+	defer span.End()
+
+	sw := statusWriter{ResponseWriter: w, status: http.StatusOK}
+	h.handler.ServeHTTP(&sw, r.WithContext(ctx))
+
+	span.SetAttributes(attribute.Int("http.response.status_code", sw.status))
+	if sw.status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, fmt.Sprintf("status %d", sw.status))
+	}
+}
+
+// statusWriter keeps the status code of the response passing through.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader records the status code of the response.
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
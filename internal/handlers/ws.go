@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// graphql-transport-ws message types
+const (
+	wsConnectionInit = "connection_init"
+	wsConnectionAck  = "connection_ack"
+	wsPing           = "ping"
+	wsPong           = "pong"
+	wsSubscribe      = "subscribe"
+	wsNext           = "next"
+	wsError          = "error"
+	wsComplete       = "complete"
+)
+
+// graphql-transport-ws close codes
+const (
+	wsCloseInternal        = 4500
+	wsCloseBadRequest      = 4400
+	wsCloseUnauthorized    = 4401
+	wsCloseForbidden       = 4403
+	wsCloseInitTimeout     = 4408
+	wsCloseSubscriberTaken = 4409
+	wsCloseTooManyInits    = 4429
+)
+
+const (
+	// wsWriteTimeout represents the time a single message has to be written to the client in.
+	wsWriteTimeout = 10 * time.Second
+
+	// wsOutboxCapacity represents the capacity of the outgoing messages queue of a connection.
+	wsOutboxCapacity = 64
+)
+
+// wsMessage represents a message of the graphql-transport-ws protocol.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// wsClose represents a request to close the connection with the given code.
+type wsClose struct {
+	code   int
+	reason string
+}
+
+// wsConnection represents a single WebSocket connection of the graphql-transport-ws protocol.
+type wsConnection struct {
+	sh     *SubscriptionHandler
+	ws     *websocket.Conn
+	r      *http.Request
+	ctx    context.Context
+	out    chan *wsMessage
+	closed chan wsClose
+
+	client *subscriptionClient
+	acked  atomic.Bool
+
+	mu  sync.Mutex
+	ops map[string]context.CancelFunc
+}
+
+// serveTransportWS upgrades the connection and serves it by the graphql-transport-ws protocol
+// until the client disconnects. The request context is kept for the whole connection,
+// so the operations are logged with the request identifier of the upgrade request.
+func (sh *SubscriptionHandler) serveTransportWS(w http.ResponseWriter, r *http.Request) {
+	ws, err := sh.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		sh.logger.Debugf("websocket upgrade failed; %s", err.Error())
+		return
+	}
+	defer func() {
+		if err := ws.Close(); err != nil {
+			sh.logger.Debugf("can not close websocket; %s", err.Error())
+		}
+	}()
+	ws.SetReadLimit(limitsMaxBodySize)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	conn := wsConnection{
+		sh:     sh,
+		ws:     ws,
+		r:      r,
+		ctx:    ctx,
+		out:    make(chan *wsMessage, wsOutboxCapacity),
+		closed: make(chan wsClose, 1),
+		ops:    make(map[string]context.CancelFunc),
+	}
+
+	go conn.read()
+	conn.write()
+}
+
+// read consumes incoming messages until the connection is closed, or broken.
+func (conn *wsConnection) read() {
+	initTimeout := time.Duration(conn.sh.cfg.InitTimeout) * time.Second
+	if initTimeout <= 0 {
+		initTimeout = conn.sh.keepAlive()
+	}
+	_ = conn.ws.SetReadDeadline(time.Now().Add(initTimeout))
+
+	for {
+		var msg wsMessage
+		_, data, err := conn.ws.ReadMessage()
+		if err != nil {
+			if conn.client == nil && isTimeout(err) {
+				conn.close(wsCloseInitTimeout, "Connection initialisation timeout")
+				return
+			}
+			conn.close(websocket.CloseNormalClosure, "")
+			return
+		}
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+			conn.close(wsCloseBadRequest, "Invalid message received")
+			return
+		}
+
+		// clients are expected to answer our pings, so a quiet connection is a dead connection
+		if conn.client != nil {
+			_ = conn.ws.SetReadDeadline(time.Now().Add(2 * conn.sh.keepAlive()))
+		}
+
+		if !conn.handle(&msg) {
+			return
+		}
+	}
+}
+
+// handle processes the incoming message; false is returned if the connection is to be closed.
+func (conn *wsConnection) handle(msg *wsMessage) bool {
+	switch msg.Type {
+	case wsConnectionInit:
+		return conn.init(msg)
+	case wsPing:
+		conn.send(&wsMessage{Type: wsPong, Payload: msg.Payload})
+	case wsPong:
+	case wsSubscribe:
+		return conn.subscribe(msg)
+	case wsComplete:
+		conn.stop(msg.ID)
+	default:
+		conn.close(wsCloseBadRequest, fmt.Sprintf("Unknown message type %s", msg.Type))
+		return false
+	}
+	return true
+}
+
+// init authorizes the client by the connection parameters and acknowledges the connection.
+func (conn *wsConnection) init(msg *wsMessage) bool {
+	if conn.client != nil {
+		conn.close(wsCloseTooManyInits, "Too many initialisation requests")
+		return false
+	}
+
+	var params map[string]interface{}
+	if len(msg.Payload) > 0 && string(msg.Payload) != "null" {
+		if err := json.Unmarshal(msg.Payload, &params); err != nil {
+			conn.close(wsCloseBadRequest, "Invalid connection parameters")
+			return false
+		}
+	}
+
+	cl, err := conn.sh.authorize(conn.r, params)
+	if err != nil {
+		conn.close(wsCloseForbidden, err.Error())
+		return false
+	}
+
+	conn.client = cl
+	_ = conn.ws.SetReadDeadline(time.Now().Add(2 * conn.sh.keepAlive()))
+	conn.send(&wsMessage{Type: wsConnectionAck})
+	conn.acked.Store(true)
+	return true
+}
+
+// subscribe starts the requested operation.
+func (conn *wsConnection) subscribe(msg *wsMessage) bool {
+	if conn.client == nil {
+		conn.close(wsCloseUnauthorized, "Unauthorized")
+		return false
+	}
+
+	var req subscriptionRequest
+	if msg.ID == "" || json.Unmarshal(msg.Payload, &req) != nil {
+		conn.close(wsCloseBadRequest, "Invalid subscribe message")
+		return false
+	}
+
+	if rej := conn.sh.admit(conn.client, &req, false); rej != nil {
+		conn.fail(msg.ID, rej.err)
+		return true
+	}
+
+	conn.mu.Lock()
+	if _, ok := conn.ops[msg.ID]; ok {
+		conn.mu.Unlock()
+		conn.close(wsCloseSubscriberTaken, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return false
+	}
+	if max := conn.sh.cfg.MaxOperations; max > 0 && len(conn.ops) >= max {
+		conn.mu.Unlock()
+		conn.fail(msg.ID, queryError{Message: fmt.Sprintf("too many operations on the connection, the limit is %d", max)})
+		return true
+	}
+
+	ctx, cancel := context.WithCancel(conn.ctx)
+	conn.ops[msg.ID] = cancel
+	conn.mu.Unlock()
+
+	go conn.run(ctx, msg.ID, &req)
+	return true
+}
+
+// run executes the operation and streams its results to the client.
+func (conn *wsConnection) run(ctx context.Context, id string, req *subscriptionRequest) {
+	defer conn.stop(id)
+
+	results, err := conn.sh.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		conn.fail(id, queryError{Message: err.Error()})
+		return
+	}
+
+	first := true
+	for res := range results {
+		// results of an operation stopped by the client are drained, but not sent
+		resp, ok := res.(*graphql.Response)
+		if !ok || ctx.Err() != nil {
+			continue
+		}
+
+		// operations failing before the execution are reported by the error message
+		if first && resp.Data == nil && len(resp.Errors) > 0 {
+			payload, err := json.Marshal(resp.Errors)
+			if err == nil {
+				conn.send(&wsMessage{ID: id, Type: wsError, Payload: payload})
+				return
+			}
+		}
+		first = false
+
+		payload, err := json.Marshal(resp)
+		if err != nil {
+			conn.sh.logger.Errorf("can not encode subscription response; %s", err.Error())
+			continue
+		}
+		conn.send(&wsMessage{ID: id, Type: wsNext, Payload: payload})
+	}
+
+	// operations stopped by the client are not completed by the server
+	if ctx.Err() == nil {
+		conn.send(&wsMessage{ID: id, Type: wsComplete})
+	}
+}
+
+// stop cancels the operation of the given id, if it's still running.
+func (conn *wsConnection) stop(id string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if cancel, ok := conn.ops[id]; ok {
+		cancel()
+		delete(conn.ops, id)
+	}
+}
+
+// fail reports the operation rejected before it started.
+func (conn *wsConnection) fail(id string, e queryError) {
+	payload, err := json.Marshal([]queryError{e})
+	if err != nil {
+		conn.close(wsCloseInternal, "Internal error")
+		return
+	}
+	conn.send(&wsMessage{ID: id, Type: wsError, Payload: payload})
+}
+
+// send queues the outgoing message; the message is dropped if the connection is closing.
+func (conn *wsConnection) send(msg *wsMessage) {
+	select {
+	case conn.out <- msg:
+	case <-conn.ctx.Done():
+	}
+}
+
+// close requests the connection to be closed with the given code.
+func (conn *wsConnection) close(code int, reason string) {
+	select {
+	case conn.closed <- wsClose{code: code, reason: reason}:
+	default:
+	}
+}
+
+// write sends queued messages and keep alive pings to the client until the connection is closed.
+func (conn *wsConnection) write() {
+	ping := time.NewTicker(conn.sh.keepAlive())
+	defer ping.Stop()
+
+	for {
+		var msg *wsMessage
+		select {
+		case msg = <-conn.out:
+		case <-ping.C:
+			if !conn.acked.Load() {
+				continue
+			}
+			msg = &wsMessage{Type: wsPing}
+		case cl := <-conn.closed:
+			_ = conn.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(cl.code, cl.reason), time.Now().Add(wsWriteTimeout))
+			return
+		}
+
+		_ = conn.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := conn.ws.WriteJSON(msg); err != nil {
+			conn.sh.logger.Debugf("can not write to websocket; %s", err.Error())
+			return
+		}
+	}
+}
+
+// isTimeout checks if the error is a network timeout.
+func isTimeout(err error) bool {
+	ne, ok := err.(interface{ Timeout() bool })
+	return ok && ne.Timeout()
+}
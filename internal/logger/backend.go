@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fmt"
+	"github.com/op/go-logging"
+	"gopkg.in/natefinch/lumberjack.v2"
+	"io"
+	"log/syslog"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// newBackend creates the logging backend of the configured output and encoding.
+func newBackend(cfg *config.Log, tag string) (logging.Backend, error) {
+	if cfg.SyslogTag != "" {
+		tag = cfg.SyslogTag
+	}
+	isJson := strings.EqualFold(cfg.Encoding, "json")
+
+	var out io.Writer
+	switch strings.ToLower(cfg.Output) {
+	case "", "stderr":
+		out = os.Stderr
+	case "file":
+		out = &lumberjack.Logger{
+			Filename:   cfg.File.Path,
+			MaxSize:    cfg.File.MaxSize,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAge:     cfg.File.MaxAge,
+			Compress:   cfg.File.Compress,
+		}
+	case "syslog":
+		// text records keep the syslog priority of their level
+		if !isJson {
+			sb, err := logging.NewSyslogBackend(tag)
+			if err != nil {
+				return nil, err
+			}
+			return formatted(sb, cfg.Format), nil
+		}
+
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+		if err != nil {
+			return nil, err
+		}
+		out = w
+	default:
+		return nil, fmt.Errorf("unknown log output %s", cfg.Output)
+	}
+
+	if isJson {
+		return &jsonBackend{out: out}, nil
+	}
+	return formatted(logging.NewLogBackend(out, "", 0), cfg.Format), nil
+}
+
+// formatted wraps the backend with the formatter of the given format.
+func formatted(b logging.Backend, format string) logging.Backend {
+	return logging.NewBackendFormatter(b, logging.MustStringFormatter(format))
+}
+
+// jsonBackend implements logging backend writing records as JSON objects, one per line.
+type jsonBackend struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// Log writes the given record to the output.
+func (jb *jsonBackend) Log(lvl logging.Level, calldepth int, rec *logging.Record) error {
+	obj := map[string]interface{}{
+		"time":   rec.Time.Format(time.RFC3339Nano),
+		"level":  lvl.String(),
+		"module": rec.Module,
+	}
+
+	if _, file, line, ok := runtime.Caller(calldepth + 1); ok {
+		obj["caller"] = fmt.Sprintf("%s:%d", shortFile(file), line)
+	}
+
+	e, ok := recordEntry(rec)
+	if !ok {
+		e = entry{msg: rec.Message()}
+	}
+	for _, f := range e.fields {
+		obj[f.key] = jsonValue(f.value)
+	}
+	obj["msg"] = e.msg
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	jb.mu.Lock()
+	defer jb.mu.Unlock()
+	_, err = jb.out.Write(append(data, '\n'))
+	return err
+}
+
+// recordEntry extracts the structured entry of the record, if available.
+func recordEntry(rec *logging.Record) (entry, bool) {
+	if len(rec.Args) != 1 {
+		return entry{}, false
+	}
+	e, ok := rec.Args[0].(entry)
+	return e, ok
+}
+
+// jsonValue converts the field value to a JSON friendly representation.
+func jsonValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case error:
+		return val.Error()
+	case fmt.Stringer:
+		return val.String()
+	case nil, bool, string, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return val
+	default:
+		if _, err := json.Marshal(val); err != nil {
+			return fmt.Sprint(val)
+		}
+		return val
+	}
+}
+
+// shortFile provides the file name along with its parent directory.
+func shortFile(file string) string {
+	if i := strings.LastIndex(file, "/"); i > 0 {
+		if j := strings.LastIndex(file[:i], "/"); j >= 0 {
+			return file[j+1:]
+		}
+	}
+	return file
+}
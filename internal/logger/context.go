@@ -0,0 +1,26 @@
+package logger
+
+import "context"
+
+// requestIDKey represents the context key of the incoming request identifier.
+type requestIDKey struct{}
+
+// WithRequestID provides a context carrying the given request identifier.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID provides the request identifier of the given context, if any.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// FromContext provides a logger attaching the request identifier of the given context
+// to each record. The logger is returned unchanged if the context has no identifier.
+func FromContext(ctx context.Context, l Logger) Logger {
+	if id := RequestID(ctx); id != "" {
+		return With(l, "request_id", id)
+	}
+	return l
+}
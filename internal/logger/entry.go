@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// field represents a single structured key/value pair of a log record.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// entry represents the message of a log record along with its structured fields.
+// It's passed to the backend as the only argument of the record, so the JSON backend
+// can encode the fields separately, while text backends get it rendered by String.
+type entry struct {
+	msg    string
+	fields []field
+}
+
+// newEntry makes a new entry from the arguments of a non-formatted call. The calls like
+// log.Error("message", "key", value) are split to the message and the key/value fields,
+// other calls are rendered the same way as fmt.Sprintln does.
+func newEntry(args []interface{}, base []field) entry {
+	if msg, ok := args[0].(string); ok && len(args) > 1 && len(args)%2 == 1 {
+		if kv := fields(args[1:]); kv != nil {
+			return entry{msg: msg, fields: append(append([]field(nil), base...), kv...)}
+		}
+	}
+
+	msg := fmt.Sprintln(args...)
+	return entry{msg: msg[:len(msg)-1], fields: base}
+}
+
+// fields turns the given key/value pairs into fields; nil is returned
+// if the pairs are not complete or any of the keys is not a string.
+func fields(kv []interface{}) []field {
+	if len(kv)%2 != 0 {
+		return nil
+	}
+
+	list := make([]field, 0, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok || key == "" {
+			return nil
+		}
+		list = append(list, field{key: key, value: kv[i+1]})
+	}
+	return list
+}
+
+// String renders the entry for text backends as the message followed by key=value pairs.
+func (e entry) String() string {
+	if len(e.fields) == 0 {
+		return e.msg
+	}
+
+	var sb strings.Builder
+	sb.WriteString(e.msg)
+	for _, f := range e.fields {
+		v := fmt.Sprint(f.value)
+		if strings.ContainsAny(v, " \t\n\"=") {
+			v = fmt.Sprintf("%q", v)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(f.key)
+		sb.WriteString("=")
+		sb.WriteString(v)
+	}
+	return sb.String()
+}
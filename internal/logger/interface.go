@@ -5,13 +5,17 @@ The app is expected to log detailed internal processes and state changes. Each d
 the level of log to be captured and provided to end user and/or automated log management facility.
 
 Supported logging levels, ordered by increasing details of internal state change recording, are:
-	- CRITICAL
-    - ERROR
-    - WARNING
-    - NOTICE
-    - INFO
-    - DEBUG
+  - CRITICAL
+  - ERROR
+  - WARNING
+  - NOTICE
+  - INFO
+  - DEBUG
+
 Corresponding configuration package accepts one of these levels as a string literal.
+The level can be overridden for each module separately, i.e. svc, repository/rpc, repository/db, or handlers.
+
+Records are written to stderr, a rotated file, or syslog, either as formatted text, or as JSON objects.
 
 For formatting specification see pkg/fmt package.
 */
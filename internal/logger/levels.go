@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fmt"
+	"github.com/op/go-logging"
+	"strings"
+	"sync"
+)
+
+// levels represents the module levels of the logging backend in use, if any.
+var levels *moduleLevels
+
+// moduleLevels implements leveled logging backend with levels of modules
+// safe to be changed while the backend is in use.
+type moduleLevels struct {
+	mu      sync.RWMutex
+	backend logging.Backend
+	def     logging.Level
+	modules map[string]logging.Level
+}
+
+// newModuleLevels creates a new leveled backend with the levels of the given configuration.
+func newModuleLevels(b logging.Backend, cfg *config.Log) *moduleLevels {
+	ml := moduleLevels{backend: b}
+	ml.reset(cfg)
+	return &ml
+}
+
+// reset replaces all the levels with the levels of the given configuration.
+func (ml *moduleLevels) reset(cfg *config.Log) {
+	def, modules := cfg.Levels()
+
+	lvl := make(map[string]logging.Level, len(modules))
+	for _, m := range modules {
+		lvl[m.Name] = level(m.Level)
+	}
+
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	ml.def, ml.modules = level(def), lvl
+}
+
+// GetLevel returns the log level of the given module.
+func (ml *moduleLevels) GetLevel(module string) logging.Level {
+	ml.mu.RLock()
+	defer ml.mu.RUnlock()
+
+	if l, ok := ml.modules[module]; ok {
+		return l
+	}
+	return ml.def
+}
+
+// SetLevel sets the log level of the given module; empty module sets the default level.
+func (ml *moduleLevels) SetLevel(lvl logging.Level, module string) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	if module == "" {
+		ml.def = lvl
+		return
+	}
+	ml.modules[module] = lvl
+}
+
+// IsEnabledFor checks if the given level is logged for the given module.
+func (ml *moduleLevels) IsEnabledFor(lvl logging.Level, module string) bool {
+	return lvl <= ml.GetLevel(module)
+}
+
+// Log passes the record to the backend if its level is enabled.
+func (ml *moduleLevels) Log(lvl logging.Level, calldepth int, rec *logging.Record) error {
+	if !ml.IsEnabledFor(lvl, rec.Module) {
+		return nil
+	}
+	return ml.backend.Log(lvl, calldepth+1, rec)
+}
+
+// SetLevel changes the log level of the given module at runtime; empty module
+// changes the default level of all the modules without their own level.
+func SetLevel(module string, lvl string) error {
+	if levels == nil {
+		return fmt.Errorf("logging levels not available")
+	}
+
+	l, err := logging.LogLevel(strings.ToUpper(lvl))
+	if err != nil {
+		return err
+	}
+
+	levels.SetLevel(l, module)
+	return nil
+}
+
+// Levels provides the default log level and the levels of the modules with their own level.
+func Levels() (string, map[string]string) {
+	if levels == nil {
+		return "", nil
+	}
+
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	res := make(map[string]string, len(levels.modules))
+	for m, l := range levels.modules {
+		res[m] = l.String()
+	}
+	return levels.def.String(), res
+}
@@ -2,43 +2,198 @@ package logger
 
 import (
 	"fantom-api-graphql/internal/config"
+	"fmt"
 	"github.com/op/go-logging"
 	"os"
+	"strings"
 )
 
 // ApiLogger defines extended logger with generic no-level logging option
+// and a set of structured fields attached to each record.
 type ApiLogger struct {
 	logging.Logger
+	fields []field
 }
 
-// Printf implements default non-leveled output.
-// We assume the information is low in importance if passed to this function so we relay it to Debug level.
-func (a ApiLogger) Printf(format string, args ...interface{}) {
-	a.Debugf(format, args...)
+// New provides pre-configured Logger with the configured output, encoding and leveled filtering.
+// The levels can be overridden per module, see Module for obtaining a logger of a module,
+// and changed at runtime, see SetLevel.
+func New(cfg *config.Config) Logger {
+	// prep the backend for exporting the log records
+	backend, err := newBackend(&cfg.Log, cfg.AppName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "can not open %s log output; %s\n", cfg.Log.Output, err.Error())
+		backend = formatted(logging.NewLogBackend(os.Stderr, "", 0), cfg.Log.Format)
+	}
+
+	// parse and apply the configured level on which the recording will be emitted;
+	// the levels follow the reloaded configuration
+	levels = newModuleLevels(backend, &cfg.Log)
+	config.OnReload(func(c *config.Config) {
+		levels.reset(&c.Log)
+	})
+
+	// assign the backend and return the new logger
+	logging.SetBackend(levels)
+	return newApiLogger(cfg.AppName)
 }
 
-// New provides pre-configured Logger with stderr output and leveled filtering.
-// Modules are not supported at the moment, but may be added in the future to make the logging setup more granular.
-func New(cfg *config.Config) Logger {
-	// Prep the backend for exporting the log records
-	// @todo Allow app to define different logging backend by configuration means.
-	backend := logging.NewLogBackend(os.Stderr, "", 0)
+// Module provides a logger of the given module, so the module can be filtered
+// on its own level. Loggers of other implementations are returned unchanged.
+func Module(l Logger, name string) Logger {
+	if _, ok := l.(*ApiLogger); !ok {
+		return l
+	}
+	return newApiLogger(name)
+}
+
+// With provides a logger attaching the given key/value pairs to each record.
+// Loggers of other implementations are returned unchanged.
+func With(l Logger, kv ...interface{}) Logger {
+	al, ok := l.(*ApiLogger)
+	if !ok {
+		return l
+	}
+
+	nl := *al
+	nl.fields = append(append([]field(nil), al.fields...), fields(kv)...)
+	return &nl
+}
 
-	// Parse log format from configuration and apply it to the backend
-	format := logging.MustStringFormatter(cfg.Log.Format)
-	fmtBackend := logging.NewBackendFormatter(backend, format)
+// newApiLogger creates a new logger of the given module.
+func newApiLogger(module string) *ApiLogger {
+	l := logging.MustGetLogger(module)
 
-	// Parse and apply the configured level on which the recording will be emitted
-	level, err := logging.LogLevel(cfg.Log.Level)
+	// skip the wrapping calls, so the caller info points to the actual caller
+	l.ExtraCalldepth = 2
+	return &ApiLogger{Logger: *l}
+}
+
+// level parses the given log level, INFO is used if the level is not valid.
+func level(lvl string) logging.Level {
+	l, err := logging.LogLevel(strings.ToUpper(lvl))
 	if err != nil {
-		level = logging.INFO
+		return logging.INFO
 	}
-	lvlBackend := logging.AddModuleLevel(fmtBackend)
-	lvlBackend.SetLevel(level, "")
+	return l
+}
 
-	// assign the backend and return the new logger
-	logging.SetBackend(lvlBackend)
-	l := logging.MustGetLogger(cfg.AppName)
+// emit sends the log record with the attached fields to the backend.
+// The trailing key/value pairs of non-formatted calls are turned into fields.
+func (a *ApiLogger) emit(lvl logging.Level, format *string, args []interface{}) {
+	if !a.IsEnabledFor(lvl) {
+		return
+	}
+
+	var e entry
+	if format != nil {
+		e = entry{msg: fmt.Sprintf(*format, args...), fields: a.fields}
+	} else {
+		e = newEntry(args, a.fields)
+	}
 
-	return &ApiLogger{*l}
+	switch lvl {
+	case logging.CRITICAL:
+		a.Logger.Critical(e)
+	case logging.ERROR:
+		a.Logger.Error(e)
+	case logging.WARNING:
+		a.Logger.Warning(e)
+	case logging.NOTICE:
+		a.Logger.Notice(e)
+	case logging.INFO:
+		a.Logger.Info(e)
+	default:
+		a.Logger.Debug(e)
+	}
+}
+
+// Fatal logs fatal error without formatting and terminates the app.
+func (a *ApiLogger) Fatal(args ...interface{}) {
+	a.emit(logging.CRITICAL, nil, args)
+	os.Exit(1)
+}
+
+// Fatalf logs fatal error with formatting and terminates the app.
+func (a *ApiLogger) Fatalf(format string, args ...interface{}) {
+	a.emit(logging.CRITICAL, &format, args)
+	os.Exit(1)
+}
+
+// Panic logs critical error without formatting and panics.
+func (a *ApiLogger) Panic(args ...interface{}) {
+	a.emit(logging.CRITICAL, nil, args)
+	panic(fmt.Sprint(args...))
+}
+
+// Panicf logs critical error with formatting and panics.
+func (a *ApiLogger) Panicf(format string, args ...interface{}) {
+	a.emit(logging.CRITICAL, &format, args)
+	panic(fmt.Sprintf(format, args...))
+}
+
+// Critical logs critical error without formatting.
+func (a *ApiLogger) Critical(args ...interface{}) {
+	a.emit(logging.CRITICAL, nil, args)
+}
+
+// Criticalf logs critical error with formatting.
+func (a *ApiLogger) Criticalf(format string, args ...interface{}) {
+	a.emit(logging.CRITICAL, &format, args)
+}
+
+// Error logs regular error without formatting.
+func (a *ApiLogger) Error(args ...interface{}) {
+	a.emit(logging.ERROR, nil, args)
+}
+
+// Errorf logs regular error with formatting.
+func (a *ApiLogger) Errorf(format string, args ...interface{}) {
+	a.emit(logging.ERROR, &format, args)
+}
+
+// Warning logs suspicious state situation without formatting.
+func (a *ApiLogger) Warning(args ...interface{}) {
+	a.emit(logging.WARNING, nil, args)
+}
+
+// Warningf logs suspicious state situation with formatting.
+func (a *ApiLogger) Warningf(format string, args ...interface{}) {
+	a.emit(logging.WARNING, &format, args)
+}
+
+// Notice logs significant state change without formatting.
+func (a *ApiLogger) Notice(args ...interface{}) {
+	a.emit(logging.NOTICE, nil, args)
+}
+
+// Noticef logs significant state change with formatting.
+func (a *ApiLogger) Noticef(format string, args ...interface{}) {
+	a.emit(logging.NOTICE, &format, args)
+}
+
+// Info logs common and regular state change without formatting.
+func (a *ApiLogger) Info(args ...interface{}) {
+	a.emit(logging.INFO, nil, args)
+}
+
+// Infof logs common and regular state change with formatting.
+func (a *ApiLogger) Infof(format string, args ...interface{}) {
+	a.emit(logging.INFO, &format, args)
+}
+
+// Debug logs regular and detailed state change without formatting.
+func (a *ApiLogger) Debug(args ...interface{}) {
+	a.emit(logging.DEBUG, nil, args)
+}
+
+// Debugf logs regular and detailed state change with formatting.
+func (a *ApiLogger) Debugf(format string, args ...interface{}) {
+	a.emit(logging.DEBUG, &format, args)
+}
+
+// Printf implements default non-leveled output.
+// We assume the information is low in importance if passed to this function so we relay it to Debug level.
+func (a *ApiLogger) Printf(format string, args ...interface{}) {
+	a.emit(logging.DEBUG, &format, args)
 }
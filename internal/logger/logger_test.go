@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/op/go-logging"
+	"testing"
+)
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		args []interface{}
+		text string
+	}{
+		{[]interface{}{"health check failed", "err", errors.New("timeout")}, "health check failed err=timeout"},
+		{[]interface{}{"health check failed", "status", 503}, "health check failed status=503"},
+		{[]interface{}{"block", 5, "done"}, "block 5 done"},
+		{[]interface{}{"plain message"}, "plain message"},
+		{[]interface{}{"quoted", "err", "not found"}, `quoted err="not found"`},
+	}
+
+	for _, tc := range tests {
+		if s := newEntry(tc.args, nil).String(); s != tc.text {
+			t.Errorf("expected %q, got %q", tc.text, s)
+		}
+	}
+}
+
+func TestJsonBackend(t *testing.T) {
+	var buf bytes.Buffer
+	lvl := logging.AddModuleLevel(&jsonBackend{out: &buf})
+	lvl.SetLevel(logging.INFO, "")
+	lvl.SetLevel(logging.DEBUG, "svc")
+	logging.SetBackend(lvl)
+
+	l := With(newApiLogger("test"), "request_id", "abc")
+	l.Error("query failed", "err", errors.New("boom"))
+	l.Debug("not logged")
+	newApiLogger("svc").Debugf("block %d", 5)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(lines))
+	}
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(lines[0], &rec); err != nil {
+		t.Fatalf("invalid record; %s", err.Error())
+	}
+	if rec["msg"] != "query failed" || rec["err"] != "boom" || rec["request_id"] != "abc" || rec["level"] != "ERROR" || rec["module"] != "test" {
+		t.Errorf("unexpected record %v", rec)
+	}
+
+	if err := json.Unmarshal(lines[1], &rec); err != nil || rec["msg"] != "block 5" || rec["module"] != "svc" {
+		t.Errorf("unexpected record %s", lines[1])
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/tracing"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.opentelemetry.io/otel/attribute"
+	"math/big"
+)
+
+// TransactionsByHash loads the transactions of the given hashes using the in-memory cache,
+// and a batch call to the node for the transactions not cached. Not found transactions are nil.
+func (p *proxy) TransactionsByHash(ctx context.Context, hashes []common.Hash) ([]*types.Transaction, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.TransactionsByHash", attribute.Int("repository.batch.size", len(hashes)))
+	defer span.End()
+
+	list := make([]*types.Transaction, len(hashes))
+	missing := make([]common.Hash, 0, len(hashes))
+	for i := range hashes {
+		if list[i] = p.cache.PullTransaction(&hashes[i]); list[i] == nil {
+			missing = append(missing, hashes[i])
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	loaded, err := p.rpc.TransactionsBatch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	// fill the gaps; pending transactions are not cached
+	for i, j := 0, 0; i < len(list); i++ {
+		if list[i] != nil {
+			continue
+		}
+		list[i] = loaded[j]
+		if list[i] != nil && list[i].BlockHash != nil {
+			p.cache.PushTransaction(list[i])
+		}
+		j++
+	}
+	return list, nil
+}
+
+// BlocksByNumber loads the blocks of the given numbers using the in-memory cache,
+// and a batch call to the node for the blocks not cached. Not found blocks are nil.
+func (p *proxy) BlocksByNumber(ctx context.Context, nums []hexutil.Uint64) ([]*types.Block, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.BlocksByNumber", attribute.Int("repository.batch.size", len(nums)))
+	defer span.End()
+
+	list := make([]*types.Block, len(nums))
+	missing := make([]hexutil.Uint64, 0, len(nums))
+	for i := range nums {
+		if list[i] = p.cache.PullBlock(nums[i].String()); list[i] == nil {
+			missing = append(missing, nums[i])
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	loaded, err := p.rpc.BlocksBatch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, 0; i < len(list); i++ {
+		if list[i] != nil {
+			continue
+		}
+		list[i] = loaded[j]
+		if list[i] != nil {
+			if err := p.cache.PushBlock(nums[i].String(), list[i]); err != nil {
+				p.log.Errorf("can not cache; %s", err.Error())
+			}
+		}
+		j++
+	}
+	return list, nil
+}
+
+// AccountsByAddress loads the accounts of the given addresses using the in-memory cache,
+// and a single database query for the accounts not cached. Unknown addresses are
+// represented by wallet accounts, the same way as for a single account.
+func (p *proxy) AccountsByAddress(ctx context.Context, addr []common.Address) ([]*types.Account, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.AccountsByAddress", attribute.Int("repository.batch.size", len(addr)))
+	defer span.End()
+
+	list := make([]*types.Account, len(addr))
+	missing := make([]common.Address, 0, len(addr))
+	for i := range addr {
+		if list[i] = p.cache.PullAccount(&addr[i]); list[i] == nil {
+			missing = append(missing, addr[i])
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	known, err := p.db.AccountsByAddress(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	// unknown accounts may still be contracts
+	unknown := make([]common.Address, 0, len(missing))
+	for _, adr := range missing {
+		if _, ok := known[adr]; !ok {
+			unknown = append(unknown, adr)
+		}
+	}
+	contracts, err := p.ContractsByAddress(ctx, unknown)
+	if err != nil {
+		return nil, err
+	}
+	for i, adr := range unknown {
+		acc := types.Account{Address: adr, Type: types.AccountTypeWallet}
+		if contracts[i] != nil {
+			acc.ContractTx = &contracts[i].TransactionHash
+		}
+		known[adr] = &acc
+	}
+
+	for i := range list {
+		if list[i] != nil {
+			continue
+		}
+		list[i] = known[addr[i]]
+		if err := p.cache.PushAccount(list[i]); err != nil {
+			p.log.Warningf("can not keep account [%s] information in memory; %s", addr[i].Hex(), err.Error())
+		}
+	}
+	return list, nil
+}
+
+// ContractsByAddress loads the smart contracts of the given addresses using the in-memory cache,
+// and a single database query for the contracts not cached. Not found contracts are nil.
+func (p *proxy) ContractsByAddress(ctx context.Context, addr []common.Address) ([]*types.Contract, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.ContractsByAddress", attribute.Int("repository.batch.size", len(addr)))
+	defer span.End()
+
+	list := make([]*types.Contract, len(addr))
+	missing := make([]common.Address, 0, len(addr))
+	for i := range addr {
+		if list[i] = p.cache.PullContract(&addr[i]); list[i] == nil {
+			missing = append(missing, addr[i])
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	loaded, err := p.db.ContractsByAddress(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range list {
+		if list[i] != nil {
+			continue
+		}
+		if list[i] = loaded[addr[i]]; list[i] != nil {
+			if err := p.cache.PushContract(list[i]); err != nil {
+				p.log.Criticalf("can not cache contract %s; %s", addr[i].String(), err.Error())
+			}
+		}
+	}
+	return list, nil
+}
+
+// Erc20TokensByAddress loads the ERC20 tokens of the given addresses using the in-memory cache,
+// and a batch call to the node for the tokens not cached.
+func (p *proxy) Erc20TokensByAddress(ctx context.Context, addr []common.Address) ([]*types.Erc20Token, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.Erc20TokensByAddress", attribute.Int("repository.batch.size", len(addr)))
+	defer span.End()
+
+	list := make([]*types.Erc20Token, len(addr))
+	missing := make([]common.Address, 0, len(addr))
+	for i := range addr {
+		if list[i] = p.cache.PullErc20Token(&addr[i]); list[i] == nil {
+			missing = append(missing, addr[i])
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	loaded, err := p.rpc.Erc20TokensBatch(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, 0; i < len(list); i++ {
+		if list[i] != nil {
+			continue
+		}
+		list[i] = loaded[j]
+		if err := p.cache.PushErc20Token(list[i]); err != nil {
+			p.log.Errorf("can not keep ERC20 token %s in cache; %s", addr[i].String(), err.Error())
+		}
+		j++
+	}
+	return list, nil
+}
+
+// ValidatorsById loads the validators of the given IDs from the SFC contract in a batch call.
+// Not found validators are nil.
+func (p *proxy) ValidatorsById(ctx context.Context, ids []hexutil.Big) ([]*types.Validator, error) {
+	ctx, span := tracing.StartChild(ctx, "repository.ValidatorsById", attribute.Int("repository.batch.size", len(ids)))
+	defer span.End()
+
+	vid := make([]*big.Int, len(ids))
+	for i := range ids {
+		vid[i] = ids[i].ToInt()
+	}
+	return p.rpc.ValidatorsBatch(ctx, vid)
+}
@@ -44,6 +44,16 @@ func (p *proxy) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
 	return p.db.UpdateLastKnownBlock(blockNo)
 }
 
+// LastKnownBlockEpoch returns the epoch and the time stamp of the last known block.
+func (p *proxy) LastKnownBlockEpoch() (uint64, uint64, error) {
+	return p.db.LastKnownBlockEpoch()
+}
+
+// UpdateLastKnownBlockEpoch update record about the epoch and the time stamp of the last known block.
+func (p *proxy) UpdateLastKnownBlockEpoch(epoch hexutil.Uint64, stamp hexutil.Uint64) error {
+	return p.db.UpdateLastKnownBlockEpoch(epoch, stamp)
+}
+
 // CacheBlock puts a block to the internal block cache.
 func (p *proxy) CacheBlock(blk *types.Block) {
 	p.cache.AddBlock(blk)
@@ -6,6 +6,7 @@ import (
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/repository/cache/ring"
 	"github.com/allegro/bigcache"
+	"github.com/prometheus/client_golang/prometheus"
 	"time"
 )
 
@@ -36,6 +37,9 @@ func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 		return nil, err
 	}
 
+	// expose the cache hit ratio
+	registerMetrics(c, log)
+
 	// log the event
 	log.Notice("memory cache initialized")
 
@@ -50,6 +54,49 @@ func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 	}, nil
 }
 
+// Evict removes the record of the given key from the cache.
+// It returns false if the key was not cached.
+func (b *MemBridge) Evict(key string) (bool, error) {
+	err := b.cache.Delete(key)
+	if err == bigcache.ErrEntryNotFound {
+		return false, nil
+	}
+	if err != nil {
+		b.log.Errorf("can not evict %s; %s", key, err.Error())
+		return false, err
+	}
+	return true, nil
+}
+
+// Reset removes all the records from the cache.
+func (b *MemBridge) Reset() error {
+	return b.cache.Reset()
+}
+
+// registerMetrics registers the hits and misses counters of the given cache.
+func registerMetrics(c *bigcache.BigCache, log logger.Logger) {
+	cs := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "graphql_cache_hits_total",
+			Help: "The total number of lookups served by the in-memory cache",
+		}, func() float64 {
+			return float64(c.Stats().Hits)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Name: "graphql_cache_misses_total",
+			Help: "The total number of lookups not found in the in-memory cache",
+		}, func() float64 {
+			return float64(c.Stats().Misses)
+		}),
+	}
+
+	for _, col := range cs {
+		if err := prometheus.Register(col); err != nil {
+			log.Errorf("can not register cache metrics; %s", err.Error())
+		}
+	}
+}
+
 // cacheConfig constructs a configuration structure for BigCache initialization.
 func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 	// log the info
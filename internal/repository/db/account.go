@@ -97,6 +97,46 @@ func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
 	}, nil
 }
 
+// AccountsByAddress loads the accounts of the given addresses in a single query.
+// Accounts not found in the database are not included in the result map.
+func (db *MongoDbBridge) AccountsByAddress(ctx context.Context, addr []common.Address) (map[common.Address]*types.Account, error) {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	ids := make(bson.A, len(addr))
+	for i := range addr {
+		ids[i] = addr[i].String()
+	}
+
+	cu, err := col.Find(ctx, bson.D{{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		db.log.Errorf("can not load accounts; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cu)
+
+	list := make(map[common.Address]*types.Account, len(addr))
+	for cu.Next(ctx) {
+		var row AccountRow
+		if err := cu.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account; %s", err.Error())
+			continue
+		}
+
+		acc := types.Account{
+			Address:      common.HexToAddress(row.Address),
+			Type:         row.Type,
+			LastActivity: hexutil.Uint64(row.Activity),
+			TrxCounter:   hexutil.Uint64(row.Counter),
+		}
+		if row.Sc != nil {
+			h := common.HexToHash(*row.Sc)
+			acc.ContractTx = &h
+		}
+		list[acc.Address] = &acc
+	}
+	return list, nil
+}
+
 // AddAccount stores an account in the blockchain if not exists.
 func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 	// do we have account data?
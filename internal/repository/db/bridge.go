@@ -74,6 +74,7 @@ func New(cfg *config.Config, log logger.Logger) (*MongoDbBridge, error) {
 	// check the state
 	db.updateDatabaseIndexes()
 	db.CheckDatabaseInitState()
+	db.migrateWithdrawalPenalty()
 	return db, nil
 }
 
@@ -83,7 +84,10 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	ctx := context.Background()
 
 	// create new Mongo client
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Url).SetRegistry(registry.DefaultRegistry()))
+	client, err := mongo.Connect(ctx, options.Client().
+		ApplyURI(cfg.Url).
+		SetRegistry(registry.DefaultRegistry()).
+		SetMonitor(new(commandMonitor).monitor()))
 	if err != nil {
 		return nil, err
 	}
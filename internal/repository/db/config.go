@@ -22,6 +22,15 @@ const (
 
 	// keyConfigLastKnownBlock is the primary key for the Last Known Block value.
 	keyConfigLastKnownBlock = "lnb"
+
+	// keyConfigLastKnownBlockEpoch is the primary key for the epoch of the Last Known Block.
+	keyConfigLastKnownBlockEpoch = "lnb_epoch"
+
+	// keyConfigLastKnownBlockTime is the primary key for the time stamp of the Last Known Block.
+	keyConfigLastKnownBlockTime = "lnb_time"
+
+	// keyConfigLogBackfillPrefix is the prefix of the primary keys for the progress of log backfill jobs.
+	keyConfigLogBackfillPrefix = "lbf_"
 )
 
 // ConfigRow represents a row in configuration collection.
@@ -51,6 +60,118 @@ func (db *MongoDbBridge) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
 	return nil
 }
 
+// UpdateLastKnownBlockEpoch stores the epoch and the time stamp of the last known block into the config collection.
+func (db *MongoDbBridge) UpdateLastKnownBlockEpoch(epoch hexutil.Uint64, stamp hexutil.Uint64) error {
+	// get the collection for cfg
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	for key, val := range map[string]hexutil.Uint64{keyConfigLastKnownBlockEpoch: epoch, keyConfigLastKnownBlockTime: stamp} {
+		_, err := col.UpdateByID(context.Background(), key, bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiConfigPk, Value: key},
+			{Key: fiConfigValue, Value: val.String()},
+		}}}, new(options.UpdateOptions).SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastKnownBlockEpoch returns the epoch and the time stamp of the last known block from the database.
+// Zero values are returned if they are not known.
+func (db *MongoDbBridge) LastKnownBlockEpoch() (uint64, uint64, error) {
+	// get the collection for cfg
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	cursor, err := col.Find(context.Background(), bson.D{{Key: fiConfigPk, Value: bson.D{
+		{Key: "$in", Value: bson.A{keyConfigLastKnownBlockEpoch, keyConfigLastKnownBlockTime}},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not load the last known block epoch; %s", err.Error())
+		return 0, 0, err
+	}
+
+	var rows []ConfigRow
+	if err := cursor.All(context.Background(), &rows); err != nil {
+		db.log.Errorf("can not decode the last known block epoch; %s", err.Error())
+		return 0, 0, err
+	}
+
+	var epoch, stamp uint64
+	for _, row := range rows {
+		val, err := hexutil.DecodeUint64(row.Value)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		switch row.Key {
+		case keyConfigLastKnownBlockEpoch:
+			epoch = val
+		case keyConfigLastKnownBlockTime:
+			stamp = val
+		}
+	}
+	return epoch, stamp, nil
+}
+
+// UpdateLogBackfill stores the progress of the log backfill job of the given name;
+// the next block to be scanned and the last block the job scans.
+func (db *MongoDbBridge) UpdateLogBackfill(name string, next uint64, end uint64) error {
+	// get the collection for cfg
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	key := keyConfigLogBackfillPrefix + name
+	for k, val := range map[string]hexutil.Uint64{key: hexutil.Uint64(next), key + "_end": hexutil.Uint64(end)} {
+		_, err := col.UpdateByID(context.Background(), k, bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiConfigPk, Value: k},
+			{Key: fiConfigValue, Value: val.String()},
+		}}}, new(options.UpdateOptions).SetUpsert(true))
+		if err != nil {
+			db.log.Errorf("can not store log backfill %s progress; %s", name, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// LogBackfill returns the progress of the log backfill job of the given name;
+// the next block to be scanned and the last block the job scans.
+// The job has not been started yet if no progress is found.
+func (db *MongoDbBridge) LogBackfill(name string) (next uint64, end uint64, found bool, err error) {
+	// get the collection for cfg
+	col := db.client.Database(db.dbName).Collection(coConfiguration)
+
+	key := keyConfigLogBackfillPrefix + name
+	cursor, err := col.Find(context.Background(), bson.D{{Key: fiConfigPk, Value: bson.D{
+		{Key: "$in", Value: bson.A{key, key + "_end"}},
+	}}})
+	if err != nil {
+		db.log.Errorf("can not load log backfill %s progress; %s", name, err.Error())
+		return 0, 0, false, err
+	}
+
+	var rows []ConfigRow
+	if err := cursor.All(context.Background(), &rows); err != nil {
+		db.log.Errorf("can not decode log backfill %s progress; %s", name, err.Error())
+		return 0, 0, false, err
+	}
+
+	for _, row := range rows {
+		val, err := hexutil.DecodeUint64(row.Value)
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		switch row.Key {
+		case key:
+			next = val
+		case key + "_end":
+			end = val
+		}
+	}
+	return next, end, len(rows) == 2, nil
+}
+
 // LastKnownBlock returns the last known block from the database.
 func (db *MongoDbBridge) LastKnownBlock() (uint64, error) {
 	// get the collection for cfg
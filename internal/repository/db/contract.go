@@ -201,6 +201,35 @@ func (db *MongoDbBridge) Contract(addr *common.Address) (*types.Contract, error)
 	return &con, nil
 }
 
+// ContractsByAddress loads the smart contracts of the given addresses in a single query.
+// Contracts not found in the database are not included in the result map.
+func (db *MongoDbBridge) ContractsByAddress(ctx context.Context, addr []common.Address) (map[common.Address]*types.Contract, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+
+	ids := make(bson.A, len(addr))
+	for i := range addr {
+		ids[i] = addr[i].String()
+	}
+
+	cu, err := col.Find(ctx, bson.D{{Key: fiContractPk, Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		db.log.Errorf("can not load contracts; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cu)
+
+	list := make(map[common.Address]*types.Contract, len(addr))
+	for cu.Next(ctx) {
+		var con types.Contract
+		if err := cu.Decode(&con); err != nil {
+			db.log.Errorf("can not decode contract; %s", err.Error())
+			continue
+		}
+		list[con.Address] = &con
+	}
+	return list, nil
+}
+
 // ContractCount calculates total number of contracts in the database.
 func (db *MongoDbBridge) ContractCount() (uint64, error) {
 	return db.EstimateCount(db.client.Database(db.dbName).Collection(coContract))
@@ -0,0 +1,266 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colFLendActions represents the name of the fLend lending pool actions collection.
+const colFLendActions = "flend_actions"
+
+// fLendActionsIndexes provides a list of indexes expected to exist on the lending pool actions' collection.
+func fLendActionsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 3)
+
+	ixOrdinal := "ix_orx"
+	ix[0] = mongo.IndexModel{Keys: bson.D{{Key: types.FiFLendActionOrdinal, Value: -1}}, Options: &options.IndexOptions{Name: &ixOrdinal}}
+
+	ixUserOrdinal := "ix_usr_orx"
+	ix[1] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiFLendActionUser, Value: 1},
+		{Key: types.FiFLendActionOrdinal, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixUserOrdinal}}
+
+	ixTypeOrdinal := "ix_typ_orx"
+	ix[2] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiFLendActionType, Value: 1},
+		{Key: types.FiFLendActionOrdinal, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixTypeOrdinal}}
+
+	return ix
+}
+
+// AddFLendAction stores an fLend lending pool action in the database.
+func (db *MongoDbBridge) AddFLendAction(act *types.FLendAction) error {
+	col := db.client.Database(db.dbName).Collection(colFLendActions)
+
+	// the action is identified by the trx hash and log index, re-scan will just replace it
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiFLendActionId, Value: act.Pk()}},
+		act,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store fLend action %s; %s", act.TrxHash.String(), err.Error())
+	}
+	return err
+}
+
+// FLendActions pulls list of fLend lending pool actions starting at the specified cursor.
+func (db *MongoDbBridge) FLendActions(cursor *string, count int32, filter *bson.D) (*types.FLendActionList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero fLend actions requested")
+	}
+
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(colFLendActions)
+
+	// init the list
+	list, err := db.fLendActionListInit(col, cursor, count, filter)
+	if err != nil {
+		db.log.Errorf("can not build fLend action list; %s", err.Error())
+		return nil, err
+	}
+
+	// load data if there are any
+	if list.Total > 0 {
+		err = db.fLendActionListLoad(col, cursor, count, list)
+		if err != nil {
+			db.log.Errorf("can not load fLend action list from database; %s", err.Error())
+			return nil, err
+		}
+
+		// reverse on negative so new-er actions will be on top
+		if count < 0 {
+			list.Reverse()
+		}
+	}
+	return list, nil
+}
+
+// fLendActionListInit initializes list of lending pool actions based on provided cursor, count, and filter.
+func (db *MongoDbBridge) fLendActionListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.FLendActionList, error) {
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// find how many actions do we have in the database
+	total, err := col.CountDocuments(context.Background(), *filter)
+	if err != nil {
+		db.log.Errorf("can not count fLend actions")
+		return nil, err
+	}
+
+	// make the list and notify the size of it
+	db.log.Debugf("found %d filtered fLend actions", total)
+	list := types.FLendActionList{
+		Collection: make([]*types.FLendAction, 0),
+		Total:      uint64(total),
+		First:      0,
+		Last:       0,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+		Filter:     *filter,
+	}
+
+	// is the list non-empty? return the list with properly calculated range marks
+	if 0 < total {
+		return db.fLendActionListCollectRangeMarks(col, &list, cursor, count)
+	}
+	// this is an empty list
+	db.log.Debug("empty fLend action list created")
+	return &list, nil
+}
+
+// fLendActionListCollectRangeMarks finds range marks of a list of lending pool actions with proper First/Last marks.
+func (db *MongoDbBridge) fLendActionListCollectRangeMarks(col *mongo.Collection, list *types.FLendActionList, cursor *string, count int32) (*types.FLendActionList, error) {
+	var err error
+
+	// find out the cursor ordinal index
+	if cursor == nil && count > 0 {
+		// get the highest available pk
+		list.First, err = db.fLendActionListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{Key: types.FiFLendActionOrdinal, Value: -1}}))
+		list.IsStart = true
+
+	} else if cursor == nil && count < 0 {
+		// get the lowest available pk
+		list.First, err = db.fLendActionListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{Key: types.FiFLendActionOrdinal, Value: 1}}))
+		list.IsEnd = true
+
+	} else if cursor != nil {
+		// the cursor itself is the starting point
+		list.First, err = db.fLendActionListBorderPk(col,
+			bson.D{{Key: types.FiFLendActionId, Value: *cursor}},
+			options.FindOne())
+	}
+
+	// check the error
+	if err != nil {
+		db.log.Errorf("can not find the initial fLend action")
+		return nil, err
+	}
+
+	// inform what we are about to do
+	db.log.Debugf("fLend action list initialized with ordinal %d", list.First)
+	return list, nil
+}
+
+// fLendActionListBorderPk finds the top PK of the lending pool actions collection based on given filter and options.
+func (db *MongoDbBridge) fLendActionListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+	// prep container
+	var row struct {
+		Value uint64 `bson:"orx"`
+	}
+
+	// make sure we pull only what we need
+	opt.SetProjection(bson.D{{Key: types.FiFLendActionOrdinal, Value: true}})
+
+	// try to decode
+	sr := col.FindOne(context.Background(), filter, opt)
+	err := sr.Decode(&row)
+	if err != nil {
+		return 0, err
+	}
+	return row.Value, nil
+}
+
+// fLendActionListFilter creates a filter for lending pool actions list loading.
+func (db *MongoDbBridge) fLendActionListFilter(cursor *string, count int32, list *types.FLendActionList) *bson.D {
+	// build an extended filter for the query; add PK (decoded cursor) to the original filter
+	if cursor == nil {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiFLendActionOrdinal, Value: bson.D{{Key: "$lte", Value: list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiFLendActionOrdinal, Value: bson.D{{Key: "$gte", Value: list.First}}})
+		}
+	} else {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiFLendActionOrdinal, Value: bson.D{{Key: "$lt", Value: list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiFLendActionOrdinal, Value: bson.D{{Key: "$gt", Value: list.First}}})
+		}
+	}
+	// return the new filter
+	return &list.Filter
+}
+
+// fLendActionListOptions creates a filter options set for lending pool actions list search.
+func (db *MongoDbBridge) fLendActionListOptions(count int32) *options.FindOptions {
+	// prep options
+	opt := options.Find()
+
+	// how to sort results in the collection
+	// from high (new) to low (old) by default; reversed if loading from bottom
+	sd := -1
+	if count < 0 {
+		sd = 1
+	}
+
+	// sort with the direction we want
+	opt.SetSort(bson.D{{Key: types.FiFLendActionOrdinal, Value: sd}})
+
+	// prep the loading limit
+	var limit = int64(count)
+	if limit < 0 {
+		limit = -limit
+	}
+
+	// apply the limit, try to get one more record, so we can detect list end
+	opt.SetLimit(limit + 1)
+	return opt
+}
+
+// fLendActionListLoad load the initialized list of lending pool actions from database.
+func (db *MongoDbBridge) fLendActionListLoad(col *mongo.Collection, cursor *string, count int32, list *types.FLendActionList) (err error) {
+	ctx := context.Background()
+
+	// load the data
+	ld, err := col.Find(ctx, db.fLendActionListFilter(cursor, count, list), db.fLendActionListOptions(count))
+	if err != nil {
+		db.log.Errorf("error loading fLend actions list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer db.closeCursor(ld)
+
+	// loop and load the list; we may not store the last value
+	var act *types.FLendAction
+	for ld.Next(ctx) {
+		// append a previous value to the list, if we have one
+		if act != nil {
+			list.Collection = append(list.Collection, act)
+		}
+
+		// try to decode the next row
+		var row types.FLendAction
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the fLend action list row; %s", err.Error())
+			return err
+		}
+
+		// use this row as the next item
+		act = &row
+	}
+
+	// we should have all the items already; we may just need to check if a boundary was reached
+	list.IsEnd = (cursor == nil && count < 0) || (count > 0 && int32(len(list.Collection)) < count)
+	list.IsStart = (cursor == nil && count > 0) || (count < 0 && int32(len(list.Collection)) < -count)
+
+	// add the last item as well if we hit the boundary
+	if (list.IsStart || list.IsEnd) && act != nil {
+		list.Collection = append(list.Collection, act)
+	}
+	return nil
+}
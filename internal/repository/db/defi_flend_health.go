@@ -0,0 +1,201 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colFLendPositions represents the name of the fLend positions collection.
+	colFLendPositions = "flend_positions"
+
+	// colFLendHealth represents the name of the fLend health factor time series collection.
+	colFLendHealth = "flend_health"
+
+	// fLendHealthSampling represents the sampling period of the health factor time series.
+	// Only the latest value of each period is kept.
+	fLendHealthSampling = 10 * time.Minute
+)
+
+// fLendPositionsIndexes provides a list of indexes expected to exist on the fLend positions' collection.
+func fLendPositionsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixHealth := "ix_dbt_hf"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiFLendPositionHasDebt, Value: 1},
+		{Key: types.FiFLendPositionHealthFactor, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixHealth}}
+
+	return ix
+}
+
+// fLendHealthIndexes provides a list of indexes expected to exist on the fLend health factor collection.
+func fLendHealthIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixUserDate := "ix_usr_date"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiFLendHealthUser, Value: 1},
+		{Key: types.FiFLendHealthTimeStamp, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixUserDate}}
+
+	return ix
+}
+
+// StoreFLendPosition stores the latest state of the given fLend position
+// and updates the health factor time series of the position owner.
+func (db *MongoDbBridge) StoreFLendPosition(pos *types.FLendPosition) error {
+	col := db.client.Database(db.dbName).Collection(colFLendPositions)
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiFLendPositionUser, Value: pos.User.String()}},
+		pos,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store fLend position of %s; %s", pos.User.String(), err.Error())
+		return err
+	}
+
+	// the time series keeps one sample per period
+	ts := pos.Updated.Truncate(fLendHealthSampling)
+	col = db.client.Database(db.dbName).Collection(colFLendHealth)
+	_, err = col.UpdateOne(context.Background(),
+		bson.D{{Key: "_id", Value: fmt.Sprintf("%s%08x", pos.User.String(), ts.Unix())}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: types.FiFLendHealthUser, Value: pos.User.String()},
+				{Key: types.FiFLendHealthTimeStamp, Value: ts},
+				{Key: "hf", Value: pos.HealthFactorValue()},
+			}},
+			{Key: "$min", Value: bson.D{{Key: "low", Value: pos.HealthFactorValue()}}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store fLend health of %s; %s", pos.User.String(), err.Error())
+	}
+	return err
+}
+
+// FLendPosition loads the latest known state of the fLend position of the given user.
+func (db *MongoDbBridge) FLendPosition(user *common.Address) (*types.FLendPosition, error) {
+	col := db.client.Database(db.dbName).Collection(colFLendPositions)
+
+	sr := col.FindOne(context.Background(), bson.D{{Key: types.FiFLendPositionUser, Value: user.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("could not load fLend position of %s; %s", user.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var pos types.FLendPosition
+	if err := sr.Decode(&pos); err != nil {
+		db.log.Errorf("could not decode fLend position of %s; %s", user.String(), err.Error())
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// FLendAtRiskPositions loads fLend positions with debt and the health factor below the given threshold,
+// the riskiest positions first.
+func (db *MongoDbBridge) FLendAtRiskPositions(threshold float64, count int32) ([]*types.FLendPosition, error) {
+	col := db.client.Database(db.dbName).Collection(colFLendPositions)
+
+	cr, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiFLendPositionHasDebt, Value: true},
+		{Key: types.FiFLendPositionHealthFactor, Value: bson.D{{Key: "$lt", Value: threshold}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiFLendPositionHealthFactor, Value: 1}}).SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load fLend positions at risk; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.FLendPosition, 0)
+	for cr.Next(context.Background()) {
+		var row types.FLendPosition
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fLend position; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// FLendHealthHistory resolves the health factor time series of the given user grouped by date interval.
+// If toTime is 0, then it calculates the series till now.
+func (db *MongoDbBridge) FLendHealthHistory(user *common.Address, resolution string, fromTime int64, toTime int64) ([]types.FLendHealthPoint, error) {
+	// create query pipeline
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiFLendHealthUser, Value: user.String()},
+			{Key: types.FiFLendHealthTimeStamp, Value: getDateBsonD(fromTime, toTime)},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiFLendHealthTimeStamp, Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: getGroupBsonD(resolution)},
+			{Key: "hf", Value: bson.D{{Key: "$last", Value: "$hf"}}},
+			{Key: "low", Value: bson.D{{Key: "$min", Value: "$low"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	// execute query
+	col := db.client.Database(db.dbName).Collection(colFLendHealth)
+	cr, err := col.Aggregate(context.Background(), pipe)
+	if err != nil {
+		db.log.Errorf("can not aggregate fLend health history; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	// iterate thru results and construct data
+	list := make([]types.FLendHealthPoint, 0)
+	for cr.Next(context.Background()) {
+		var row types.FLendHealthPoint
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fLend health point; %s", err.Error())
+			continue
+		}
+		list = append(list, row)
+	}
+	return list, nil
+}
+
+// FLendUsers loads the list of all the users who ever opened a position on the fLend lending pool,
+// optionally limited to positions in the given asset.
+func (db *MongoDbBridge) FLendUsers(asset *common.Address) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(colFLendActions)
+
+	fi := bson.D{
+		{Key: types.FiFLendActionType, Value: bson.D{{Key: "$in", Value: bson.A{types.FLendActionDeposit, types.FLendActionBorrow}}}},
+	}
+	if asset != nil {
+		fi = append(fi, bson.E{Key: types.FiFLendActionAsset, Value: asset.String()})
+	}
+
+	res, err := col.Distinct(context.Background(), types.FiFLendActionUser, fi)
+	if err != nil {
+		db.log.Errorf("can not load fLend users; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]common.Address, 0, len(res))
+	for _, u := range res {
+		if s, ok := u.(string); ok {
+			list = append(list, common.HexToAddress(s))
+		}
+	}
+	return list, nil
+}
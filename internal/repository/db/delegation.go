@@ -12,7 +12,6 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"math/big"
-	"time"
 )
 
 // colDelegations represents the name of the delegations collection
@@ -121,8 +120,8 @@ func (db *MongoDbBridge) UpdateDelegation(dl *types.Delegation) error {
 		{Key: types.FiDelegationToValidator, Value: dl.ToStakerId.String()},
 	}, bson.D{{Key: "$set", Value: bson.D{
 		{Key: types.FiDelegationOrdinal, Value: dl.OrdinalIndex()},
-		{Key: types.FiDelegationStamp, Value: time.Unix(int64(dl.CreatedTime), 0)},
-		{Key: types.FiDelegationTransaction, Value: dl.Transaction.String()},
+		{Key: types.FiDelegationStamp, Value: dl.BsonStamp()},
+		{Key: types.FiDelegationTransaction, Value: dl.BsonTransaction()},
 		{Key: types.FiDelegationToValidatorAddress, Value: dl.ToStakerAddress.String()},
 		{Key: types.FiDelegationAmountActive, Value: dl.AmountDelegated.String()},
 		{Key: types.FiDelegationValue, Value: val},
@@ -473,3 +472,22 @@ func (db *MongoDbBridge) DelegationsAll(filter *bson.D) ([]*types.Delegation, er
 	}
 	return list, nil
 }
+
+// Delegators pulls the list of addresses of all the known delegators.
+func (db *MongoDbBridge) Delegators() ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(colDelegations)
+
+	res, err := col.Distinct(context.Background(), types.FiDelegationAddress, bson.D{})
+	if err != nil {
+		db.log.Errorf("can not load delegators; %s", err.Error())
+		return nil, err
+	}
+
+	list := make([]common.Address, 0, len(res))
+	for _, adr := range res {
+		if s, ok := adr.(string); ok {
+			list = append(list, common.HexToAddress(s))
+		}
+	}
+	return list, nil
+}
@@ -13,6 +13,9 @@ import (
 // colLockedDelegations represents the name of the delegations lock collection
 const colLockedDelegations = "locked_dlg"
 
+// colDelegationUnlocks represents the name of the delegations unlock collection
+const colDelegationUnlocks = "dlg_unlocks"
+
 // lockedDelegationsIndexes provides a list of indexes expected to exist on the locked delegations' collection.
 func lockedDelegationsIndexes() []mongo.IndexModel {
 	ix := make([]mongo.IndexModel, 1)
@@ -27,6 +30,18 @@ func lockedDelegationsIndexes() []mongo.IndexModel {
 	return ix
 }
 
+// delegationUnlocksIndexes provides a list of indexes expected to exist on the delegation unlocks' collection.
+func delegationUnlocksIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixDelegatorWhen := "ix_from_when"
+	ix[0] = mongo.IndexModel{Keys: bson.D{{Key: "from", Value: 1}, {Key: "when", Value: 1}}, Options: &options.IndexOptions{
+		Name: &ixDelegatorWhen,
+	}}
+
+	return ix
+}
+
 // StoreLockedDelegation stores the given locked delegation into the database.
 func (db *MongoDbBridge) StoreLockedDelegation(dl *types.LockedDelegation) error {
 	col := db.client.Database(db.dbName).Collection(colLockedDelegations)
@@ -102,3 +117,74 @@ func (db *MongoDbBridge) AdjustLockedDelegation(dlg common.Address, validatorID
 
 	return nil
 }
+
+// LockedDelegation loads the locked delegation of the given delegator to the given validator, if any.
+func (db *MongoDbBridge) LockedDelegation(dlg common.Address, validatorID int64) (*types.LockedDelegation, error) {
+	col := db.client.Database(db.dbName).Collection(colLockedDelegations)
+
+	sr := col.FindOne(context.Background(), bson.D{
+		{Key: "from", Value: dlg},
+		{Key: "to", Value: validatorID},
+	})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("could not load locked delegation; %s", sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var ld types.LockedDelegation
+	if err := sr.Decode(&ld); err != nil {
+		db.log.Errorf("could not decode locked delegation; %s", err.Error())
+		return nil, err
+	}
+	return &ld, nil
+}
+
+// ReplaceLockedDelegation overwrites the state of the given locked delegation in the database.
+func (db *MongoDbBridge) ReplaceLockedDelegation(dl *types.LockedDelegation) error {
+	col := db.client.Database(db.dbName).Collection(colLockedDelegations)
+
+	_, err := col.UpdateOne(
+		context.Background(),
+		bson.D{
+			{Key: "from", Value: dl.Delegator},
+			{Key: "to", Value: dl.ValidatorId},
+		},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "locked", Value: dl.Locked},
+				{Key: "duration", Value: dl.Duration},
+				{Key: "expires", Value: dl.LockedUntil},
+				{Key: "value", Value: dl.Value},
+			}},
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: "from", Value: dl.Delegator},
+				{Key: "to", Value: dl.ValidatorId},
+				{Key: "created", Value: dl.Locked},
+			}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not replace locked delegation; %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// AddDelegationUnlock stores the given delegation unlock into the database.
+func (db *MongoDbBridge) AddDelegationUnlock(du *types.DelegationUnlock) error {
+	col := db.client.Database(db.dbName).Collection(colDelegationUnlocks)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: "_id", Value: du.Pk()}},
+		du,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store delegation unlock; %s", err.Error())
+	}
+	return err
+}
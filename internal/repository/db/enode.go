@@ -32,6 +32,9 @@ func operaNodeCollectionIndexes() []mongo.IndexModel {
 	ixNodeSeen := "ix_node_seen"
 	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: "seen_last", Value: -1}}, Options: &options.IndexOptions{Name: &ixNodeSeen}}
 
+	ixNodeSeenId := "ix_node_seen_id"
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: "seen_last", Value: -1}, {Key: "_id", Value: 1}}, Options: &options.IndexOptions{Name: &ixNodeSeenId}})
+
 	ixNodeChecked := "ix_node_checked"
 	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: "checked", Value: 1}}, Options: &options.IndexOptions{Name: &ixNodeChecked}}
 
@@ -108,7 +111,8 @@ func (db *MongoDbBridge) IsNetworkNodeKnown(id enode.ID) bool {
 }
 
 // NetworkNodeConfirmCheck confirms successful check of the given Opera network node.
-func (db *MongoDbBridge) NetworkNodeConfirmCheck(id enode.ID, inf *types.OperaNodeInformation) error {
+// The geographic location of the node is updated, if provided.
+func (db *MongoDbBridge) NetworkNodeConfirmCheck(id enode.ID, inf *types.OperaNodeInformation, loc *types.GeoLocation) error {
 	col := db.client.Database(db.dbName).Collection(colNetworkNodes)
 
 	// prep update set
@@ -123,6 +127,9 @@ func (db *MongoDbBridge) NetworkNodeConfirmCheck(id enode.ID, inf *types.OperaNo
 	if inf != nil {
 		set = append(set, bson.E{Key: "info", Value: inf})
 	}
+	if loc != nil {
+		set = append(set, bson.E{Key: "location", Value: loc})
+	}
 
 	ur, err := col.UpdateByID(context.Background(), id, bson.D{
 		{Key: "$set", Value: set},
@@ -317,3 +324,147 @@ func (db *MongoDbBridge) NetworkNodesGeoAggregated(level int) ([]*types.OperaNod
 
 	return list, nil
 }
+
+// NetworkNodes loads a page of the Opera network nodes matching the filter, the most recently seen first.
+// The cursor is the position of the last seen node, if any; negative count loads nodes
+// preceding the cursor.
+func (db *MongoDbBridge) NetworkNodes(fi *types.OperaNodeFilter, cursor *types.OperaNodeListCursor, count int64) (*types.OperaNodeList, error) {
+	col := db.client.Database(db.dbName).Collection(colNetworkNodes)
+	filter := networkNodesFilter(fi)
+
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count network nodes; %s", err.Error())
+		return nil, err
+	}
+
+	// sort from the most recently seen by default; reversed if loading backwards
+	sd, seenOp, idOp, limit := -1, "$lt", "$gt", count
+	if count < 0 {
+		sd, seenOp, idOp, limit = 1, "$gt", "$lt", -limit
+	}
+	if cursor != nil {
+		filter = append(filter, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "seen_last", Value: bson.D{{Key: seenOp, Value: cursor.LastSeen}}}},
+			bson.D{{Key: "seen_last", Value: cursor.LastSeen}, {Key: "_id", Value: bson.D{{Key: idOp, Value: cursor.Id}}}},
+		}})
+	}
+
+	// try to get one more record, so we can detect the list border
+	cu, err := col.Find(context.Background(), filter, options.Find().
+		SetSort(bson.D{{Key: "seen_last", Value: sd}, {Key: "_id", Value: -sd}}).
+		SetLimit(limit+1))
+	if err != nil {
+		db.log.Errorf("can not load network nodes; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cu)
+
+	list := types.OperaNodeList{
+		Collection: make([]*types.OperaNode, 0, limit),
+		Total:      uint64(total),
+	}
+	for cu.Next(context.Background()) {
+		var node types.OperaNode
+		if err := cu.Decode(&node); err != nil {
+			db.log.Errorf("could not decode network node; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &node)
+	}
+
+	more := int64(len(list.Collection)) > limit
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+		return &list, nil
+	}
+
+	// reverse on negative so the most recently seen nodes will be on top
+	for i, j := 0, len(list.Collection)-1; i < j; i, j = i+1, j-1 {
+		list.Collection[i], list.Collection[j] = list.Collection[j], list.Collection[i]
+	}
+	list.IsStart, list.IsEnd = !more, cursor == nil
+	return &list, nil
+}
+
+// networkNodesFilter builds the database filter of the Opera network nodes list.
+func networkNodesFilter(fi *types.OperaNodeFilter) bson.D {
+	filter := bson.D{}
+	if fi == nil {
+		return filter
+	}
+
+	if fi.IsOpera != nil {
+		filter = append(filter, bson.E{Key: "info.is_opera", Value: *fi.IsOpera})
+	}
+	if fi.IsSynced != nil {
+		filter = append(filter, bson.E{Key: "info.synced", Value: *fi.IsSynced})
+	}
+	if fi.IsResponsive != nil {
+		if *fi.IsResponsive {
+			filter = append(filter, bson.E{Key: "fails", Value: 0})
+		} else {
+			filter = append(filter, bson.E{Key: "fails", Value: bson.D{{Key: "$gt", Value: 0}}})
+		}
+	}
+	if fi.Client != nil {
+		filter = append(filter, bson.E{Key: "info.client", Value: *fi.Client})
+	}
+	if fi.ClientVersion != nil {
+		filter = append(filter, bson.E{Key: "info.client_ver", Value: *fi.ClientVersion})
+	}
+	if fi.Country != nil {
+		filter = append(filter, bson.E{Key: "location.country", Value: *fi.Country})
+	}
+	return filter
+}
+
+// NetworkNodesVersionAggregated provides a list of active Opera nodes aggregated by their client version.
+func (db *MongoDbBridge) NetworkNodesVersionAggregated() ([]*types.OperaNodeVersionAggregate, error) {
+	col := db.client.Database(db.dbName).Collection(colNetworkNodes)
+
+	cu, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "fails", Value: 0},
+			{Key: "info.is_opera", Value: true},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "client", Value: "$info.client"},
+				{Key: "ver", Value: "$info.client_ver"},
+			}},
+			{Key: "client", Value: bson.D{{Key: "$first", Value: "$info.client"}}},
+			{Key: "client_ver", Value: bson.D{{Key: "$first", Value: "$info.client_ver"}}},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "synced", Value: bson.D{{Key: "$sum", Value: bson.D{
+				{Key: "$cond", Value: bson.A{"$info.synced", 1, 0}},
+			}}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "total", Value: -1},
+			{Key: "client_ver", Value: -1},
+		}}},
+	})
+	if err != nil {
+		db.log.Criticalf("can not aggregate network nodes by version; %s", err.Error())
+		return nil, err
+	}
+
+	defer db.closeCursor(cu)
+
+	list := make([]*types.OperaNodeVersionAggregate, 0)
+	for cu.Next(context.Background()) {
+		var ag types.OperaNodeVersionAggregate
+		if err := cu.Decode(&ag); err != nil {
+			db.log.Errorf("could not decode network node aggregate; %s", err.Error())
+			continue
+		}
+		list = append(list, &ag)
+	}
+
+	return list, nil
+}
@@ -91,6 +91,27 @@ func (db *MongoDbBridge) isEpochKnown(col *mongo.Collection, e *types.Epoch) boo
 	return false
 }
 
+// StoredEpoch loads the epoch of the given id from the database; nil is returned if the epoch is not known.
+func (db *MongoDbBridge) StoredEpoch(id uint64) (*types.Epoch, error) {
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	sr := col.FindOne(context.Background(), bson.D{{Key: fiEpochPk, Value: int64(id)}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load epoch #%d; %s", id, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var ep types.Epoch
+	if err := sr.Decode(&ep); err != nil {
+		db.log.Errorf("can not decode epoch #%d; %s", id, err.Error())
+		return nil, err
+	}
+	return &ep, nil
+}
+
 // LastKnownEpoch provides the number of the newest epoch stored in the database.
 func (db *MongoDbBridge) LastKnownEpoch() (uint64, error) {
 	return db.epochListBorderPk(db.client.Database(db.dbName).Collection(colEpochs), options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
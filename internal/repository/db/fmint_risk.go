@@ -0,0 +1,84 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colFMintRisk represents the name of the fMint account risk snapshots collection.
+const colFMintRisk = "fmint_risk"
+
+// fMintRiskIndexes provides a list of indexes expected to exist on the fMint account risk collection.
+func fMintRiskIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixUserDate := "ix_usr_date"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiFMintRiskUser, Value: 1},
+		{Key: types.FiFMintRiskTimeStamp, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixUserDate}}
+
+	return ix
+}
+
+// AddFMintAccountRisk stores an fMint account risk snapshot in the database.
+func (db *MongoDbBridge) AddFMintAccountRisk(risk *types.FMintAccountRisk) error {
+	col := db.client.Database(db.dbName).Collection(colFMintRisk)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: "_id", Value: risk.Pk()}},
+		risk,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store fMint risk of %s; %s", risk.User.String(), err.Error())
+	}
+	return err
+}
+
+// FMintRiskHistory resolves the collateral to debt ratio time series of the given fMint account
+// grouped by date interval. The last snapshot of each interval is used.
+// If toTime is 0, then it calculates the series till now.
+func (db *MongoDbBridge) FMintRiskHistory(user *common.Address, resolution string, fromTime int64, toTime int64) ([]types.FMintRiskPoint, error) {
+	// create query pipeline
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiFMintRiskUser, Value: user.String()},
+			{Key: types.FiFMintRiskTimeStamp, Value: getDateBsonD(fromTime, toTime)},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: types.FiFMintRiskTimeStamp, Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: getGroupBsonD(resolution)},
+			{Key: types.FiFMintRiskColValue, Value: bson.D{{Key: "$last", Value: "$" + types.FiFMintRiskColValue}}},
+			{Key: types.FiFMintRiskDebtValue, Value: bson.D{{Key: "$last", Value: "$" + types.FiFMintRiskDebtValue}}},
+			{Key: types.FiFMintRiskRatio, Value: bson.D{{Key: "$last", Value: "$" + types.FiFMintRiskRatio}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	// execute query
+	col := db.client.Database(db.dbName).Collection(colFMintRisk)
+	cr, err := col.Aggregate(context.Background(), pipe)
+	if err != nil {
+		db.log.Errorf("can not aggregate fMint risk history; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	// iterate thru results and construct data
+	list := make([]types.FMintRiskPoint, 0)
+	for cr.Next(context.Background()) {
+		var row types.FMintRiskPoint
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode fMint risk point; %s", err.Error())
+			continue
+		}
+		list = append(list, row)
+	}
+	return list, nil
+}
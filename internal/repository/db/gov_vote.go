@@ -0,0 +1,271 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+	"time"
+)
+
+const (
+	// colGovernanceVotes represents the name of the governance votes collection.
+	colGovernanceVotes = "gov_votes"
+
+	// colGovernanceProposalEvents represents the name of the governance proposal events collection.
+	colGovernanceProposalEvents = "gov_proposal_events"
+)
+
+// governanceVotesIndexes provides a list of indexes expected to exist on the governance votes' collection.
+func governanceVotesIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 2)
+
+	ixProposal := "ix_gov_prop_orx"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiGovernanceVoteGovernance, Value: 1},
+		{Key: types.FiGovernanceVoteProposal, Value: 1},
+		{Key: types.FiGovernanceVoteOrdinal, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixProposal}}
+
+	ixVoter := "ix_voter_orx"
+	ix[1] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiGovernanceVoteVoter, Value: 1},
+		{Key: types.FiGovernanceVoteOrdinal, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixVoter}}
+
+	return ix
+}
+
+// governanceProposalEventsIndexes provides a list of indexes expected to exist on the governance proposal events' collection.
+func governanceProposalEventsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixProposal := "ix_gov_prop_orx"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiGovernanceProposalEventGovernance, Value: 1},
+		{Key: types.FiGovernanceProposalEventProposal, Value: 1},
+		{Key: types.FiGovernanceProposalEventOrdinal, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixProposal}}
+
+	return ix
+}
+
+// StoreGovernanceVote stores, or replaces the given governance vote in the database.
+// The stored vote is not replaced if it has been canceled, or its weight changed, by a newer event;
+// this happens when older blocks are re-scanned.
+func (db *MongoDbBridge) StoreGovernanceVote(gv *types.GovernanceVoteRecord) error {
+	col := db.client.Database(db.dbName).Collection(colGovernanceVotes)
+
+	_, err := col.ReplaceOne(context.Background(), bson.D{
+		{Key: "_id", Value: gv.Pk()},
+		{Key: "$nor", Value: bson.A{
+			bson.D{{Key: types.FiGovernanceVoteCanceledOrdinal, Value: bson.D{{Key: "$gt", Value: gv.Ordinal}}}},
+			bson.D{{Key: types.FiGovernanceVoteWeightOrdinal, Value: bson.D{{Key: "$gt", Value: gv.Ordinal}}}},
+		}},
+	}, gv, options.Replace().SetUpsert(true))
+	if err != nil {
+		// the upsert collides with the newer state of the vote
+		if mongo.IsDuplicateKeyError(err) {
+			db.log.Debugf("governance vote %s has newer state", gv.Pk())
+			return nil
+		}
+		db.log.Errorf("could not store governance vote %s; %s", gv.Pk(), err.Error())
+	}
+	return err
+}
+
+// CancelGovernanceVote marks the governance vote canceled by the event of the given ordinal index.
+// Votes placed by a newer event are not affected.
+func (db *MongoDbBridge) CancelGovernanceVote(gov common.Address, propId *hexutil.Big, voter common.Address, delegatedTo common.Address, ts time.Time, ordinal int64) error {
+	col := db.client.Database(db.dbName).Collection(colGovernanceVotes)
+
+	id := types.GovernanceVoteId(gov, propId, voter, delegatedTo)
+	res, err := col.UpdateOne(context.Background(), bson.D{
+		{Key: "_id", Value: id},
+		{Key: types.FiGovernanceVoteOrdinal, Value: bson.D{{Key: "$lt", Value: ordinal}}},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: types.FiGovernanceVoteCanceled, Value: ts},
+		{Key: types.FiGovernanceVoteCanceledOrdinal, Value: ordinal},
+	}}})
+	if err != nil {
+		db.log.Errorf("could not cancel governance vote %s; %s", id, err.Error())
+		return err
+	}
+	if res.MatchedCount == 0 {
+		db.log.Warningf("canceled governance vote %s not found", id)
+	}
+	return nil
+}
+
+// SetGovernanceVoteWeight updates the weight of the governance vote of the voter's own delegation
+// as changed by the event of the given ordinal index. Votes changed by a newer event are not affected.
+func (db *MongoDbBridge) SetGovernanceVoteWeight(gov common.Address, propId *hexutil.Big, voter common.Address, weight *hexutil.Big, ordinal int64) error {
+	col := db.client.Database(db.dbName).Collection(colGovernanceVotes)
+
+	id := types.GovernanceVoteId(gov, propId, voter, voter)
+	res, err := col.UpdateOne(context.Background(), bson.D{
+		{Key: "_id", Value: id},
+		{Key: types.FiGovernanceVoteOrdinal, Value: bson.D{{Key: "$lt", Value: ordinal}}},
+		{Key: types.FiGovernanceVoteWeightOrdinal, Value: bson.D{{Key: "$not", Value: bson.D{{Key: "$gt", Value: ordinal}}}}},
+	}, bson.D{{Key: "$set", Value: bson.D{
+		{Key: types.FiGovernanceVoteWeight, Value: weight.String()},
+		{Key: types.FiGovernanceVoteValue, Value: new(big.Int).Div(weight.ToInt(), types.DelegationDecimalsCorrection).Int64()},
+		{Key: types.FiGovernanceVoteWeightOrdinal, Value: ordinal},
+	}}})
+	if err != nil {
+		db.log.Errorf("could not update governance vote %s weight; %s", id, err.Error())
+		return err
+	}
+	if res.MatchedCount == 0 {
+		db.log.Warningf("governance vote %s with weight changed not found", id)
+	}
+	return nil
+}
+
+// StoreGovernanceProposalEvent stores the given governance proposal event in the database.
+func (db *MongoDbBridge) StoreGovernanceProposalEvent(ge *types.GovernanceProposalEvent) error {
+	col := db.client.Database(db.dbName).Collection(colGovernanceProposalEvents)
+
+	_, err := col.ReplaceOne(context.Background(), bson.D{{Key: "_id", Value: ge.Id}}, ge, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("could not store governance proposal event %s; %s", ge.Id, err.Error())
+	}
+	return err
+}
+
+// GovernanceProposalEvents loads the life cycle events of the given governance proposal, the oldest first.
+func (db *MongoDbBridge) GovernanceProposalEvents(gov common.Address, propId *hexutil.Big) ([]*types.GovernanceProposalEvent, error) {
+	col := db.client.Database(db.dbName).Collection(colGovernanceProposalEvents)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiGovernanceProposalEventGovernance, Value: gov.String()},
+		{Key: types.FiGovernanceProposalEventProposal, Value: propId.String()},
+	}, options.Find().SetSort(bson.D{{Key: types.FiGovernanceProposalEventOrdinal, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load governance proposal events; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.GovernanceProposalEvent, 0)
+	for cur.Next(context.Background()) {
+		var ge types.GovernanceProposalEvent
+		if err := cur.Decode(&ge); err != nil {
+			db.log.Errorf("can not decode governance proposal event; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &ge)
+	}
+	return list, nil
+}
+
+// GovernanceProposalVotes loads a list of votes placed on the given governance proposal.
+func (db *MongoDbBridge) GovernanceProposalVotes(gov common.Address, propId *hexutil.Big, cursor *int64, count int32) (*types.GovernanceVoteList, error) {
+	return db.governanceVotes(bson.D{
+		{Key: types.FiGovernanceVoteGovernance, Value: gov.String()},
+		{Key: types.FiGovernanceVoteProposal, Value: propId.String()},
+		{Key: types.FiGovernanceVoteCanceled, Value: bson.D{{Key: "$type", Value: 10}}},
+	}, cursor, count)
+}
+
+// GovernanceProposalVotesAll loads all the votes placed on the given governance proposal.
+func (db *MongoDbBridge) GovernanceProposalVotesAll(gov common.Address, propId *hexutil.Big) ([]*types.GovernanceVoteRecord, error) {
+	col := db.client.Database(db.dbName).Collection(colGovernanceVotes)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiGovernanceVoteGovernance, Value: gov.String()},
+		{Key: types.FiGovernanceVoteProposal, Value: propId.String()},
+		{Key: types.FiGovernanceVoteCanceled, Value: bson.D{{Key: "$type", Value: 10}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not load governance proposal votes; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.GovernanceVoteRecord, 0)
+	for cur.Next(context.Background()) {
+		var gv types.GovernanceVoteRecord
+		if err := cur.Decode(&gv); err != nil {
+			db.log.Errorf("can not decode governance vote; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &gv)
+	}
+	return list, nil
+}
+
+// GovernanceVotesBy loads a list of votes placed by the given voter across all governance contracts.
+func (db *MongoDbBridge) GovernanceVotesBy(voter common.Address, cursor *int64, count int32) (*types.GovernanceVoteList, error) {
+	return db.governanceVotes(bson.D{
+		{Key: types.FiGovernanceVoteVoter, Value: voter.String()},
+		{Key: types.FiGovernanceVoteCanceled, Value: bson.D{{Key: "$type", Value: 10}}},
+	}, cursor, count)
+}
+
+// governanceVotes loads a list of governance votes for the given filter, the latest first.
+// The cursor is the ordinal index of the last seen vote; negative count loads votes
+// preceding the cursor.
+func (db *MongoDbBridge) governanceVotes(filter bson.D, cursor *int64, count int32) (*types.GovernanceVoteList, error) {
+	col := db.client.Database(db.dbName).Collection(colGovernanceVotes)
+
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count governance votes; %s", err.Error())
+		return nil, err
+	}
+
+	// sort from the latest by default; reversed if loading backwards
+	sd, op, limit := -1, "$lt", int64(count)
+	if count < 0 {
+		sd, op, limit = 1, "$gt", -limit
+	}
+	if cursor != nil {
+		filter = append(filter, bson.E{Key: types.FiGovernanceVoteOrdinal, Value: bson.D{{Key: op, Value: *cursor}}})
+	}
+
+	// try to get one more record, so we can detect the list border
+	cur, err := col.Find(context.Background(), filter, options.Find().
+		SetSort(bson.D{{Key: types.FiGovernanceVoteOrdinal, Value: sd}}).
+		SetLimit(limit+1))
+	if err != nil {
+		db.log.Errorf("can not load governance votes; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := types.GovernanceVoteList{
+		Collection: make([]*types.GovernanceVoteRecord, 0, limit),
+		Total:      uint64(total),
+	}
+	for cur.Next(context.Background()) {
+		var gv types.GovernanceVoteRecord
+		if err := cur.Decode(&gv); err != nil {
+			db.log.Errorf("can not decode governance vote; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &gv)
+	}
+
+	more := int64(len(list.Collection)) > limit
+	if more {
+		list.Collection = list.Collection[:limit]
+	}
+
+	if count > 0 {
+		list.IsStart, list.IsEnd = cursor == nil, !more
+		return &list, nil
+	}
+
+	// reverse on negative so the latest votes will be on top
+	for i, j := 0, len(list.Collection)-1; i < j; i, j = i+1, j-1 {
+		list.Collection[i], list.Collection[j] = list.Collection[j], list.Collection[i]
+	}
+	list.IsStart, list.IsEnd = !more, cursor == nil
+	return &list, nil
+}
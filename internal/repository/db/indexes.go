@@ -23,8 +23,22 @@ type IndexList struct {
 func (db *MongoDbBridge) updateDatabaseIndexes() {
 	// define index list loaders
 	var ixLoaders = map[string]indexListProvider{
-		colNetworkNodes:      operaNodeCollectionIndexes,
-		colLockedDelegations: lockedDelegationsIndexes,
+		colNetworkNodes:             operaNodeCollectionIndexes,
+		colLockedDelegations:        lockedDelegationsIndexes,
+		colUniswapV3Actions:         uniswapV3ActionsIndexes,
+		colUniswapV3Pools:           uniswapV3PoolsIndexes,
+		colUniswapV3Positions:       uniswapV3PositionsIndexes,
+		colFMintRisk:                fMintRiskIndexes,
+		colFLendActions:             fLendActionsIndexes,
+		colFLendPositions:           fLendPositionsIndexes,
+		colFLendHealth:              fLendHealthIndexes,
+		colValidatorEpochs:          validatorEpochsIndexes,
+		colStakeFixes:               stakeFixesIndexes,
+		colPriceHistory:             priceHistoryIndexes,
+		colDelegationUnlocks:        delegationUnlocksIndexes,
+		colValidatorAlerts:          validatorAlertsIndexes,
+		colGovernanceVotes:          governanceVotesIndexes,
+		colGovernanceProposalEvents: governanceProposalEventsIndexes,
 	}
 
 	// the DB bridge needs a way to terminate this thread
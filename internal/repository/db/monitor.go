@@ -0,0 +1,78 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+)
+
+var (
+	dbOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "graphql_db_operation_duration_seconds",
+		Help:    "The duration of database commands executed by the persistent storage",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{
+		"operation",
+	})
+	dbOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "graphql_db_operation_errors_total",
+		Help: "The total number of failed database commands executed by the persistent storage",
+	}, []string{
+		"operation",
+	})
+)
+
+// commandMonitor records latency metrics of database commands and trace spans
+// of the commands executed in a context of a recorded trace.
+type commandMonitor struct {
+	spans sync.Map
+}
+
+// monitor provides the command monitor to be attached to the Mongo client.
+func (cm *commandMonitor) monitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started:   cm.started,
+		Succeeded: cm.succeeded,
+		Failed:    cm.failed,
+	}
+}
+
+// started starts a new span for the database command, if it belongs to a recorded trace.
+func (cm *commandMonitor) started(ctx context.Context, evt *event.CommandStartedEvent) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return
+	}
+
+	_, span := tracing.Start(ctx, "db.MongoDbBridge."+evt.CommandName,
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.name", evt.DatabaseName),
+		attribute.String("db.operation", evt.CommandName),
+	)
+	cm.spans.Store(evt.RequestID, span)
+}
+
+// succeeded records a successful database command and ends its span.
+func (cm *commandMonitor) succeeded(_ context.Context, evt *event.CommandSucceededEvent) {
+	dbOperationDuration.WithLabelValues(evt.CommandName).Observe(evt.Duration.Seconds())
+
+	if span, ok := cm.spans.LoadAndDelete(evt.RequestID); ok {
+		tracing.End(span.(trace.Span), nil)
+	}
+}
+
+// failed records a failed database command and ends its span recording the failure.
+func (cm *commandMonitor) failed(_ context.Context, evt *event.CommandFailedEvent) {
+	dbOperationDuration.WithLabelValues(evt.CommandName).Observe(evt.Duration.Seconds())
+	dbOperationErrors.WithLabelValues(evt.CommandName).Inc()
+
+	if span, ok := cm.spans.LoadAndDelete(evt.RequestID); ok {
+		tracing.End(span.(trace.Span), errors.New(evt.Failure))
+	}
+}
@@ -0,0 +1,181 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colNetworkStats represents the name of the network topology snapshots collection.
+const colNetworkStats = "network_stats"
+
+// networkStatsUnknownKey represents the group key of nodes without the grouping property.
+const networkStatsUnknownKey = "unknown"
+
+// networkStatsGroupStage provides an aggregation pipeline grouping active nodes by the given expression.
+func networkStatsGroupStage(key interface{}) bson.A {
+	return bson.A{
+		bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: key},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "synced", Value: bson.D{{Key: "$sum", Value: bson.D{
+				{Key: "$cond", Value: bson.A{"$info.synced", 1, 0}},
+			}}}},
+			{Key: "org", Value: bson.D{{Key: "$first", Value: "$location.as_org"}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+}
+
+// CollectNetworkStats aggregates the current state of the active Opera network nodes into a topology snapshot.
+func (db *MongoDbBridge) CollectNetworkStats() (*types.NetworkStats, error) {
+	col := db.client.Database(db.dbName).Collection(colNetworkNodes)
+
+	cu, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "fails", Value: 0},
+			{Key: "info.is_opera", Value: true},
+		}}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: networkStatsGroupStage(nil)},
+			{Key: types.NetworkStatsByCountry, Value: networkStatsGroupStage("$location.country")},
+			{Key: types.NetworkStatsByContinent, Value: networkStatsGroupStage("$location.continent")},
+			{Key: types.NetworkStatsByVersion, Value: networkStatsGroupStage(bson.D{{Key: "$concat", Value: bson.A{
+				bson.D{{Key: "$ifNull", Value: bson.A{"$info.client", ""}}},
+				"/",
+				bson.D{{Key: "$ifNull", Value: bson.A{"$info.client_ver", ""}}},
+			}}})},
+			{Key: types.NetworkStatsByHost, Value: networkStatsGroupStage(bson.D{{Key: "$ifNull", Value: bson.A{"$location.asn", 0}}})},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate network stats; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cu)
+
+	if !cu.Next(context.Background()) {
+		return nil, fmt.Errorf("network stats aggregation failed")
+	}
+
+	type group struct {
+		Key    interface{} `bson:"_id"`
+		Count  int32       `bson:"count"`
+		Synced int32       `bson:"synced"`
+		Org    *string     `bson:"org"`
+	}
+	var row struct {
+		Total      []group `bson:"total"`
+		Countries  []group `bson:"country"`
+		Continents []group `bson:"continent"`
+		Versions   []group `bson:"version"`
+		Hosts      []group `bson:"asn"`
+	}
+	if err := cu.Decode(&row); err != nil {
+		db.log.Errorf("can not decode network stats aggregation; %s", err.Error())
+		return nil, err
+	}
+
+	// convert the groups making sure the keys are readable
+	groups := func(list []group, key func(g *group) string) []types.NetworkStatsGroup {
+		res := make([]types.NetworkStatsGroup, len(list))
+		for i := range list {
+			res[i] = types.NetworkStatsGroup{Key: key(&list[i]), Count: list[i].Count, Synced: list[i].Synced}
+		}
+		return res
+	}
+	name := func(g *group) string {
+		if s, ok := g.Key.(string); ok && s != "" && s != "/" {
+			return s
+		}
+		return networkStatsUnknownKey
+	}
+	host := func(g *group) string {
+		asn := fmt.Sprintf("%v", g.Key)
+		if asn == "0" {
+			return networkStatsUnknownKey
+		}
+		if g.Org == nil || *g.Org == "" {
+			return fmt.Sprintf("AS%s", asn)
+		}
+		return fmt.Sprintf("AS%s %s", asn, *g.Org)
+	}
+
+	now := time.Now().UTC()
+	ns := types.NetworkStats{
+		TimeStamp:  now.Unix(),
+		Stamp:      now,
+		Countries:  groups(row.Countries, name),
+		Continents: groups(row.Continents, name),
+		Versions:   groups(row.Versions, name),
+		Hosts:      groups(row.Hosts, host),
+	}
+	if len(row.Total) > 0 {
+		ns.Total, ns.Synced = row.Total[0].Count, row.Total[0].Synced
+	}
+	return &ns, nil
+}
+
+// StoreNetworkStats stores the given network topology snapshot in the database.
+func (db *MongoDbBridge) StoreNetworkStats(ns *types.NetworkStats) error {
+	col := db.client.Database(db.dbName).Collection(colNetworkStats)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: "_id", Value: ns.TimeStamp}},
+		ns,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store network stats; %s", err.Error())
+	}
+	return err
+}
+
+// NetworkStats loads the network topology snapshots taken in the given time range, the oldest first.
+// Only the first snapshot of each period of the given resolution is provided.
+func (db *MongoDbBridge) NetworkStats(from time.Time, to time.Time, resolution time.Duration) ([]*types.NetworkStats, error) {
+	col := db.client.Database(db.dbName).Collection(colNetworkStats)
+
+	res := int64(resolution / time.Second)
+	if res < 1 {
+		res = 1
+	}
+
+	cu, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "_id", Value: bson.D{
+			{Key: "$gte", Value: from.Unix()},
+			{Key: "$lte", Value: to.Unix()},
+		}}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$subtract", Value: bson.A{
+				"$_id",
+				bson.D{{Key: "$mod", Value: bson.A{"$_id", res}}},
+			}}}},
+			{Key: "doc", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$doc"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not load network stats; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cu)
+
+	list := make([]*types.NetworkStats, 0)
+	for cu.Next(context.Background()) {
+		var row types.NetworkStats
+		if err := cu.Decode(&row); err != nil {
+			db.log.Errorf("can not decode network stats; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,100 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"strings"
+	"time"
+)
+
+// colPriceHistory represents the name of the native token price history collection.
+const colPriceHistory = "price_history"
+
+// priceHistoryIndexes provides a list of indexes expected to exist on the price history collection.
+func priceHistoryIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	unique := true
+	ixSymDate := "ix_sym_date"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiPriceTickSymbol, Value: 1},
+		{Key: types.FiPriceTickTimeStamp, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixSymDate, Unique: &unique}}
+
+	return ix
+}
+
+// AddPriceTick stores the given price tick in the price history.
+func (db *MongoDbBridge) AddPriceTick(pt *types.PriceTick) error {
+	col := db.client.Database(db.dbName).Collection(colPriceHistory)
+
+	pt.Symbol = strings.ToUpper(pt.Symbol)
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{
+			{Key: types.FiPriceTickSymbol, Value: pt.Symbol},
+			{Key: types.FiPriceTickTimeStamp, Value: pt.TimeStamp},
+		},
+		pt,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store price tick of %s; %s", pt.Symbol, err.Error())
+	}
+	return err
+}
+
+// PriceAt loads the latest price of the given symbol recorded at, or before the given time.
+// Prices recorded more than maxAge before the time are not used.
+func (db *MongoDbBridge) PriceAt(sym string, ts time.Time, maxAge time.Duration) (*types.PriceTick, error) {
+	col := db.client.Database(db.dbName).Collection(colPriceHistory)
+
+	sr := col.FindOne(context.Background(), bson.D{
+		{Key: types.FiPriceTickSymbol, Value: strings.ToUpper(sym)},
+		{Key: types.FiPriceTickTimeStamp, Value: bson.D{
+			{Key: "$lte", Value: ts},
+			{Key: "$gt", Value: ts.Add(-maxAge)},
+		}},
+	}, options.FindOne().SetSort(bson.D{{Key: types.FiPriceTickTimeStamp, Value: -1}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load price of %s at %s; %s", sym, ts.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var pt types.PriceTick
+	if err := sr.Decode(&pt); err != nil {
+		db.log.Errorf("can not decode price tick; %s", err.Error())
+		return nil, err
+	}
+	return &pt, nil
+}
+
+// PriceHistoryStart loads the time of the oldest recorded price of the given symbol;
+// nil if no price has been recorded yet.
+func (db *MongoDbBridge) PriceHistoryStart(sym string) (*time.Time, error) {
+	col := db.client.Database(db.dbName).Collection(colPriceHistory)
+
+	sr := col.FindOne(context.Background(), bson.D{
+		{Key: types.FiPriceTickSymbol, Value: strings.ToUpper(sym)},
+	}, options.FindOne().SetSort(bson.D{{Key: types.FiPriceTickTimeStamp, Value: 1}}))
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load price history start of %s; %s", sym, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var pt types.PriceTick
+	if err := sr.Decode(&pt); err != nil {
+		db.log.Errorf("can not decode price tick; %s", err.Error())
+		return nil, err
+	}
+	return &pt.TimeStamp, nil
+}
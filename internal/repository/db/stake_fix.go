@@ -0,0 +1,36 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colStakeFixes represents the name of the stake repairs audit log collection.
+const colStakeFixes = "stake_fixes"
+
+// stakeFixesIndexes provides a list of indexes expected to exist on the stake repairs audit log collection.
+func stakeFixesIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 1)
+
+	ixAddrDate := "ix_adr_date"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: "adr", Value: 1},
+		{Key: "date", Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixAddrDate}}
+
+	return ix
+}
+
+// AddStakeFix stores the given stake repair record into the audit log.
+func (db *MongoDbBridge) AddStakeFix(fix *types.StakeFix) error {
+	col := db.client.Database(db.dbName).Collection(colStakeFixes)
+	if _, err := col.InsertOne(context.Background(), fix); err != nil {
+		db.log.Errorf("could not store stake fix of %s; %s", fix.Address.String(), err.Error())
+		return err
+	}
+	return nil
+}
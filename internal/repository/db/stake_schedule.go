@@ -0,0 +1,174 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+	"time"
+)
+
+// stakeScheduleDayFormat represents the format of the daily aggregation key.
+const stakeScheduleDayFormat = "%Y-%m-%d"
+
+// stakeScheduleDay represents a daily aggregation of scheduled stake amounts.
+type stakeScheduleDay struct {
+	Day   string `bson:"_id"`
+	Value int64  `bson:"value"`
+	Count int    `bson:"count"`
+}
+
+// ActiveLockedDelegations loads locked delegations of the given delegator
+// expiring after the given time.
+func (db *MongoDbBridge) ActiveLockedDelegations(addr *common.Address, since time.Time) ([]*types.LockedDelegation, error) {
+	col := db.client.Database(db.dbName).Collection(colLockedDelegations)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: "from", Value: *addr},
+		{Key: "expires", Value: bson.D{{Key: "$gt", Value: since}}},
+		{Key: "value", Value: bson.D{{Key: "$gt", Value: 0}}},
+	}, options.Find().SetSort(bson.D{{Key: "expires", Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load locked delegations of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.LockedDelegation, 0)
+	for cur.Next(context.Background()) {
+		var ld types.LockedDelegation
+		if err := cur.Decode(&ld); err != nil {
+			db.log.Errorf("can not decode locked delegation; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &ld)
+	}
+	return list, nil
+}
+
+// PendingWithdrawals loads all withdraw requests of the given delegator not finalized yet.
+func (db *MongoDbBridge) PendingWithdrawals(addr *common.Address) ([]*types.WithdrawRequest, error) {
+	col := db.client.Database(db.dbName).Collection(colWithdrawals)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiWithdrawalAddress, Value: addr.String()},
+		{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
+		{Key: types.FiWithdrawalClosed, Value: bson.D{{Key: "$ne", Value: true}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiWithdrawalStamp, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load pending withdrawals of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.WithdrawRequest, 0)
+	for cur.Next(context.Background()) {
+		var wr types.WithdrawRequest
+		if err := cur.Decode(&wr); err != nil {
+			db.log.Errorf("can not decode withdraw request; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &wr)
+	}
+	return list, nil
+}
+
+// UnlockScheduleDaily aggregates the locked amounts of all the delegations
+// by the day of the lock expiration in the given time range.
+func (db *MongoDbBridge) UnlockScheduleDaily(from time.Time, to time.Time) ([]*types.StakeScheduleTick, error) {
+	col := db.client.Database(db.dbName).Collection(colLockedDelegations)
+
+	cur, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "expires", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+			{Key: "value", Value: bson.D{{Key: "$gt", Value: 0}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{
+				{Key: "format", Value: stakeScheduleDayFormat},
+				{Key: "date", Value: "$expires"},
+			}}}},
+			{Key: "value", Value: bson.D{{Key: "$sum", Value: "$value"}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate unlock schedule; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	days, err := db.loadStakeScheduleDays(cur)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.StakeScheduleTick, 0, len(days))
+	for _, d := range days {
+		tick := types.StakeScheduleTick{UnlockCount: d.Count}
+		tick.Date, _ = time.Parse("2006-01-02", d.Day)
+		tick.Unlocking.ToInt().Mul(big.NewInt(d.Value), types.DelegationDecimalsCorrection)
+		list = append(list, &tick)
+	}
+	return list, nil
+}
+
+// WithdrawalScheduleDaily aggregates the amounts of all the pending withdraw requests
+// by the day they become claimable in the given time range; the requests become claimable
+// after the given withdrawal period passes.
+func (db *MongoDbBridge) WithdrawalScheduleDaily(from time.Time, to time.Time, period time.Duration) ([]*types.StakeScheduleTick, error) {
+	col := db.client.Database(db.dbName).Collection(colWithdrawals)
+
+	cur, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
+			{Key: types.FiWithdrawalClosed, Value: bson.D{{Key: "$ne", Value: true}}},
+			{Key: types.FiWithdrawalStamp, Value: bson.D{{Key: "$gte", Value: from.Add(-period)}, {Key: "$lt", Value: to.Add(-period)}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$dateToString", Value: bson.D{
+				{Key: "format", Value: stakeScheduleDayFormat},
+				{Key: "date", Value: bson.D{{Key: "$add", Value: bson.A{"$" + types.FiWithdrawalStamp, period.Milliseconds()}}}},
+			}}}},
+			{Key: "value", Value: bson.D{{Key: "$sum", Value: "$" + types.FiWithdrawalValue}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate withdrawal schedule; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	days, err := db.loadStakeScheduleDays(cur)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.StakeScheduleTick, 0, len(days))
+	for _, d := range days {
+		tick := types.StakeScheduleTick{WithdrawCount: d.Count}
+		tick.Date, _ = time.Parse("2006-01-02", d.Day)
+		tick.Withdrawable.ToInt().Mul(big.NewInt(d.Value), types.WithdrawDecimalsCorrection)
+		list = append(list, &tick)
+	}
+	return list, nil
+}
+
+// loadStakeScheduleDays loads daily aggregations of scheduled stake amounts from the given cursor.
+func (db *MongoDbBridge) loadStakeScheduleDays(cur *mongo.Cursor) ([]stakeScheduleDay, error) {
+	list := make([]stakeScheduleDay, 0)
+	for cur.Next(context.Background()) {
+		var row stakeScheduleDay
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode stake schedule day; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, row)
+	}
+	return list, nil
+}
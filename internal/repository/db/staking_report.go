@@ -0,0 +1,119 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// RewardClaimsRange loads all reward claims of the given delegator made in the given time range.
+func (db *MongoDbBridge) RewardClaimsRange(addr *common.Address, from time.Time, to time.Time) ([]*types.RewardClaim, error) {
+	col := db.client.Database(db.dbName).Collection(colRewards)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiRewardClaimAddress, Value: addr.String()},
+		{Key: types.FiRewardClaimedTimeStamp, Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiRewardClaimedTimeStamp, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load reward claims of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.RewardClaim, 0)
+	for cur.Next(context.Background()) {
+		var rc types.RewardClaim
+		if err := cur.Decode(&rc); err != nil {
+			db.log.Errorf("can not decode reward claim; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &rc)
+	}
+	return list, nil
+}
+
+// WithdrawalsFinalizedRange loads all withdraw requests of the given delegator
+// finalized in the given time range.
+func (db *MongoDbBridge) WithdrawalsFinalizedRange(addr *common.Address, from time.Time, to time.Time) ([]*types.WithdrawRequest, error) {
+	col := db.client.Database(db.dbName).Collection(colWithdrawals)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiWithdrawalAddress, Value: addr.String()},
+		{Key: types.FiWithdrawalFinTime, Value: bson.D{{Key: "$gte", Value: uint64(from.Unix())}, {Key: "$lt", Value: uint64(to.Unix())}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiWithdrawalFinTime, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load withdrawals of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.WithdrawRequest, 0)
+	for cur.Next(context.Background()) {
+		var wr types.WithdrawRequest
+		if err := cur.Decode(&wr); err != nil {
+			db.log.Errorf("can not decode withdraw request; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &wr)
+	}
+	return list, nil
+}
+
+// DelegationUnlocksRange loads all penalized delegation unlocks of the given delegator
+// made in the given time range.
+func (db *MongoDbBridge) DelegationUnlocksRange(addr *common.Address, from time.Time, to time.Time) ([]*types.DelegationUnlock, error) {
+	col := db.client.Database(db.dbName).Collection(colDelegationUnlocks)
+
+	cur, err := col.Find(context.Background(), bson.D{
+		{Key: "from", Value: addr.String()},
+		{Key: "when", Value: bson.D{{Key: "$gte", Value: from}, {Key: "$lt", Value: to}}},
+		{Key: "fine", Value: bson.D{{Key: "$gt", Value: 0}}},
+	}, options.Find().SetSort(bson.D{{Key: "when", Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load delegation unlocks of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.DelegationUnlock, 0)
+	for cur.Next(context.Background()) {
+		var du types.DelegationUnlock
+		if err := cur.Decode(&du); err != nil {
+			db.log.Errorf("can not decode delegation unlock; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &du)
+	}
+	return list, nil
+}
+
+// EpochAt finds the epoch which was active at the given time.
+// Zero is returned if the epoch is not known.
+func (db *MongoDbBridge) EpochAt(ts time.Time) (uint64, error) {
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+
+	sr := col.FindOne(context.Background(),
+		bson.D{{Key: fiEpochEndTime, Value: bson.D{{Key: "$gte", Value: ts}}}},
+		options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: 1}}).SetProjection(bson.D{{Key: fiEpochPk, Value: true}}),
+	)
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		db.log.Errorf("can not find epoch at %s; %s", ts.String(), sr.Err().Error())
+		return 0, sr.Err()
+	}
+
+	var row struct {
+		Id int64 `bson:"_id"`
+	}
+	if err := sr.Decode(&row); err != nil {
+		return 0, err
+	}
+	return uint64(row.Id), nil
+}
@@ -384,6 +384,45 @@ func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count
 	return nil
 }
 
+// TransactionsStatsInRange provides the number of transactions and the total gas used
+// by transactions included in the given range of blocks, both ends inclusive.
+func (db *MongoDbBridge) TransactionsStatsInRange(fromBlock uint64, toBlock uint64) (uint64, uint64, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// the ordinal index starts with the block number so we can use it for the range
+	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: fiTransactionOrdinalIndex, Value: bson.D{
+			{Key: "$gte", Value: int64(fromBlock << 14)},
+			{Key: "$lt", Value: int64((toBlock + 1) << 14)},
+		}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas_use"}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not collect transactions of blocks #%d to #%d; %s", fromBlock, toBlock, err.Error())
+		return 0, 0, err
+	}
+
+	// no transactions in the range
+	defer db.closeCursor(cr)
+	if !cr.Next(context.Background()) {
+		return 0, 0, nil
+	}
+
+	var row struct {
+		Count int64 `bson:"count"`
+		Gas   int64 `bson:"gas"`
+	}
+	if err := cr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode transactions aggregation cursor; %s", err.Error())
+		return 0, 0, err
+	}
+	return uint64(row.Count), uint64(row.Gas), nil
+}
+
 // TransactionsCount returns the number of transactions stored in the database.
 func (db *MongoDbBridge) TransactionsCount() (uint64, error) {
 	return db.EstimateCount(db.client.Database(db.dbName).Collection(coTransactions))
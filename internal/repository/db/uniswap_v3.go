@@ -0,0 +1,522 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sort"
+)
+
+const (
+	// colUniswapV3Actions represents the name of the concentrated liquidity pool actions collection.
+	colUniswapV3Actions = "uniswap_v3_actions"
+
+	// colUniswapV3Pools represents the name of the concentrated liquidity pools collection.
+	colUniswapV3Pools = "uniswap_v3_pools"
+
+	// colUniswapV3Positions represents the name of the liquidity positions collection.
+	colUniswapV3Positions = "uniswap_v3_positions"
+)
+
+// uniswapV3ActionsIndexes provides a list of indexes expected to exist on the pool actions' collection.
+func uniswapV3ActionsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 4)
+
+	ixOrdinal := "ix_orx"
+	ix[0] = mongo.IndexModel{Keys: bson.D{{Key: types.FiUniswapV3ActionOrdinal, Value: -1}}, Options: &options.IndexOptions{Name: &ixOrdinal}}
+
+	ixPoolOrdinal := "ix_pool_orx"
+	ix[1] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiUniswapV3ActionPool, Value: 1},
+		{Key: types.FiUniswapV3ActionOrdinal, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixPoolOrdinal}}
+
+	ixPoolDate := "ix_pool_date"
+	ix[2] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiUniswapV3ActionPool, Value: 1},
+		{Key: types.FiUniswapV3ActionTimeStamp, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixPoolDate}}
+
+	ixOwner := "ix_owner"
+	ix[3] = mongo.IndexModel{Keys: bson.D{{Key: types.FiUniswapV3ActionOwner, Value: 1}}, Options: &options.IndexOptions{Name: &ixOwner}}
+
+	return ix
+}
+
+// uniswapV3PoolsIndexes provides a list of indexes expected to exist on the pools' collection.
+func uniswapV3PoolsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 2)
+
+	ixTokens := "ix_tokens"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiUniswapV3PoolToken0, Value: 1},
+		{Key: types.FiUniswapV3PoolToken1, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixTokens}}
+
+	ixCreated := "ix_created"
+	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: types.FiUniswapV3PoolCreated, Value: 1}}, Options: &options.IndexOptions{Name: &ixCreated}}
+
+	return ix
+}
+
+// uniswapV3PositionsIndexes provides a list of indexes expected to exist on the positions' collection.
+func uniswapV3PositionsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 2)
+
+	ixOwner := "ix_owner"
+	ix[0] = mongo.IndexModel{Keys: bson.D{{Key: types.FiUniswapV3PositionOwner, Value: 1}}, Options: &options.IndexOptions{Name: &ixOwner}}
+
+	ixPool := "ix_pool"
+	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: types.FiUniswapV3PositionPool, Value: 1}}, Options: &options.IndexOptions{Name: &ixPool}}
+
+	return ix
+}
+
+// StoreUniswapV3Pool stores the given concentrated liquidity pool in the database.
+func (db *MongoDbBridge) StoreUniswapV3Pool(pool *types.UniswapV3Pool) error {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Pools)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiUniswapV3PoolAddress, Value: pool.Address.String()}},
+		pool,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store uniswap v3 pool %s; %s", pool.Address.String(), err.Error())
+	}
+	return err
+}
+
+// UniswapV3Pool loads a concentrated liquidity pool by its address.
+func (db *MongoDbBridge) UniswapV3Pool(adr *common.Address) (*types.UniswapV3Pool, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Pools)
+
+	sr := col.FindOne(context.Background(), bson.D{{Key: types.FiUniswapV3PoolAddress, Value: adr.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("could not load uniswap v3 pool %s; %s", adr.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var pool types.UniswapV3Pool
+	if err := sr.Decode(&pool); err != nil {
+		db.log.Errorf("could not decode uniswap v3 pool %s; %s", adr.String(), err.Error())
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// UniswapV3Pools loads the list of all known concentrated liquidity pools.
+func (db *MongoDbBridge) UniswapV3Pools() ([]*types.UniswapV3Pool, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Pools)
+
+	cr, err := col.Find(context.Background(), bson.D{}, options.Find().SetSort(bson.D{{Key: types.FiUniswapV3PoolCreated, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("could not load uniswap v3 pools; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.UniswapV3Pool, 0)
+	for cr.Next(context.Background()) {
+		var row types.UniswapV3Pool
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("could not decode uniswap v3 pool; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// StoreUniswapV3Position stores the given liquidity position in the database.
+func (db *MongoDbBridge) StoreUniswapV3Position(pos *types.UniswapV3Position) error {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Positions)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiUniswapV3PositionTokenId, Value: pos.TokenId.String()}},
+		pos,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store uniswap v3 position %s; %s", pos.TokenId.String(), err.Error())
+	}
+	return err
+}
+
+// UniswapV3Position loads a liquidity position by the NFT token id.
+func (db *MongoDbBridge) UniswapV3Position(tokenId *hexutil.Big) (*types.UniswapV3Position, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Positions)
+
+	sr := col.FindOne(context.Background(), bson.D{{Key: types.FiUniswapV3PositionTokenId, Value: tokenId.String()}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("could not load uniswap v3 position %s; %s", tokenId.String(), sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var pos types.UniswapV3Position
+	if err := sr.Decode(&pos); err != nil {
+		db.log.Errorf("could not decode uniswap v3 position %s; %s", tokenId.String(), err.Error())
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// UniswapV3Positions loads liquidity positions matching the given owner and/or pool.
+func (db *MongoDbBridge) UniswapV3Positions(owner *common.Address, pool *common.Address) ([]*types.UniswapV3Position, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Positions)
+
+	filter := bson.D{}
+	if owner != nil {
+		filter = append(filter, bson.E{Key: types.FiUniswapV3PositionOwner, Value: owner.String()})
+	}
+	if pool != nil {
+		filter = append(filter, bson.E{Key: types.FiUniswapV3PositionPool, Value: pool.String()})
+	}
+
+	cr, err := col.Find(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("could not load uniswap v3 positions; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.UniswapV3Position, 0)
+	for cr.Next(context.Background()) {
+		var row types.UniswapV3Position
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("could not decode uniswap v3 position; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// AddUniswapV3Action stores a concentrated liquidity pool action in the database if it doesn't exist.
+func (db *MongoDbBridge) AddUniswapV3Action(act *types.UniswapV3Action) error {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Actions)
+
+	// the action is identified by the trx hash and log index, re-scan will just replace it
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: types.FiUniswapV3ActionId, Value: act.Pk()}},
+		act,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store uniswap v3 action %s; %s", act.TrxHash.String(), err.Error())
+	}
+	return err
+}
+
+// UniswapV3PoolTicks provides a sorted list of distinct ticks used as position boundaries on the given pool.
+func (db *MongoDbBridge) UniswapV3PoolTicks(pool *common.Address) ([]int32, error) {
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Actions)
+	filter := bson.D{
+		{Key: types.FiUniswapV3ActionPool, Value: pool.String()},
+		{Key: types.FiUniswapV3ActionType, Value: types.UniswapV3ActionMint},
+	}
+
+	// collect both lower and upper boundaries
+	ticks := make(map[int32]bool)
+	for _, fi := range []string{types.FiUniswapV3ActionTickLower, types.FiUniswapV3ActionTickUpper} {
+		l, err := col.Distinct(context.Background(), fi, filter)
+		if err != nil {
+			db.log.Errorf("could not collect ticks of pool %s; %s", pool.String(), err.Error())
+			return nil, err
+		}
+		for _, v := range l {
+			if t, ok := v.(int32); ok {
+				ticks[t] = true
+			}
+		}
+	}
+
+	// make the sorted list
+	list := make([]int32, 0, len(ticks))
+	for t := range ticks {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list, nil
+}
+
+// UniswapV3TimePrices resolves OHLC prices of swaps on the given pool grouped by date interval.
+// If toTime is 0, then it calculates prices till now. Non-zero direction provides the inverse price.
+func (db *MongoDbBridge) UniswapV3TimePrices(pool *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
+	// the price is stored as token0 in token1, the inverse needs to be calculated
+	var priceBsonD interface{} = "$" + types.FiUniswapV3ActionPrice
+	if direction != 0 {
+		priceBsonD = bson.D{{Key: "$divide", Value: bson.A{1, "$" + types.FiUniswapV3ActionPrice}}}
+	}
+
+	// create query pipeline
+	pipe := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiUniswapV3ActionTimeStamp, Value: getDateBsonD(fromTime, toTime)},
+			{Key: types.FiUniswapV3ActionType, Value: types.UniswapV3ActionSwap},
+			{Key: types.FiUniswapV3ActionPool, Value: pool.String()},
+			{Key: types.FiUniswapV3ActionPrice, Value: bson.D{{Key: "$gt", Value: 0}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: types.FiUniswapV3ActionTimeStamp, Value: 1},
+			{Key: types.FiUniswapV3ActionOrdinal, Value: 1},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: getGroupBsonD(resolution)},
+			{Key: "low", Value: bson.D{{Key: "$min", Value: priceBsonD}}},
+			{Key: "high", Value: bson.D{{Key: "$max", Value: priceBsonD}}},
+			{Key: "open", Value: bson.D{{Key: "$first", Value: priceBsonD}}},
+			{Key: "close", Value: bson.D{{Key: "$last", Value: priceBsonD}}},
+			{Key: "avg", Value: bson.D{{Key: "$avg", Value: priceBsonD}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	// execute query
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Actions)
+	cr, err := col.Aggregate(context.Background(), pipe)
+	if err != nil {
+		db.log.Errorf("can not aggregate uniswap v3 prices; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	// iterate thru results and construct data
+	list := make([]types.DefiTimePrice, 0)
+	for cr.Next(context.Background()) {
+		var row types.DefiTimePrice
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode uniswap v3 price; %s", err.Error())
+			continue
+		}
+		row.PairAddress = *pool
+		list = append(list, row)
+	}
+	return list, nil
+}
+
+// UniswapV3Actions pulls list of concentrated liquidity pool actions starting at the specified cursor.
+func (db *MongoDbBridge) UniswapV3Actions(cursor *string, count int32, filter *bson.D) (*types.UniswapV3ActionList, error) {
+	// nothing to load?
+	if count == 0 {
+		return nil, fmt.Errorf("nothing to do, zero uniswap v3 actions requested")
+	}
+
+	// get the collection and context
+	col := db.client.Database(db.dbName).Collection(colUniswapV3Actions)
+
+	// init the list
+	list, err := db.uniswapV3ActionListInit(col, cursor, count, filter)
+	if err != nil {
+		db.log.Errorf("can not build uniswap v3 action list; %s", err.Error())
+		return nil, err
+	}
+
+	// load data if there are any
+	if list.Total > 0 {
+		err = db.uniswapV3ActionListLoad(col, cursor, count, list)
+		if err != nil {
+			db.log.Errorf("can not load uniswap v3 action list from database; %s", err.Error())
+			return nil, err
+		}
+
+		// reverse on negative so new-er actions will be on top
+		if count < 0 {
+			list.Reverse()
+		}
+	}
+	return list, nil
+}
+
+// uniswapV3ActionListInit initializes list of pool actions based on provided cursor, count, and filter.
+func (db *MongoDbBridge) uniswapV3ActionListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.UniswapV3ActionList, error) {
+	// make sure some filter is used
+	if nil == filter {
+		filter = &bson.D{}
+	}
+
+	// find how many actions do we have in the database
+	total, err := col.CountDocuments(context.Background(), *filter)
+	if err != nil {
+		db.log.Errorf("can not count uniswap v3 actions")
+		return nil, err
+	}
+
+	// make the list and notify the size of it
+	db.log.Debugf("found %d filtered uniswap v3 actions", total)
+	list := types.UniswapV3ActionList{
+		Collection: make([]*types.UniswapV3Action, 0),
+		Total:      uint64(total),
+		First:      0,
+		Last:       0,
+		IsStart:    total == 0,
+		IsEnd:      total == 0,
+		Filter:     *filter,
+	}
+
+	// is the list non-empty? return the list with properly calculated range marks
+	if 0 < total {
+		return db.uniswapV3ActionListCollectRangeMarks(col, &list, cursor, count)
+	}
+	// this is an empty list
+	db.log.Debug("empty uniswap v3 action list created")
+	return &list, nil
+}
+
+// uniswapV3ActionListCollectRangeMarks finds range marks of a list of pool actions with proper First/Last marks.
+func (db *MongoDbBridge) uniswapV3ActionListCollectRangeMarks(col *mongo.Collection, list *types.UniswapV3ActionList, cursor *string, count int32) (*types.UniswapV3ActionList, error) {
+	var err error
+
+	// find out the cursor ordinal index
+	if cursor == nil && count > 0 {
+		// get the highest available pk
+		list.First, err = db.uniswapV3ActionListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{Key: types.FiUniswapV3ActionOrdinal, Value: -1}}))
+		list.IsStart = true
+
+	} else if cursor == nil && count < 0 {
+		// get the lowest available pk
+		list.First, err = db.uniswapV3ActionListBorderPk(col,
+			list.Filter,
+			options.FindOne().SetSort(bson.D{{Key: types.FiUniswapV3ActionOrdinal, Value: 1}}))
+		list.IsEnd = true
+
+	} else if cursor != nil {
+		// the cursor itself is the starting point
+		list.First, err = db.uniswapV3ActionListBorderPk(col,
+			bson.D{{Key: types.FiUniswapV3ActionId, Value: *cursor}},
+			options.FindOne())
+	}
+
+	// check the error
+	if err != nil {
+		db.log.Errorf("can not find the initial uniswap v3 action")
+		return nil, err
+	}
+
+	// inform what we are about to do
+	db.log.Debugf("uniswap v3 action list initialized with ordinal %d", list.First)
+	return list, nil
+}
+
+// uniswapV3ActionListBorderPk finds the top PK of the pool actions collection based on given filter and options.
+func (db *MongoDbBridge) uniswapV3ActionListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+	// prep container
+	var row struct {
+		Value uint64 `bson:"orx"`
+	}
+
+	// make sure we pull only what we need
+	opt.SetProjection(bson.D{{Key: types.FiUniswapV3ActionOrdinal, Value: true}})
+
+	// try to decode
+	sr := col.FindOne(context.Background(), filter, opt)
+	err := sr.Decode(&row)
+	if err != nil {
+		return 0, err
+	}
+	return row.Value, nil
+}
+
+// uniswapV3ActionListFilter creates a filter for pool actions list loading.
+func (db *MongoDbBridge) uniswapV3ActionListFilter(cursor *string, count int32, list *types.UniswapV3ActionList) *bson.D {
+	// build an extended filter for the query; add PK (decoded cursor) to the original filter
+	if cursor == nil {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiUniswapV3ActionOrdinal, Value: bson.D{{Key: "$lte", Value: list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiUniswapV3ActionOrdinal, Value: bson.D{{Key: "$gte", Value: list.First}}})
+		}
+	} else {
+		if count > 0 {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiUniswapV3ActionOrdinal, Value: bson.D{{Key: "$lt", Value: list.First}}})
+		} else {
+			list.Filter = append(list.Filter, bson.E{Key: types.FiUniswapV3ActionOrdinal, Value: bson.D{{Key: "$gt", Value: list.First}}})
+		}
+	}
+	// return the new filter
+	return &list.Filter
+}
+
+// uniswapV3ActionListOptions creates a filter options set for pool actions list search.
+func (db *MongoDbBridge) uniswapV3ActionListOptions(count int32) *options.FindOptions {
+	// prep options
+	opt := options.Find()
+
+	// how to sort results in the collection
+	// from high (new) to low (old) by default; reversed if loading from bottom
+	sd := -1
+	if count < 0 {
+		sd = 1
+	}
+
+	// sort with the direction we want
+	opt.SetSort(bson.D{{Key: types.FiUniswapV3ActionOrdinal, Value: sd}})
+
+	// prep the loading limit
+	var limit = int64(count)
+	if limit < 0 {
+		limit = -limit
+	}
+
+	// apply the limit, try to get one more record, so we can detect list end
+	opt.SetLimit(limit + 1)
+	return opt
+}
+
+// uniswapV3ActionListLoad load the initialized list of pool actions from database.
+func (db *MongoDbBridge) uniswapV3ActionListLoad(col *mongo.Collection, cursor *string, count int32, list *types.UniswapV3ActionList) (err error) {
+	ctx := context.Background()
+
+	// load the data
+	ld, err := col.Find(ctx, db.uniswapV3ActionListFilter(cursor, count, list), db.uniswapV3ActionListOptions(count))
+	if err != nil {
+		db.log.Errorf("error loading uniswap v3 actions list; %s", err.Error())
+		return err
+	}
+
+	// close the cursor as we leave
+	defer db.closeCursor(ld)
+
+	// loop and load the list; we may not store the last value
+	var act *types.UniswapV3Action
+	for ld.Next(ctx) {
+		// append a previous value to the list, if we have one
+		if act != nil {
+			list.Collection = append(list.Collection, act)
+		}
+
+		// try to decode the next row
+		var row types.UniswapV3Action
+		if err = ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode the uniswap v3 action list row; %s", err.Error())
+			return err
+		}
+
+		// use this row as the next item
+		act = &row
+	}
+
+	// we should have all the items already; we may just need to check if a boundary was reached
+	list.IsEnd = (cursor == nil && count < 0) || (count > 0 && int32(len(list.Collection)) < count)
+	list.IsStart = (cursor == nil && count > 0) || (count < 0 && int32(len(list.Collection)) < -count)
+
+	// add the last item as well if we hit the boundary
+	if (list.IsStart || list.IsEnd) && act != nil {
+		list.Collection = append(list.Collection, act)
+	}
+	return nil
+}
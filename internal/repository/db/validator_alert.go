@@ -0,0 +1,77 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colValidatorAlerts represents the name of the validator alerts collection.
+const colValidatorAlerts = "validator_alerts"
+
+// validatorAlertsIndexes provides a list of indexes expected to exist on the validator alerts' collection.
+func validatorAlertsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 2)
+
+	ixValidatorRaised := "ix_val_raised"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiValidatorAlertValidator, Value: 1},
+		{Key: types.FiValidatorAlertRaised, Value: -1},
+	}, Options: &options.IndexOptions{Name: &ixValidatorRaised}}
+
+	ixRaised := "ix_raised"
+	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: types.FiValidatorAlertRaised, Value: -1}}, Options: &options.IndexOptions{Name: &ixRaised}}
+
+	return ix
+}
+
+// StoreValidatorAlert stores, or updates the given validator alert in the database.
+func (db *MongoDbBridge) StoreValidatorAlert(va *types.ValidatorAlert) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorAlerts)
+
+	_, err := col.ReplaceOne(context.Background(), bson.D{{Key: "_id", Value: va.Id}}, va, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("could not store validator alert %s; %s", va.Id, err.Error())
+	}
+	return err
+}
+
+// ValidatorAlerts loads the latest validator alerts, optionally limited to the given validator
+// and to alerts not resolved yet.
+func (db *MongoDbBridge) ValidatorAlerts(valID *uint64, activeOnly bool, count int64) ([]*types.ValidatorAlert, error) {
+	col := db.client.Database(db.dbName).Collection(colValidatorAlerts)
+
+	filter := bson.D{}
+	if valID != nil {
+		filter = append(filter, bson.E{Key: types.FiValidatorAlertValidator, Value: *valID})
+	}
+	if activeOnly {
+		filter = append(filter, bson.E{Key: types.FiValidatorAlertResolved, Value: bson.D{{Key: "$type", Value: 10}}})
+	}
+
+	opt := options.Find().SetSort(bson.D{{Key: types.FiValidatorAlertRaised, Value: -1}})
+	if count > 0 {
+		opt.SetLimit(count)
+	}
+
+	cur, err := col.Find(context.Background(), filter, opt)
+	if err != nil {
+		db.log.Errorf("can not load validator alerts; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	list := make([]*types.ValidatorAlert, 0)
+	for cur.Next(context.Background()) {
+		var va types.ValidatorAlert
+		if err := cur.Decode(&va); err != nil {
+			db.log.Errorf("can not decode validator alert; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &va)
+	}
+	return list, nil
+}
@@ -0,0 +1,153 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colValidatorEpochs represents the name of the validator epoch snapshots collection.
+const colValidatorEpochs = "validator_epochs"
+
+// colValidatorEpochGaps represents the name of the collection of epochs with missing validator snapshots.
+const colValidatorEpochGaps = "validator_epoch_gaps"
+
+// validatorEpochsIndexes provides a list of indexes expected to exist on the validator epochs collection.
+func validatorEpochsIndexes() []mongo.IndexModel {
+	ix := make([]mongo.IndexModel, 2)
+
+	ixValEpoch := "ix_val_epoch"
+	ix[0] = mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiValidatorEpochValidator, Value: 1},
+		{Key: types.FiValidatorEpochEpoch, Value: 1},
+	}, Options: &options.IndexOptions{Name: &ixValEpoch}}
+
+	ixEpoch := "ix_epoch"
+	ix[1] = mongo.IndexModel{Keys: bson.D{{Key: types.FiValidatorEpochEpoch, Value: 1}}, Options: &options.IndexOptions{Name: &ixEpoch}}
+
+	return ix
+}
+
+// AddValidatorEpoch stores a validator epoch snapshot in the database.
+func (db *MongoDbBridge) AddValidatorEpoch(ve *types.ValidatorEpoch) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochs)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: "_id", Value: ve.Pk()}},
+		ve,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store validator #%d epoch #%d; %s", ve.ValidatorId.ToInt().Uint64(), ve.Epoch, err.Error())
+	}
+	return err
+}
+
+// ValidatorEpochs loads snapshots of the given validator for the given range of epochs,
+// the oldest epoch first. Up to count snapshots is loaded.
+func (db *MongoDbBridge) ValidatorEpochs(valID *hexutil.Big, fromEpoch uint64, toEpoch uint64, count int64) ([]*types.ValidatorEpoch, error) {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochs)
+
+	cr, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiValidatorEpochValidator, Value: valID.ToInt().Int64()},
+		{Key: types.FiValidatorEpochEpoch, Value: bson.D{
+			{Key: "$gte", Value: int64(fromEpoch)},
+			{Key: "$lte", Value: int64(toEpoch)},
+		}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiValidatorEpochEpoch, Value: 1}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load epochs of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.ValidatorEpoch, 0)
+	for cr.Next(context.Background()) {
+		var row types.ValidatorEpoch
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator epoch; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// ValidatorEpochsOf loads snapshots of all the validators of the given epoch.
+func (db *MongoDbBridge) ValidatorEpochsOf(epoch uint64) ([]*types.ValidatorEpoch, error) {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochs)
+
+	cr, err := col.Find(context.Background(), bson.D{
+		{Key: types.FiValidatorEpochEpoch, Value: int64(epoch)},
+	}, options.Find().SetSort(bson.D{{Key: types.FiValidatorEpochValidator, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load validators of epoch #%d; %s", epoch, err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.ValidatorEpoch, 0)
+	for cr.Next(context.Background()) {
+		var row types.ValidatorEpoch
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator epoch; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// AddValidatorEpochGap stores an epoch with missing validator snapshots in the database.
+// An existing gap of the same epoch is replaced.
+func (db *MongoDbBridge) AddValidatorEpochGap(gap *types.ValidatorEpochGap) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochGaps)
+
+	_, err := col.ReplaceOne(context.Background(),
+		bson.D{{Key: "_id", Value: gap.Epoch}},
+		gap,
+		options.Replace().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("could not store validator gap of epoch #%d; %s", gap.Epoch, err.Error())
+	}
+	return err
+}
+
+// RemoveValidatorEpochGap removes the gap of the given epoch from the database.
+func (db *MongoDbBridge) RemoveValidatorEpochGap(epoch int64) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochGaps)
+
+	_, err := col.DeleteOne(context.Background(), bson.D{{Key: "_id", Value: epoch}})
+	if err != nil {
+		db.log.Errorf("could not remove validator gap of epoch #%d; %s", epoch, err.Error())
+	}
+	return err
+}
+
+// ValidatorEpochGaps loads up to count epochs with missing validator snapshots, the oldest first.
+func (db *MongoDbBridge) ValidatorEpochGaps(count int64) ([]*types.ValidatorEpochGap, error) {
+	col := db.client.Database(db.dbName).Collection(colValidatorEpochGaps)
+
+	cr, err := col.Find(context.Background(), bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(count))
+	if err != nil {
+		db.log.Errorf("can not load validator epoch gaps; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cr)
+
+	list := make([]*types.ValidatorEpochGap, 0)
+	for cr.Next(context.Background()) {
+		var row types.ValidatorEpochGap
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator epoch gap; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
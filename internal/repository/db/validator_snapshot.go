@@ -0,0 +1,195 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+	"time"
+)
+
+// colValidatorSnapshots represents the name of the validators directory snapshot collection.
+const colValidatorSnapshots = "validator_snapshots"
+
+// StoreValidatorSnapshot stores, or replaces the snapshot of a validator.
+func (db *MongoDbBridge) StoreValidatorSnapshot(vs *types.ValidatorSnapshot) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorSnapshots)
+
+	_, err := col.ReplaceOne(context.Background(), bson.D{{Key: "_id", Value: vs.Id}}, vs, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("could not store snapshot of validator #%d; %s", vs.Id, err.Error())
+	}
+	return err
+}
+
+// PurgeValidatorSnapshots removes validator snapshots taken before the given time.
+func (db *MongoDbBridge) PurgeValidatorSnapshots(before time.Time) error {
+	col := db.client.Database(db.dbName).Collection(colValidatorSnapshots)
+
+	_, err := col.DeleteMany(context.Background(), bson.D{{Key: types.FiValidatorSnapshotTime, Value: bson.D{{Key: "$lt", Value: before}}}})
+	if err != nil {
+		db.log.Errorf("could not purge validator snapshots; %s", err.Error())
+	}
+	return err
+}
+
+// DelegatorsCountByValidator provides the number of active delegations of each validator.
+func (db *MongoDbBridge) DelegatorsCountByValidator() (map[uint64]int64, error) {
+	col := db.client.Database(db.dbName).Collection(colDelegations)
+
+	cur, err := col.Aggregate(context.Background(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + types.FiDelegationToValidator},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not count delegators; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	res := make(map[uint64]int64)
+	for cur.Next(context.Background()) {
+		var row struct {
+			Validator string `bson:"_id"`
+			Count     int64  `bson:"count"`
+		}
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode delegators count; %s", err.Error())
+			return nil, err
+		}
+
+		id, err := hexutil.DecodeBig(row.Validator)
+		if err != nil {
+			continue
+		}
+		res[id.Uint64()] = row.Count
+	}
+	return res, nil
+}
+
+// ValidatorSnapshots loads a page of the validators directory matching the filter
+// and sorted by the given field. The cursor is the position of the last seen item, if any;
+// the count can be negative to load the page preceding the cursor.
+func (db *MongoDbBridge) ValidatorSnapshots(fi *types.ValidatorSnapshotFilter, sortBy string, desc bool, cursor *uint64, count int64) (*types.ValidatorSnapshotList, error) {
+	col := db.client.Database(db.dbName).Collection(colValidatorSnapshots)
+	filter := validatorSnapshotFilter(fi)
+
+	total, err := col.CountDocuments(context.Background(), filter)
+	if err != nil {
+		db.log.Errorf("can not count validator snapshots; %s", err.Error())
+		return nil, err
+	}
+
+	offset, limit := validatorSnapshotsRange(cursor, count, total)
+	list := types.ValidatorSnapshotList{
+		Collection: make([]*types.ValidatorSnapshot, 0, limit),
+		Offset:     uint64(offset),
+		Total:      uint64(total),
+		IsStart:    offset == 0,
+		IsEnd:      offset+limit >= total,
+	}
+	if limit <= 0 {
+		return &list, nil
+	}
+
+	dir := 1
+	if desc {
+		dir = -1
+	}
+
+	cur, err := col.Find(context.Background(), filter, options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: dir}, {Key: "_id", Value: 1}}).
+		SetSkip(offset).
+		SetLimit(limit))
+	if err != nil {
+		db.log.Errorf("can not load validator snapshots; %s", err.Error())
+		return nil, err
+	}
+	defer db.closeCursor(cur)
+
+	for cur.Next(context.Background()) {
+		var row types.ValidatorSnapshot
+		if err := cur.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator snapshot; %s", err.Error())
+			return nil, err
+		}
+		list.Collection = append(list.Collection, &row)
+	}
+	return &list, nil
+}
+
+// validatorSnapshotsRange calculates the offset and the number of items
+// of the directory page for the given cursor and count.
+func validatorSnapshotsRange(cursor *uint64, count int64, total int64) (int64, int64) {
+	// forward from the cursor, or from the top
+	if count > 0 {
+		offset := int64(0)
+		if cursor != nil {
+			offset = int64(*cursor) + 1
+		}
+		if offset > total {
+			offset = total
+		}
+		return offset, count
+	}
+
+	// backward from the cursor, or from the bottom
+	end := total
+	if cursor != nil && int64(*cursor) < total {
+		end = int64(*cursor)
+	}
+	offset := end + count
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, end - offset
+}
+
+// validatorSnapshotFilter builds the database filter of the validators directory.
+func validatorSnapshotFilter(fi *types.ValidatorSnapshotFilter) bson.D {
+	filter := bson.D{}
+	if fi == nil {
+		return filter
+	}
+
+	if fi.IsActive != nil {
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotActive, Value: *fi.IsActive})
+	}
+	if fi.IsOffline != nil {
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotOffline, Value: *fi.IsOffline})
+	}
+	if fi.HasInfo != nil {
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotHasInfo, Value: *fi.HasInfo})
+	}
+	if fi.IsLocked != nil {
+		op := "$lte"
+		if *fi.IsLocked {
+			op = "$gt"
+		}
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotLocked, Value: bson.D{{Key: op, Value: time.Now().UTC()}}})
+	}
+
+	commission := bson.D{}
+	if fi.MinCommission != nil {
+		commission = append(commission, bson.E{Key: "$gte", Value: *fi.MinCommission})
+	}
+	if fi.MaxCommission != nil {
+		commission = append(commission, bson.E{Key: "$lte", Value: *fi.MaxCommission})
+	}
+	if len(commission) > 0 {
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotCommission, Value: commission})
+	}
+
+	if fi.MinStake != nil {
+		val, _ := new(big.Float).Quo(new(big.Float).SetInt(fi.MinStake), big.NewFloat(1e18)).Float64()
+		filter = append(filter, bson.E{Key: types.FiValidatorSnapshotStake, Value: bson.D{{Key: "$gte", Value: val}}})
+	}
+	return filter
+}
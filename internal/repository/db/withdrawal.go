@@ -50,6 +50,33 @@ func (db *MongoDbBridge) initWithdrawalsCollection(col *mongo.Collection) {
 	db.log.Debugf("withdrawals collection initialized")
 }
 
+// migrateWithdrawalPenalty moves the penalty of withdraw requests from the legacy "slash" field
+// to the "penalty" field. The legacy field was also used to store the withdraw transaction hash
+// of requests stored already finalized; such values are not a penalty and are dropped.
+// Migrated documents don't have the legacy field anymore, so the migration runs only once.
+func (db *MongoDbBridge) migrateWithdrawalPenalty() {
+	col := db.client.Database(db.dbName).Collection(colWithdrawals)
+
+	res, err := col.UpdateMany(context.Background(),
+		bson.D{{Key: types.FiWithdrawalLegacySlash, Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.A{
+			bson.D{{Key: "$set", Value: bson.D{{Key: types.FiWithdrawalPenalty, Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$eq", Value: bson.A{"$" + types.FiWithdrawalLegacySlash, "$" + types.FiWithdrawalFinTrx}}},
+				"$$REMOVE",
+				"$" + types.FiWithdrawalLegacySlash,
+			}}}}}}},
+			bson.D{{Key: "$unset", Value: types.FiWithdrawalLegacySlash}},
+		})
+	if err != nil {
+		db.log.Errorf("can not migrate withdrawal penalty; %s", err.Error())
+		return
+	}
+
+	if res.ModifiedCount > 0 {
+		db.log.Noticef("penalty of %d withdraw requests migrated", res.ModifiedCount)
+	}
+}
+
 // Withdrawal returns details of a withdrawal request specified by the request ID.
 func (db *MongoDbBridge) Withdrawal(addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
 	// get the collection for withdrawals
@@ -204,10 +231,11 @@ func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
 		{Key: types.FiWithdrawalCreated, Value: uint64(wr.CreatedTime)},
 		{Key: types.FiWithdrawalStamp, Value: time.Unix(int64(wr.CreatedTime), 0)},
 		{Key: types.FiWithdrawalValue, Value: val},
-		{Key: types.FiWithdrawalSlash, Value: pen},
+		{Key: types.FiWithdrawalPenalty, Value: pen},
 		{Key: types.FiWithdrawalRequestTrx, Value: wr.RequestTrx.String()},
 		{Key: types.FiWithdrawalFinTrx, Value: trx},
 		{Key: types.FiWithdrawalFinTime, Value: (*uint64)(wr.WithdrawTime)},
+		{Key: types.FiWithdrawalClosed, Value: wr.IsClosed()},
 	}}}, new(options.UpdateOptions).SetUpsert(true))
 	if err != nil {
 		db.log.Critical(err)
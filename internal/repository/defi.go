@@ -103,8 +103,8 @@ func (p *proxy) FLendGetReserveList() ([]common.Address, error) {
 	return p.rpc.FLendGetReserveList()
 }
 
-// FLendGetUserDepositHistory resolves deposit history
-// data for specified user and asset address
-func (p *proxy) FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
-	return p.rpc.FLendGetUserDepositHistory(userAddress, assetAddress)
+// FLendPriceFeeds resolves the price feed aggregators used by the fLend price oracle
+// mapped to the list of fLend assets they provide price for.
+func (p *proxy) FLendPriceFeeds() (map[common.Address][]common.Address, error) {
+	return p.rpc.FLendPriceFeeds()
 }
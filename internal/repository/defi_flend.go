@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"math"
+	"math/big"
+	"time"
+)
+
+// fLendHistoryPageSize represents the number of actions loaded in one pass
+// when the non-paginated fLend history lists are collected.
+const fLendHistoryPageSize = 500
+
+// fLendRayPerBasisPoint represents a single basis point in the ray precision used by fLend rates.
+var fLendRayPerBasisPoint = new(big.Int).Exp(big.NewInt(10), big.NewInt(23), nil)
+
+// FLendLendingPoolAddress returns the address of the configured fLend lending pool.
+func (p *proxy) FLendLendingPoolAddress() common.Address {
+	return p.rpc.FLendLendingPoolAddress()
+}
+
+// AddFLendAction stores a new fLend lending pool action in the persistent storage.
+func (p *proxy) AddFLendAction(act *types.FLendAction) error {
+	return p.db.AddFLendAction(act)
+}
+
+// FLendActions provides list of fLend lending pool actions for the given user, asset and type.
+func (p *proxy) FLendActions(user *common.Address, asset *common.Address, actionType *int32, cursor *string, count int32) (*types.FLendActionList, error) {
+	// prep the filter
+	fi := bson.D{}
+
+	// add position owner to the filter
+	if user != nil {
+		fi = append(fi, bson.E{Key: types.FiFLendActionUser, Value: user.String()})
+	}
+
+	// add asset address to the filter
+	if asset != nil {
+		fi = append(fi, bson.E{Key: types.FiFLendActionAsset, Value: asset.String()})
+	}
+
+	// add action type to the filter
+	if actionType != nil {
+		fi = append(fi, bson.E{Key: types.FiFLendActionType, Value: *actionType})
+	}
+	return p.db.FLendActions(cursor, count, &fi)
+}
+
+// fLendHistory collects the full list of fLend lending pool actions of the given type
+// for the given user and asset, the most recent first, loading the index page by page.
+func (p *proxy) fLendHistory(user *common.Address, asset *common.Address, actionType int32) ([]*types.FLendAction, error) {
+	list := make([]*types.FLendAction, 0)
+
+	var cursor *string
+	for {
+		al, err := p.FLendActions(user, asset, &actionType, cursor, fLendHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, al.Collection...)
+		if al.IsEnd || len(al.Collection) == 0 {
+			return list, nil
+		}
+
+		pk := al.Collection[len(al.Collection)-1].Pk()
+		cursor = &pk
+	}
+}
+
+// FLendGetUserDepositHistory resolves deposit history
+// data for specified user and asset address
+func (p *proxy) FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
+	tp := int32(types.FLendActionDeposit)
+	al, err := p.fLendHistory(userAddress, assetAddress, tp)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.FLendDeposit, len(al))
+	for i, act := range al {
+		list[i] = &types.FLendDeposit{
+			AssetAddress:      act.Asset,
+			UserAddress:       act.Counterparty,
+			OnBehalfOfAddress: act.User,
+			Amount:            act.Amount,
+			ReferralCode:      act.ReferralCode,
+			Timestamp:         act.TimeStamp,
+		}
+	}
+	return list, nil
+}
+
+// FLendGetUserBorrowHistory resolves borrow history
+// data for specified user and asset address
+func (p *proxy) FLendGetUserBorrowHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendBorrow, error) {
+	tp := int32(types.FLendActionBorrow)
+	al, err := p.fLendHistory(userAddress, assetAddress, tp)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.FLendBorrow, len(al))
+	for i, act := range al {
+		list[i] = &types.FLendBorrow{
+			AssetAddress:      act.Asset,
+			UserAddress:       act.Counterparty,
+			OnBehalfOfAddress: act.User,
+			Amount:            act.Amount,
+			InterestRateMode:  act.InterestRateMode,
+			BorrowRate:        fLendRateBasisPoints(act.BorrowRate.ToInt()),
+			BorrowRateRay:     act.BorrowRate,
+			ReferralCode:      act.ReferralCode,
+			Timestamp:         act.TimeStamp,
+		}
+	}
+	return list, nil
+}
+
+// FLendUsers provides the list of all users who ever opened a position on the fLend lending pool,
+// optionally limited to positions in the given asset.
+func (p *proxy) FLendUsers(asset *common.Address) ([]common.Address, error) {
+	return p.db.FLendUsers(asset)
+}
+
+// RefreshFLendPosition loads the current state of the fLend position of the given user
+// from the lending pool and stores it in the persistent storage.
+func (p *proxy) RefreshFLendPosition(user *common.Address) (*types.FLendPosition, error) {
+	ad, err := p.rpc.FLendGetUserAccountData(user)
+	if err != nil {
+		return nil, err
+	}
+
+	pos := types.FLendPosition{
+		User:            *user,
+		TotalCollateral: ad.TotalCollateralFUSD,
+		TotalDebt:       ad.TotalDebtFUSD,
+		HealthFactor:    ad.HealthFactor,
+		Updated:         time.Now().UTC(),
+	}
+	if err := p.db.StoreFLendPosition(&pos); err != nil {
+		return nil, err
+	}
+	return &pos, nil
+}
+
+// FLendPosition provides the latest known state of the fLend position of the given user.
+func (p *proxy) FLendPosition(user *common.Address) (*types.FLendPosition, error) {
+	return p.db.FLendPosition(user)
+}
+
+// FLendAtRiskPositions provides the list of fLend positions with health factor below the given threshold.
+func (p *proxy) FLendAtRiskPositions(threshold float64, count int32) ([]*types.FLendPosition, error) {
+	return p.db.FLendAtRiskPositions(threshold, count)
+}
+
+// FLendHealthHistory provides the health factor time series of the given fLend user.
+func (p *proxy) FLendHealthHistory(user *common.Address, resolution string, fromTime int64, toTime int64) ([]types.FLendHealthPoint, error) {
+	return p.db.FLendHealthHistory(user, resolution, fromTime, toTime)
+}
+
+// fLendRateBasisPoints converts the given rate in ray (1e27 = 100%) to basis points.
+func fLendRateBasisPoints(ray *big.Int) int32 {
+	bp := new(big.Int).Div(ray, fLendRayPerBasisPoint)
+	if !bp.IsInt64() || bp.Int64() > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(bp.Int64())
+}
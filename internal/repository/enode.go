@@ -46,8 +46,17 @@ func (p *proxy) NetworkNodeConfirmCheck(node *enode.Node, bhp p2p.BlockHeightPro
 		}
 	}
 
+	// locate the server; the location is refreshed on each check since the node may move
+	var locUpdate *types.GeoLocation
+	loc, err := p.GeoLocation(node.IP())
+	if err != nil {
+		p.log.Errorf("geographic location not found for %s; %s", node.IP().String(), err.Error())
+	} else {
+		locUpdate = &loc
+	}
+
 	// write the updated info to the DB
-	err = p.db.NetworkNodeConfirmCheck(node.ID(), inf)
+	err = p.db.NetworkNodeConfirmCheck(node.ID(), inf, locUpdate)
 	if err == nil {
 		return false, nil
 	}
@@ -58,12 +67,6 @@ func (p *proxy) NetworkNodeConfirmCheck(node *enode.Node, bhp p2p.BlockHeightPro
 		return false, err
 	}
 
-	// locate the server
-	loc, err := p.GeoLocation(node.IP())
-	if err != nil {
-		p.log.Errorf("geographic location not found for %s; %s", node.IP().String(), err.Error())
-	}
-
 	// inform about new node
 	p.log.Infof("new network node %s found at %s", node.ID(), node.URLv4())
 	now := time.Now().UTC()
@@ -125,6 +128,16 @@ func (p *proxy) NetworkNodesGeoAggregated(level int) ([]*types.OperaNodeLocation
 	return p.db.NetworkNodesGeoAggregated(level)
 }
 
+// NetworkNodes provides a page of the Opera network nodes matching the filter, the most recently seen first.
+func (p *proxy) NetworkNodes(fi *types.OperaNodeFilter, cursor *types.OperaNodeListCursor, count int64) (*types.OperaNodeList, error) {
+	return p.db.NetworkNodes(fi, cursor, count)
+}
+
+// NetworkNodesVersionAggregated provides a list of active Opera nodes aggregated by their client version.
+func (p *proxy) NetworkNodesVersionAggregated() ([]*types.OperaNodeVersionAggregate, error) {
+	return p.db.NetworkNodesVersionAggregated()
+}
+
 // PeerInformation returns detailed information of the given peer, if it can be obtained.
 func (p *proxy) PeerInformation(node *enode.Node, bhp p2p.BlockHeightProvider) (*types.OperaNodeInformation, error) {
 	return p2p.PeerInformation(node, bhp)
@@ -0,0 +1,177 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StoredEpoch provides the epoch of the given id from the persistent storage
+// including its block range, transactions and validators detail.
+// Nil is returned if the epoch has not been processed yet.
+func (p *proxy) StoredEpoch(id uint64) (*types.Epoch, error) {
+	return p.db.StoredEpoch(id)
+}
+
+// EnrichEpoch collects the block range, transactions and the validator set
+// of the given sealed epoch. The blocks of the epoch must already be scanned.
+func (p *proxy) EnrichEpoch(ep *types.Epoch) error {
+	first, last, err := p.epochBlockRange(ep)
+	if err != nil {
+		return err
+	}
+
+	trx, gas, err := p.db.TransactionsStatsInRange(first, last)
+	if err != nil {
+		return err
+	}
+
+	vl, err := p.db.ValidatorEpochsOf(uint64(ep.Id))
+	if err != nil {
+		return err
+	}
+
+	vc := hexutil.Uint64(len(vl))
+	ep.Validators = make([]types.EpochValidator, len(vl))
+	for i, ve := range vl {
+		ep.Validators[i] = types.EpochValidator{Id: ve.ValidatorId, Stake: ve.ReceivedStake}
+	}
+
+	ep.FirstBlock = (*hexutil.Uint64)(&first)
+	ep.LastBlock = (*hexutil.Uint64)(&last)
+	ep.TransactionCount = (*hexutil.Uint64)(&trx)
+	ep.GasUsed = (*hexutil.Uint64)(&gas)
+	ep.ValidatorsCount = &vc
+	return nil
+}
+
+// epochBlockRange finds the first and the last block of the given epoch
+// within the range of blocks already scanned.
+func (p *proxy) epochBlockRange(ep *types.Epoch) (uint64, uint64, error) {
+	top, err := p.db.LastKnownBlock()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the previous epoch, if processed, tells us where to start
+	bottom := uint64(1)
+	if ep.Id > 1 {
+		prev, err := p.db.StoredEpoch(uint64(ep.Id) - 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		if prev != nil && prev.LastBlock != nil {
+			bottom = uint64(*prev.LastBlock) + 1
+		}
+	}
+
+	first, err := p.epochBoundary(uint64(ep.Id), bottom, top)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the block of the next epoch must already be known to close the range
+	next, err := p.epochBoundary(uint64(ep.Id)+1, first, top)
+	if err != nil {
+		return 0, 0, err
+	}
+	if first > top || next > top || next == first {
+		return 0, 0, fmt.Errorf("blocks of epoch #%d not scanned yet", ep.Id)
+	}
+	return first, next - 1, nil
+}
+
+// epochBoundary finds the lowest block of the given range belonging to the given epoch, or a later one.
+// The block above the top of the range is returned if no such block exists.
+func (p *proxy) epochBoundary(epoch uint64, bottom uint64, top uint64) (uint64, error) {
+	lo, hi := bottom, top+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		blk, err := p.BlockByNumber((*hexutil.Uint64)(&mid))
+		if err != nil {
+			return 0, err
+		}
+
+		// the node must provide the epoch of the block
+		if blk.Epoch == 0 {
+			return 0, fmt.Errorf("epoch of block #%d not available", mid)
+		}
+
+		if uint64(blk.Epoch) >= epoch {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// EpochBlocks pulls a list of blocks of the given epoch starting at the specified block number,
+// the newest block first. The list is empty if the block range of the epoch is not known.
+func (p *proxy) EpochBlocks(ep *types.Epoch, num *uint64, count int32) (*types.BlockList, error) {
+	list := types.BlockList{Collection: make([]*types.Block, 0)}
+	if ep.FirstBlock == nil || ep.LastBlock == nil || count == 0 {
+		list.IsStart, list.IsEnd = true, true
+		return &list, nil
+	}
+	first, last := uint64(*ep.FirstBlock), uint64(*ep.LastBlock)
+
+	// find the range of blocks to be loaded; positive count goes down from the cursor, negative goes up
+	var top, bottom uint64
+	if count > 0 {
+		top = last
+		if num != nil {
+			if *num <= first {
+				list.IsEnd = true
+				return &list, nil
+			}
+			if *num-1 < top {
+				top = *num - 1
+			}
+		}
+		bottom = first
+		if top-first+1 > uint64(count) {
+			bottom = top - uint64(count) + 1
+		}
+	} else {
+		bottom = first
+		if num != nil {
+			if *num >= last {
+				list.IsStart = true
+				return &list, nil
+			}
+			if *num+1 > bottom {
+				bottom = *num + 1
+			}
+		}
+		top = last
+		if last-bottom+1 > uint64(-count) {
+			top = bottom + uint64(-count) - 1
+		}
+	}
+
+	// load the blocks, the newest first
+	for n := top; n >= bottom; n-- {
+		blk, err := p.BlockByNumber((*hexutil.Uint64)(&n))
+		if err != nil {
+			return nil, err
+		}
+		list.Collection = append(list.Collection, blk)
+
+		if n == 0 {
+			break
+		}
+	}
+
+	list.IsStart, list.IsEnd = top == last, bottom == first
+	return &list, nil
+}
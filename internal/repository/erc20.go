@@ -124,9 +124,10 @@ func (p *proxy) Erc20TokensList(count int32) ([]common.Address, error) {
 // Erc20LogoURL provides URL address of a logo of the ERC20 token.
 func (p *proxy) Erc20LogoURL(addr *common.Address) string {
 	// do we know the token?
-	logo, ok := p.cfg.TokenLogo[*addr]
+	logo, ok := p.cfg.TokenLogoOf(addr)
 	if !ok {
-		logo = p.cfg.TokenLogo[common.HexToAddress(config.EmptyAddress)]
+		empty := common.HexToAddress(config.EmptyAddress)
+		logo, _ = p.cfg.TokenLogoOf(&empty)
 	}
 	return logo
 }
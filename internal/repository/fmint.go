@@ -8,7 +8,14 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 */
 package repository
 
-import "fantom-api-graphql/internal/types"
+import (
+	"fantom-api-graphql/internal/types"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // AddFMintTransaction adds the specified fMint transaction to persistent storage.
 func (p *proxy) AddFMintTransaction(trx *types.FMintTransaction) error {
@@ -20,3 +27,62 @@ func (p *proxy) AddFMintTransaction(trx *types.FMintTransaction) error {
 func (p *proxy) FMintUsers(tt int32) ([]*types.FMintUserTokens, error) {
 	return p.db.FMintUsers(tt)
 }
+
+// FMintTransactions provides list of fMint transactions for the given user, token, type
+// and time range. Nil filter values are not applied; zero time bounds are open.
+func (p *proxy) FMintTransactions(user *common.Address, token *common.Address, trxType *int32, fromTime int64, toTime int64, cursor *string, count int32) (*types.FMintTransactionList, error) {
+	// prep the filter
+	fi := bson.D{}
+
+	// add user address to the filter
+	if user != nil {
+		fi = append(fi, bson.E{Key: types.FiFMintTransactionUser, Value: user.String()})
+	}
+
+	// add token address to the filter
+	if token != nil {
+		fi = append(fi, bson.E{Key: types.FiFMintTransactionToken, Value: token.String()})
+	}
+
+	// add transaction type to the filter
+	if trxType != nil {
+		fi = append(fi, bson.E{Key: types.FiFMintTransactionType, Value: *trxType})
+	}
+
+	// add time range to the filter
+	if fromTime != 0 || toTime != 0 {
+		tr := bson.D{}
+		if fromTime != 0 {
+			tr = append(tr, bson.E{Key: "$gte", Value: primitive.NewDateTimeFromTime(time.Unix(fromTime, 0))})
+		}
+		if toTime != 0 {
+			tr = append(tr, bson.E{Key: "$lte", Value: primitive.NewDateTimeFromTime(time.Unix(toTime, 0))})
+		}
+		fi = append(fi, bson.E{Key: types.FiFMintTransactionTimestamp, Value: tr})
+	}
+	return p.db.FMintTransactions(cursor, count, &fi)
+}
+
+// IsFMintRewardsDistribution checks if the given address is the fMint rewards distribution contract.
+func (p *proxy) IsFMintRewardsDistribution(adr *common.Address) bool {
+	rd, err := p.rpc.FMintRewardsDistributionAddress()
+	if err != nil {
+		return false
+	}
+	return rd == *adr
+}
+
+// FMintRewardsToken provides the address of the token paid out as fMint rewards.
+func (p *proxy) FMintRewardsToken() (common.Address, error) {
+	return p.rpc.FMintRewardsToken()
+}
+
+// AddFMintAccountRisk stores the fMint account risk snapshot in the persistent storage.
+func (p *proxy) AddFMintAccountRisk(risk *types.FMintAccountRisk) error {
+	return p.db.AddFMintAccountRisk(risk)
+}
+
+// FMintRiskHistory provides the collateral to debt ratio time series of the given fMint account.
+func (p *proxy) FMintRiskHistory(user *common.Address, resolution string, fromTime int64, toTime int64) ([]types.FMintRiskPoint, error) {
+	return p.db.FMintRiskHistory(user, resolution, fromTime, toTime)
+}
@@ -13,6 +13,7 @@ const defaultLocationNameFormat = "en"
 // Bridge represents the bridge to GeoIP database services.
 type Bridge struct {
 	db  *geoip2.Reader
+	asn *geoip2.Reader
 	log logger.Logger
 }
 
@@ -24,8 +25,19 @@ func New(cfg *config.PeerNetworking, log logger.Logger) (*Bridge, error) {
 		return nil, err
 	}
 
+	// the hosting network database is optional
+	var asn *geoip2.Reader
+	if cfg.GeoASNPath != "" {
+		asn, err = geoip2.Open(cfg.GeoASNPath)
+		if err != nil {
+			log.Criticalf("can not open GeoIP ASN database at %s; %s", cfg.GeoASNPath, err.Error())
+			return nil, err
+		}
+	}
+
 	return &Bridge{
 		db:  db,
+		asn: asn,
 		log: log,
 	}, nil
 }
@@ -36,6 +48,12 @@ func (gib *Bridge) Close() {
 	if err != nil {
 		gib.log.Errorf("could not close GeoIP database; %s", err.Error())
 	}
+
+	if gib.asn != nil {
+		if err := gib.asn.Close(); err != nil {
+			gib.log.Errorf("could not close GeoIP ASN database; %s", err.Error())
+		}
+	}
 }
 
 // Location provides location details for the given IP address.
@@ -61,5 +79,16 @@ func (gib *Bridge) Location(ip net.IP) (types.GeoLocation, error) {
 	} else {
 		loc.Region = loc.Country
 	}
+
+	// add the hosting network, if possible
+	if gib.asn != nil {
+		as, err := gib.asn.ASN(ip)
+		if err != nil {
+			gib.log.Errorf("could not lookup ASN of IP address %s, %s", ip.String(), err.Error())
+			return loc, nil
+		}
+		loc.ASN = uint32(as.AutonomousSystemNumber)
+		loc.ASOrganization = as.AutonomousSystemOrganization
+	}
 	return loc, nil
 }
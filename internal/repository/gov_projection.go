@@ -0,0 +1,312 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+)
+
+// govBallot represents a single vote counted in the projected tally.
+type govBallot struct {
+	weight  *big.Int
+	choices []hexutil.Uint64
+}
+
+// govValidatorStake represents the current stake delegated to a validator split by delegators.
+type govValidatorStake struct {
+	id         hexutil.Big
+	delegators map[common.Address]*big.Int
+}
+
+// GovernanceProjection calculates the projected outcome of the given Governance Proposal
+// from the indexed votes and the current stake of the delegators. The what-if votes,
+// if any, are added to the votes placed so far in a separate scenario.
+func (p *proxy) GovernanceProjection(gov common.Address, propId *hexutil.Big, whatIf []*types.GovernanceVote) (*types.GovernanceProjection, error) {
+	gp, err := p.GovernanceProposal(gov, propId)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := p.GovernanceTotalWeight(&gov)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := p.db.GovernanceProposalVotesAll(gov, propId)
+	if err != nil {
+		return nil, err
+	}
+
+	// the Governance ratios use the same decimal unit as the SFC
+	minVotes := new(big.Int).Div(new(big.Int).Mul(total.ToInt(), gp.MinVotes.ToInt()), sfcDecimalUnit)
+	proj := types.GovernanceProjection{
+		TotalWeight: total,
+		MinVotes:    hexutil.Big(*minVotes),
+		Overridable: make([]*types.GovernanceOverridableWeight, 0),
+		Scenarios:   make([]*types.GovernanceScenario, 0, 4),
+	}
+
+	// load the current stake of all the validators involved
+	stakes, err := p.govValidatorStakes(votes)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect delegators overriding their validator vote
+	overrides := make(map[common.Address]map[common.Address]bool)
+	for _, v := range votes {
+		if *v.DelegatedTo == v.From {
+			continue
+		}
+		if _, ok := overrides[*v.DelegatedTo]; !ok {
+			overrides[*v.DelegatedTo] = make(map[common.Address]bool)
+		}
+		overrides[*v.DelegatedTo][v.From] = true
+	}
+
+	// calculate the current weight of the votes; validators vote with their received stake
+	// except the stake of delegators who voted on their own
+	ballots := make([]govBallot, 0, len(votes))
+	opposed := make([]govBallot, 0, len(votes))
+	favored := make([]govBallot, 0, len(votes))
+	voted := new(big.Int)
+	for _, v := range votes {
+		vs := stakes[*v.DelegatedTo]
+
+		// delegator vote overriding the validator
+		if *v.DelegatedTo != v.From {
+			w, ok := vs.delegators[v.From]
+			if !ok {
+				continue
+			}
+
+			ballots = append(ballots, govBallot{weight: w, choices: v.Choices})
+			voted.Add(voted, w)
+			continue
+		}
+
+		// validator vote
+		w, free := new(big.Int), new(big.Int)
+		var count int32
+		for adr, amo := range vs.delegators {
+			if overrides[v.From][adr] {
+				continue
+			}
+			w.Add(w, amo)
+			if adr != v.From {
+				free.Add(free, amo)
+				count++
+			}
+		}
+
+		ballots = append(ballots, govBallot{weight: w, choices: v.Choices})
+		voted.Add(voted, w)
+
+		if count > 0 {
+			proj.Overridable = append(proj.Overridable, &types.GovernanceOverridableWeight{
+				Validator:   v.From,
+				ValidatorId: vs.id,
+				Weight:      hexutil.Big(*free),
+				Delegators:  count,
+			})
+
+			// in the override scenarios the delegators take their weight from the validator vote
+			rest := new(big.Int).Sub(w, free)
+			opposed = append(opposed, govBallot{weight: rest, choices: v.Choices}, govBallot{weight: free, choices: govUniformChoices(len(gp.Options), 0)})
+			favored = append(favored, govBallot{weight: rest, choices: v.Choices}, govBallot{weight: free, choices: govUniformChoices(len(gp.Options), len(gp.OpinionScales)-1)})
+			continue
+		}
+
+		opposed = append(opposed, govBallot{weight: w, choices: v.Choices})
+		favored = append(favored, govBallot{weight: w, choices: v.Choices})
+	}
+	proj.VotedWeight = hexutil.Big(*voted)
+
+	proj.Scenarios = append(proj.Scenarios,
+		govTally(types.GovernanceScenarioCurrent, gp, minVotes, ballots),
+		govTally(types.GovernanceScenarioOverridesOppose, gp, minVotes, opposed),
+		govTally(types.GovernanceScenarioOverridesFavor, gp, minVotes, favored),
+	)
+
+	// add the hypothetical votes, if any
+	if len(whatIf) > 0 {
+		extra := make([]govBallot, len(ballots), len(ballots)+len(whatIf))
+		copy(extra, ballots)
+		for _, v := range whatIf {
+			if err := govCheckChoices(gp, v.Choices); err != nil {
+				return nil, err
+			}
+			extra = append(extra, govBallot{weight: v.Weight.ToInt(), choices: v.Choices})
+		}
+		proj.Scenarios = append(proj.Scenarios, govTally(types.GovernanceScenarioWhatIf, gp, minVotes, extra))
+	}
+	return &proj, nil
+}
+
+// govValidatorStakes loads the current stake of all the validators involved in the given votes.
+func (p *proxy) govValidatorStakes(votes []*types.GovernanceVoteRecord) (map[common.Address]*govValidatorStake, error) {
+	res := make(map[common.Address]*govValidatorStake)
+	for _, v := range votes {
+		if _, ok := res[*v.DelegatedTo]; ok {
+			continue
+		}
+
+		dl, err := p.db.DelegationsAll(&bson.D{
+			{Key: types.FiDelegationToValidatorAddress, Value: v.DelegatedTo.String()},
+			{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		vs := govValidatorStake{delegators: make(map[common.Address]*big.Int)}
+		for _, d := range dl {
+			vs.id = *d.ToStakerId
+			if _, ok := vs.delegators[d.Address]; !ok {
+				vs.delegators[d.Address] = new(big.Int)
+			}
+			vs.delegators[d.Address].Add(vs.delegators[d.Address], d.AmountDelegated.ToInt())
+		}
+		res[*v.DelegatedTo] = &vs
+	}
+	return res, nil
+}
+
+// govCheckChoices validates the choices of a vote against the Governance Proposal.
+func govCheckChoices(gp *types.GovernanceProposal, choices []hexutil.Uint64) error {
+	if len(choices) != len(gp.Options) {
+		return fmt.Errorf("expected %d choices, %d given", len(gp.Options), len(choices))
+	}
+	for _, c := range choices {
+		if int(c) >= len(gp.OpinionScales) {
+			return fmt.Errorf("unknown opinion %d", c)
+		}
+	}
+	return nil
+}
+
+// govUniformChoices makes a list of choices with the same opinion on all the options.
+func govUniformChoices(options int, opinion int) []hexutil.Uint64 {
+	res := make([]hexutil.Uint64, options)
+	for i := range res {
+		res[i] = hexutil.Uint64(opinion)
+	}
+	return res
+}
+
+// govTally calculates the outcome of the Governance Proposal for the given votes
+// the same way the Governance contract does.
+func govTally(name string, gp *types.GovernanceProposal, minVotes *big.Int, ballots []govBallot) *types.GovernanceScenario {
+	sc := types.GovernanceScenario{
+		Name:    name,
+		Options: make([]*types.GovernanceOptionState, len(gp.Options)),
+	}
+
+	// the agreement is relative to the top of the opinion scale
+	var maxScale *big.Int
+	if len(gp.OpinionScales) > 0 {
+		maxScale = new(big.Int).SetUint64(uint64(gp.OpinionScales[len(gp.OpinionScales)-1]))
+	}
+
+	votes := new(big.Int)
+	agreement := make([]*big.Int, len(gp.Options))
+	for i := range agreement {
+		agreement[i] = new(big.Int)
+	}
+	for _, b := range ballots {
+		if b.weight.Sign() <= 0 || len(b.choices) != len(gp.Options) || maxScale == nil || maxScale.Sign() == 0 {
+			continue
+		}
+
+		votes.Add(votes, b.weight)
+		for i, c := range b.choices {
+			if int(c) >= len(gp.OpinionScales) {
+				continue
+			}
+			scale := new(big.Int).SetUint64(uint64(gp.OpinionScales[c]))
+			agreement[i].Add(agreement[i], new(big.Int).Div(new(big.Int).Mul(scale, b.weight), maxScale))
+		}
+	}
+	sc.Votes = hexutil.Big(*votes)
+	sc.MinVotesReached = votes.Sign() > 0 && votes.Cmp(minVotes) >= 0
+
+	// find the option with the most agreement reaching the min agreement ratio
+	var most *big.Int
+	for i := range gp.Options {
+		ratio := new(big.Int)
+		if votes.Sign() > 0 {
+			ratio.Div(new(big.Int).Mul(agreement[i], sfcDecimalUnit), votes)
+		}
+
+		sc.Options[i] = &types.GovernanceOptionState{
+			OptionId:       hexutil.Big(*big.NewInt(int64(i))),
+			Votes:          hexutil.Big(*votes),
+			AgreementRatio: hexutil.Big(*ratio),
+			Agreement:      hexutil.Big(*agreement[i]),
+		}
+
+		if agreement[i].Sign() == 0 || ratio.Cmp(gp.MinAgreement.ToInt()) < 0 {
+			continue
+		}
+		sc.MinAgreementReached = true
+
+		if sc.MinVotesReached && (most == nil || agreement[i].Cmp(most) > 0) {
+			most = agreement[i]
+			sc.WinnerId = &sc.Options[i].OptionId
+		}
+	}
+	return &sc
+}
+
+// GovernanceVotingPower provides the voting power of the given address
+// broken down by its own stake, the stake delegated to it and its delegations.
+func (p *proxy) GovernanceVotingPower(addr common.Address) (*types.GovernanceVotingPower, error) {
+	received, err := p.db.DelegationsAll(&bson.D{
+		{Key: types.FiDelegationToValidatorAddress, Value: addr.String()},
+		{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vp := types.GovernanceVotingPower{Delegations: make([]*types.GovernanceDelegatedPower, 0)}
+	own, rec := new(big.Int), new(big.Int)
+	for _, d := range received {
+		if d.Address == addr {
+			own.Add(own, d.AmountDelegated.ToInt())
+			continue
+		}
+		rec.Add(rec, d.AmountDelegated.ToInt())
+	}
+	vp.Own, vp.Received = hexutil.Big(*own), hexutil.Big(*rec)
+
+	dl, err := p.db.DelegationsAll(&bson.D{
+		{Key: types.FiDelegationAddress, Value: addr.String()},
+		{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dl {
+		if d.ToStakerAddress == addr {
+			continue
+		}
+		vp.Delegations = append(vp.Delegations, &types.GovernanceDelegatedPower{
+			DelegatedTo: d.ToStakerAddress,
+			ValidatorId: *d.ToStakerId,
+			Weight:      *d.AmountDelegated,
+		})
+	}
+	return &vp, nil
+}
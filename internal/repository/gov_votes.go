@@ -0,0 +1,59 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// StoreGovernanceVote stores an indexed vote placed on a Governance Proposal.
+func (p *proxy) StoreGovernanceVote(gv *types.GovernanceVoteRecord) error {
+	return p.db.StoreGovernanceVote(gv)
+}
+
+// CancelGovernanceVote marks an indexed vote on a Governance Proposal canceled
+// by the event of the given ordinal index.
+func (p *proxy) CancelGovernanceVote(gov common.Address, propId *hexutil.Big, voter common.Address, delegatedTo common.Address, ts time.Time, ordinal int64) error {
+	return p.db.CancelGovernanceVote(gov, propId, voter, delegatedTo, ts, ordinal)
+}
+
+// UpdateGovernanceVoteWeight updates the weight of the indexed vote the voter placed with its own
+// delegation to the state at the given block, after the weight has been changed by the event
+// of the given ordinal index.
+func (p *proxy) UpdateGovernanceVoteWeight(gov common.Address, propId *hexutil.Big, voter common.Address, block uint64, ordinal int64) error {
+	weight, err := p.rpc.GovernanceVoteWeightAt(gov, propId, voter, new(big.Int).SetUint64(block))
+	if err != nil {
+		return err
+	}
+	return p.db.SetGovernanceVoteWeight(gov, propId, voter, weight, ordinal)
+}
+
+// StoreGovernanceProposalEvent stores an indexed life cycle event of a Governance Proposal.
+func (p *proxy) StoreGovernanceProposalEvent(ge *types.GovernanceProposalEvent) error {
+	return p.db.StoreGovernanceProposalEvent(ge)
+}
+
+// GovernanceProposalEvents provides the life cycle events of the given Governance Proposal.
+func (p *proxy) GovernanceProposalEvents(gov common.Address, propId *hexutil.Big) ([]*types.GovernanceProposalEvent, error) {
+	return p.db.GovernanceProposalEvents(gov, propId)
+}
+
+// GovernanceProposalVotes provides a list of indexed votes placed on the given Governance Proposal.
+func (p *proxy) GovernanceProposalVotes(gov common.Address, propId *hexutil.Big, cursor *int64, count int32) (*types.GovernanceVoteList, error) {
+	return p.db.GovernanceProposalVotes(gov, propId, cursor, count)
+}
+
+// GovernanceVotesBy provides a list of indexed votes placed by the given voter.
+func (p *proxy) GovernanceVotesBy(voter common.Address, cursor *int64, count int32) (*types.GovernanceVoteList, error) {
+	return p.db.GovernanceVotesBy(voter, cursor, count)
+}
@@ -55,8 +55,8 @@ func (p *proxy) GovernanceVote(
 
 // GovernanceContractBy provides governance contract details by its address.
 func (p *proxy) GovernanceContractBy(addr common.Address) (config.GovernanceContract, error) {
-	// try to pull the config from the map
-	if gc, ok := p.govContracts[addr.String()]; ok {
+	// try to find the contract config
+	if gc, ok := p.governanceContract(&addr); ok {
 		return gc, nil
 	}
 
@@ -64,6 +64,17 @@ func (p *proxy) GovernanceContractBy(addr common.Address) (config.GovernanceCont
 	return config.GovernanceContract{}, fmt.Errorf("governance contract %s not found", addr.String())
 }
 
+// governanceContract finds the configuration of the governance contract of the given address.
+// The list of contracts can change with the configuration reload, so it's not kept here.
+func (p *proxy) governanceContract(addr *common.Address) (config.GovernanceContract, bool) {
+	for _, gc := range p.cfg.Governance.ContractList() {
+		if gc.Address == *addr {
+			return gc, true
+		}
+	}
+	return config.GovernanceContract{}, false
+}
+
 // GovernanceProposalFee returns the fee payable for a new proposal
 // in given Governance contract context.
 func (p *proxy) GovernanceProposalFee(gov *common.Address) (hexutil.Big, error) {
@@ -77,7 +88,7 @@ func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error)
 	we := p.cache.PullGovernanceTotalWeight(gov)
 	if we == nil {
 		// get the governance config
-		cfg, ok := p.govContracts[gov.String()]
+		cfg, ok := p.governanceContract(gov)
 		if !ok {
 			return hexutil.Big{}, fmt.Errorf("unknown governance %s", gov.String())
 		}
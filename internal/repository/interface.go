@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/p2p"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
@@ -28,6 +29,9 @@ type Repository interface {
 	// Account returns account at Opera blockchain for an address, nil if not found.
 	Account(*common.Address) (*types.Account, error)
 
+	// AccountsByAddress loads the accounts of the given addresses in a batch.
+	AccountsByAddress(context.Context, []common.Address) ([]*types.Account, error)
+
 	// AccountBalance returns the current balance of an account at Opera blockchain.
 	AccountBalance(*common.Address) (*hexutil.Big, error)
 
@@ -71,6 +75,23 @@ type Repository interface {
 	// UpdateLastKnownBlock update record about last known block.
 	UpdateLastKnownBlock(blockNo *hexutil.Uint64) error
 
+	// LastKnownBlockEpoch returns the epoch and the time stamp of the last known block.
+	LastKnownBlockEpoch() (uint64, uint64, error)
+
+	// UpdateLastKnownBlockEpoch update record about the epoch and the time stamp of the last known block.
+	UpdateLastKnownBlockEpoch(epoch hexutil.Uint64, stamp hexutil.Uint64) error
+
+	// Logs loads the logs of the given topics emitted by the given contract
+	// in the given range of blocks, both ends of the range included.
+	Logs(contract common.Address, topics []common.Hash, from uint64, to uint64) ([]etc.Log, error)
+
+	// LogBackfill returns the progress of the log backfill job of the given name;
+	// the next block to be scanned, the last block to be scanned, and if the job is known.
+	LogBackfill(name string) (uint64, uint64, bool, error)
+
+	// UpdateLogBackfill stores the progress of the log backfill job of the given name.
+	UpdateLogBackfill(name string, next uint64, end uint64) error
+
 	// ObservedHeaders provides a channel fed with new headers observed
 	// by the connected blockchain node.
 	ObservedHeaders() chan *etc.Header
@@ -80,6 +101,9 @@ type Repository interface {
 	// If the block is not found, ErrBlockNotFound error is returned.
 	BlockByNumber(*hexutil.Uint64) (*types.Block, error)
 
+	// BlocksByNumber loads the blocks of the given numbers in a batch; not found blocks are nil.
+	BlocksByNumber(context.Context, []hexutil.Uint64) ([]*types.Block, error)
+
 	// BlockByHash returns a block at Opera blockchain represented by a hash.
 	// The Top block is returned if the hash is not provided.
 	// If the block is not found, ErrBlockNotFound error is returned.
@@ -95,6 +119,9 @@ type Repository interface {
 	// Contract extracts smart contract information by address if available.
 	Contract(*common.Address) (*types.Contract, error)
 
+	// ContractsByAddress loads the smart contracts of the given addresses in a batch; not found contracts are nil.
+	ContractsByAddress(context.Context, []common.Address) ([]*types.Contract, error)
+
 	// Contracts returns list of smart contracts at Opera blockchain.
 	Contracts(bool, *string, int32) (*types.ContractList, error)
 
@@ -130,6 +157,17 @@ type Repository interface {
 	// Epochs pull a list of epochs starting at the specified cursor.
 	Epochs(cursor *string, count int32) (*types.EpochList, error)
 
+	// StoredEpoch provides the epoch of the given id from the persistent storage
+	// including its block range, transactions and validators detail.
+	StoredEpoch(id uint64) (*types.Epoch, error)
+
+	// EnrichEpoch collects the block range, transactions and the validator set
+	// of the given sealed epoch.
+	EnrichEpoch(ep *types.Epoch) error
+
+	// EpochBlocks pulls a list of blocks of the given epoch starting at the specified block number.
+	EpochBlocks(ep *types.Epoch, num *uint64, count int32) (*types.BlockList, error)
+
 	// TotalStaked calculates the current total staked amount for all stakers.
 	TotalStaked() (*hexutil.Big, error)
 
@@ -155,6 +193,9 @@ type Repository interface {
 	// Transaction returns a transaction at Opera blockchain by a hash, nil if not found.
 	Transaction(*common.Hash) (*types.Transaction, error)
 
+	// TransactionsByHash loads the transactions of the given hashes in a batch; not found transactions are nil.
+	TransactionsByHash(context.Context, []common.Hash) ([]*types.Transaction, error)
+
 	// Transactions returns list of transaction hashes at Opera blockchain.
 	Transactions(*string, int32) (*types.TransactionList, error)
 
@@ -191,12 +232,40 @@ type Repository interface {
 	// Validator extracts staker information from SFC smart contract.
 	Validator(*hexutil.Big) (*types.Validator, error)
 
+	// ValidatorsById loads the validators of the given IDs from SFC contract in a batch; not found validators are nil.
+	ValidatorsById(context.Context, []hexutil.Big) ([]*types.Validator, error)
+
 	// ValidatorByAddress extract staker information by address.
 	ValidatorByAddress(*common.Address) (*types.Validator, error)
 
 	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
 	ValidatorDowntime(*hexutil.Big) (uint64, uint64, error)
 
+	// RefreshValidatorDirectory takes a new snapshot of all the validators
+	// used to filter and sort the validators directory.
+	RefreshValidatorDirectory() (int, error)
+
+	// ValidatorDirectory provides a page of the validators directory snapshot for the given filter and sorting.
+	ValidatorDirectory(fi *types.ValidatorSnapshotFilter, sortBy string, desc bool, cursor *uint64, count int64) (*types.ValidatorSnapshotList, error)
+
+	// StoreValidatorAlert stores, or updates the given validator alert in the persistent storage.
+	StoreValidatorAlert(va *types.ValidatorAlert) error
+
+	// ValidatorAlerts provides the latest validator alerts, optionally limited to the given validator
+	// and to alerts not resolved yet.
+	ValidatorAlerts(valID *uint64, activeOnly bool, count int64) ([]*types.ValidatorAlert, error)
+
+	// AddValidatorEpochs takes snapshots of all the validators of the given sealed epoch
+	// and stores them in the persistent storage.
+	AddValidatorEpochs(*types.Epoch) error
+
+	// BackfillValidatorEpochs tries to complete the validator snapshots of epochs recorded as incomplete.
+	BackfillValidatorEpochs() error
+
+	// ValidatorEpochs provides snapshots of the given validator for the given range of epochs,
+	// the oldest epoch first.
+	ValidatorEpochs(valID *hexutil.Big, fromEpoch uint64, toEpoch uint64) ([]*types.ValidatorEpoch, error)
+
 	// DownValidators provides a list of validators with non-zero downtime.
 	DownValidators() ([]types.OfflineValidator, error)
 
@@ -255,6 +324,30 @@ type Repository interface {
 	// AdjustLockedDelegation reduces the given locked delegation by the give amount in the database.
 	AdjustLockedDelegation(common.Address, int64, int64) error
 
+	// Delegators provides the list of addresses of all the known delegators.
+	Delegators() ([]common.Address, error)
+
+	// ReconcileStake compares the stored delegations, locks and pending withdraw requests
+	// of the given address with the SFC state at the given block and repairs any differences found.
+	// Repairs are skipped if the scanned callback reports the block scanner moved past the block.
+	// If discover is set, all the validators are checked for delegations not known yet.
+	ReconcileStake(addr *common.Address, discover bool, blk *types.Block, scanned func() uint64) (int, error)
+
+	// StakingSchedule provides the list of upcoming lock expirations and pending withdrawals
+	// of the given delegator sorted by the date.
+	StakingSchedule(addr *common.Address) ([]*types.StakeScheduleItem, error)
+
+	// StakeUnlockSchedule provides the network-wide amounts of stake unlocking and becoming
+	// withdrawable in the given time range aggregated by the given resolution.
+	StakeUnlockSchedule(from time.Time, to time.Time, resolution string) ([]*types.StakeScheduleTick, error)
+
+	// StoreDelegationUnlock stores the given delegation unlock into the database.
+	StoreDelegationUnlock(du *types.DelegationUnlock) error
+
+	// StakingReport builds the staking rewards report of the given address for the given time range
+	// with fiat values calculated from the price history in the given currency.
+	StakingReport(addr *common.Address, currency string, from time.Time, to time.Time) (*types.StakingReport, error)
+
 	// PendingRewards returns a detail of pending rewards for the given delegation.
 	PendingRewards(*common.Address, *hexutil.Big) (*types.PendingRewards, error)
 
@@ -298,6 +391,21 @@ type Repository interface {
 	// Price returns a price information for the given target symbol.
 	Price(sym string) (types.Price, error)
 
+	// RecordPrice stores the current price of the native token in the given symbol
+	// into the price history.
+	RecordPrice(sym string) error
+
+	// PriceAt provides the recorded price of the native token in the given symbol at the given time.
+	PriceAt(sym string, ts time.Time) (*types.PriceTick, error)
+
+	// PriceHistoryStart provides the time of the oldest recorded price of the native token
+	// in the given symbol; nil if no price has been recorded yet.
+	PriceHistoryStart(sym string) (*time.Time, error)
+
+	// BackfillPriceHistory loads hourly prices of the native token in the given symbol
+	// recorded before the given time from the external price history API and stores them.
+	BackfillPriceHistory(sym string, to time.Time) (int, time.Time, error)
+
 	// GasPrice provides the raw suggested value for the gas price.
 	GasPrice() (hexutil.Big, error)
 
@@ -368,6 +476,21 @@ type Repository interface {
 	// AddFMintTransaction adds the specified fMint transaction to persistent storage.
 	AddFMintTransaction(*types.FMintTransaction) error
 
+	// FMintTransactions provides list of fMint transactions for the given user, token, type and time range.
+	FMintTransactions(*common.Address, *common.Address, *int32, int64, int64, *string, int32) (*types.FMintTransactionList, error)
+
+	// IsFMintRewardsDistribution checks if the given address is the fMint rewards distribution contract.
+	IsFMintRewardsDistribution(*common.Address) bool
+
+	// FMintRewardsToken provides the address of the token paid out as fMint rewards.
+	FMintRewardsToken() (common.Address, error)
+
+	// AddFMintAccountRisk stores the fMint account risk snapshot in the persistent storage.
+	AddFMintAccountRisk(*types.FMintAccountRisk) error
+
+	// FMintRiskHistory provides the collateral to debt ratio time series of the given fMint account.
+	FMintRiskHistory(*common.Address, string, int64, int64) ([]types.FMintRiskPoint, error)
+
 	// UniswapPairs returns list of all token pairs managed by Uniswap core.
 	UniswapPairs() ([]common.Address, error)
 
@@ -434,6 +557,48 @@ type Repository interface {
 	// UniswapActions provides list of uniswap actions stored in the persistent db.
 	UniswapActions(*common.Address, *string, int32, int32) (*types.UniswapActionList, error)
 
+	// UniswapV3Factory returns the address of the configured concentrated liquidity pools factory.
+	UniswapV3Factory() common.Address
+
+	// UniswapV3PositionManager returns the address of the configured liquidity positions manager.
+	UniswapV3PositionManager() common.Address
+
+	// IsUniswapV3Pool checks if the given address is a pool deployed by the configured v3 factory.
+	IsUniswapV3Pool(*common.Address) bool
+
+	// StoreUniswapV3Pool stores the given concentrated liquidity pool in the persistent storage.
+	StoreUniswapV3Pool(*types.UniswapV3Pool) error
+
+	// UniswapV3Pool provides details of a concentrated liquidity pool by its address.
+	UniswapV3Pool(*common.Address) (*types.UniswapV3Pool, error)
+
+	// UniswapV3Pools provides the list of all known concentrated liquidity pools.
+	UniswapV3Pools() ([]*types.UniswapV3Pool, error)
+
+	// UniswapV3PoolState provides the current price, tick and liquidity of a concentrated liquidity pool.
+	UniswapV3PoolState(*common.Address) (*types.UniswapV3PoolState, error)
+
+	// UniswapV3PoolTicks provides liquidity details of initialized ticks of a concentrated liquidity pool.
+	UniswapV3PoolTicks(*common.Address) ([]*types.UniswapV3Tick, error)
+
+	// AddUniswapV3Action stores a new concentrated liquidity pool action in the persistent storage.
+	AddUniswapV3Action(*types.UniswapV3Action) error
+
+	// UniswapV3Actions provides list of concentrated liquidity pool actions for the given pool, owner and type.
+	UniswapV3Actions(pool *common.Address, owner *common.Address, actionType *int32, cursor *string, count int32) (*types.UniswapV3ActionList, error)
+
+	// UniswapV3TimePrices returns grouped OHLC prices for specified pool, time and resolution.
+	UniswapV3TimePrices(*common.Address, string, int64, int64, int32) ([]types.DefiTimePrice, error)
+
+	// UpdateUniswapV3Position refreshes the stored liquidity position from the position manager.
+	UpdateUniswapV3Position(*big.Int, *types.Block) error
+
+	// UniswapV3Position provides a liquidity position by its NFT token id.
+	UniswapV3Position(*hexutil.Big) (*types.UniswapV3Position, error)
+
+	// UniswapV3Positions provides liquidity positions of the given owner and/or pool.
+	UniswapV3Positions(*common.Address, *common.Address) ([]*types.UniswapV3Position, error)
+
 	// NativeTokenAddress returns address of the native token wrapper, if available.
 	NativeTokenAddress() (*common.Address, error)
 
@@ -447,6 +612,9 @@ type Repository interface {
 	// Erc20Token returns an ERC20 token for the given address, if available.
 	Erc20Token(*common.Address) (*types.Erc20Token, error)
 
+	// Erc20TokensByAddress loads the ERC20 tokens of the given addresses in a batch.
+	Erc20TokensByAddress(context.Context, []common.Address) ([]*types.Erc20Token, error)
+
 	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
 	Erc20TokensList(int32) ([]common.Address, error)
 
@@ -551,6 +719,36 @@ type Repository interface {
 	// GovernanceVote provides a single vote in the Governance Proposal context.
 	GovernanceVote(*common.Address, *hexutil.Big, *common.Address, *common.Address) (*types.GovernanceVote, error)
 
+	// GovernanceProjection calculates the projected outcome of the given Governance Proposal.
+	GovernanceProjection(common.Address, *hexutil.Big, []*types.GovernanceVote) (*types.GovernanceProjection, error)
+
+	// GovernanceVotingPower provides the voting power of the given address
+	// broken down by its own stake, the stake delegated to it and its delegations.
+	GovernanceVotingPower(common.Address) (*types.GovernanceVotingPower, error)
+
+	// StoreGovernanceVote stores an indexed vote placed on a Governance Proposal.
+	StoreGovernanceVote(*types.GovernanceVoteRecord) error
+
+	// CancelGovernanceVote marks an indexed vote on a Governance Proposal canceled
+	// by the event of the given ordinal index.
+	CancelGovernanceVote(common.Address, *hexutil.Big, common.Address, common.Address, time.Time, int64) error
+
+	// UpdateGovernanceVoteWeight updates the weight of the indexed vote the voter placed with its own
+	// delegation to the state at the given block, changed by the event of the given ordinal index.
+	UpdateGovernanceVoteWeight(common.Address, *hexutil.Big, common.Address, uint64, int64) error
+
+	// StoreGovernanceProposalEvent stores an indexed life cycle event of a Governance Proposal.
+	StoreGovernanceProposalEvent(*types.GovernanceProposalEvent) error
+
+	// GovernanceProposalEvents provides the life cycle events of the given Governance Proposal.
+	GovernanceProposalEvents(common.Address, *hexutil.Big) ([]*types.GovernanceProposalEvent, error)
+
+	// GovernanceProposalVotes provides a list of indexed votes placed on the given Governance Proposal.
+	GovernanceProposalVotes(common.Address, *hexutil.Big, *int64, int32) (*types.GovernanceVoteList, error)
+
+	// GovernanceVotesBy provides a list of indexed votes placed by the given voter.
+	GovernanceVotesBy(common.Address, *int64, int32) (*types.GovernanceVoteList, error)
+
 	// GovernanceProposals loads a list of proposals from given a set of Governance contracts.
 	GovernanceProposals([]common.Address, *string, int32, bool) (*types.GovernanceProposalList, error)
 
@@ -580,10 +778,44 @@ type Repository interface {
 	// FLendGetReserveList resolves list of reserves in lending pool
 	FLendGetReserveList() ([]common.Address, error)
 
+	// FLendPriceFeeds resolves the price feed aggregators used by the fLend price oracle
+	// mapped to the list of fLend assets they provide price for.
+	FLendPriceFeeds() (map[common.Address][]common.Address, error)
+
 	// FLendGetUserDepositHistory resolves deposit history
 	// data for specified user and asset address
 	FLendGetUserDepositHistory(*common.Address, *common.Address) ([]*types.FLendDeposit, error)
 
+	// FLendGetUserBorrowHistory resolves borrow history
+	// data for specified user and asset address
+	FLendGetUserBorrowHistory(*common.Address, *common.Address) ([]*types.FLendBorrow, error)
+
+	// FLendLendingPoolAddress returns the address of the configured fLend lending pool.
+	FLendLendingPoolAddress() common.Address
+
+	// AddFLendAction stores a new fLend lending pool action in the persistent storage.
+	AddFLendAction(*types.FLendAction) error
+
+	// FLendActions provides list of fLend lending pool actions for the given user, asset and type.
+	FLendActions(*common.Address, *common.Address, *int32, *string, int32) (*types.FLendActionList, error)
+
+	// FLendUsers provides the list of all users who ever opened a position on the fLend lending pool,
+	// optionally limited to positions in the given asset.
+	FLendUsers(*common.Address) ([]common.Address, error)
+
+	// RefreshFLendPosition loads the current state of the fLend position of the given user
+	// from the lending pool and stores it in the persistent storage.
+	RefreshFLendPosition(*common.Address) (*types.FLendPosition, error)
+
+	// FLendPosition provides the latest known state of the fLend position of the given user.
+	FLendPosition(*common.Address) (*types.FLendPosition, error)
+
+	// FLendAtRiskPositions provides the list of fLend positions with health factor below the given threshold.
+	FLendAtRiskPositions(float64, int32) ([]*types.FLendPosition, error)
+
+	// FLendHealthHistory provides the health factor time series of the given fLend user.
+	FLendHealthHistory(user *common.Address, resolution string, fromTime int64, toTime int64) ([]types.FLendHealthPoint, error)
+
 	// TrxFlowVolume resolves the list of daily trx flow aggregations.
 	TrxFlowVolume(from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error)
 
@@ -654,6 +886,27 @@ type Repository interface {
 	// NetworkNodesGeoAggregated provides a list of aggregated opera nodes based on given location detail level.
 	NetworkNodesGeoAggregated(level int) ([]*types.OperaNodeLocationAggregate, error)
 
+	// NetworkNodes provides a page of the Opera network nodes matching the filter, the most recently seen first.
+	NetworkNodes(fi *types.OperaNodeFilter, cursor *types.OperaNodeListCursor, count int64) (*types.OperaNodeList, error)
+
+	// NetworkNodesVersionAggregated provides a list of active Opera nodes aggregated by their client version.
+	NetworkNodesVersionAggregated() ([]*types.OperaNodeVersionAggregate, error)
+
+	// TakeNetworkStats takes a snapshot of the current Opera network topology
+	// and stores it in the persistent storage.
+	TakeNetworkStats() (*types.NetworkStats, error)
+
+	// NetworkStats provides the Opera network topology snapshots taken in the given time range
+	// with the given resolution, the oldest first.
+	NetworkStats(from time.Time, to time.Time, resolution time.Duration) ([]*types.NetworkStats, error)
+
+	// CacheEvict removes the record of the given key from the in-memory cache;
+	// false is returned if the key was not cached.
+	CacheEvict(key string) (bool, error)
+
+	// CacheReset removes all the records from the in-memory cache.
+	CacheReset() error
+
 	// Close and cleanup the repository.
 	Close()
 }
@@ -0,0 +1,30 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Logs loads the logs of the given topics emitted by the given contract
+// in the given range of blocks, both ends of the range included.
+func (p *proxy) Logs(contract common.Address, topics []common.Hash, from uint64, to uint64) ([]etc.Log, error) {
+	return p.rpc.Logs(contract, topics, from, to)
+}
+
+// LogBackfill returns the progress of the log backfill job of the given name.
+func (p *proxy) LogBackfill(name string) (uint64, uint64, bool, error) {
+	return p.db.LogBackfill(name)
+}
+
+// UpdateLogBackfill stores the progress of the log backfill job of the given name.
+func (p *proxy) UpdateLogBackfill(name string, next uint64, end uint64) error {
+	return p.db.UpdateLogBackfill(name, next, end)
+}
@@ -0,0 +1,30 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// TakeNetworkStats takes a snapshot of the current Opera network topology
+// and stores it in the persistent storage.
+func (p *proxy) TakeNetworkStats() (*types.NetworkStats, error) {
+	ns, err := p.db.CollectNetworkStats()
+	if err != nil {
+		return nil, err
+	}
+	return ns, p.db.StoreNetworkStats(ns)
+}
+
+// NetworkStats provides the Opera network topology snapshots taken in the given time range
+// with the given resolution, the oldest first.
+func (p *proxy) NetworkStats(from time.Time, to time.Time, resolution time.Duration) ([]*types.NetworkStats, error) {
+	return p.db.NetworkStats(from, to, resolution)
+}
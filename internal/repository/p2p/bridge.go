@@ -72,7 +72,7 @@ func PeerInformation(node *enode.Node, bhp BlockHeightProvider) (*types.OperaNod
 	}
 
 	// establish TCP connection to the remote peer
-	addr := fmt.Sprintf("%s:%d", node.IP().String(), node.TCP())
+	addr := net.JoinHostPort(node.IP().String(), strconv.Itoa(node.TCP()))
 	log.Debugf("p2p connecting to %s; signing as %s", addr, crypto.PubkeyToAddress(cfg.Signature.PrivateKey.PublicKey).String())
 
 	c, err := net.DialTimeout("tcp", addr, peerConnectionTimeout)
@@ -168,6 +168,7 @@ func readNext(con *rlpx.Conn, info *types.OperaNodeInformation, bhp BlockHeightP
 
 	case msgTypeHello:
 		info.Name = msg.(*msgHello).Name
+		info.Client, info.ClientVersion = types.OperaNodeClient(info.Name)
 		info.Version = strconv.FormatUint(msg.(*msgHello).Version, 16)
 
 		// validate protocols selection
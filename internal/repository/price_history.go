@@ -0,0 +1,103 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// priceHistoryApiAddress represents the REST API endpoint providing hourly price history.
+	priceHistoryApiAddress = "https://min-api.cryptocompare.com/data/v2/histohour"
+
+	// priceHistoryApiLimit represents the max number of hourly prices provided in one request.
+	priceHistoryApiLimit = 2000
+)
+
+// priceHistoryResponse represents the response of the hourly price history API.
+type priceHistoryResponse struct {
+	Response string `json:"Response"`
+	Message  string `json:"Message"`
+	Data     struct {
+		Data []struct {
+			Time  int64   `json:"time"`
+			Close float64 `json:"close"`
+		} `json:"Data"`
+	} `json:"Data"`
+}
+
+// PriceHistoryStart provides the time of the oldest recorded price of the native token
+// in the given symbol; nil if no price has been recorded yet.
+func (p *proxy) PriceHistoryStart(sym string) (*time.Time, error) {
+	return p.db.PriceHistoryStart(sym)
+}
+
+// BackfillPriceHistory loads hourly prices of the native token in the given symbol
+// recorded before the given time from the external price history API and stores them
+// in the price history. It returns the number of prices stored and the time of the oldest one;
+// zero prices are returned if the history does not reach before the given time.
+func (p *proxy) BackfillPriceHistory(sym string, to time.Time) (int, time.Time, error) {
+	if !p.isValidPriceSymbol(sym) {
+		return 0, to, fmt.Errorf("unknown price symbol requested")
+	}
+
+	url := fmt.Sprintf("%s?fsym=%s&tsym=%s&limit=%d&toTs=%d",
+		priceHistoryApiAddress, ownPriceSymbol, strings.ToUpper(sym), priceHistoryApiLimit, to.Unix())
+
+	client := &http.Client{Timeout: time.Second * pricePullRequestTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return 0, to, fmt.Errorf("can not query price history API; %s", err.Error())
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			p.log.Errorf("error closing price history API request; %s", err.Error())
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, to, fmt.Errorf("can not read price history API response; %s", err.Error())
+	}
+
+	var data priceHistoryResponse
+	if err := json.Unmarshal(body, &data); err != nil {
+		return 0, to, fmt.Errorf("can not decode price history API response; %s", err.Error())
+	}
+	if data.Response != "Success" {
+		return 0, to, fmt.Errorf("price history API failed; %s", data.Message)
+	}
+
+	// zero prices are provided for the time before the token was traded
+	var count int
+	oldest := to
+	for _, tick := range data.Data.Data {
+		ts := time.Unix(tick.Time, 0).UTC()
+		if tick.Close <= 0 || !ts.Before(to) {
+			continue
+		}
+
+		err := p.db.AddPriceTick(&types.PriceTick{Symbol: strings.ToUpper(sym), Price: tick.Close, TimeStamp: ts})
+		if err != nil {
+			return count, oldest, err
+		}
+
+		count++
+		if ts.Before(oldest) {
+			oldest = ts
+		}
+	}
+	return count, oldest, nil
+}
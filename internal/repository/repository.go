@@ -72,9 +72,6 @@ type proxy struct {
 	// we need a Group to use single flight to control price pulls
 	apiRequestGroup singleflight.Group
 
-	// governance contracts reference
-	govContracts map[string]config.GovernanceContract
-
 	// smart contract compilers
 	solCompiler string
 }
@@ -107,9 +104,6 @@ func newRepository() Repository {
 		log:   log,
 		cfg:   cfg,
 
-		// get the map of governance contracts
-		govContracts: governanceContractsMap(cfg.Governance),
-
 		// keep reference to the SOL compiler
 		solCompiler: cfg.Compiler.DefaultSolCompilerPath,
 	}
@@ -118,19 +112,6 @@ func newRepository() Repository {
 	return &p
 }
 
-// governanceContractsMap creates map of governance contracts keyed
-// by the contract address.
-func governanceContractsMap(cfg config.Governance) map[string]config.GovernanceContract {
-	// prep the result set
-	res := make(map[string]config.GovernanceContract)
-
-	// collect all the configured governance contracts into the map
-	for _, gv := range cfg.Contracts {
-		res[gv.Address.String()] = gv
-	}
-	return res
-}
-
 // connect opens connections to the external sources we need.
 func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.MongoDbBridge, *rpc.FtmBridge, *geoip.Bridge, error) {
 	// create new in-memory cache bridge
@@ -141,14 +122,14 @@ func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.Mongo
 	}
 
 	// create new database connection bridge
-	dbBridge, err := db.New(cfg, log)
+	dbBridge, err := db.New(cfg, logger.Module(log, "repository/db"))
 	if err != nil {
 		log.Criticalf("can not connect backend persistent storage, %s", err.Error())
 		return nil, nil, nil, nil, err
 	}
 
 	// create new Opera RPC bridge
-	rpcBridge, err := rpc.New(cfg, log)
+	rpcBridge, err := rpc.New(cfg, logger.Module(log, "repository/rpc"))
 	if err != nil {
 		log.Criticalf("can not connect Opera RPC interface, %s", err.Error())
 		return nil, nil, nil, nil, err
@@ -177,3 +158,15 @@ func (p *proxy) Close() {
 	// inform about actions
 	p.log.Notice("repository done")
 }
+
+// CacheEvict removes the record of the given key from the in-memory cache;
+// false is returned if the key was not cached.
+func (p *proxy) CacheEvict(key string) (bool, error) {
+	return p.cache.Evict(key)
+}
+
+// CacheReset removes all the records from the in-memory cache.
+func (p *proxy) CacheReset() error {
+	p.log.Notice("in-memory cache reset")
+	return p.cache.Reset()
+}
@@ -0,0 +1,253 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/tracing"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	client "github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rpcMaxBatchSize represents the max number of calls sent to the node in a single batch request.
+const rpcMaxBatchSize = 100
+
+// erc20Abi represents the parsed ABI of the ERC20 token contract used for batched calls.
+var (
+	erc20Abi     abi.ABI
+	erc20AbiOnce sync.Once
+)
+
+// batchCall executes the given list of calls in batches of limited size.
+// Errors of individual calls are reported in the call elements.
+func (ftm *FtmBridge) batchCall(ctx context.Context, calls []client.BatchElem) (err error) {
+	ctx, span := tracing.StartChild(ctx, "rpc.FtmBridge.batchCall", attribute.Int("rpc.batch.size", len(calls)))
+	defer func() { tracing.End(span, err) }()
+
+	for i := 0; i < len(calls); i += rpcMaxBatchSize {
+		chunk := calls[i:min(i+rpcMaxBatchSize, len(calls))]
+
+		start := time.Now()
+		err = ftm.pool.do("", func(ep *endpoint) error {
+			c, _ := ep.clients()
+			return c.BatchCallContext(ctx, chunk)
+		})
+		observeBatch(chunk, start, err)
+
+		if err != nil {
+			ftm.log.Errorf("batch call failed; %s", err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// contractCall builds a contract call batch element for the given contract, ABI and method.
+func contractCall(ab *abi.ABI, addr *common.Address, res *hexutil.Bytes, method string, args ...interface{}) (client.BatchElem, error) {
+	data, err := ab.Pack(method, args...)
+	if err != nil {
+		return client.BatchElem{}, err
+	}
+
+	return client.BatchElem{
+		Method: "eth_call",
+		Args: []interface{}{
+			map[string]interface{}{"to": addr, "data": hexutil.Bytes(data)},
+			"latest",
+		},
+		Result: res,
+	}, nil
+}
+
+// TransactionsBatch loads the transactions of the given hashes in batch calls.
+// The list of transactions follows the list of hashes, not found transactions are nil.
+func (ftm *FtmBridge) TransactionsBatch(ctx context.Context, hashes []common.Hash) ([]*types.Transaction, error) {
+	ftm.log.Debugf("loading batch of %d transactions", len(hashes))
+
+	// load the transactions
+	list := make([]*types.Transaction, len(hashes))
+	calls := make([]client.BatchElem, len(hashes))
+	for i := range hashes {
+		calls[i] = client.BatchElem{Method: "ftm_getTransactionByHash", Args: []interface{}{hashes[i]}, Result: &list[i]}
+	}
+	if err := ftm.batchCall(ctx, calls); err != nil {
+		return nil, err
+	}
+
+	// load receipts of the transactions already included in a block
+	receipts := make([]trxReceipt, len(hashes))
+	rcCalls := make([]client.BatchElem, 0, len(hashes))
+	for i := range list {
+		if calls[i].Error != nil {
+			ftm.log.Errorf("transaction %s could not be extracted; %s", hashes[i].String(), calls[i].Error.Error())
+			list[i] = nil
+			continue
+		}
+		if list[i] != nil && list[i].BlockNumber != nil {
+			rcCalls = append(rcCalls, client.BatchElem{Method: "ftm_getTransactionReceipt", Args: []interface{}{hashes[i]}, Result: &receipts[i]})
+		}
+	}
+	if err := ftm.batchCall(ctx, rcCalls); err != nil {
+		return nil, err
+	}
+
+	// apply the receipts; transactions without a receipt are dropped
+	for i, j := 0, 0; i < len(list); i++ {
+		if list[i] == nil || list[i].BlockNumber == nil {
+			continue
+		}
+		if rcCalls[j].Error != nil {
+			ftm.log.Errorf("can not get receipt for transaction %s; %s", hashes[i].String(), rcCalls[j].Error.Error())
+			list[i] = nil
+		} else {
+			receipts[i].apply(list[i])
+		}
+		j++
+	}
+	return list, nil
+}
+
+// BlocksBatch loads the blocks of the given numbers in batch calls.
+// The list of blocks follows the list of numbers, not found blocks are nil.
+func (ftm *FtmBridge) BlocksBatch(ctx context.Context, nums []hexutil.Uint64) ([]*types.Block, error) {
+	ftm.log.Debugf("loading batch of %d blocks", len(nums))
+
+	list := make([]*types.Block, len(nums))
+	calls := make([]client.BatchElem, len(nums))
+	for i := range nums {
+		calls[i] = client.BatchElem{Method: "ftm_getBlockByNumber", Args: []interface{}{nums[i].String(), false}, Result: &list[i]}
+	}
+	if err := ftm.batchCall(ctx, calls); err != nil {
+		return nil, err
+	}
+
+	// detect block not found situation; block number is zero and the hash is also zero
+	for i := range list {
+		if calls[i].Error != nil {
+			ftm.log.Errorf("block #%d could not be extracted; %s", uint64(nums[i]), calls[i].Error.Error())
+			list[i] = nil
+			continue
+		}
+		if list[i] != nil && uint64(list[i].Number) == 0 && list[i].Hash == (common.Hash{}) {
+			list[i] = nil
+		}
+	}
+	return list, nil
+}
+
+// Erc20TokensBatch loads the name, symbol and decimals of the given ERC20 tokens in batch calls.
+// Details not available are replaced the same way as for a single token.
+func (ftm *FtmBridge) Erc20TokensBatch(ctx context.Context, addr []common.Address) ([]*types.Erc20Token, error) {
+	ftm.log.Debugf("loading batch of %d ERC20 tokens", len(addr))
+
+	erc20AbiOnce.Do(func() {
+		var err error
+		erc20Abi, err = abi.JSON(strings.NewReader(contracts.ERCTwentyMetaData.ABI))
+		if err != nil {
+			ftm.log.Criticalf("failed to parse ERC20 contract ABI; %s", err.Error())
+			panic(err)
+		}
+	})
+
+	// three calls per token; name, symbol and decimals
+	methods := []string{"name", "symbol", "decimals"}
+	res := make([]hexutil.Bytes, len(addr)*len(methods))
+	calls := make([]client.BatchElem, len(res))
+	for i := range addr {
+		for j, m := range methods {
+			var err error
+			calls[i*len(methods)+j], err = contractCall(&erc20Abi, &addr[i], &res[i*len(methods)+j], m)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := ftm.batchCall(ctx, calls); err != nil {
+		return nil, err
+	}
+
+	// unpack the details
+	list := make([]*types.Erc20Token, len(addr))
+	for i := range addr {
+		tk := types.Erc20Token{Address: addr[i], Name: addr[i].String(), Symbol: "-"}
+		for j, m := range methods {
+			k := i*len(methods) + j
+			if calls[k].Error != nil {
+				ftm.log.Errorf("ERC20 token %s %s not available; %s", addr[i].String(), m, calls[k].Error.Error())
+				continue
+			}
+
+			out, err := erc20Abi.Unpack(m, res[k])
+			if err != nil || len(out) == 0 {
+				ftm.log.Errorf("ERC20 token %s %s not recognized", addr[i].String(), m)
+				continue
+			}
+
+			switch m {
+			case "name":
+				tk.Name = *abi.ConvertType(out[0], new(string)).(*string)
+			case "symbol":
+				tk.Symbol = *abi.ConvertType(out[0], new(string)).(*string)
+			case "decimals":
+				tk.Decimals = int32(*abi.ConvertType(out[0], new(uint8)).(*uint8))
+			}
+		}
+		list[i] = &tk
+	}
+	return list, nil
+}
+
+// ValidatorsBatch loads the details of the given validators from SFC contract in batch calls.
+// The list of validators follows the list of IDs, not found validators are nil.
+func (ftm *FtmBridge) ValidatorsBatch(ctx context.Context, ids []*big.Int) ([]*types.Validator, error) {
+	ftm.log.Debugf("loading batch of %d validators", len(ids))
+
+	res := make([]hexutil.Bytes, len(ids))
+	calls := make([]client.BatchElem, len(ids))
+	for i := range ids {
+		var err error
+		calls[i], err = contractCall(ftm.SfcAbi(), &ftm.sfcConfig.SFCContract, &res[i], "getValidator", ids[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ftm.batchCall(ctx, calls); err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.Validator, len(ids))
+	for i := range ids {
+		if calls[i].Error != nil {
+			ftm.log.Criticalf("failed to load validator #%d from SFC; %s", ids[i].Uint64(), calls[i].Error.Error())
+			continue
+		}
+
+		var val sfcValidator
+		if err := ftm.SfcAbi().UnpackIntoInterface(&val, "getValidator", res[i]); err != nil {
+			ftm.log.Criticalf("failed to decode validator #%d; %s", ids[i].Uint64(), err.Error())
+			continue
+		}
+		list[i], _ = ftm.validatorFromSfc(ids[i], &val)
+	}
+	return list, nil
+}
@@ -22,10 +22,15 @@ import (
 // ftmHeadsObserverSubscribeTick represents the time between subscription attempts.
 const ftmHeadsObserverSubscribeTick = 30 * time.Second
 
+// ftmHeadsObserverHealthTick represents the time between health checks of the subscribed endpoint.
+const ftmHeadsObserverHealthTick = 5 * time.Second
+
 // observeBlocks collects new blocks from the blockchain network
-// and posts them into the proxy channel for processing.
+// and posts them into the proxy channel for processing. The subscription
+// is moved to another endpoint if the subscribed one fails or becomes unhealthy.
 func (ftm *FtmBridge) observeBlocks() {
 	var sub ethereum.Subscription
+	var ep *endpoint
 	defer func() {
 		if sub != nil {
 			sub.Unsubscribe()
@@ -34,16 +39,20 @@ func (ftm *FtmBridge) observeBlocks() {
 		ftm.wg.Done()
 	}()
 
-	sub = ftm.blockSubscription()
+	health := time.NewTicker(ftmHeadsObserverHealthTick)
+	defer health.Stop()
+
+	sub, ep = ftm.blockSubscription(nil)
 	for {
 		// re-subscribe if the subscription ref is not valid
 		if sub == nil {
 			tm := time.NewTimer(ftmHeadsObserverSubscribeTick)
 			select {
 			case <-ftm.sigClose:
+				tm.Stop()
 				return
 			case <-tm.C:
-				sub = ftm.blockSubscription()
+				sub, ep = ftm.blockSubscription(nil)
 				continue
 			}
 		}
@@ -53,19 +62,45 @@ func (ftm *FtmBridge) observeBlocks() {
 		case <-ftm.sigClose:
 			return
 		case err := <-sub.Err():
-			ftm.log.Errorf("block subscription failed; %s", err.Error())
-			sub = nil
+			ftm.log.Errorf("block subscription at %s failed; %s", ep.name, err.Error())
+			sub, ep = ftm.blockSubscription(ep)
+		case <-health.C:
+			// a fallback endpoint without the head role is left as soon as a head endpoint is healthy
+			if (ep.roles[roleHead] && ftm.pool.isHealthy(ep)) || !ftm.pool.hasHealthy(roleHead) {
+				continue
+			}
+
+			ftm.log.Warningf("blockchain node %s is not healthy, moving block subscription", ep.name)
+			sub.Unsubscribe()
+			sub, ep = ftm.blockSubscription(ep)
 		}
 	}
 }
 
 // blockSubscription provides a subscription for new blocks received
-// by the connected blockchain node.
-func (ftm *FtmBridge) blockSubscription() ethereum.Subscription {
-	sub, err := ftm.rpc.EthSubscribe(context.Background(), ftm.headers, "newHeads")
-	if err != nil {
-		ftm.log.Criticalf("can not observe new blocks; %s", err.Error())
-		return nil
+// by the best available blockchain node. The failed endpoint, if any, is tried last.
+func (ftm *FtmBridge) blockSubscription(failed *endpoint) (ethereum.Subscription, *endpoint) {
+	eps := ftm.pool.ranked(roleHead)
+	for i, ep := range eps {
+		if ep == failed && i < len(eps)-1 {
+			eps = append(append(eps[:i:i], eps[i+1:]...), ep)
+			break
+		}
 	}
-	return sub
+
+	for _, ep := range eps {
+		c, _ := ep.clients()
+
+		sub, err := c.EthSubscribe(context.Background(), ftm.headers, "newHeads")
+		if err != nil {
+			ftm.log.Errorf("can not observe new blocks at %s; %s", ep.name, err.Error())
+			continue
+		}
+
+		ftm.log.Noticef("observing new blocks at %s", ep.name)
+		return sub, ep
+	}
+
+	ftm.log.Critical("can not observe new blocks, no blockchain node available")
+	return nil, nil
 }
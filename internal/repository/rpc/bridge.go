@@ -21,9 +21,9 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	etc "github.com/ethereum/go-ethereum/core/types"
-	eth "github.com/ethereum/go-ethereum/ethclient"
 	ftm "github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/sync/singleflight"
+	"math/big"
 	"strings"
 	"sync"
 )
@@ -33,15 +33,16 @@ const rpcHeadProxyChannelCapacity = 10000
 
 // FtmBridge represents Opera RPC abstraction layer.
 type FtmBridge struct {
-	rpc *ftm.Client
-	eth *eth.Client
-	log logger.Logger
-	cg  *singleflight.Group
+	pool *pool
+	eth  *meteredClient
+	log  logger.Logger
+	cg   *singleflight.Group
 
 	// fMintCfg represents the configuration of the fMint protocol
-	sigConfig     *config.ServerSignature
-	sfcConfig     *config.Staking
-	uniswapConfig *config.DeFiUniswap
+	sigConfig       *config.ServerSignature
+	sfcConfig       *config.Staking
+	uniswapConfig   *config.DeFiUniswap
+	uniswapV3Config *config.DeFiUniswapV3
 
 	// extended minter config
 	fMintCfg fMintConfig
@@ -60,23 +61,25 @@ type FtmBridge struct {
 
 // New creates new Opera RPC connection bridge.
 func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
-	cli, con, err := connect(cfg, log)
+	pool, err := connect(cfg, log)
 	if err != nil {
 		log.Criticalf("can not open connection; %s", err.Error())
 		return nil, err
 	}
 
 	// build the bridge structure using the con we have
+	con := &meteredClient{pool: pool}
 	br := &FtmBridge{
-		rpc: cli,
-		eth: con,
-		log: log,
-		cg:  new(singleflight.Group),
+		pool: pool,
+		eth:  con,
+		log:  log,
+		cg:   new(singleflight.Group),
 
 		// special configuration options below this line
-		sigConfig:     &cfg.Signature,
-		sfcConfig:     &cfg.Staking,
-		uniswapConfig: &cfg.DeFi.Uniswap,
+		sigConfig:       &cfg.Signature,
+		sfcConfig:       &cfg.Staking,
+		uniswapConfig:   &cfg.DeFi.Uniswap,
+		uniswapV3Config: &cfg.DeFi.UniswapV3,
 		fMintCfg: fMintConfig{
 			addressProvider: cfg.DeFi.FMint.AddressProvider,
 		},
@@ -100,28 +103,21 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 	return br, nil
 }
 
-// connect opens connections we need to communicate with the blockchain node.
-func connect(cfg *config.Config, log logger.Logger) (*ftm.Client, *eth.Client, error) {
+// connect opens connections we need to communicate with the blockchain nodes.
+func connect(cfg *config.Config, log logger.Logger) (*pool, error) {
 	// log what we do
-	log.Debugf("connecting blockchain node at %s", cfg.Opera.ApiNodeUrl)
+	log.Debugf("connecting blockchain nodes")
 
-	// try to establish a connection
-	client, err := ftm.Dial(cfg.Opera.ApiNodeUrl)
+	// try to establish connections to the configured endpoints
+	p, err := newPool(&cfg.Opera, log)
 	if err != nil {
 		log.Critical(err)
-		return nil, nil, err
-	}
-
-	// try to establish a for smart contract interaction
-	con, err := eth.Dial(cfg.Opera.ApiNodeUrl)
-	if err != nil {
-		log.Critical(err)
-		return nil, nil, err
+		return nil, err
 	}
 
 	// log
 	log.Notice("node connection open")
-	return client, con, nil
+	return p, nil
 }
 
 // run starts the bridge threads required to collect blockchain data.
@@ -143,16 +139,21 @@ func (ftm *FtmBridge) Close() {
 	ftm.terminate()
 
 	// do we have a connection?
-	if ftm.rpc != nil {
-		ftm.rpc.Close()
-		ftm.eth.Close()
+	if ftm.pool != nil {
+		ftm.pool.close()
 		ftm.log.Info("blockchain connections are closed")
 	}
 }
 
-// Connection returns open Opera connection.
+// Connection returns open Opera connection of the best available endpoint.
 func (ftm *FtmBridge) Connection() *ftm.Client {
-	return ftm.rpc
+	eps := ftm.pool.ranked("")
+	if len(eps) == 0 {
+		return nil
+	}
+
+	c, _ := eps[0].clients()
+	return c
 }
 
 // DefaultCallOpts creates a default record for call options.
@@ -169,6 +170,19 @@ func (ftm *FtmBridge) DefaultCallOpts() *bind.CallOpts {
 	return co.(*bind.CallOpts)
 }
 
+// CallOptsAt provides call options for contract calls made on the state of the given block.
+// If the block is not specified, the default call options are used.
+func (ftm *FtmBridge) CallOptsAt(block *big.Int) *bind.CallOpts {
+	if block == nil {
+		return ftm.DefaultCallOpts()
+	}
+	return &bind.CallOpts{
+		From:        ftm.sigConfig.Address,
+		BlockNumber: block,
+		Context:     context.Background(),
+	}
+}
+
 // SfcContract returns instance of SFC contract for interaction.
 func (ftm *FtmBridge) SfcContract() *contracts.SfcContract {
 	// lazy create SFC contract instance
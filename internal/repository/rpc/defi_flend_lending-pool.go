@@ -16,7 +16,10 @@ package rpc
 import (
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"strings"
+	"sync"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -34,6 +37,25 @@ type fLendConfig struct {
 	lendigPoolAddress common.Address
 }
 
+// fLendOracleAbiSrc represents the subset of the fLend addresses provider, price oracle
+// and the price feed proxy ABI we need to resolve the price feeds of fLend assets.
+const fLendOracleAbiSrc = `[
+{"inputs":[],"name":"getPriceOracle","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"asset","type":"address"}],"name":"getSourceOfAsset","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"aggregator","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"}
+]`
+
+// fLendOracleAbi represents the parsed fLend oracle ABI.
+var (
+	fLendOracleAbi     abi.ABI
+	fLendOracleAbiOnce sync.Once
+)
+
+// FLendLendingPoolAddress returns the address of the configured fLend lending pool.
+func (ftm *FtmBridge) FLendLendingPoolAddress() common.Address {
+	return ftm.fLendCfg.lendigPoolAddress
+}
+
 // FLendGetLendingPool resolves Lending pool contract instance
 func (ftm *FtmBridge) FLendGetLendingPool() (*contracts.ILendingPool, error) {
 	// get the lending pool contract
@@ -132,56 +154,66 @@ func (ftm *FtmBridge) FLendGetUserAccountData(userAddress *common.Address) (*typ
 	return uad, nil
 }
 
-// FLendGetUserDepositHistory resolves deposit event history data for specified user and asset address
-func (ftm *FtmBridge) FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
-	// create user filter
-	userFilter := make([]common.Address, 0)
-	if userAddress != nil {
-		userFilter = append(userFilter, *userAddress)
+// FLendPriceFeeds resolves the price feed aggregators used by the fLend price oracle.
+// The map is keyed by the aggregator address emitting price updates
+// and contains the list of fLend assets priced by the aggregator.
+func (ftm *FtmBridge) FLendPriceFeeds() (map[common.Address][]common.Address, error) {
+	fLendOracleAbiOnce.Do(func() {
+		var err error
+		fLendOracleAbi, err = abi.JSON(strings.NewReader(fLendOracleAbiSrc))
+		if err != nil {
+			ftm.log.Criticalf("invalid fLend oracle ABI; %s", err.Error())
+		}
+	})
+
+	lp, err := ftm.FLendGetLendingPool()
+	if err != nil {
+		return nil, err
 	}
 
-	// create asset filter
-	assetFilter := make([]common.Address, 0)
-	if assetAddress != nil {
-		assetFilter = append(assetFilter, *assetAddress)
+	// the price oracle is registered in the addresses provider of the lending pool
+	ap, err := lp.GetAddressesProvider(nil)
+	if err != nil {
+		ftm.log.Errorf("can not get fLend addresses provider; %s", err.Error())
+		return nil, err
 	}
 
-	// get the lending pool contract
-	lp, err := ftm.FLendGetLendingPool()
+	oracle, err := ftm.fLendOracleAddress(ap, "getPriceOracle")
 	if err != nil {
-		ftm.log.Errorf("Can not access lending pool %s", err.Error())
+		ftm.log.Errorf("can not get fLend price oracle; %s", err.Error())
 		return nil, err
 	}
 
-	// filter logs
-	fdi, err := lp.FilterDeposit(&bind.FilterOpts{}, assetFilter, userFilter, []uint16{0})
+	reserves, err := ftm.FLendGetReserveList()
 	if err != nil {
-		ftm.log.Errorf("can not filter lending pool deposit logs: %s", err.Error())
 		return nil, err
 	}
 
-	// results array
-	depositArray := make([]*types.FLendDeposit, 0)
+	feeds := make(map[common.Address][]common.Address, len(reserves))
+	for _, asset := range reserves {
+		src, err := ftm.fLendOracleAddress(oracle, "getSourceOfAsset", asset)
+		if err != nil {
+			ftm.log.Errorf("can not get fLend price source of %s; %s", asset.String(), err.Error())
+			return nil, err
+		}
 
-	// iterate thru filtered logs
-	for fdi.Next() {
-		// get block for timestamp information
-		blkHash := fdi.Event.Raw.BlockHash.String()
-		blk, err := ftm.BlockByHash(&blkHash)
+		// price updates are emitted by the aggregator behind the feed proxy;
+		// if the source is not a proxy, it's the aggregator itself
+		agg, err := ftm.fLendOracleAddress(src, "aggregator")
 		if err != nil {
-			ftm.log.Errorf("fLend block with hash %s was not found: %s", blkHash, err.Error())
-			continue
+			agg = src
 		}
+		feeds[agg] = append(feeds[agg], asset)
+	}
+	return feeds, nil
+}
 
-		// add deposit event data to results
-		depositArray = append(depositArray, &types.FLendDeposit{
-			AssetAddress:      fdi.Event.Reserve,
-			UserAddress:       fdi.Event.User,
-			OnBehalfOfAddress: fdi.Event.OnBehalfOf,
-			Amount:            hexutil.Big(*fdi.Event.Amount),
-			ReferralCode:      int32(byte(fdi.Event.Referral)),
-			Timestamp:         blk.TimeStamp,
-		})
-	}
-	return depositArray, nil
+// fLendOracleAddress calls the given address getter on the contract of the given address.
+func (ftm *FtmBridge) fLendOracleAddress(addr common.Address, method string, args ...interface{}) (common.Address, error) {
+	var out []interface{}
+	err := bind.NewBoundContract(addr, fLendOracleAbi, ftm.eth, nil, nil).Call(nil, &out, method, args...)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return *abi.ConvertType(out[0], new(common.Address)).(*common.Address), nil
 }
@@ -355,3 +355,33 @@ func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
 	// @todo Check the amount of rewards available so we know that it will push.
 	return true, nil
 }
+
+// FMintRewardsDistributionAddress returns the address of the fMint rewards distribution contract.
+func (ftm *FtmBridge) FMintRewardsDistributionAddress() (common.Address, error) {
+	return ftm.fMintCfg.contractAddress(fMintAddressRewardDistribution)
+}
+
+// FMintRewardsToken returns the address of the token paid out
+// by the fMint rewards distribution contract.
+func (ftm *FtmBridge) FMintRewardsToken() (common.Address, error) {
+	// the reward token does not change, use the cached address if we have it
+	if adr, ok := ftm.fMintCfg.contracts.Load(fMintRewardToken); ok {
+		return adr.(common.Address), nil
+	}
+
+	// connect the contract
+	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	// get the reward token
+	adr, err := contract.RewardTokenAddress(nil)
+	if err != nil {
+		ftm.log.Errorf("can not get rewards token; %s", err.Error())
+		return common.Address{}, err
+	}
+
+	ftm.fMintCfg.contracts.Store(fMintRewardToken, adr)
+	return adr, nil
+}
@@ -30,6 +30,10 @@ const (
 	fMintAddressTokenRegistry      = "token_registry"
 	fMintCollateralPool            = "collateral_pool"
 	fMintDebtPool                  = "debt_pool"
+
+	// fMintRewardToken is not provided by the AddressProvider,
+	// it's resolved from the reward distribution contract
+	fMintRewardToken = "reward_token"
 )
 
 // fMintConfig represents the configuration for DeFi fMint module.
@@ -17,7 +17,9 @@ import (
 	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -293,6 +295,24 @@ func (ftm *FtmBridge) GovernanceVote(
 	}, nil
 }
 
+// GovernanceVoteWeightAt returns the weight of the vote the given voter placed on the given proposal
+// with its own delegation, at the given block. The weight is reduced by the votes of the delegators
+// voting on their own.
+func (ftm *FtmBridge) GovernanceVoteWeightAt(gov common.Address, propId *hexutil.Big, voter common.Address, block *big.Int) (*hexutil.Big, error) {
+	gc, err := contracts.NewGovernance(gov, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("can not access governance %s; %s", gov.String(), err.Error())
+		return nil, err
+	}
+
+	vote, err := gc.GetVote(ftm.CallOptsAt(block), voter, voter, propId.ToInt())
+	if err != nil {
+		ftm.log.Errorf("can not access vote of %s on governance %s; %s", voter.String(), gov.String(), err.Error())
+		return nil, err
+	}
+	return (*hexutil.Big)(vote.Weight), nil
+}
+
 // GovernanceProposalsBy loads list of proposals of the given Governance contract.
 func (ftm *FtmBridge) GovernanceProposalsBy(gov common.Address) ([]*types.GovernanceProposal, error) {
 	// get the contract
@@ -393,3 +413,36 @@ func (ftm *FtmBridge) GovernanceProposalOwner(gp *common.Address) (*common.Addre
 	a.SetBytes(data)
 	return &a, nil
 }
+
+var governanceContractAbi *abi.ABI // parsed ABI singleton
+
+// GovernanceParseVotedData decodes the data of the Governance Voted event
+// into the vote of the given Governance contract.
+// event Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight)
+func GovernanceParseVotedData(gov common.Address, data []byte) (*types.GovernanceVote, error) {
+	if governanceContractAbi == nil {
+		contractAbi, err := abi.JSON(strings.NewReader(contracts.GovernanceMetaData.ABI))
+		if err != nil {
+			return nil, err
+		}
+		governanceContractAbi = &contractAbi
+	}
+
+	outs, err := governanceContractAbi.Unpack("Voted", data)
+	if err != nil {
+		return nil, err
+	}
+	if len(outs) != 5 {
+		return nil, fmt.Errorf("unexpected Voted event structure, %d values found", len(outs))
+	}
+
+	dlg := outs[1].(common.Address)
+	return &types.GovernanceVote{
+		GovernanceId: gov,
+		ProposalId:   hexutil.Big(*outs[2].(*big.Int)),
+		From:         outs[0].(common.Address),
+		DelegatedTo:  &dlg,
+		Weight:       hexutil.Big(*outs[4].(*big.Int)),
+		Choices:      govConvertScales(outs[3].([]*big.Int)),
+	}, nil
+}
@@ -0,0 +1,38 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// Logs loads the logs of the given topics emitted by the given contract
+// in the given range of blocks, both ends of the range included.
+func (ftm *FtmBridge) Logs(contract common.Address, topics []common.Hash, from uint64, to uint64) ([]retypes.Log, error) {
+	logs, err := ftm.eth.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{contract},
+		Topics:    [][]common.Hash{topics},
+	})
+	if err != nil {
+		ftm.log.Errorf("can not load logs of %s in blocks #%d-#%d; %s", contract.String(), from, to, err.Error())
+		return nil, err
+	}
+	return logs, nil
+}
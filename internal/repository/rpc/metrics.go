@@ -0,0 +1,215 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	client "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"math/big"
+	"time"
+)
+
+var (
+	rpcCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "graphql_rpc_call_duration_seconds",
+		Help:    "The duration of RPC calls to the blockchain node",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{
+		"method",
+	})
+	rpcCallErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "graphql_rpc_call_errors_total",
+		Help: "The total number of failed RPC calls to the blockchain node",
+	}, []string{
+		"method",
+	})
+	rpcBatchCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "graphql_rpc_batch_calls_total",
+		Help: "The total number of RPC calls executed inside batch requests to the blockchain node",
+	}, []string{
+		"method",
+	})
+)
+
+// rpcBatchMethod represents the method label of the whole batch request.
+const rpcBatchMethod = "batch"
+
+// observeCall records the metrics of a finished RPC call.
+func observeCall(method string, start time.Time, err error) {
+	rpcCallDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		rpcCallErrors.WithLabelValues(method).Inc()
+	}
+}
+
+// observeBatch records the metrics of a finished RPC batch request and its calls.
+func observeBatch(calls []client.BatchElem, start time.Time, err error) {
+	observeCall(rpcBatchMethod, start, err)
+	for _, c := range calls {
+		rpcBatchCalls.WithLabelValues(c.Method).Inc()
+		if err == nil && c.Error != nil {
+			rpcCallErrors.WithLabelValues(c.Method).Inc()
+		}
+	}
+}
+
+// call executes the RPC call of the given method on the endpoint able to serve it,
+// recording the call metrics.
+func (ftm *FtmBridge) call(result interface{}, method string, args ...interface{}) error {
+	start := time.Now()
+	err := ftm.pool.call(result, method, args...)
+	observeCall(method, start, err)
+	return err
+}
+
+// meteredClient implements Ethereum client used by the smart contract bindings.
+// The calls are routed to the endpoints of the pool and their metrics are recorded.
+type meteredClient struct {
+	pool *pool
+}
+
+// CallContract executes a message call transaction.
+func (mc *meteredClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (data []byte, err error) {
+	start := time.Now()
+	err = mc.pool.do(stateRole(blockNumber), func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		data, err = c.CallContract(ctx, msg, blockNumber)
+		return err
+	})
+	observeCall("eth_call", start, err)
+	return
+}
+
+// CodeAt returns the contract code of the given account.
+func (mc *meteredClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	start := time.Now()
+	err = mc.pool.do(stateRole(blockNumber), func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		code, err = c.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	observeCall("eth_getCode", start, err)
+	return
+}
+
+// PendingCodeAt returns the contract code of the given account in the pending state.
+func (mc *meteredClient) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		code, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	observeCall("eth_getCode", start, err)
+	return
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (mc *meteredClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		nonce, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	observeCall("eth_getTransactionCount", start, err)
+	return
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price.
+func (mc *meteredClient) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		price, err = c.SuggestGasPrice(ctx)
+		return err
+	})
+	observeCall("eth_gasPrice", start, err)
+	return
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap.
+func (mc *meteredClient) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		tip, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	observeCall("eth_maxPriorityFeePerGas", start, err)
+	return
+}
+
+// EstimateGas estimates the gas needed to execute the given transaction.
+func (mc *meteredClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		gas, err = c.EstimateGas(ctx, msg)
+		return err
+	})
+	observeCall("eth_estimateGas", start, err)
+	return
+}
+
+// SendTransaction injects a signed transaction into the pending pool for execution.
+// Re-sending the same signed transaction to another endpoint is safe, it has the same hash.
+func (mc *meteredClient) SendTransaction(ctx context.Context, tx *etc.Transaction) (err error) {
+	start := time.Now()
+	err = mc.pool.do("", func(ep *endpoint) error {
+		_, c := ep.clients()
+		return c.SendTransaction(ctx, tx)
+	})
+	observeCall("eth_sendRawTransaction", start, err)
+	return
+}
+
+// FilterLogs executes a filter query.
+func (mc *meteredClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) (logs []etc.Log, err error) {
+	start := time.Now()
+	err = mc.pool.do(roleArchive, func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		logs, err = c.FilterLogs(ctx, q)
+		return err
+	})
+	observeCall("eth_getLogs", start, err)
+	return
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (mc *meteredClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- etc.Log) (sub ethereum.Subscription, err error) {
+	err = mc.pool.do(roleHead, func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		sub, err = c.SubscribeFilterLogs(ctx, q, ch)
+		return err
+	})
+	return
+}
+
+// HeaderByNumber returns a block header from the current canonical chain.
+func (mc *meteredClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *etc.Header, err error) {
+	start := time.Now()
+	err = mc.pool.do(stateRole(number), func(ep *endpoint) (err error) {
+		_, c := ep.clients()
+		header, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	observeCall("eth_getBlockByNumber", start, err)
+	return
+}
@@ -0,0 +1,451 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/ethclient"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"math/big"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// endpoint roles
+const (
+	roleArchive = "archive"
+	roleTracing = "tracing"
+	roleHead    = "head"
+)
+
+const (
+	// poolLagPenalty represents the score penalty of a block the endpoint lags behind the head,
+	// it's denominated in milliseconds of the call latency.
+	poolLagPenalty = 100.0
+
+	// poolErrorPenalty represents the score penalty of the full error rate in milliseconds of the call latency.
+	poolErrorPenalty = 1000.0
+
+	// poolMaxErrorRate represents the error rate above which the endpoint is considered unhealthy.
+	poolMaxErrorRate = 0.5
+
+	// poolEwmaWeight represents the weight of a new sample in the moving averages of latency and error rate.
+	poolEwmaWeight = 0.2
+)
+
+var (
+	endpointHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "graphql_rpc_endpoint_healthy",
+		Help: "The health of the blockchain node endpoint, 1 if healthy",
+	}, []string{
+		"endpoint",
+	})
+	endpointLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "graphql_rpc_endpoint_lag_blocks",
+		Help: "The number of blocks the blockchain node endpoint lags behind the best known head",
+	}, []string{
+		"endpoint",
+	})
+	endpointLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "graphql_rpc_endpoint_latency_seconds",
+		Help: "The moving average of the blockchain node endpoint call latency",
+	}, []string{
+		"endpoint",
+	})
+)
+
+// errNoEndpoint is returned if there is no connected endpoint to serve the call.
+var errNoEndpoint = errors.New("no blockchain node endpoint available")
+
+// endpoint represents a single blockchain node endpoint of the pool along with its health state.
+type endpoint struct {
+	url   string
+	name  string
+	roles map[string]bool
+
+	mu      sync.RWMutex
+	rpc     *ftm.Client
+	eth     *eth.Client
+	alive   bool
+	height  uint64
+	latency float64
+	errRate float64
+}
+
+// pool represents a set of blockchain node endpoints. Each call is routed to the best healthy endpoint
+// able to serve it; if the endpoint fails, the call is retried on the next one.
+type pool struct {
+	log      logger.Logger
+	eps      []*endpoint
+	interval time.Duration
+	maxLag   uint64
+
+	mu   sync.RWMutex
+	head uint64
+
+	wg       sync.WaitGroup
+	sigClose chan bool
+}
+
+// newPool creates a new pool of the configured node endpoints. If no endpoints are configured,
+// the pool consists of the single node URL serving all the roles.
+func newPool(cfg *config.OperaNode, log logger.Logger) (*pool, error) {
+	list := cfg.Endpoints
+	if len(list) == 0 {
+		list = []config.OperaEndpoint{{Url: cfg.ApiNodeUrl, Roles: []string{roleArchive, roleTracing, roleHead}}}
+	}
+
+	p := pool{
+		log:      log,
+		eps:      make([]*endpoint, 0, len(list)),
+		interval: time.Duration(cfg.HealthInterval) * time.Second,
+		maxLag:   cfg.MaxBlockLag,
+		sigClose: make(chan bool, 1),
+	}
+	if p.interval <= 0 {
+		p.interval = 5 * time.Second
+	}
+
+	var connected int
+	for _, ec := range list {
+		ep := endpoint{url: ec.Url, name: endpointName(ec.Url), roles: make(map[string]bool)}
+		for _, r := range ec.Roles {
+			ep.roles[strings.ToLower(r)] = true
+		}
+
+		if err := ep.dial(); err != nil {
+			log.Errorf("can not connect blockchain node %s; %s", ep.name, err.Error())
+		} else {
+			connected++
+		}
+		p.eps = append(p.eps, &ep)
+	}
+
+	if connected == 0 {
+		return nil, fmt.Errorf("no blockchain node connected out of %d endpoints", len(p.eps))
+	}
+
+	// get the initial health state before the pool is used
+	p.probe()
+	log.Noticef("%d of %d blockchain node endpoints connected", connected, len(p.eps))
+
+	p.wg.Add(1)
+	go p.monitor()
+	return &p, nil
+}
+
+// endpointName provides the name of the endpoint safe to be logged and used as a metric label;
+// the URL path and credentials may contain API keys of node providers.
+func endpointName(addr string) string {
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return filepath.Base(addr)
+	}
+	return u.Host
+}
+
+// dial opens the connection of the endpoint.
+func (ep *endpoint) dial() error {
+	c, err := ftm.Dial(ep.url)
+	if err != nil {
+		return err
+	}
+
+	ep.mu.Lock()
+	ep.rpc, ep.eth = c, eth.NewClient(c)
+	ep.mu.Unlock()
+	return nil
+}
+
+// clients provides the connection of the endpoint, if any.
+func (ep *endpoint) clients() (*ftm.Client, *eth.Client) {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+	return ep.rpc, ep.eth
+}
+
+// record updates the moving averages of the endpoint by the outcome of a call.
+func (ep *endpoint) record(dur time.Duration, failed bool) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	fail := 0.0
+	if failed {
+		fail = 1.0
+	}
+	ep.errRate += poolEwmaWeight * (fail - ep.errRate)
+	if !failed {
+		ep.latency += poolEwmaWeight * (float64(dur.Milliseconds()) - ep.latency)
+	}
+}
+
+// state provides the health and the score of the endpoint against the given head;
+// the lower the score, the better the endpoint.
+func (ep *endpoint) state(head uint64, maxLag uint64) (bool, float64) {
+	ep.mu.RLock()
+	defer ep.mu.RUnlock()
+
+	var lag uint64
+	if head > ep.height {
+		lag = head - ep.height
+	}
+
+	healthy := ep.rpc != nil && ep.alive && lag <= maxLag && ep.errRate < poolMaxErrorRate
+	return healthy, ep.latency + float64(lag)*poolLagPenalty + ep.errRate*poolErrorPenalty
+}
+
+// ranked provides the connected endpoints able to serve the given role ordered by their health
+// and score. Endpoints without the role are used as the last resort, the regular calls
+// can be served by any endpoint.
+func (p *pool) ranked(role string) []*endpoint {
+	p.mu.RLock()
+	head := p.head
+	p.mu.RUnlock()
+
+	type rankedEndpoint struct {
+		ep      *endpoint
+		primary bool
+		healthy bool
+		score   float64
+	}
+
+	list := make([]rankedEndpoint, 0, len(p.eps))
+	for _, ep := range p.eps {
+		if c, _ := ep.clients(); c == nil {
+			continue
+		}
+		healthy, score := ep.state(head, p.maxLag)
+		list = append(list, rankedEndpoint{ep: ep, primary: role == "" || ep.roles[role], healthy: healthy, score: score})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].primary != list[j].primary {
+			return list[i].primary
+		}
+		if list[i].healthy != list[j].healthy {
+			return list[i].healthy
+		}
+		return list[i].score < list[j].score
+	})
+
+	res := make([]*endpoint, len(list))
+	for i, r := range list {
+		res[i] = r.ep
+	}
+	return res
+}
+
+// do executes the call on the best endpoint of the given role. If the endpoint fails to respond,
+// the call is retried on the next endpoint; errors reported by the node itself are returned right away.
+func (p *pool) do(role string, call func(*endpoint) error) error {
+	err := errNoEndpoint
+	for _, ep := range p.ranked(role) {
+		start := time.Now()
+		err = call(ep)
+
+		failed := isNodeFailure(err)
+		ep.record(time.Since(start), failed)
+		if !failed {
+			return err
+		}
+		p.log.Warningf("blockchain node %s failed; %s", ep.name, err.Error())
+	}
+	return err
+}
+
+// call executes the RPC call of the given method on the best endpoint able to serve it.
+func (p *pool) call(result interface{}, method string, args ...interface{}) error {
+	return p.do(methodRole(method), func(ep *endpoint) error {
+		c, _ := ep.clients()
+		return c.Call(result, method, args...)
+	})
+}
+
+// methodRole provides the endpoint role required by the given RPC method.
+func methodRole(method string) string {
+	if strings.HasPrefix(method, "trace_") || strings.HasPrefix(method, "debug_") {
+		return roleTracing
+	}
+	return ""
+}
+
+// stateRole provides the endpoint role required to access the state at the given block.
+func stateRole(block *big.Int) string {
+	if block != nil && block.Sign() > 0 {
+		return roleArchive
+	}
+	return ""
+}
+
+// isNodeFailure checks if the error signals the endpoint failed to serve the call,
+// as opposed to an error reported by the node for the call itself.
+func isNodeFailure(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var re ftm.Error
+	return !errors.As(err, &re)
+}
+
+// monitor probes the health of the endpoints periodically until the pool is closed.
+func (p *pool) monitor() {
+	defer p.wg.Done()
+
+	tick := time.NewTicker(p.interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-p.sigClose:
+			return
+		case <-tick.C:
+			p.probe()
+		}
+	}
+}
+
+// probe checks the block height and the latency of all the endpoints in parallel;
+// disconnected endpoints are dialed again.
+func (p *pool) probe() {
+	var wg sync.WaitGroup
+	for _, ep := range p.eps {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			p.probeEndpoint(ep)
+		}(ep)
+	}
+	wg.Wait()
+
+	var head uint64
+	for _, ep := range p.eps {
+		ep.mu.RLock()
+		if ep.alive && ep.height > head {
+			head = ep.height
+		}
+		ep.mu.RUnlock()
+	}
+
+	p.mu.Lock()
+	p.head = head
+	p.mu.Unlock()
+
+	p.updateMetrics(head)
+}
+
+// probeEndpoint checks the block height and the latency of the endpoint.
+func (p *pool) probeEndpoint(ep *endpoint) {
+	c, _ := ep.clients()
+	if c == nil {
+		if err := ep.dial(); err != nil {
+			p.log.Debugf("can not connect blockchain node %s; %s", ep.name, err.Error())
+			return
+		}
+		p.log.Noticef("blockchain node %s connected", ep.name)
+		c, _ = ep.clients()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.interval)
+	defer cancel()
+
+	var height hexutil.Uint64
+	start := time.Now()
+	err := c.CallContext(ctx, &height, "ftm_blockNumber")
+	ep.record(time.Since(start), err != nil)
+
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+
+	if err != nil {
+		if ep.alive {
+			p.log.Warningf("blockchain node %s is down; %s", ep.name, err.Error())
+		}
+		ep.alive = false
+		return
+	}
+	if !ep.alive {
+		p.log.Noticef("blockchain node %s is up at block #%d", ep.name, uint64(height))
+	}
+	ep.alive = true
+	ep.height = uint64(height)
+}
+
+// updateMetrics updates the health metrics of the endpoints.
+func (p *pool) updateMetrics(head uint64) {
+	for _, ep := range p.eps {
+		healthy, _ := ep.state(head, p.maxLag)
+
+		ep.mu.RLock()
+		var lag uint64
+		if head > ep.height {
+			lag = head - ep.height
+		}
+		latency := ep.latency
+		ep.mu.RUnlock()
+
+		val := 0.0
+		if healthy {
+			val = 1.0
+		}
+		endpointHealthy.WithLabelValues(ep.name).Set(val)
+		endpointLag.WithLabelValues(ep.name).Set(float64(lag))
+		endpointLatency.WithLabelValues(ep.name).Set(latency / 1000)
+	}
+}
+
+// isHealthy checks if the given endpoint is healthy.
+func (p *pool) isHealthy(ep *endpoint) bool {
+	p.mu.RLock()
+	head := p.head
+	p.mu.RUnlock()
+
+	healthy, _ := ep.state(head, p.maxLag)
+	return healthy
+}
+
+// hasHealthy checks if there is a healthy endpoint to serve the given role.
+// Endpoints without the role, used by ranked only as the last resort, are not counted.
+func (p *pool) hasHealthy(role string) bool {
+	for _, ep := range p.ranked(role) {
+		if (role == "" || ep.roles[role]) && p.isHealthy(ep) {
+			return true
+		}
+	}
+	return false
+}
+
+// close terminates the health monitor and the connections of the endpoints.
+func (p *pool) close() {
+	p.sigClose <- true
+	p.wg.Wait()
+
+	for _, ep := range p.eps {
+		if c, _ := ep.clients(); c != nil {
+			c.Close()
+		}
+	}
+}
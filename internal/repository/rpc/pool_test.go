@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// mockNode starts a JSON-RPC server responding to ftm_blockNumber with the given height.
+// The node can be turned down, in which case it responds with HTTP errors.
+func mockNode(height uint64, down *atomic.Bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Method != "ftm_blockNumber" {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"method not found"}}`, req.ID)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, req.ID, hexutil.Uint64(height).String())
+	}))
+}
+
+func TestPoolFailover(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var downA, downB atomic.Bool
+	a, b := mockNode(100, &downA), mockNode(100, &downB)
+	defer a.Close()
+	defer b.Close()
+
+	cfg := config.Config{AppName: "Fantom/GraphQL-API", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	p, err := newPool(&config.OperaNode{
+		Endpoints: []config.OperaEndpoint{
+			{Url: a.URL, Roles: []string{roleHead}},
+			{Url: b.URL, Roles: []string{roleHead, roleTracing}},
+		},
+		HealthInterval: 60,
+		MaxBlockLag:    10,
+	}, logger.New(&cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer p.close()
+
+	// the first endpoint is down; the call is served by the other one
+	downA.Store(true)
+	var height hexutil.Uint64
+	g.Expect(p.call(&height, "ftm_blockNumber")).To(gomega.Succeed())
+	g.Expect(uint64(height)).To(gomega.Equal(uint64(100)))
+
+	// errors reported by the node do not fail over
+	downA.Store(false)
+	g.Expect(isNodeFailure(p.call(&height, "ftm_unknown"))).To(gomega.BeFalse())
+
+	// tracing calls go to the tracing endpoint first
+	g.Expect(p.ranked(roleTracing)[0].url).To(gomega.Equal(b.URL))
+}
+
+func TestPoolHealth(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var down atomic.Bool
+	a, b := mockNode(100, &down), mockNode(50, &down)
+	defer a.Close()
+	defer b.Close()
+
+	cfg := config.Config{AppName: "Fantom/GraphQL-API", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}}
+	p, err := newPool(&config.OperaNode{
+		Endpoints: []config.OperaEndpoint{
+			{Url: b.URL, Roles: []string{roleHead}},
+			{Url: a.URL, Roles: []string{roleHead}},
+		},
+		HealthInterval: 60,
+		MaxBlockLag:    10,
+	}, logger.New(&cfg))
+	g.Expect(err).To(gomega.BeNil())
+	defer p.close()
+
+	// the lagging endpoint is ranked last
+	eps := p.ranked(roleHead)
+	g.Expect(eps).To(gomega.HaveLen(2))
+	g.Expect(eps[0].url).To(gomega.Equal(a.URL))
+	g.Expect(p.isHealthy(eps[0])).To(gomega.BeTrue())
+	g.Expect(p.isHealthy(eps[1])).To(gomega.BeFalse())
+
+	// healthy endpoints without the role do not count
+	g.Expect(p.hasHealthy(roleHead)).To(gomega.BeTrue())
+	g.Expect(p.hasHealthy(roleTracing)).To(gomega.BeFalse())
+}
@@ -161,6 +161,18 @@ func (ftm *FtmBridge) SfcWithdrawalPeriodEpochs() (*big.Int, error) {
 	return ftm.sfcShards.withdrawalPeriodEpochs()
 }
 
+// SfcValidatorCommission extracts the commission a validator gets from delegators' rewards,
+// denominated in 18 decimals units, i.e. 15% is 0.15e18.
+func (ftm *FtmBridge) SfcValidatorCommission() (*big.Int, error) {
+	val, err := ftm.SfcContract().ValidatorCommission(ftm.DefaultCallOpts())
+	if err == nil {
+		return val, err
+	}
+
+	// fallback to shards (the new SFC)
+	return ftm.sfcShards.validatorCommission()
+}
+
 // SfcWithdrawalPeriodTime extracts a minimal number of seconds between un-delegate and withdraw.
 func (ftm *FtmBridge) SfcWithdrawalPeriodTime() (*big.Int, error) {
 	val, err := ftm.SfcContract().WithdrawalPeriodTime(ftm.DefaultCallOpts())
@@ -26,9 +26,15 @@ import (
 
 // AmountStaked returns the current amount at stake for the given staker address and target validator
 func (ftm *FtmBridge) AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error) {
+	return ftm.AmountStakedAt(addr, valID, nil)
+}
+
+// AmountStakedAt returns the amount at stake for the given staker address and target validator
+// on the state of the given block; the current state is used if the block is not specified.
+func (ftm *FtmBridge) AmountStakedAt(addr *common.Address, valID *big.Int, block *big.Int) (*big.Int, error) {
 	// keep track of the operation
 	ftm.log.Debugf("verifying amount staked by %s to %d", addr.String(), valID.Uint64())
-	return ftm.SfcContract().GetStake(ftm.DefaultCallOpts(), *addr, valID)
+	return ftm.SfcContract().GetStake(ftm.CallOptsAt(block), *addr, valID)
 }
 
 // AmountStakeLocked returns the current locked amount at stake for the given staker address and target validator.
@@ -93,7 +99,13 @@ func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*typ
 }
 
 // DelegationLock returns delegation lock information using SFC contract binding.
-func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error) {
+func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (*types.DelegationLock, error) {
+	return ftm.DelegationLockAt(addr, valID, nil)
+}
+
+// DelegationLockAt returns delegation lock information on the state of the given block;
+// the current state is used if the block is not specified.
+func (ftm *FtmBridge) DelegationLockAt(addr *common.Address, valID *hexutil.Big, block *big.Int) (dll *types.DelegationLock, err error) {
 	// recover from panic here
 	defer func() {
 		if r := recover(); r != nil {
@@ -103,7 +115,7 @@ func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (
 	}()
 
 	// get staker locking detail
-	lock, err := ftm.SfcContract().GetLockupInfo(ftm.DefaultCallOpts(), *addr, valID.ToInt())
+	lock, err := ftm.SfcContract().GetLockupInfo(ftm.CallOptsAt(block), *addr, valID.ToInt())
 	if err != nil {
 		ftm.log.Errorf("delegation lock query failed; %v", err)
 		return nil, err
@@ -163,3 +175,15 @@ func (ftm *FtmBridge) DelegationTokenizerUnlocked(addr *common.Address, valID *b
 
 	return lock, nil
 }
+
+// PendingWithdrawalAmount returns the amount of the given pending withdrawal request as recorded in SFC
+// on the state of the given block; the current state is used if the block is not specified.
+// The request is removed from the SFC when withdrawn, in which case the returned flag is false.
+func (ftm *FtmBridge) PendingWithdrawalAmount(addr *common.Address, valID *big.Int, reqID *big.Int, block *big.Int) (*big.Int, bool, error) {
+	wr, err := ftm.SfcContract().GetWithdrawalRequest(ftm.CallOptsAt(block), *addr, valID, reqID)
+	if err != nil {
+		ftm.log.Errorf("withdrawal request %s of %s to %d not available; %s", reqID.String(), addr.String(), valID.Uint64(), err.Error())
+		return nil, false, err
+	}
+	return wr.Amount, wr.Epoch != nil && wr.Epoch.Sign() > 0, nil
+}
@@ -21,7 +21,6 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/status-im/keycard-go/hexutils"
 	"math/big"
 	"sync"
@@ -31,7 +30,7 @@ import (
 // sfcShards holds a cached values for SFC contract shards.
 type sfcShards struct {
 	log       logger.Logger
-	client    *ethclient.Client
+	client    *meteredClient
 	sfc       common.Address
 	update    time.Time
 	lock      sync.Mutex
@@ -96,7 +95,7 @@ func (sha *sfcShards) assertShards() {
 }
 
 // callForAddress calls given contract and returns decoded address from the reponse.
-func callForAddress(contract common.Address, call []byte, client *ethclient.Client, log logger.Logger) (common.Address, error) {
+func callForAddress(contract common.Address, call []byte, client *meteredClient, log logger.Logger) (common.Address, error) {
 	data, err := client.CallContract(context.Background(), ethereum.CallMsg{
 		From: common.Address{},
 		To:   &contract,
@@ -28,7 +28,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 		Blocks hexutil.Uint64 `json:"offlineBlocks"`
 		Time   hexutil.Uint64 `json:"offlineTime"`
 	}
-	if err := ftm.rpc.Call(&dt, "abft_getDowntime", valID); err != nil {
+	if err := ftm.call(&dt, "abft_getDowntime", valID); err != nil {
 		ftm.log.Errorf("failed to get downtime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, 0, err
 	}
@@ -40,7 +40,7 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
 	var ut hexutil.Uint64
-	if err := ftm.rpc.Call(&ut, "abft_getEpochUptime", valID); err != nil {
+	if err := ftm.call(&ut, "abft_getEpochUptime", valID); err != nil {
 		ftm.log.Errorf("failed to get epoch uptime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, err
 	}
@@ -50,8 +50,14 @@ func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 
 // LastValidatorId returns the last staker id in Opera blockchain.
 func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
+	return ftm.LastValidatorIdAt(nil)
+}
+
+// LastValidatorIdAt returns the last used validator identifier on the state of the given block;
+// the current state is used if the block is not specified.
+func (ftm *FtmBridge) LastValidatorIdAt(block *big.Int) (uint64, error) {
 	// get the value from the contract
-	sl, err := ftm.SfcContract().LastValidatorID(nil)
+	sl, err := ftm.SfcContract().LastValidatorID(ftm.CallOptsAt(block))
 	if err != nil {
 		ftm.log.Errorf("failed to get the last staker ID: %s", err.Error())
 		return 0, err
@@ -91,6 +97,17 @@ func (ftm *FtmBridge) Validator(valID *big.Int) (*types.Validator, error) {
 	return ftm.validatorById(valID)
 }
 
+// sfcValidator represents the validator record of the SFC contract.
+type sfcValidator struct {
+	Status           *big.Int
+	DeactivatedTime  *big.Int
+	DeactivatedEpoch *big.Int
+	ReceivedStake    *big.Int
+	CreatedEpoch     *big.Int
+	CreatedTime      *big.Int
+	Auth             common.Address
+}
+
 // validatorById loads details of a validator with the specified ID.
 func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
 	// call for data
@@ -100,8 +117,14 @@ func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
 		return nil, err
 	}
 
+	rec := sfcValidator(val)
+	return ftm.validatorFromSfc(valID, &rec)
+}
+
+// validatorFromSfc builds the validator details from the given SFC validator record.
+func (ftm *FtmBridge) validatorFromSfc(valID *big.Int, val *sfcValidator) (*types.Validator, error) {
 	// any creation record?
-	if 0 == val.CreatedTime.Uint64() {
+	if val.CreatedTime == nil || 0 == val.CreatedTime.Uint64() {
 		ftm.log.Errorf("validator #%d has zero created time, assuming empty record", valID.Uint64())
 		return nil, fmt.Errorf("validator #%d not found", valID.Uint64())
 	}
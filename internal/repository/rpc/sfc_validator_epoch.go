@@ -0,0 +1,196 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by the extra networking overhead of remote RPC calls.
+
+You should also consider the security implications of opening Opera RPC interface for remote access.
+If you consider it as your deployment strategy, you should establish an encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	client "github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+// validatorEpochMethods represents the list of SFC calls made to collect
+// a validator epoch snapshot; accumulated values are loaded for the previous epoch too.
+var validatorEpochMethods = []struct {
+	method string
+	prev   bool
+}{
+	{"getEpochReceivedStake", false},
+	{"getEpochOfflineTime", false},
+	{"getEpochOfflineBlocks", false},
+	{"getEpochAccumulatedUptime", false},
+	{"getEpochAccumulatedOriginatedTxsFee", false},
+	{"getEpochAccumulatedRewardPerToken", false},
+	{"getEpochAccumulatedUptime", true},
+	{"getEpochAccumulatedOriginatedTxsFee", true},
+	{"getEpochAccumulatedRewardPerToken", true},
+}
+
+// ValidatorEpochs loads snapshots of the validators of the given sealed epoch.
+// If the list of validator IDs is empty, all the validators of the epoch are loaded.
+// The self stake of validators is only available as the current value, so it's
+// loaded only if requested by the caller. Validators which could not be loaded
+// are skipped and their IDs are returned so the snapshot can be completed later.
+func (ftm *FtmBridge) ValidatorEpochs(ep *types.Epoch, withSelfStake bool, ids []*big.Int) ([]*types.ValidatorEpoch, []*big.Int, error) {
+	id := new(big.Int).SetUint64(uint64(ep.Id))
+	prev := new(big.Int).Sub(id, big.NewInt(1))
+
+	// get the list of validators of the epoch
+	if len(ids) == 0 {
+		var err error
+		ids, err = ftm.SfcContract().GetEpochValidatorIDs(nil, id)
+		if err != nil {
+			ftm.log.Errorf("failed to get validators of epoch #%d; %s", ep.Id, err.Error())
+			return nil, nil, err
+		}
+	}
+
+	if len(ids) == 0 {
+		return []*types.ValidatorEpoch{}, nil, nil
+	}
+
+	// get the previous epoch so we know the length of the epoch
+	pe, err := ftm.SfcContract().GetEpochSnapshot(nil, prev)
+	if err != nil {
+		ftm.log.Errorf("failed to get epoch #%d; %s", prev.Uint64(), err.Error())
+		return nil, nil, err
+	}
+
+	var dur uint64
+	if pe.EndTime != nil && pe.EndTime.Uint64() > 0 && pe.EndTime.Uint64() < uint64(ep.EndTime) {
+		dur = uint64(ep.EndTime) - pe.EndTime.Uint64()
+	}
+
+	// collect all the values in batch calls
+	res, calls, err := ftm.validatorEpochBatch(id, prev, ids, withSelfStake)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	per := len(calls) / len(ids)
+	list := make([]*types.ValidatorEpoch, 0, len(ids))
+	failed := make([]*big.Int, 0)
+	for i, vid := range ids {
+		ve, err := ftm.validatorEpoch(id, vid, res[i*per:(i+1)*per], calls[i*per:(i+1)*per])
+		if err != nil {
+			ftm.log.Errorf("failed to snapshot validator #%d at epoch #%d; %s", vid.Uint64(), ep.Id, err.Error())
+			failed = append(failed, vid)
+			continue
+		}
+
+		ve.EndTime = ep.EndTime
+		ve.Duration = hexutil.Uint64(dur)
+		list = append(list, ve)
+	}
+	return list, failed, nil
+}
+
+// validatorEpochBatch makes the batch of SFC calls needed to snapshot the given validators.
+// The calls of each validator are grouped together in the order of validatorEpochMethods,
+// the self stake call follows if requested.
+func (ftm *FtmBridge) validatorEpochBatch(id *big.Int, prev *big.Int, ids []*big.Int, withSelfStake bool) ([]hexutil.Bytes, []client.BatchElem, error) {
+	per := len(validatorEpochMethods)
+	if withSelfStake {
+		per++
+	}
+
+	res := make([]hexutil.Bytes, len(ids)*per)
+	calls := make([]client.BatchElem, 0, len(res))
+	add := func(method string, args ...interface{}) error {
+		c, err := contractCall(ftm.SfcAbi(), &ftm.sfcConfig.SFCContract, &res[len(calls)], method, args...)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, c)
+		return nil
+	}
+
+	for _, vid := range ids {
+		for _, m := range validatorEpochMethods {
+			ep := id
+			if m.prev {
+				ep = prev
+			}
+			if err := add(m.method, ep, vid); err != nil {
+				return nil, nil, err
+			}
+		}
+		if withSelfStake {
+			if err := add("getSelfStake", vid); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	if err := ftm.batchCall(context.Background(), calls); err != nil {
+		return nil, nil, err
+	}
+	return res, calls, nil
+}
+
+// validatorEpoch builds the snapshot of the given validator from the results of the batch calls.
+// Accumulated values are compared to the previous epoch to get the epoch increments.
+func (ftm *FtmBridge) validatorEpoch(id *big.Int, vid *big.Int, res []hexutil.Bytes, calls []client.BatchElem) (*types.ValidatorEpoch, error) {
+	val := make([]*big.Int, len(res))
+	for i := range res {
+		if calls[i].Error != nil {
+			return nil, calls[i].Error
+		}
+
+		// the self stake is the only call not listed in epoch methods
+		method := "getSelfStake"
+		if i < len(validatorEpochMethods) {
+			method = validatorEpochMethods[i].method
+		}
+
+		out, err := ftm.SfcAbi().Unpack(method, res[i])
+		if err != nil || len(out) != 1 {
+			return nil, fmt.Errorf("invalid %s response", method)
+		}
+		val[i] = abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	}
+
+	ve := types.ValidatorEpoch{
+		ValidatorId:   (hexutil.Big)(*vid),
+		Epoch:         hexutil.Uint64(id.Uint64()),
+		ReceivedStake: (hexutil.Big)(*val[0]),
+		OfflineTime:   hexutil.Uint64(val[1].Uint64()),
+		OfflineBlocks: hexutil.Uint64(val[2].Uint64()),
+	}
+
+	// accumulated values of the epoch and the previous one
+	up, fee, rpt := val[3], val[4], val[5]
+	pUp, pFee, pRpt := val[6], val[7], val[8]
+
+	// the validator may not have existed in the previous epoch; deltas would be misleading
+	ve.AccumulatedRewardPerToken = (hexutil.Big)(*rpt)
+	if up.Cmp(pUp) >= 0 {
+		ve.Uptime = hexutil.Uint64(new(big.Int).Sub(up, pUp).Uint64())
+	}
+	if fee.Cmp(pFee) >= 0 {
+		ve.OriginatedFee = (hexutil.Big)(*new(big.Int).Sub(fee, pFee))
+	}
+	if rpt.Cmp(pRpt) >= 0 {
+		ve.RewardPerToken = (hexutil.Big)(*new(big.Int).Sub(rpt, pRpt))
+	}
+
+	// the self stake is only the current value
+	if len(val) > len(validatorEpochMethods) {
+		ve.SelfStake = (*hexutil.Big)(val[len(validatorEpochMethods)])
+	}
+	return &ve, nil
+}
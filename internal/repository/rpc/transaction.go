@@ -20,6 +20,26 @@ import (
 	retypes "github.com/ethereum/go-ethereum/core/types"
 )
 
+// trxReceipt represents the part of a transaction receipt we keep with the transaction.
+type trxReceipt struct {
+	Index             hexutil.Uint64  `json:"transactionIndex"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	ContractAddress   *common.Address `json:"contractAddress,omitempty"`
+	Status            hexutil.Uint64  `json:"status"`
+	Logs              []retypes.Log   `json:"logs"`
+}
+
+// apply copies the receipt details into the given transaction.
+func (rec *trxReceipt) apply(trx *types.Transaction) {
+	trx.Index = &rec.Index
+	trx.CumulativeGasUsed = &rec.CumulativeGasUsed
+	trx.GasUsed = &rec.GasUsed
+	trx.ContractAddress = rec.ContractAddress
+	trx.Status = &rec.Status
+	trx.Logs = rec.Logs
+}
+
 // Transaction returns information about a blockchain transaction by hash.
 func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error) {
 	// keep track of the operation
@@ -27,7 +47,7 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 
 	// call for data
 	var trx types.Transaction
-	err := ftm.rpc.Call(&trx, "ftm_getTransactionByHash", hash)
+	err := ftm.call(&trx, "ftm_getTransactionByHash", hash)
 	if err != nil {
 		ftm.log.Error("transaction could not be extracted")
 		return nil, err
@@ -36,29 +56,17 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 	// is there a block reference already?
 	if trx.BlockNumber != nil {
 		// get transaction receipt
-		var rec struct {
-			Index             hexutil.Uint64  `json:"transactionIndex"`
-			CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
-			GasUsed           hexutil.Uint64  `json:"gasUsed"`
-			ContractAddress   *common.Address `json:"contractAddress,omitempty"`
-			Status            hexutil.Uint64  `json:"status"`
-			Logs              []retypes.Log   `json:"logs"`
-		}
+		var rec trxReceipt
 
 		// call for the transaction receipt data
-		err := ftm.rpc.Call(&rec, "ftm_getTransactionReceipt", hash)
+		err := ftm.call(&rec, "ftm_getTransactionReceipt", hash)
 		if err != nil {
 			ftm.log.Errorf("can not get receipt for transaction %s", hash)
 			return nil, err
 		}
 
 		// copy some data
-		trx.Index = &rec.Index
-		trx.CumulativeGasUsed = &rec.CumulativeGasUsed
-		trx.GasUsed = &rec.GasUsed
-		trx.ContractAddress = rec.ContractAddress
-		trx.Status = &rec.Status
-		trx.Logs = rec.Logs
+		rec.apply(&trx)
 	}
 
 	// keep track of the operation
@@ -72,7 +80,7 @@ func (ftm *FtmBridge) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
 	ftm.log.Debug("sending new transaction to block chain")
 
 	var hash common.Hash
-	err := ftm.rpc.Call(&hash, "eth_sendRawTransaction", tx)
+	err := ftm.call(&hash, "eth_sendRawTransaction", tx)
 	if err != nil {
 		ftm.log.Error("transaction could not be sent")
 		return nil, err
@@ -111,7 +111,7 @@ func (ftm *FtmBridge) isUniswapPairWhitelisted(pair *common.Address) bool {
 	pairAddr := pair.String()
 
 	// check the pair address against all the white listed pairs in config
-	for _, addr := range ftm.uniswapConfig.PairsWhiteList {
+	for _, addr := range ftm.uniswapConfig.WhiteList() {
 		if strings.EqualFold(addr.String(), pairAddr) {
 			return true
 		}
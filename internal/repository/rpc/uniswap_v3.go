@@ -0,0 +1,250 @@
+/*
+Package rpc implements bridge to Opera full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Opera node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Opera RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Opera RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Opera RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	client "github.com/ethereum/go-ethereum/rpc"
+)
+
+// uniswapV3MaxTicks represents the max number of ticks of a pool loaded in one request.
+const uniswapV3MaxTicks = 1000
+
+// uniswapV3PoolAbi represents the parsed ABI of the v3 pool contract used for batched calls.
+var (
+	uniswapV3PoolAbi     abi.ABI
+	uniswapV3PoolAbiOnce sync.Once
+)
+
+//go:generate tools/abigen.sh --abi ./contracts/abi/uniswap-v3-factory.abi --pkg contracts --type UniswapV3Factory --out ./contracts/uniswap_v3_factory.go
+//go:generate tools/abigen.sh --abi ./contracts/abi/uniswap-v3-pool.abi --pkg contracts --type UniswapV3Pool --out ./contracts/uniswap_v3_pool.go
+//go:generate tools/abigen.sh --abi ./contracts/abi/uniswap-v3-position-manager.abi --pkg contracts --type UniswapV3PositionManager --out ./contracts/uniswap_v3_positions.go
+
+// UniswapV3Factory returns the address of the configured concentrated liquidity pools factory.
+func (ftm *FtmBridge) UniswapV3Factory() common.Address {
+	return ftm.uniswapV3Config.Factory
+}
+
+// UniswapV3PositionManager returns the address of the configured liquidity positions manager.
+func (ftm *FtmBridge) UniswapV3PositionManager() common.Address {
+	return ftm.uniswapV3Config.PositionManager
+}
+
+// UniswapV3PoolFactory returns the address of the factory which deployed the given pool.
+func (ftm *FtmBridge) UniswapV3PoolFactory(pool *common.Address) (common.Address, error) {
+	contract, err := contracts.NewUniswapV3PoolCaller(*pool, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s contract not found; %s", pool.String(), err.Error())
+		return common.Address{}, err
+	}
+	return contract.Factory(nil)
+}
+
+// UniswapV3Pool loads the base details of a concentrated liquidity pool from the chain.
+func (ftm *FtmBridge) UniswapV3Pool(pool *common.Address) (*types.UniswapV3Pool, error) {
+	contract, err := contracts.NewUniswapV3PoolCaller(*pool, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s contract not found; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	// collect the pool details
+	up := types.UniswapV3Pool{Address: *pool}
+	if up.Token0, err = contract.Token0(nil); err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s token0 not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+	if up.Token1, err = contract.Token1(nil); err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s token1 not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	fee, err := contract.Fee(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s fee not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+	up.Fee = int32(fee.Int64())
+
+	ts, err := contract.TickSpacing(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s tick spacing not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+	up.TickSpacing = int32(ts.Int64())
+	return &up, nil
+}
+
+// UniswapV3PoolState loads the current price, tick and liquidity of a concentrated liquidity pool.
+func (ftm *FtmBridge) UniswapV3PoolState(pool *common.Address) (*types.UniswapV3PoolState, error) {
+	contract, err := contracts.NewUniswapV3PoolCaller(*pool, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s contract not found; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	slot, err := contract.Slot0(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s slot0 not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	liq, err := contract.Liquidity(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s liquidity not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	fg0, err := contract.FeeGrowthGlobal0X128(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s fee growth not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	fg1, err := contract.FeeGrowthGlobal1X128(nil)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool %s fee growth not available; %s", pool.String(), err.Error())
+		return nil, err
+	}
+
+	return &types.UniswapV3PoolState{
+		SqrtPriceX96: hexutil.Big(*slot.SqrtPriceX96),
+		Tick:         int32(slot.Tick.Int64()),
+		Liquidity:    hexutil.Big(*liq),
+		FeeGrowth0:   hexutil.Big(*fg0),
+		FeeGrowth1:   hexutil.Big(*fg1),
+	}, nil
+}
+
+// UniswapV3Ticks loads liquidity details of the given ticks of a concentrated liquidity pool in batch calls.
+// Only the first uniswapV3MaxTicks ticks are loaded; ticks no longer used by any position are skipped.
+func (ftm *FtmBridge) UniswapV3Ticks(pool *common.Address, ticks []int32) ([]*types.UniswapV3Tick, error) {
+	uniswapV3PoolAbiOnce.Do(func() {
+		var err error
+		uniswapV3PoolAbi, err = abi.JSON(strings.NewReader(contracts.UniswapV3PoolMetaData.ABI))
+		if err != nil {
+			ftm.log.Criticalf("failed to parse uniswap v3 pool contract ABI; %s", err.Error())
+			panic(err)
+		}
+	})
+
+	if len(ticks) > uniswapV3MaxTicks {
+		ftm.log.Warningf("uniswap v3 pool %s has %d ticks, only %d loaded", pool.String(), len(ticks), uniswapV3MaxTicks)
+		ticks = ticks[:uniswapV3MaxTicks]
+	}
+
+	res := make([]hexutil.Bytes, len(ticks))
+	calls := make([]client.BatchElem, len(ticks))
+	for i, t := range ticks {
+		var err error
+		calls[i], err = contractCall(&uniswapV3PoolAbi, pool, &res[i], "ticks", big.NewInt(int64(t)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := ftm.batchCall(context.Background(), calls); err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.UniswapV3Tick, 0, len(ticks))
+	for i, t := range ticks {
+		if calls[i].Error != nil {
+			ftm.log.Errorf("uniswap v3 pool %s tick %d not available; %s", pool.String(), t, calls[i].Error.Error())
+			return nil, calls[i].Error
+		}
+
+		out, err := uniswapV3PoolAbi.Unpack("ticks", res[i])
+		if err != nil || len(out) != 8 {
+			ftm.log.Errorf("uniswap v3 pool %s tick %d not recognized", pool.String(), t)
+			return nil, fmt.Errorf("invalid tick %d of pool %s", t, pool.String())
+		}
+
+		// skip ticks no longer used by any position
+		if !*abi.ConvertType(out[7], new(bool)).(*bool) {
+			continue
+		}
+
+		list = append(list, &types.UniswapV3Tick{
+			Index:          t,
+			LiquidityGross: hexutil.Big(*abi.ConvertType(out[0], new(big.Int)).(*big.Int)),
+			LiquidityNet:   hexutil.Big(*abi.ConvertType(out[1], new(big.Int)).(*big.Int)),
+			Initialized:    true,
+		})
+	}
+	return list, nil
+}
+
+// UniswapV3Position loads the state of a liquidity position from the position manager
+// at the given block; the latest state is loaded if the block is not specified.
+func (ftm *FtmBridge) UniswapV3Position(tokenId *big.Int, block *big.Int) (*types.UniswapV3Position, error) {
+	if ftm.uniswapV3Config.PositionManager == (common.Address{}) {
+		return nil, fmt.Errorf("uniswap v3 position manager not configured")
+	}
+
+	contract, err := contracts.NewUniswapV3PositionManagerCaller(ftm.uniswapV3Config.PositionManager, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 position manager contract not found; %s", err.Error())
+		return nil, err
+	}
+
+	opts := ftm.CallOptsAt(block)
+	pos, err := contract.Positions(opts, tokenId)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 position %s not available; %s", tokenId.String(), err.Error())
+		return nil, err
+	}
+
+	owner, err := contract.OwnerOf(opts, tokenId)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 position %s owner not available; %s", tokenId.String(), err.Error())
+		return nil, err
+	}
+
+	// find the pool of the position on the factory
+	factory, err := contracts.NewUniswapV3FactoryCaller(ftm.uniswapV3Config.Factory, ftm.eth)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 factory contract not found; %s", err.Error())
+		return nil, err
+	}
+
+	pool, err := factory.GetPool(opts, pos.Token0, pos.Token1, pos.Fee)
+	if err != nil {
+		ftm.log.Errorf("uniswap v3 pool of position %s not available; %s", tokenId.String(), err.Error())
+		return nil, err
+	}
+
+	return &types.UniswapV3Position{
+		TokenId:     hexutil.Big(*tokenId),
+		Owner:       owner,
+		Pool:        pool,
+		Token0:      pos.Token0,
+		Token1:      pos.Token1,
+		Fee:         int32(pos.Fee.Int64()),
+		TickLower:   int32(pos.TickLower.Int64()),
+		TickUpper:   int32(pos.TickUpper.Int64()),
+		Liquidity:   hexutil.Big(*pos.Liquidity),
+		TokensOwed0: hexutil.Big(*pos.TokensOwed0),
+		TokensOwed1: hexutil.Big(*pos.TokensOwed1),
+	}, nil
+}
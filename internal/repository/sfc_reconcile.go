@@ -0,0 +1,311 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// reconcileMaxWithdrawals represents the max number of pending withdraw requests
+// of a delegation verified by the stake reconciliation.
+const reconcileMaxWithdrawals = 100
+
+// Delegators provides the list of addresses of all the known delegators.
+func (p *proxy) Delegators() ([]common.Address, error) {
+	return p.db.Delegators()
+}
+
+// stakeReconcile represents the state of the SFC the stored stake is reconciled with.
+type stakeReconcile struct {
+	block   *big.Int
+	time    time.Time
+	scanned func() uint64
+}
+
+// outdated checks if the block scanner moved past the reconciled block. The events of the new blocks
+// may have updated the stored stake already, so the stored state is not repaired in such case;
+// the difference, if it still exists, is repaired by the next reconciliation.
+func (rc *stakeReconcile) outdated() bool {
+	return rc.scanned != nil && rc.scanned() > rc.block.Uint64()
+}
+
+// newer checks if the stored record created at the given time comes from a block newer than the reconciled one.
+func (rc *stakeReconcile) newer(created hexutil.Uint64) bool {
+	return time.Unix(int64(created), 0).After(rc.time)
+}
+
+// ReconcileStake compares the stored delegations, locks and pending withdraw requests
+// of the given address with the SFC state at the given block and repairs any differences found.
+// The block should be the last block processed by the block scanner so events not processed
+// yet are not mistaken for differences; the scanned callback provides the number of the last
+// block processed by the scanner so repairs are skipped if the scanner moved past the block
+// in the meantime. If discover is set, all the validators are checked for delegations
+// not known yet. It returns the number of repairs made; each repair is recorded in the audit log.
+func (p *proxy) ReconcileStake(addr *common.Address, discover bool, blk *types.Block, scanned func() uint64) (int, error) {
+	dl, err := p.DelegationsByAddressAll(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	rc := &stakeReconcile{
+		block:   new(big.Int).SetUint64(uint64(blk.Number)),
+		time:    time.Unix(int64(blk.TimeStamp), 0),
+		scanned: scanned,
+	}
+
+	// verify known delegations
+	var fixes int
+	known := make(map[uint64]bool, len(dl))
+	for _, dlg := range dl {
+		known[dlg.ToStakerId.ToInt().Uint64()] = true
+
+		// the delegation has been created after the reconciled block
+		if rc.newer(dlg.CreatedTime) {
+			continue
+		}
+
+		n, err := p.reconcileDelegation(dlg, rc)
+		fixes += n
+		if err != nil {
+			return fixes, err
+		}
+	}
+
+	// look for delegations we missed completely
+	if discover {
+		n, err := p.discoverDelegations(addr, known, rc)
+		fixes += n
+		if err != nil {
+			return fixes, err
+		}
+	}
+	return fixes, nil
+}
+
+// reconcileDelegation verifies the given delegation, its lock and pending withdraw requests.
+func (p *proxy) reconcileDelegation(dlg *types.Delegation, rc *stakeReconcile) (int, error) {
+	var fixes int
+
+	// check the active amount
+	amo, err := p.rpc.AmountStakedAt(&dlg.Address, dlg.ToStakerId.ToInt(), rc.block)
+	if err != nil {
+		return 0, err
+	}
+
+	if (dlg.AmountDelegated == nil || dlg.AmountDelegated.ToInt().Cmp(amo) != 0) && !rc.outdated() {
+		prev := "nil"
+		if dlg.AmountDelegated != nil {
+			prev = dlg.AmountDelegated.String()
+		}
+
+		dlg.AmountDelegated = (*hexutil.Big)(amo)
+		if err := p.db.UpdateDelegationBalance(&dlg.Address, dlg.ToStakerId, dlg.AmountDelegated); err != nil {
+			return fixes, err
+		}
+		p.cache.PushDelegation(dlg)
+
+		p.addStakeFix(&dlg.Address, dlg.ToStakerId, types.StakeFixDelegation, prev, dlg.AmountDelegated.String())
+		fixes++
+	}
+
+	// check the lock
+	n, err := p.reconcileLock(&dlg.Address, dlg.ToStakerId, rc)
+	fixes += n
+	if err != nil {
+		return fixes, err
+	}
+
+	// check pending withdrawals
+	n, err = p.reconcileWithdrawals(&dlg.Address, dlg.ToStakerId, rc)
+	return fixes + n, err
+}
+
+// reconcileLock verifies the stored lock of the given delegation against the SFC.
+func (p *proxy) reconcileLock(addr *common.Address, valID *hexutil.Big, rc *stakeReconcile) (int, error) {
+	lock, err := p.rpc.DelegationLockAt(addr, valID, rc.block)
+	if err != nil {
+		return 0, err
+	}
+
+	ld, err := p.db.LockedDelegation(*addr, valID.ToInt().Int64())
+	if err != nil {
+		return 0, err
+	}
+
+	// the lock has been made after the reconciled block
+	if ld != nil && ld.Locked.After(rc.time) {
+		return 0, nil
+	}
+
+	// make the lock state expected by the SFC at the reconciled block
+	now := rc.time
+	var want *types.LockedDelegation
+	until := time.Unix(int64(lock.LockedUntil), 0)
+	if lock.LockedAmount.ToInt().Sign() > 0 && until.After(now) {
+		want = &types.LockedDelegation{
+			Delegator:   *addr,
+			ValidatorId: valID.ToInt().Int64(),
+			Locked:      until.Add(-time.Duration(lock.Duration) * time.Second),
+			Duration:    int64(lock.Duration),
+			LockedUntil: until,
+		}
+		want.SetAmount(&lock.LockedAmount)
+	}
+
+	// is the stored state active?
+	active := ld != nil && ld.Value > 0 && ld.LockedUntil.After(now)
+	switch {
+	case want == nil && !active:
+		return 0, nil
+	case want != nil && active && ld.Value == want.Value && ld.LockedUntil.Equal(want.LockedUntil):
+		return 0, nil
+	case want == nil:
+		// the lock is gone on chain; clear the stored value
+		cl := *ld
+		cl.Value = 0
+		want = &cl
+	}
+
+	if rc.outdated() {
+		return 0, nil
+	}
+	if err := p.db.ReplaceLockedDelegation(want); err != nil {
+		return 0, err
+	}
+
+	prev := "none"
+	if ld != nil {
+		prev = lockFixDetail(ld)
+	}
+	p.addStakeFix(addr, valID, types.StakeFixLock, prev, lockFixDetail(want))
+	return 1, nil
+}
+
+// reconcileWithdrawals verifies pending withdraw requests of the given delegation against the SFC.
+func (p *proxy) reconcileWithdrawals(addr *common.Address, valID *hexutil.Big, rc *stakeReconcile) (int, error) {
+	wl, err := p.WithdrawRequests(addr, valID, nil, reconcileMaxWithdrawals, true)
+	if err != nil {
+		return 0, err
+	}
+
+	var fixes int
+	for _, wr := range wl.Collection {
+		// only SFC3 requests can be verified; previous versions were settled on upgrade
+		if wr.Type != types.WithdrawTypeUndelegated || rc.newer(wr.CreatedTime) {
+			continue
+		}
+
+		amo, pending, err := p.rpc.PendingWithdrawalAmount(addr, valID.ToInt(), wr.WithdrawRequestID.ToInt(), rc.block)
+		if err != nil {
+			return fixes, err
+		}
+
+		// the request has been withdrawn, but we missed the event;
+		// we don't know the withdraw transaction and time, so we close it without them
+		if !pending && !rc.outdated() {
+			wr.Closed = true
+			if err := p.db.UpdateWithdrawal(wr); err != nil {
+				return fixes, err
+			}
+
+			p.addStakeFix(addr, valID, types.StakeFixWithdrawClosed, wr.WithdrawRequestID.String(), "withdrawn")
+			fixes++
+			continue
+		}
+
+		// the amount differs
+		if pending && (wr.Amount == nil || wr.Amount.ToInt().Cmp(amo) != 0) && !rc.outdated() {
+			prev := "nil"
+			if wr.Amount != nil {
+				prev = wr.Amount.String()
+			}
+
+			wr.Amount = (*hexutil.Big)(amo)
+			if err := p.db.UpdateWithdrawal(wr); err != nil {
+				return fixes, err
+			}
+
+			p.addStakeFix(addr, valID, types.StakeFixWithdrawAmount, prev, wr.Amount.String())
+			fixes++
+		}
+	}
+	return fixes, nil
+}
+
+// discoverDelegations checks all the validators for delegations of the given address
+// not known to the API and creates them from the SFC state.
+func (p *proxy) discoverDelegations(addr *common.Address, known map[uint64]bool, rc *stakeReconcile) (int, error) {
+	last, err := p.rpc.LastValidatorIdAt(rc.block)
+	if err != nil {
+		return 0, err
+	}
+
+	var fixes int
+	for id := uint64(1); id <= last; id++ {
+		if known[id] {
+			continue
+		}
+
+		valID := (*hexutil.Big)(new(big.Int).SetUint64(id))
+		amo, err := p.rpc.AmountStakedAt(addr, valID.ToInt(), rc.block)
+		if err != nil {
+			return fixes, err
+		}
+		if amo.Sign() == 0 || rc.outdated() {
+			continue
+		}
+
+		val, err := p.ValidatorAddress(valID)
+		if err != nil {
+			return fixes, err
+		}
+
+		// we don't know the original transaction and time, they are left unknown
+		err = p.db.AddDelegation(&types.Delegation{
+			Address:         *addr,
+			ToStakerId:      valID,
+			ToStakerAddress: *val,
+			AmountStaked:    (*hexutil.Big)(amo),
+			AmountDelegated: (*hexutil.Big)(amo),
+		})
+		if err != nil {
+			return fixes, err
+		}
+
+		p.addStakeFix(addr, valID, types.StakeFixDelegationCreated, "none", (*hexutil.Big)(amo).String())
+		fixes++
+
+		// the new delegation may be locked
+		n, err := p.reconcileLock(addr, valID, rc)
+		fixes += n
+		if err != nil {
+			return fixes, err
+		}
+	}
+	return fixes, nil
+}
+
+// addStakeFix records the stake repair in the audit log.
+func (p *proxy) addStakeFix(addr *common.Address, valID *hexutil.Big, kind string, prev string, cur string) {
+	p.log.Noticef("stake of %s to #%d repaired; %s changed from %s to %s", addr.String(), valID.ToInt().Uint64(), kind, prev, cur)
+
+	err := p.db.AddStakeFix(&types.StakeFix{
+		Address:     *addr,
+		ValidatorId: valID.ToInt().Int64(),
+		Kind:        kind,
+		Previous:    prev,
+		Current:     cur,
+		TimeStamp:   time.Now().UTC(),
+	})
+	if err != nil {
+		p.log.Errorf("stake fix of %s not recorded; %s", addr.String(), err.Error())
+	}
+}
+
+// lockFixDetail provides a human-readable detail of the lock state for the audit log.
+func lockFixDetail(ld *types.LockedDelegation) string {
+	return fmt.Sprintf("%d until %s", ld.Value, ld.LockedUntil.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,146 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	// StakeScheduleDay represents a daily resolution of the stake unlock schedule.
+	StakeScheduleDay = "day"
+
+	// StakeScheduleWeek represents a weekly resolution of the stake unlock schedule.
+	StakeScheduleWeek = "week"
+
+	// StakeScheduleMonth represents a monthly resolution of the stake unlock schedule.
+	StakeScheduleMonth = "month"
+)
+
+// withdrawalPeriod provides the time needed for a withdraw request to become claimable.
+func (p *proxy) withdrawalPeriod() (time.Duration, error) {
+	sc, err := p.SfcConfiguration()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(sc.WithdrawalPeriodTime.ToInt().Int64()) * time.Second, nil
+}
+
+// StakingSchedule provides the list of upcoming lock expirations and pending withdrawals
+// of the given delegator sorted by the date.
+func (p *proxy) StakingSchedule(addr *common.Address) ([]*types.StakeScheduleItem, error) {
+	period, err := p.withdrawalPeriod()
+	if err != nil {
+		return nil, err
+	}
+
+	locks, err := p.db.ActiveLockedDelegations(addr, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	wrs, err := p.db.PendingWithdrawals(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.StakeScheduleItem, 0, len(locks)+len(wrs))
+	for _, ld := range locks {
+		item := types.StakeScheduleItem{
+			Type:        types.StakeScheduleUnlock,
+			ValidatorId: (hexutil.Big)(*big.NewInt(ld.ValidatorId)),
+			Date:        ld.LockedUntil,
+		}
+		item.Amount.ToInt().Mul(big.NewInt(ld.Value), types.DelegationDecimalsCorrection)
+		list = append(list, &item)
+	}
+
+	for _, wr := range wrs {
+		list = append(list, &types.StakeScheduleItem{
+			Type:        types.StakeScheduleWithdrawal,
+			ValidatorId: *wr.StakerID,
+			Date:        time.Unix(int64(wr.CreatedTime), 0).Add(period),
+			Amount:      *wr.Amount,
+			RequestId:   wr.WithdrawRequestID,
+		})
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Date.Before(list[j].Date)
+	})
+	return list, nil
+}
+
+// StakeUnlockSchedule provides the network-wide amounts of stake unlocking and becoming
+// withdrawable in the given time range aggregated by the given resolution.
+func (p *proxy) StakeUnlockSchedule(from time.Time, to time.Time, resolution string) ([]*types.StakeScheduleTick, error) {
+	period, err := p.withdrawalPeriod()
+	if err != nil {
+		return nil, err
+	}
+
+	unlocks, err := p.db.UnlockScheduleDaily(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	wds, err := p.db.WithdrawalScheduleDaily(from, to, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregateStakeSchedule(append(unlocks, wds...), resolution), nil
+}
+
+// aggregateStakeSchedule merges the given daily ticks into ticks of the given resolution.
+func aggregateStakeSchedule(days []*types.StakeScheduleTick, resolution string) []*types.StakeScheduleTick {
+	idx := make(map[int64]*types.StakeScheduleTick)
+	list := make([]*types.StakeScheduleTick, 0)
+
+	for _, d := range days {
+		date := stakeScheduleTickStart(d.Date, resolution)
+
+		tick, ok := idx[date.Unix()]
+		if !ok {
+			tick = &types.StakeScheduleTick{Date: date}
+			idx[date.Unix()] = tick
+			list = append(list, tick)
+		}
+
+		tick.Unlocking.ToInt().Add(tick.Unlocking.ToInt(), d.Unlocking.ToInt())
+		tick.UnlockCount += d.UnlockCount
+		tick.Withdrawable.ToInt().Add(tick.Withdrawable.ToInt(), d.Withdrawable.ToInt())
+		tick.WithdrawCount += d.WithdrawCount
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Date.Before(list[j].Date)
+	})
+	return list
+}
+
+// stakeScheduleTickStart calculates the start of the schedule tick of the given resolution
+// the given date belongs to. Weeks start on Monday.
+func stakeScheduleTickStart(date time.Time, resolution string) time.Time {
+	y, m, d := date.UTC().Date()
+	switch resolution {
+	case StakeScheduleMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case StakeScheduleWeek:
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+const (
+	// validatorDirectoryEpochs represents the number of recent epochs used
+	// to calculate the validator uptime in the directory snapshot.
+	validatorDirectoryEpochs = 100
+
+	// validator status flags of the SFC contract
+	sfcStatusWithdrawn  = 1
+	sfcStatusOffline    = 1 << 3
+	sfcStatusDoubleSign = 1 << 7
+)
+
+// RefreshValidatorDirectory takes a new snapshot of all the validators
+// used to filter and sort the validators directory. Outdated snapshots are purged
+// only if all the validators were refreshed, so a validator failing to load
+// is not dropped from the directory.
+func (p *proxy) RefreshValidatorDirectory() (int, error) {
+	num, err := p.rpc.LastValidatorId()
+	if err != nil {
+		return 0, err
+	}
+
+	// the commission is common for all the validators
+	com, err := p.rpc.SfcValidatorCommission()
+	if err != nil {
+		return 0, err
+	}
+	commission, _ := new(big.Float).Quo(new(big.Float).SetInt(com), new(big.Float).SetInt(sfcDecimalUnit)).Float64()
+
+	dlgCount, err := p.db.DelegatorsCountByValidator()
+	if err != nil {
+		return 0, err
+	}
+
+	epoch, err := p.rpc.CurrentSealedEpoch()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	var count, failed int
+	for id := uint64(1); id <= num; id++ {
+		vs, err := p.validatorSnapshot(id, uint64(epoch), now)
+		if err != nil {
+			p.log.Errorf("can not take snapshot of validator #%d; %s", id, err.Error())
+			failed++
+			continue
+		}
+		if vs == nil {
+			continue
+		}
+
+		vs.Commission = commission * 100
+		vs.DelegatorCount = dlgCount[id]
+		if err := p.db.StoreValidatorSnapshot(vs); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	if failed > 0 {
+		p.log.Warningf("%d validators not refreshed, outdated directory snapshots kept", failed)
+		return count, nil
+	}
+	return count, p.db.PurgeValidatorSnapshots(now)
+}
+
+// validatorSnapshot builds the directory snapshot of the given validator.
+func (p *proxy) validatorSnapshot(id uint64, epoch uint64, now time.Time) (*types.ValidatorSnapshot, error) {
+	valID := (*hexutil.Big)(new(big.Int).SetUint64(id))
+	val, err := p.rpc.Validator(valID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	if val.Id.ToInt().Uint64() == 0 {
+		return nil, nil
+	}
+
+	vs := types.ValidatorSnapshot{
+		Id:          id,
+		Address:     val.StakerAddress.String(),
+		Status:      uint64(val.Status),
+		IsActive:    val.Status == 0,
+		IsOffline:   uint64(val.Status)&sfcStatusOffline > 0,
+		IsWithdrawn: uint64(val.Status)&sfcStatusWithdrawn > 0,
+		IsCheater:   uint64(val.Status)&sfcStatusDoubleSign > 0,
+		TotalStake:  "0x0",
+		SelfStake:   "0x0",
+		Snapshot:    now,
+	}
+
+	if val.TotalStake != nil {
+		vs.TotalStake = val.TotalStake.String()
+		vs.StakeValue, _ = new(big.Float).Quo(new(big.Float).SetInt(val.TotalStake.ToInt()), new(big.Float).SetInt(sfcDecimalUnit)).Float64()
+	}
+
+	if err := p.validatorSnapshotStake(&vs, &val.StakerAddress, valID); err != nil {
+		return nil, err
+	}
+
+	if err := p.validatorSnapshotEpochs(&vs, valID, epoch); err != nil {
+		return nil, err
+	}
+
+	if sti := p.cache.PullStakerInfo(valID); sti != nil {
+		vs.HasInfo = true
+		if sti.Name != nil {
+			vs.Name = *sti.Name
+		}
+	}
+	return &vs, nil
+}
+
+// validatorSnapshotStake adds the self-stake and its lock to the validator snapshot.
+func (p *proxy) validatorSnapshotStake(vs *types.ValidatorSnapshot, addr *common.Address, valID *hexutil.Big) error {
+	self, err := p.rpc.AmountStaked(addr, valID.ToInt())
+	if err != nil {
+		return err
+	}
+	vs.SelfStake = (*hexutil.Big)(self).String()
+
+	lock, err := p.rpc.DelegationLock(addr, valID)
+	if err != nil {
+		return err
+	}
+	vs.LockedUntil = time.Unix(int64(lock.LockedUntil), 0).UTC()
+	return nil
+}
+
+// validatorSnapshotEpochs adds the uptime and APR calculated from the recent epochs to the validator snapshot.
+func (p *proxy) validatorSnapshotEpochs(vs *types.ValidatorSnapshot, valID *hexutil.Big, epoch uint64) error {
+	from := uint64(0)
+	if epoch > validatorDirectoryEpochs {
+		from = epoch - validatorDirectoryEpochs
+	}
+
+	list, err := p.db.ValidatorEpochs(valID, from, epoch, validatorDirectoryEpochs+1)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	var up, total uint64
+	for _, ve := range list {
+		up += uint64(ve.Uptime)
+		total += uint64(ve.Duration)
+	}
+	if total > 0 {
+		vs.Uptime = float64(up) / float64(total) * 100
+	}
+
+	vs.Apr = list[len(list)-1].Apr()
+	return nil
+}
+
+// ValidatorDirectory provides a page of the validators directory snapshot for the given filter and sorting.
+func (p *proxy) ValidatorDirectory(fi *types.ValidatorSnapshotFilter, sortBy string, desc bool, cursor *uint64, count int64) (*types.ValidatorSnapshotList, error) {
+	return p.db.ValidatorSnapshots(fi, sortBy, desc, cursor, count)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+const (
+	// validatorEpochsMaxItems represents the max number of validator epoch snapshots provided in one request.
+	validatorEpochsMaxItems = 1000
+
+	// validatorEpochGapsBatch represents the max number of epochs backfilled in one pass.
+	validatorEpochGapsBatch = 5
+)
+
+// AddValidatorEpochs takes snapshots of all the validators of the given sealed epoch
+// and stores them in the persistent storage. Validators which could not be loaded
+// are recorded as a gap of the epoch to be backfilled later.
+func (p *proxy) AddValidatorEpochs(ep *types.Epoch) error {
+	// the self stake is known only for the current epoch
+	cur, err := p.rpc.CurrentSealedEpoch()
+	if err != nil {
+		p.addValidatorEpochGap(ep, nil)
+		return err
+	}
+
+	vl, failed, err := p.rpc.ValidatorEpochs(ep, cur == ep.Id, nil)
+	if err != nil {
+		p.addValidatorEpochGap(ep, nil)
+		return err
+	}
+
+	failed = append(failed, p.storeValidatorEpochs(vl)...)
+	if len(failed) > 0 {
+		p.addValidatorEpochGap(ep, failed)
+	}
+	return nil
+}
+
+// BackfillValidatorEpochs tries to complete the validator snapshots of epochs
+// recorded as incomplete. The self stake is not available for backfilled snapshots.
+func (p *proxy) BackfillValidatorEpochs() error {
+	gaps, err := p.db.ValidatorEpochGaps(validatorEpochGapsBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, gap := range gaps {
+		ids := make([]*big.Int, len(gap.Validators))
+		for i, v := range gap.Validators {
+			ids[i] = big.NewInt(v)
+		}
+
+		ep := types.Epoch{Id: hexutil.Uint64(gap.Epoch), EndTime: hexutil.Uint64(gap.EndTime)}
+		vl, failed, err := p.rpc.ValidatorEpochs(&ep, false, ids)
+		if err != nil {
+			p.log.Errorf("can not backfill validators of epoch #%d; %s", gap.Epoch, err.Error())
+			continue
+		}
+
+		failed = append(failed, p.storeValidatorEpochs(vl)...)
+		if len(failed) > 0 {
+			p.addValidatorEpochGap(&ep, failed)
+			continue
+		}
+
+		p.log.Noticef("validators of epoch #%d backfilled", gap.Epoch)
+		if err := p.db.RemoveValidatorEpochGap(gap.Epoch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeValidatorEpochs stores the given validator snapshots
+// and returns the IDs of validators which could not be stored.
+func (p *proxy) storeValidatorEpochs(vl []*types.ValidatorEpoch) []*big.Int {
+	failed := make([]*big.Int, 0)
+	for _, ve := range vl {
+		if err := p.db.AddValidatorEpoch(ve); err != nil {
+			failed = append(failed, ve.ValidatorId.ToInt())
+		}
+	}
+	return failed
+}
+
+// addValidatorEpochGap records the given validators of the epoch as missing;
+// an empty list of validators marks the whole epoch as missing.
+func (p *proxy) addValidatorEpochGap(ep *types.Epoch, failed []*big.Int) {
+	gap := types.ValidatorEpochGap{
+		Epoch:      int64(ep.Id),
+		EndTime:    int64(ep.EndTime),
+		Validators: make([]int64, len(failed)),
+	}
+	for i, vid := range failed {
+		gap.Validators[i] = vid.Int64()
+	}
+
+	p.log.Warningf("validators of epoch #%d incomplete, %d missing", ep.Id, len(failed))
+	_ = p.db.AddValidatorEpochGap(&gap)
+}
+
+// ValidatorEpochs provides snapshots of the given validator for the given range of epochs,
+// the oldest epoch first.
+func (p *proxy) ValidatorEpochs(valID *hexutil.Big, fromEpoch uint64, toEpoch uint64) ([]*types.ValidatorEpoch, error) {
+	return p.db.ValidatorEpochs(valID, fromEpoch, toEpoch, validatorEpochsMaxItems)
+}
@@ -54,7 +54,10 @@ func (p *proxy) WithdrawRequests(addr *common.Address, stakerID *hexutil.Big, cu
 
 	// limit the output to active withdrawals only
 	if activeOnly {
-		filter = append(filter, bson.E{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}})
+		filter = append(filter,
+			bson.E{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
+			bson.E{Key: types.FiWithdrawalClosed, Value: bson.D{{Key: "$ne", Value: true}}},
+		)
 	}
 	return p.db.Withdrawals(cursor, count, &filter)
 }
@@ -71,6 +74,7 @@ func (p *proxy) WithdrawRequestsPendingTotal(addr *common.Address, stakerID *hex
 		return p.db.WithdrawalsSumValue(&bson.D{
 			{Key: types.FiWithdrawalAddress, Value: addr.String()},
 			{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
+			{Key: types.FiWithdrawalClosed, Value: bson.D{{Key: "$ne", Value: true}}},
 		})
 	}
 
@@ -79,5 +83,6 @@ func (p *proxy) WithdrawRequestsPendingTotal(addr *common.Address, stakerID *hex
 		{Key: types.FiWithdrawalAddress, Value: addr.String()},
 		{Key: types.FiWithdrawalToValidator, Value: stakerID.String()},
 		{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
+		{Key: types.FiWithdrawalClosed, Value: bson.D{{Key: "$ne", Value: true}}},
 	})
 }
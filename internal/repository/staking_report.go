@@ -0,0 +1,227 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// priceHistoryMaxAge represents the max age of a recorded price
+// to be used for valuation of a staking event.
+const priceHistoryMaxAge = 24 * time.Hour
+
+// RecordPrice stores the current price of the native token in the given symbol
+// into the price history.
+func (p *proxy) RecordPrice(sym string) error {
+	pri, err := p.Price(sym)
+	if err != nil {
+		return err
+	}
+
+	return p.db.AddPriceTick(&types.PriceTick{
+		Symbol:    strings.ToUpper(sym),
+		Price:     pri.Price,
+		TimeStamp: time.Now().UTC().Truncate(time.Hour),
+	})
+}
+
+// PriceAt provides the recorded price of the native token in the given symbol at the given time.
+// Nil is returned if the price history does not cover the time.
+func (p *proxy) PriceAt(sym string, ts time.Time) (*types.PriceTick, error) {
+	return p.db.PriceAt(sym, ts, priceHistoryMaxAge)
+}
+
+// StoreDelegationUnlock stores the given delegation unlock into the database.
+func (p *proxy) StoreDelegationUnlock(du *types.DelegationUnlock) error {
+	return p.db.AddDelegationUnlock(du)
+}
+
+// StakingReport builds the staking rewards report of the given address for the given time range.
+// The fiat values are calculated from the price history in the given currency symbol.
+func (p *proxy) StakingReport(addr *common.Address, currency string, from time.Time, to time.Time) (*types.StakingReport, error) {
+	// check the currency validity
+	if !p.isValidPriceSymbol(currency) {
+		return nil, fmt.Errorf("unknown price symbol requested")
+	}
+
+	rep := types.StakingReport{
+		Address:  *addr,
+		Currency: strings.ToUpper(currency),
+		From:     from,
+		To:       to,
+		Entries:  make([]*types.StakingReportEntry, 0),
+	}
+
+	if err := p.stakingReportRewards(&rep); err != nil {
+		return nil, err
+	}
+	if err := p.stakingReportPenalties(&rep); err != nil {
+		return nil, err
+	}
+	if err := p.stakingReportWithdrawals(&rep); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rep.Entries, func(i, j int) bool {
+		return rep.Entries[i].TimeStamp.Before(rep.Entries[j].TimeStamp)
+	})
+
+	if err := p.stakingReportValuate(&rep); err != nil {
+		return nil, err
+	}
+	rep.Totals = stakingReportTotals(rep.Entries)
+	return &rep, nil
+}
+
+// stakingReportRewards adds reward claims and restakes to the report.
+func (p *proxy) stakingReportRewards(rep *types.StakingReport) error {
+	list, err := p.db.RewardClaimsRange(&rep.Address, rep.From, rep.To)
+	if err != nil {
+		return err
+	}
+
+	for _, rc := range list {
+		e := types.StakingReportEntry{
+			Type:        types.StakingReportClaim,
+			TimeStamp:   time.Unix(int64(rc.Claimed), 0).UTC(),
+			ValidatorId: rc.ToValidatorId,
+			Amount:      rc.Amount,
+			Trx:         rc.ClaimTrx,
+		}
+		if rc.IsDelegated {
+			e.Type = types.StakingReportRestake
+		}
+		rep.Entries = append(rep.Entries, &e)
+	}
+	return nil
+}
+
+// stakingReportPenalties adds penalties paid on premature unlocks to the report.
+func (p *proxy) stakingReportPenalties(rep *types.StakingReport) error {
+	list, err := p.db.DelegationUnlocksRange(&rep.Address, rep.From, rep.To)
+	if err != nil {
+		return err
+	}
+
+	for _, du := range list {
+		rep.Entries = append(rep.Entries, &types.StakingReportEntry{
+			Type:        types.StakingReportLockPenalty,
+			TimeStamp:   du.When.UTC(),
+			ValidatorId: (hexutil.Big)(*big.NewInt(du.ValidatorId)),
+			Amount:      du.Penalty,
+			Trx:         du.Trx,
+		})
+	}
+	return nil
+}
+
+// stakingReportWithdrawals adds finalized withdrawals to the report.
+// The withdrawn amount is the requested amount reduced by the slashing penalty, if any.
+func (p *proxy) stakingReportWithdrawals(rep *types.StakingReport) error {
+	list, err := p.db.WithdrawalsFinalizedRange(&rep.Address, rep.From, rep.To)
+	if err != nil {
+		return err
+	}
+
+	for _, wr := range list {
+		if wr.WithdrawTime == nil || wr.WithdrawTrx == nil {
+			continue
+		}
+
+		amo := new(big.Int).Set(wr.Amount.ToInt())
+		if wr.Penalty != nil {
+			amo.Sub(amo, wr.Penalty.ToInt())
+		}
+
+		rep.Entries = append(rep.Entries, &types.StakingReportEntry{
+			Type:        types.StakingReportWithdrawal,
+			TimeStamp:   time.Unix(int64(*wr.WithdrawTime), 0).UTC(),
+			ValidatorId: *wr.StakerID,
+			Amount:      (hexutil.Big)(*amo),
+			Trx:         *wr.WithdrawTrx,
+		})
+	}
+	return nil
+}
+
+// stakingReportValuate adds the epoch and the fiat value at the event time to the report entries.
+func (p *proxy) stakingReportValuate(rep *types.StakingReport) error {
+	prices := make(map[int64]*types.PriceTick)
+	for _, e := range rep.Entries {
+		ep, err := p.db.EpochAt(e.TimeStamp)
+		if err != nil {
+			return err
+		}
+		e.Epoch = ep
+
+		// the price history is recorded per hour
+		hour := e.TimeStamp.Truncate(time.Hour).Unix()
+		pt, ok := prices[hour]
+		if !ok {
+			pt, err = p.db.PriceAt(rep.Currency, e.TimeStamp, priceHistoryMaxAge)
+			if err != nil {
+				return err
+			}
+			prices[hour] = pt
+		}
+		if pt == nil {
+			continue
+		}
+
+		val := tokenAmountFloat(e.Amount.ToInt()) * pt.Price
+		e.Price = &pt.Price
+		e.FiatValue = &val
+	}
+	return nil
+}
+
+// stakingReportTotals calculates per year totals of each event type of the report entries.
+func stakingReportTotals(entries []*types.StakingReportEntry) []*types.StakingReportTotal {
+	idx := make(map[string]*types.StakingReportTotal)
+	list := make([]*types.StakingReportTotal, 0)
+
+	for _, e := range entries {
+		key := e.Type + e.TimeStamp.Format("2006")
+		tot, ok := idx[key]
+		if !ok {
+			tot = &types.StakingReportTotal{Year: e.TimeStamp.Year(), Type: e.Type, Amount: hexutil.Big{}}
+			idx[key] = tot
+			list = append(list, tot)
+		}
+
+		tot.Count++
+		tot.Amount = (hexutil.Big)(*new(big.Int).Add(tot.Amount.ToInt(), e.Amount.ToInt()))
+		if e.FiatValue == nil {
+			tot.Unvalued++
+			continue
+		}
+		tot.FiatValue += *e.FiatValue
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Year != list[j].Year {
+			return list[i].Year < list[j].Year
+		}
+		return list[i].Type < list[j].Type
+	})
+	return list
+}
+
+// tokenAmountFloat converts the given amount of WEI units to a float amount of tokens.
+func tokenAmountFloat(amo *big.Int) float64 {
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(amo), new(big.Float).SetInt(sfcDecimalUnit)).Float64()
+	return val
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"fantom-api-graphql/internal/types"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// UniswapV3Factory returns the address of the configured concentrated liquidity pools factory.
+func (p *proxy) UniswapV3Factory() common.Address {
+	return p.rpc.UniswapV3Factory()
+}
+
+// UniswapV3PositionManager returns the address of the configured liquidity positions manager.
+func (p *proxy) UniswapV3PositionManager() common.Address {
+	return p.rpc.UniswapV3PositionManager()
+}
+
+// IsUniswapV3Pool checks if the given address is a pool deployed by the configured v3 factory.
+// Pools not yet known to the persistent storage are verified on chain and stored for future use.
+func (p *proxy) IsUniswapV3Pool(adr *common.Address) bool {
+	// no factory configured, no pools
+	factory := p.rpc.UniswapV3Factory()
+	if factory == (common.Address{}) {
+		return false
+	}
+
+	// do we know the pool already?
+	pool, err := p.db.UniswapV3Pool(adr)
+	if err == nil && pool != nil {
+		return true
+	}
+
+	// check the pool factory on chain; a non-pool contract will simply fail here
+	fa, err := p.rpc.UniswapV3PoolFactory(adr)
+	if err != nil || fa != factory {
+		return false
+	}
+
+	// collect the pool details and store them
+	pool, err = p.rpc.UniswapV3Pool(adr)
+	if err != nil {
+		log.Errorf("uniswap v3 pool %s not available; %s", adr.String(), err.Error())
+		return false
+	}
+	if err := p.db.StoreUniswapV3Pool(pool); err != nil {
+		log.Errorf("uniswap v3 pool %s not stored; %s", adr.String(), err.Error())
+	}
+	return true
+}
+
+// StoreUniswapV3Pool stores the given concentrated liquidity pool in the persistent storage.
+func (p *proxy) StoreUniswapV3Pool(pool *types.UniswapV3Pool) error {
+	return p.db.StoreUniswapV3Pool(pool)
+}
+
+// UniswapV3Pool provides details of a concentrated liquidity pool by its address.
+func (p *proxy) UniswapV3Pool(adr *common.Address) (*types.UniswapV3Pool, error) {
+	return p.db.UniswapV3Pool(adr)
+}
+
+// UniswapV3Pools provides the list of all known concentrated liquidity pools.
+func (p *proxy) UniswapV3Pools() ([]*types.UniswapV3Pool, error) {
+	return p.db.UniswapV3Pools()
+}
+
+// UniswapV3PoolState provides the current price, tick and liquidity of a concentrated liquidity pool.
+func (p *proxy) UniswapV3PoolState(adr *common.Address) (*types.UniswapV3PoolState, error) {
+	return p.rpc.UniswapV3PoolState(adr)
+}
+
+// UniswapV3PoolTicks provides liquidity details of initialized ticks of a concentrated liquidity pool.
+// The list of candidate ticks is collected from the liquidity provisions known to the persistent storage.
+func (p *proxy) UniswapV3PoolTicks(adr *common.Address) ([]*types.UniswapV3Tick, error) {
+	ticks, err := p.db.UniswapV3PoolTicks(adr)
+	if err != nil {
+		return nil, err
+	}
+	return p.rpc.UniswapV3Ticks(adr, ticks)
+}
+
+// AddUniswapV3Action stores a new concentrated liquidity pool action in the persistent storage.
+func (p *proxy) AddUniswapV3Action(act *types.UniswapV3Action) error {
+	return p.db.AddUniswapV3Action(act)
+}
+
+// UniswapV3Actions provides list of concentrated liquidity pool actions for the given pool, owner and type.
+func (p *proxy) UniswapV3Actions(pool *common.Address, owner *common.Address, actionType *int32, cursor *string, count int32) (*types.UniswapV3ActionList, error) {
+	// prep the filter
+	fi := bson.D{}
+
+	// add pool address to the filter
+	if pool != nil {
+		fi = append(fi, bson.E{Key: types.FiUniswapV3ActionPool, Value: pool.String()})
+	}
+
+	// add position owner to the filter
+	if owner != nil {
+		fi = append(fi, bson.E{Key: types.FiUniswapV3ActionOwner, Value: owner.String()})
+	}
+
+	// add action type to the filter
+	if actionType != nil {
+		fi = append(fi, bson.E{Key: types.FiUniswapV3ActionType, Value: *actionType})
+	}
+	return p.db.UniswapV3Actions(cursor, count, &fi)
+}
+
+// UniswapV3TimePrices returns grouped OHLC prices for specified pool, time and resolution.
+func (p *proxy) UniswapV3TimePrices(pool *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
+	return p.db.UniswapV3TimePrices(pool, resolution, fromTime, toTime, direction)
+}
+
+// UpdateUniswapV3Position refreshes the stored liquidity position from the position manager
+// using the state at the given block. Positions already updated by a newer block are kept.
+func (p *proxy) UpdateUniswapV3Position(tokenId *big.Int, blk *types.Block) error {
+	known, err := p.db.UniswapV3Position((*hexutil.Big)(tokenId))
+	if err != nil {
+		return err
+	}
+	if known != nil && known.Updated > blk.TimeStamp {
+		return nil
+	}
+
+	pos, err := p.rpc.UniswapV3Position(tokenId, new(big.Int).SetUint64(uint64(blk.Number)))
+	if err != nil {
+		return err
+	}
+	pos.Updated = blk.TimeStamp
+	return p.db.StoreUniswapV3Position(pos)
+}
+
+// UniswapV3Position provides a liquidity position by its NFT token id.
+func (p *proxy) UniswapV3Position(tokenId *hexutil.Big) (*types.UniswapV3Position, error) {
+	return p.db.UniswapV3Position(tokenId)
+}
+
+// UniswapV3Positions provides liquidity positions of the given owner and/or pool.
+func (p *proxy) UniswapV3Positions(owner *common.Address, pool *common.Address) ([]*types.UniswapV3Position, error) {
+	return p.db.UniswapV3Positions(owner, pool)
+}
@@ -0,0 +1,22 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import "fantom-api-graphql/internal/types"
+
+// StoreValidatorAlert stores, or updates the given validator alert in the persistent storage.
+func (p *proxy) StoreValidatorAlert(va *types.ValidatorAlert) error {
+	return p.db.StoreValidatorAlert(va)
+}
+
+// ValidatorAlerts provides the latest validator alerts, optionally limited to the given validator
+// and to alerts not resolved yet.
+func (p *proxy) ValidatorAlerts(valID *uint64, activeOnly bool, count int64) ([]*types.ValidatorAlert, error) {
+	return p.db.ValidatorAlerts(valID, activeOnly, count)
+}
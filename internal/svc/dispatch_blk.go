@@ -2,9 +2,12 @@
 package svc
 
 import (
+	"context"
+	"fantom-api-graphql/internal/tracing"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
 	"time"
 )
 
@@ -14,6 +17,7 @@ const trxBufferCapacity = 50000
 // eventTrx represents a packed transaction event
 // sent between block dispatcher and transaction dispatcher
 type eventTrx struct {
+	ctx context.Context
 	blk *types.Block
 	trx *types.Transaction
 }
@@ -82,6 +86,7 @@ func (bld *blockDispatcher) execute() {
 			if !bld.process(blk) {
 				continue
 			}
+			blocksProcessed.Inc()
 
 			// broadcast the block event
 			select {
@@ -111,8 +116,14 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 		return true
 	}
 
+	ctx, span := tracing.Start(context.Background(), "svc.block",
+		attribute.Int64("block.number", int64(blk.Number)),
+		attribute.Int("block.transactions", len(blk.Txs)),
+	)
+	defer span.End()
+
 	log.Debugf("%d transaction found in block #%d", len(blk.Txs), blk.Number)
-	if !bld.processTxs(blk) {
+	if !bld.processTxs(ctx, blk) {
 		return false
 	}
 
@@ -122,7 +133,7 @@ func (bld *blockDispatcher) process(blk *types.Block) bool {
 
 // processTxs loops all the transactions in the block and pushes them
 // into the transaction dispatcher queue observing the term signal.
-func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
+func (bld *blockDispatcher) processTxs(ctx context.Context, blk *types.Block) bool {
 	for i, th := range blk.Txs {
 		log.Debugf("loading trx #%d from block #%d", i, blk.Number)
 		trx := bld.load(blk, th)
@@ -130,6 +141,7 @@ func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
 			// queue and broadcast the transaction
 			select {
 			case bld.outTransaction <- &eventTrx{
+				ctx: ctx,
 				blk: blk,
 				trx: trx,
 			}:
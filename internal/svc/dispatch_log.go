@@ -2,16 +2,25 @@
 package svc
 
 import (
+	"context"
+	"fantom-api-graphql/internal/tracing"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
 )
 
 // logDispatcher implements dispatcher of new log events in the blockchain.
 type logDispatcher struct {
 	service
-	inLog       chan *types.LogRecord
-	knownTopics map[common.Hash]func(*types.LogRecord)
+	inLog        chan *types.LogRecord
+	knownTopics  map[common.Hash]func(*types.LogRecord)
+	handlerNames map[common.Hash]string
 }
 
 // name returns the name of the service used by orchestrator.
@@ -95,6 +104,32 @@ func (lgd *logDispatcher) init() {
 		/* SFC3::UnlockedStake(address indexed delegator, uint256 indexed validatorID, uint256 amount, uint256 penalty) */
 		common.HexToHash("0xef6c0c14fe9aa51af36acd791464dec3badbde668b63189b47bfa4e25be9b2b9"): handleUnlockedStake,
 
+		/* -------------------- Governance contract related event hooks below this line -------------------- */
+
+		/* Governance::ProposalCreated(uint256 proposalID) */
+		common.HexToHash("0xc2c021f5d73c63c481d336fbbafec58f694fc45095f00b02d2deb8cca59afe07"): handleGovernanceProposalCreated,
+
+		/* Governance::ProposalResolved(uint256 proposalID) */
+		common.HexToHash("0x663674d96fd5c2a954bf75ad2e6795f9c9701eb687a7a8f3297c7a299467c941"): handleGovernanceProposalResolved,
+
+		/* Governance::ProposalRejected(uint256 proposalID) */
+		common.HexToHash("0xd92fba445edb3153b571e6df782d7a66fd0ce668519273670820ee3a86da0ef4"): handleGovernanceProposalRejected,
+
+		/* Governance::ProposalCanceled(uint256 proposalID) */
+		common.HexToHash("0x789cf55be980739dad1d0699b93b58e806b51c9d96619bfa8fe0a28abaa7b30c"): handleGovernanceProposalCanceled,
+
+		/* Governance::Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight) */
+		common.HexToHash("0x6e5f0f6e0ce2bdcdb0a82952fc6eb90c4c22f0b6228e4619b5dc2118e1166a12"): handleGovernanceVoted,
+
+		/* Governance::VoteCanceled(address voter, address delegatedTo, uint256 proposalID) */
+		common.HexToHash("0x666685d133047310e2a2e8c4f6794b6dccb4e9ad9c6903ac753fb10d8918b649"): handleGovernanceVoteCanceled,
+
+		/* Governance::VoteWeightOverridden(address voter, uint256 diff) */
+		common.HexToHash("0x68fe85c5f71a2900fddf574935a27d4f1cb28af34d4fa2742b202684b45d3d14"): handleGovernanceVoteWeightOverridden,
+
+		/* Governance::VoteWeightUnOverridden(address voter, uint256 diff) */
+		common.HexToHash("0x11d7313426c62d856bd4ea20cdef8b93af4b40d2ea5b8f6f962fc705dbdcdbef"): handleGovernanceVoteWeightUnOverridden,
+
 		/* ---------------- ERC20 and ERC721 contracts related event hooks below this line ---------------- */
 
 		/* ERC20::Approval(address indexed owner, address indexed spender, uint256 value) */
@@ -123,6 +158,32 @@ func (lgd *logDispatcher) init() {
 		/* UniswapPair::Sync(uint112 reserve0, uint112 reserve1) */
 		common.HexToHash("0x1c411e9a96e071241c2f21f7726b17ae89e3cab4c78be50e062b03a9fffbbad1"): handleUniswapSync,
 
+		/* ------------------ Uniswap V3 contracts related event hooks below this line -------------------- */
+
+		/* UniswapV3Factory::PoolCreated(address indexed token0, address indexed token1, uint24 indexed fee, int24 tickSpacing, address pool) */
+		common.HexToHash("0x783cca1c0412dd0d695e784568c96da2e9c22ff989357a2e8b1d9b2b4e6b7118"): handleUniswapV3PoolCreated,
+
+		/* UniswapV3Pool::Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick) */
+		common.HexToHash("0xc42079f94a6350d7e6235f29174924f928cc2ac818eb64fed8004e115fbcca67"): handleUniswapV3Swap,
+
+		/* UniswapV3Pool::Mint(address sender, address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1) */
+		common.HexToHash("0x7a53080ba414158be7ec69b987b5fb7d07dee101fe85488f0853ae16239d0bde"): handleUniswapV3Mint,
+
+		/* UniswapV3Pool::Burn(address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1) */
+		common.HexToHash("0x0c396cd989a39f4459b5fa1aed6a9a8dcdbc45908acfd67e028cd568da98982c"): handleUniswapV3Burn,
+
+		/* UniswapV3Pool::Collect(address indexed owner, address recipient, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount0, uint128 amount1) */
+		common.HexToHash("0x70935338e69775456a85ddef226c395fb668b63fa0115f5f20610b388e6ca9c0"): handleUniswapV3Collect,
+
+		/* UniswapV3PositionManager::IncreaseLiquidity(uint256 indexed tokenId, uint128 liquidity, uint256 amount0, uint256 amount1) */
+		common.HexToHash("0x3067048beee31b25b2f1681f88dac838c8bba36af25bfb2b7cf7473a5847e35f"): handleUniswapV3PositionChange,
+
+		/* UniswapV3PositionManager::DecreaseLiquidity(uint256 indexed tokenId, uint128 liquidity, uint256 amount0, uint256 amount1) */
+		common.HexToHash("0x26f6a048ee9138f2c0ce266f322cb99228e8d619ae2bff30c67f8dcf9d2377b4"): handleUniswapV3PositionChange,
+
+		/* UniswapV3PositionManager::Collect(uint256 indexed tokenId, address recipient, uint256 amount0, uint256 amount1) */
+		common.HexToHash("0x40d0efd1a53d60ecbf40971b9daf7dc90178c3aadc7aab1765632738fa8b8f01"): handleUniswapV3PositionChange,
+
 		/* ---------------------- fMint contract related event hooks below this line ----------------------- */
 
 		/* FantomMintCollateral::Deposited(address indexed token, address indexed user, uint256 amount) */
@@ -139,6 +200,42 @@ func (lgd *logDispatcher) init() {
 
 		/* FantomMintRewardManager::RewardPaid(address indexed user, uint256 reward) */
 		common.HexToHash("0xe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e0486"): handleFMintReward,
+
+		/* ---------------------- fLend contract related event hooks below this line ----------------------- */
+
+		/* LendingPool::Deposit(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint16 indexed referral) */
+		common.HexToHash("0xde6857219544bb5b7746f48ed30be6386fefc61b2f864cacf559893bf50fd951"): handleFLendDeposit,
+
+		/* LendingPool::Withdraw(address indexed reserve, address indexed user, address indexed to, uint256 amount) */
+		common.HexToHash("0x3115d1449a7b732c986cba18244e897a450f61e1bb8d589cd2e69e6c8924f9f7"): handleFLendWithdraw,
+
+		/* LendingPool::Borrow(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint256 borrowRateMode, uint256 borrowRate, uint16 indexed referral) */
+		common.HexToHash("0xc6a898309e823ee50bac64e45ca8adba6690e99e7841c45d754e2a38e9019d9b"): handleFLendBorrow,
+
+		/* LendingPool::Repay(address indexed reserve, address indexed user, address indexed repayer, uint256 amount) */
+		common.HexToHash("0x4cdde6e09bb755c9a5589ebaec640bbfedff1362d4b255ebf8339782b9942faa"): handleFLendRepay,
+
+		/* LendingPool::Swap(address indexed reserve, address indexed user, uint256 rateMode) */
+		common.HexToHash("0xea368a40e9570069bb8e6511d668293ad2e1f03b0d982431fd223de9f3b70ca6"): handleFLendSwap,
+
+		/* LendingPool::RebalanceStableBorrowRate(address indexed reserve, address indexed user) */
+		common.HexToHash("0x9f439ae0c81e41a04d3fdfe07aed54e6a179fb0db15be7702eb66fa8ef6f5300"): handleFLendRebalanceStableBorrowRate,
+
+		/* LendingPool::FlashLoan(address indexed target, address indexed initiator, address indexed asset, uint256 amount, uint256 premium, uint16 referralCode) */
+		common.HexToHash("0x631042c832b07452973831137f2d73e395028b44b250dedc5abb0ee766e168ac"): handleFLendFlashLoan,
+
+		/* LendingPool::LiquidationCall(address indexed collateralAsset, address indexed debtAsset, address indexed user, uint256 debtToCover, uint256 liquidatedCollateralAmount, address liquidator, bool receiveAToken) */
+		common.HexToHash("0xe413a321e8681d831f4dbccbca790d2952b56f977908e45be37335533e005286"): handleFLendLiquidationCall,
+
+		/* AccessControlledAggregator::AnswerUpdated(int256 indexed current, uint256 indexed roundId, uint256 updatedAt) */
+		common.HexToHash("0x0559884fd3a460db3073b7fc896cc77986f16e378210ded43186175bf646fc5f"): handleOracleAnswerUpdated,
+	}
+
+	// names of the handlers are used to trace the processing
+	lgd.handlerNames = make(map[common.Hash]string, len(lgd.knownTopics))
+	for topic, handler := range lgd.knownTopics {
+		name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+		lgd.handlerNames[topic] = name[strings.LastIndexByte(name, '.')+1:]
 	}
 }
 
@@ -179,7 +276,7 @@ func (lgd *logDispatcher) execute() {
 				handler, ok := lgd.knownTopics[lr.Topics[0]]
 				if ok && lr.Block != nil && lr.Trx != nil {
 					log.Debugf("known topic %s found, processing", lr.Topics[0].String())
-					handler(lr)
+					lgd.handle(lr, handler)
 				}
 			}
 
@@ -188,3 +285,29 @@ func (lgd *logDispatcher) execute() {
 		}
 	}
 }
+
+// handle processes the log record by the given handler tracing the processing
+// as a part of the block processing, and collecting the handler metrics.
+func (lgd *logDispatcher) handle(lr *types.LogRecord, handler func(*types.LogRecord)) {
+	ctx := lr.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name := lgd.handlerNames[lr.Topics[0]]
+	_, span := tracing.StartChild(ctx, "svc.logDispatcher."+name,
+		attribute.Int64("block.number", int64(lr.Block.Number)),
+		attribute.String("trx.hash", lr.Trx.Hash.String()),
+		attribute.String("log.address", lr.Address.String()),
+	)
+	defer span.End()
+
+	start := time.Now()
+	handler(lr)
+	logHandlerDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+
+	if lr.Failed {
+		logHandlerErrors.WithLabelValues(name).Inc()
+		span.SetStatus(codes.Error, "log record processing failed")
+	}
+}
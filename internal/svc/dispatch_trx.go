@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
@@ -37,6 +38,7 @@ type trxDispatcher struct {
 	onTransaction  chan *types.Transaction
 	bot            *time.Ticker
 	blkObserver    *atomic.Uint64
+	blkLastSeen    *atomic.Pointer[types.Block]
 	inTransaction  chan *eventTrx
 	outTransaction chan *eventTrx
 	outAccount     chan *eventAcc
@@ -52,6 +54,7 @@ func (trd *trxDispatcher) name() string {
 func (trd *trxDispatcher) init() {
 	trd.sigStop = make(chan struct{})
 	trd.blkObserver = atomic.NewUint64(1)
+	trd.blkLastSeen = atomic.NewPointer[types.Block](nil)
 	trd.outAccount = make(chan *eventAcc, trxAddressQueueCapacity)
 	trd.outLog = make(chan *types.LogRecord, trxLogQueueCapacity)
 	trd.outTransaction = make(chan *eventTrx, trxLogQueueCapacity)
@@ -129,6 +132,13 @@ func (trd *trxDispatcher) updateLastSeenBlock() {
 	if err != nil {
 		log.Errorf("could not update last seen block; %s", err.Error())
 	}
+
+	// the epoch scanner follows the epoch of the last seen block
+	if blk := trd.blkLastSeen.Load(); blk != nil {
+		if err := repo.UpdateLastKnownBlockEpoch(blk.Epoch, blk.TimeStamp); err != nil {
+			log.Errorf("could not update last seen block epoch; %s", err.Error())
+		}
+	}
 }
 
 // process the given transaction event into the required targets.
@@ -148,7 +158,7 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 
 	// process transaction logs; exit if terminated
 	for _, lg := range evt.trx.Logs {
-		if !trd.pushLog(lg, evt.blk, evt.trx, &wg) {
+		if !trd.pushLog(evt.ctx, lg, evt.blk, evt.trx, &wg) {
 			return
 		}
 	}
@@ -176,6 +186,7 @@ func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup) {
 	repo.IncTrxCountEstimate(1)
 	repo.CacheTransaction(evt.trx)
 	trd.blkObserver.Store(uint64(evt.blk.Number))
+	trd.blkLastSeen.Store(evt.blk)
 }
 
 // pushAccounts pushes given transaction accounts on both sides observing terminate signal on process.
@@ -219,11 +230,12 @@ func (trd *trxDispatcher) pushAccount(at string, adr *common.Address, blk *types
 }
 
 // pushLog pushes specified log record into a processing queue observing terminate signal.
-func (trd *trxDispatcher) pushLog(lg retypes.Log, blk *types.Block, trx *types.Transaction, wg *sync.WaitGroup) bool {
+func (trd *trxDispatcher) pushLog(ctx context.Context, lg retypes.Log, blk *types.Block, trx *types.Transaction, wg *sync.WaitGroup) bool {
 	wg.Add(1)
 	select {
 	case trd.outLog <- &types.LogRecord{
 		WatchDog: wg,
+		Ctx:      ctx,
 		Block:    blk,
 		Trx:      trx,
 		Log:      lg,
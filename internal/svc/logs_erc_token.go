@@ -46,6 +46,11 @@ func handleErcTransaction(lr *types.LogRecord, trxType int32) {
 		amount := big.NewInt(1)
 		tokenId := new(big.Int).SetBytes(lr.Topics[3].Bytes())
 		storeTokenTransaction(lr, types.AccountTypeERC721Contract, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+
+		// liquidity positions are ERC721 tokens, follow the owner change
+		if trxType == types.TokenTrxTypeTransfer {
+			handleUniswapV3PositionTransfer(lr, to, tokenId)
+		}
 		return
 	}
 
@@ -74,10 +79,10 @@ func handleErc1155TransferBatch(lr *types.LogRecord) {
 		to := common.BytesToAddress(lr.Topics[3].Bytes())
 		ids, values, err := rpc.Erc1155ParseTransferBatchData(lr.Data)
 		if err != nil {
-			log.Errorf("failed to parse ERC1155 TransferBatch data - trx %s; %s", lr.TxHash.String(), err.Error())
+			handlerErrorf(lr, "failed to parse ERC1155 TransferBatch data - trx %s; %s", lr.TxHash.String(), err.Error())
 		}
 		if len(ids) != len(values) {
-			log.Errorf("ERC1155 TransferBatch ids and values length differs - trx %s", lr.TxHash.String())
+			handlerErrorf(lr, "ERC1155 TransferBatch ids and values length differs - trx %s", lr.TxHash.String())
 		}
 		for i := range ids {
 			log.Infof("ERC1155 storing TransferBatch - trx %s - len %d", lr.TxHash.String(), len(ids))
@@ -115,6 +120,6 @@ func storeTokenTransaction(lr *types.LogRecord, tokenType string, eventType int3
 		BlockNumber:  lr.BlockNumber,
 		Seq:          seq, // sequence of erc transactions emitted by one log event - non-zero only for batch transfer events
 	}); err != nil {
-		log.Errorf("can not store token %s trx for call %s; %s", tokenType, lr.TxHash.String(), err.Error())
+		handlerErrorf(lr, "can not store token %s trx for call %s; %s", tokenType, lr.TxHash.String(), err.Error())
 	}
 }
@@ -0,0 +1,250 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// handleFLendDeposit processes a new deposit into the fLend lending pool.
+// LendingPool::Deposit(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint16 indexed referral)
+func handleFLendDeposit(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (address + uint256 = 64 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 64 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 64 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionDeposit)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.ReferralCode = int32(new(big.Int).SetBytes(lr.Topics[3].Bytes()).Int64())
+	act.Counterparty = common.BytesToAddress(lr.Data[:32])
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[32:]))
+	storeFLendAction(lr, act)
+}
+
+// handleFLendWithdraw processes a withdrawal from the fLend lending pool.
+// LendingPool::Withdraw(address indexed reserve, address indexed user, address indexed to, uint256 amount)
+func handleFLendWithdraw(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (uint256 = 32 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 32 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 32 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionWithdraw)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.Counterparty = common.BytesToAddress(lr.Topics[3].Bytes())
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data))
+	storeFLendAction(lr, act)
+}
+
+// handleFLendBorrow processes a new borrow from the fLend lending pool.
+// LendingPool::Borrow(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint256 borrowRateMode, uint256 borrowRate, uint16 indexed referral)
+func handleFLendBorrow(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (address + 3 x uint256 = 128 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 128 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 128 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionBorrow)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.ReferralCode = int32(new(big.Int).SetBytes(lr.Topics[3].Bytes()).Int64())
+	act.Counterparty = common.BytesToAddress(lr.Data[:32])
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.InterestRateMode = int32(new(big.Int).SetBytes(lr.Data[64:96]).Int64())
+	act.BorrowRate = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[96:]))
+	storeFLendAction(lr, act)
+}
+
+// handleFLendRepay processes a debt repay on the fLend lending pool.
+// LendingPool::Repay(address indexed reserve, address indexed user, address indexed repayer, uint256 amount)
+func handleFLendRepay(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (uint256 = 32 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 32 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 32 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionRepay)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.Counterparty = common.BytesToAddress(lr.Topics[3].Bytes())
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data))
+	storeFLendAction(lr, act)
+}
+
+// handleFLendSwap processes a borrow rate mode swap on the fLend lending pool.
+// LendingPool::Swap(address indexed reserve, address indexed user, uint256 rateMode)
+func handleFLendSwap(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (uint256 = 32 bytes), (1 x subject topic + 2 x indexed = 3 topics)
+	if len(lr.Data) != 32 || len(lr.Topics) != 3 {
+		handlerErrorf(lr, "%s invalid data length; expected 32 bytes, %d bytes given; expected 3 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionSwap)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.InterestRateMode = int32(new(big.Int).SetBytes(lr.Data).Int64())
+	storeFLendAction(lr, act)
+}
+
+// handleFLendRebalanceStableBorrowRate processes a stable borrow rate rebalance on the fLend lending pool.
+// LendingPool::RebalanceStableBorrowRate(address indexed reserve, address indexed user)
+func handleFLendRebalanceStableBorrowRate(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (no data), (1 x subject topic + 2 x indexed = 3 topics)
+	if len(lr.Data) != 0 || len(lr.Topics) != 3 {
+		handlerErrorf(lr, "%s invalid data length; expected 0 bytes, %d bytes given; expected 3 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionRebalanceStableBorrowRate)
+	act.Asset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	storeFLendAction(lr, act)
+}
+
+// handleFLendFlashLoan processes a flash loan on the fLend lending pool.
+// LendingPool::FlashLoan(address indexed target, address indexed initiator, address indexed asset, uint256 amount, uint256 premium, uint16 referralCode)
+func handleFLendFlashLoan(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (3 x 32 bytes = 96 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 96 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 96 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionFlashLoan)
+	act.Counterparty = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.Asset = common.BytesToAddress(lr.Topics[3].Bytes())
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[:32]))
+	act.Premium = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.ReferralCode = int32(new(big.Int).SetBytes(lr.Data[64:]).Int64())
+	storeFLendAction(lr, act)
+}
+
+// handleFLendLiquidationCall processes a position liquidation on the fLend lending pool.
+// LendingPool::LiquidationCall(address indexed collateralAsset, address indexed debtAsset, address indexed user, uint256 debtToCover, uint256 liquidatedCollateralAmount, address liquidator, bool receiveAToken)
+func handleFLendLiquidationCall(lr *types.LogRecord) {
+	if lr.Address != repo.FLendLendingPoolAddress() {
+		return
+	}
+
+	// sanity check for data (4 x 32 bytes = 128 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 128 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 128 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := fLendAction(lr, types.FLendActionLiquidationCall)
+	act.CollateralAsset = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.Asset = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.User = common.BytesToAddress(lr.Topics[3].Bytes())
+	act.Amount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[:32]))
+	act.CollateralAmount = (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.Counterparty = common.BytesToAddress(lr.Data[64:96])
+	act.ReceiveAToken = new(big.Int).SetBytes(lr.Data[96:]).Sign() != 0
+	storeFLendAction(lr, act)
+}
+
+// fLendAction prepares a lending pool action base from the log record.
+func fLendAction(lr *types.LogRecord, actionType int32) *types.FLendAction {
+	return &types.FLendAction{
+		OrdIndex:    uniswapOrdinalIndex(lr),
+		Type:        actionType,
+		TrxHash:     lr.TxHash,
+		LogIndex:    hexutil.Uint(lr.Index),
+		BlockNumber: lr.Block.Number,
+		TimeStamp:   lr.Block.TimeStamp,
+	}
+}
+
+// storeFLendAction stores the given lending pool action into the repository.
+func storeFLendAction(lr *types.LogRecord, act *types.FLendAction) {
+	if err := repo.AddFLendAction(act); err != nil {
+		handlerErrorf(lr, "%s could not store fLend event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+	}
+
+	// the action changes the health of the position of the user; the interest accrued
+	// on other positions is picked up by the periodic refresh of the monitor
+	notifyFLendUserChange(act.User)
+
+	// the liquidator receiving aTokens gets the collateral deposited
+	if act.Type == types.FLendActionLiquidationCall && act.ReceiveAToken {
+		notifyFLendUserChange(act.Counterparty)
+	}
+}
+
+// handleOracleAnswerUpdated processes a price update of a price feed aggregator.
+// The fLend monitor ignores aggregators not used by the fLend price oracle.
+// AccessControlledAggregator::AnswerUpdated(int256 indexed current, uint256 indexed roundId, uint256 updatedAt)
+func handleOracleAnswerUpdated(lr *types.LogRecord) {
+	notifyFLendPriceChange(lr.Address)
+}
@@ -13,7 +13,7 @@ import (
 func handleFMintDeposit(lr *types.LogRecord) {
 	// sanity check for data (1 uint256 = 32 bytes); call + token + user = 3 topics
 	if len(lr.Data) != 32 || len(lr.Topics) != 3 {
-		log.Criticalf("%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -32,7 +32,7 @@ func handleFMintDeposit(lr *types.LogRecord) {
 func handleFMintWithdraw(lr *types.LogRecord) {
 	// sanity check for data (1 uint256 = 32 bytes); call + token + user = 3 topics
 	if len(lr.Data) != 32 || len(lr.Topics) != 3 {
-		log.Criticalf("%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -51,7 +51,7 @@ func handleFMintWithdraw(lr *types.LogRecord) {
 func handleFMintMint(lr *types.LogRecord) {
 	// sanity check for data (2 uint256 = 64 bytes); call + token + user = 3 topics
 	if len(lr.Data) != 64 || len(lr.Topics) != 3 {
-		log.Criticalf("%s invalid event; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s invalid event; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -70,7 +70,7 @@ func handleFMintMint(lr *types.LogRecord) {
 func handleFMintRepay(lr *types.LogRecord) {
 	// sanity check for data (1 uint256 = 32 bytes); call + token + user = 3 topics
 	if len(lr.Data) != 32 || len(lr.Topics) != 3 {
-		log.Criticalf("%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s invalid event; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -98,7 +98,7 @@ func handleNewFMintRecord(lr *types.LogRecord, tp int32, user common.Address, to
 		TimeStamp:    lr.Block.TimeStamp,
 	})
 	if err != nil {
-		log.Errorf("can not register fMint trx %s; %s", lr.TxHash.String(), err.Error())
+		handlerErrorf(lr, "can not register fMint trx %s; %s", lr.TxHash.String(), err.Error())
 	}
 }
 
@@ -107,8 +107,29 @@ func handleNewFMintRecord(lr *types.LogRecord, tp int32, user common.Address, to
 func handleFMintReward(lr *types.LogRecord) {
 	// sanity check for data (1 uint256 = 32 bytes); call + user = 2 topics
 	if len(lr.Data) != 32 || len(lr.Topics) != 2 {
-		log.Criticalf("%s invalid event; expected 32 bytes, %d bytes given; expected 2 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s invalid event; expected 32 bytes, %d bytes given; expected 2 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
+	// the same event signature is used by other reward contracts,
+	// we are interested in the fMint reward distribution only
+	if !repo.IsFMintRewardsDistribution(&lr.Address) {
+		return
+	}
+
+	// get the token rewards are paid in
+	token, err := repo.FMintRewardsToken()
+	if err != nil {
+		handlerErrorf(lr, "can not register fMint reward %s; %s", lr.TxHash.String(), err.Error())
+		return
+	}
+
+	handleNewFMintRecord(
+		lr,
+		types.FMintTrxTypeReward,
+		common.BytesToAddress(lr.Topics[1].Bytes()),
+		token,
+		new(big.Int).SetBytes(lr.Data),
+		new(big.Int),
+	)
 }
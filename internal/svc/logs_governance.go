@@ -0,0 +1,188 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+var (
+	// governanceVotedTopic is the topic of the Governance Voted event.
+	governanceVotedTopic = common.HexToHash("0x6e5f0f6e0ce2bdcdb0a82952fc6eb90c4c22f0b6228e4619b5dc2118e1166a12")
+
+	// governanceVoteCanceledTopic is the topic of the Governance VoteCanceled event.
+	governanceVoteCanceledTopic = common.HexToHash("0x666685d133047310e2a2e8c4f6794b6dccb4e9ad9c6903ac753fb10d8918b649")
+)
+
+// isGovernanceLog checks if the log record has been emitted by a known Governance contract.
+// The Governance events do not have indexed params, so the topic alone is not specific enough.
+func isGovernanceLog(lr *types.LogRecord) bool {
+	_, err := repo.GovernanceContractBy(lr.Address)
+	return err == nil
+}
+
+// handleGovernanceVoted handles a new vote placed on a Governance Proposal.
+// event Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight)
+func handleGovernanceVoted(lr *types.LogRecord) {
+	if !isGovernanceLog(lr) {
+		return
+	}
+
+	gv, err := rpc.GovernanceParseVotedData(lr.Address, lr.Data)
+	if err != nil {
+		handlerCriticalf(lr, "%s is not event Voted; %s", lr.TxHash.String(), err.Error())
+		return
+	}
+
+	err = repo.StoreGovernanceVote(&types.GovernanceVoteRecord{
+		GovernanceVote: *gv,
+		Trx:            lr.TxHash,
+		TimeStamp:      time.Unix(int64(lr.Block.TimeStamp), 0),
+		Ordinal:        types.GovernanceOrdinalIndex(lr.BlockNumber, lr.Index),
+	})
+	if err != nil {
+		handlerErrorf(lr, "failed to store governance vote; %s", err.Error())
+	}
+}
+
+// handleGovernanceVoteCanceled handles a vote being canceled on a Governance Proposal.
+// event VoteCanceled(address voter, address delegatedTo, uint256 proposalID)
+func handleGovernanceVoteCanceled(lr *types.LogRecord) {
+	// sanity check for data (3x 32 bytes)
+	if len(lr.Data) != 96 {
+		handlerCriticalf(lr, "%s is not event VoteCanceled; expected 96 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+	if !isGovernanceLog(lr) {
+		return
+	}
+
+	err := repo.CancelGovernanceVote(
+		lr.Address,
+		(*hexutil.Big)(new(big.Int).SetBytes(lr.Data[64:96])),
+		common.BytesToAddress(lr.Data[:32]),
+		common.BytesToAddress(lr.Data[32:64]),
+		time.Unix(int64(lr.Block.TimeStamp), 0),
+		types.GovernanceOrdinalIndex(lr.BlockNumber, lr.Index),
+	)
+	if err != nil {
+		handlerErrorf(lr, "failed to cancel governance vote; %s", err.Error())
+	}
+}
+
+// handleGovernanceVoteWeightOverridden handles the vote weight of a validator being reduced
+// by a delegator voting on its own.
+// event VoteWeightOverridden(address voter, uint256 diff)
+func handleGovernanceVoteWeightOverridden(lr *types.LogRecord) {
+	handleGovernanceVoteWeightChange(lr)
+}
+
+// handleGovernanceVoteWeightUnOverridden handles the vote weight of a validator being restored
+// by a delegator canceling its own vote.
+// event VoteWeightUnOverridden(address voter, uint256 diff)
+func handleGovernanceVoteWeightUnOverridden(lr *types.LogRecord) {
+	handleGovernanceVoteWeightChange(lr)
+}
+
+// handleGovernanceVoteWeightChange updates the stored weight of the vote of a validator
+// changed by a delegator. The events do not identify the proposal, it's taken from the vote,
+// or the vote cancel of the delegator emitted by the same transaction. The weight is loaded
+// from the contract, so processing the event again does not change it twice.
+func handleGovernanceVoteWeightChange(lr *types.LogRecord) {
+	// sanity check for data (2x 32 bytes)
+	if len(lr.Data) != 64 {
+		handlerCriticalf(lr, "%s is not governance vote weight event; expected 64 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+	if !isGovernanceLog(lr) {
+		return
+	}
+
+	voter := common.BytesToAddress(lr.Data[:32])
+	propId := governanceDelegatorVoteProposal(lr, voter)
+	if propId == nil {
+		handlerErrorf(lr, "%s governance vote weight of %s changed without delegator vote", lr.TxHash.String(), voter.String())
+		return
+	}
+
+	err := repo.UpdateGovernanceVoteWeight(lr.Address, propId, voter, lr.BlockNumber, types.GovernanceOrdinalIndex(lr.BlockNumber, lr.Index))
+	if err != nil {
+		handlerErrorf(lr, "failed to update governance vote weight; %s", err.Error())
+	}
+}
+
+// governanceDelegatorVoteProposal finds the proposal of the vote, or the vote cancel, emitted
+// by the transaction of the given log record by a delegator of the given validator.
+func governanceDelegatorVoteProposal(lr *types.LogRecord, validator common.Address) *hexutil.Big {
+	if lr.Trx == nil {
+		return nil
+	}
+
+	for _, l := range lr.Trx.Logs {
+		// both events start with (address voter, address delegatedTo, uint256 proposalID)
+		if l.Address != lr.Address || len(l.Topics) == 0 || len(l.Data) < 96 {
+			continue
+		}
+		if l.Topics[0] != governanceVotedTopic && l.Topics[0] != governanceVoteCanceledTopic {
+			continue
+		}
+		if common.BytesToAddress(l.Data[32:64]) == validator && common.BytesToAddress(l.Data[:32]) != validator {
+			return (*hexutil.Big)(new(big.Int).SetBytes(l.Data[64:96]))
+		}
+	}
+	return nil
+}
+
+// handleGovernanceProposalCreated handles a new Governance Proposal.
+// event ProposalCreated(uint256 proposalID)
+func handleGovernanceProposalCreated(lr *types.LogRecord) {
+	handleGovernanceProposalEvent(lr, types.GovernanceProposalCreated)
+}
+
+// handleGovernanceProposalResolved handles a Governance Proposal being resolved.
+// event ProposalResolved(uint256 proposalID)
+func handleGovernanceProposalResolved(lr *types.LogRecord) {
+	handleGovernanceProposalEvent(lr, types.GovernanceProposalResolved)
+}
+
+// handleGovernanceProposalRejected handles a Governance Proposal being rejected.
+// event ProposalRejected(uint256 proposalID)
+func handleGovernanceProposalRejected(lr *types.LogRecord) {
+	handleGovernanceProposalEvent(lr, types.GovernanceProposalRejected)
+}
+
+// handleGovernanceProposalCanceled handles a Governance Proposal being canceled.
+// event ProposalCanceled(uint256 proposalID)
+func handleGovernanceProposalCanceled(lr *types.LogRecord) {
+	handleGovernanceProposalEvent(lr, types.GovernanceProposalCanceled)
+}
+
+// handleGovernanceProposalEvent stores a life cycle event of a Governance Proposal.
+func handleGovernanceProposalEvent(lr *types.LogRecord, evt string) {
+	// sanity check for data (1x uint256 = 32 bytes)
+	if len(lr.Data) != 32 {
+		handlerCriticalf(lr, "%s is not governance proposal event; expected 32 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+	if !isGovernanceLog(lr) {
+		return
+	}
+
+	err := repo.StoreGovernanceProposalEvent(&types.GovernanceProposalEvent{
+		Id:           fmt.Sprintf("%s:%d", lr.TxHash.String(), lr.Index),
+		GovernanceId: lr.Address,
+		ProposalId:   hexutil.Big(*new(big.Int).SetBytes(lr.Data)),
+		Type:         evt,
+		Trx:          lr.TxHash,
+		TimeStamp:    time.Unix(int64(lr.Block.TimeStamp), 0),
+		Ordinal:      types.GovernanceOrdinalIndex(lr.BlockNumber, lr.Index),
+	})
+	if err != nil {
+		handlerErrorf(lr, "failed to store governance proposal event; %s", err.Error())
+	}
+}
@@ -14,14 +14,14 @@ func handleNewDelegation(lr *types.LogRecord, stakerID *big.Int, addr common.Add
 	// get the validator address
 	val, err := repo.ValidatorAddress((*hexutil.Big)(stakerID))
 	if err != nil {
-		log.Errorf("unknown validator #%d; %s", stakerID.Uint64(), err.Error())
+		handlerErrorf(lr, "unknown validator #%d; %s", stakerID.Uint64(), err.Error())
 		return
 	}
 
 	// pull the current value of the stake
 	staked, err := repo.DelegationAmountStaked(&addr, (*hexutil.Big)(stakerID))
 	if err != nil {
-		log.Errorf("delegation balance not available for %s to %d; %s", addr.String(), stakerID.Uint64(), err.Error())
+		handlerErrorf(lr, "delegation balance not available for %s to %d; %s", addr.String(), stakerID.Uint64(), err.Error())
 		return
 	}
 
@@ -38,7 +38,7 @@ func handleNewDelegation(lr *types.LogRecord, stakerID *big.Int, addr common.Add
 
 	// store the delegation
 	if err := repo.StoreDelegation(&dl); err != nil {
-		log.Errorf("failed to store delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to store delegation; %s", err.Error())
 	}
 }
 
@@ -67,7 +67,7 @@ func handleSfc1IncreasedDelegation(lr *types.LogRecord) {
 	if err := repo.UpdateDelegationBalance(&addr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, (*hexutil.Big)(valID), amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 }
 
@@ -78,7 +78,7 @@ func handleSfc1IncreasedDelegation(lr *types.LogRecord) {
 func handleSfcUndelegated(lr *types.LogRecord) {
 	// sanity check for data (1x uint256 = 32 bytes)
 	if len(lr.Data) != 32 {
-		log.Criticalf("%s lr invalid data length; expected 32 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 32 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -117,14 +117,14 @@ func handleNewWithdrawRequest(wrt string, adr common.Address, valID *big.Int, re
 
 	// store the request
 	if err := repo.StoreWithdrawRequest(&wr); err != nil {
-		log.Errorf("failed to store new withdraw request; %s", err.Error())
+		handlerErrorf(lr, "failed to store new withdraw request; %s", err.Error())
 	}
 
 	// check active amount on the delegation
 	if err := repo.UpdateDelegationBalance(&wr.Address, wr.StakerID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &wr.Address, wr.StakerID, amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 }
 
@@ -136,7 +136,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 		if err := repo.UpdateDelegationBalance(&adr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 			return makeAdHocDelegation(lr, &adr, (*hexutil.Big)(valID), amo)
 		}); err != nil {
-			log.Errorf("failed to update delegation; %s", err.Error())
+			handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 		}
 	}()
 
@@ -151,7 +151,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 	// try to get the request from database
 	req, err := repo.WithdrawRequest(&adr, (*hexutil.Big)(valID), (*hexutil.Big)(reqID))
 	if err != nil {
-		log.Errorf("can not load withdraw requests to finalise; %s", err.Error())
+		handlerErrorf(lr, "can not load withdraw requests to finalise; %s", err.Error())
 		return
 	}
 
@@ -162,7 +162,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 
 	// store the updated request
 	if err := repo.UpdateWithdrawRequest(req); err != nil {
-		log.Errorf("failed to store finalized withdraw request; %s", err.Error())
+		handlerErrorf(lr, "failed to store finalized withdraw request; %s", err.Error())
 	}
 }
 
@@ -172,7 +172,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 func handleSfc1DeactivatedDelegation(lr *types.LogRecord) {
 	// sanity check for data
 	if len(lr.Data) != 0 {
-		log.Criticalf("%s lr invalid data length; expected 0 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 0 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -193,7 +193,7 @@ func handleSfc1DeactivatedDelegation(lr *types.LogRecord) {
 func handleSfc1CreatedWithdrawRequest(lr *types.LogRecord) {
 	// sanity check for data (2x uint256 + 1x bool = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, %d bytes given", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -213,7 +213,7 @@ func handleSfc1CreatedWithdrawRequest(lr *types.LogRecord) {
 func handleSfc1PartialWithdrawByRequest(lr *types.LogRecord) {
 	// sanity check for data (2x uint256 + 1x bool = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -232,7 +232,7 @@ func handleSfc1PartialWithdrawByRequest(lr *types.LogRecord) {
 func handleSfc1UpdatedDelegation(lr *types.LogRecord) {
 	// sanity check for data (4x topic + 1x uint256 (value) = 32 bytes)
 	if len(lr.Topics) != 4 || len(lr.Data) != 32 {
-		log.Criticalf("%s not UpdatedDelegation; expected 32 bytes, %d bytes given; expected 4 topics, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s not UpdatedDelegation; expected 32 bytes, %d bytes given; expected 4 topics, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -242,7 +242,7 @@ func handleSfc1UpdatedDelegation(lr *types.LogRecord) {
 	if err := repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, valID, amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 
 	// this should have created a new delegation
@@ -259,7 +259,7 @@ func handleSfc1UpdatedDelegation(lr *types.LogRecord) {
 func handleSfcWithdrawn(lr *types.LogRecord) {
 	// sanity check for data (4x topic + 1x + 1 x uint256 = 32 bytes)
 	if len(lr.Topics) != 4 || len(lr.Data) != 32 {
-		log.Criticalf("%s is not event Withdrawn; expected 32 bytes, %d bytes given; expected 4 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s is not event Withdrawn; expected 32 bytes, %d bytes given; expected 4 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -278,7 +278,7 @@ func handleSfcWithdrawn(lr *types.LogRecord) {
 func handleSfc1WithdrawnDelegation(lr *types.LogRecord) {
 	// sanity check for data (3x topic + 1x + 1 x uint256 = 32 bytes)
 	if len(lr.Topics) != 3 || len(lr.Data) != 32 {
-		log.Criticalf("%s is not event Withdrawn; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s is not event Withdrawn; expected 32 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -318,7 +318,7 @@ func makeAdHocDelegation(lr *types.LogRecord, addr *common.Address, stakerID *he
 func handleLockedUpStake(lr *types.LogRecord) {
 	// sanity check for data (3x topic + 2x uint256 = 64 bytes)
 	if len(lr.Topics) != 3 || len(lr.Data) != 64 {
-		log.Criticalf("%s is not event LockedUpStake; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s is not event LockedUpStake; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -336,7 +336,7 @@ func handleLockedUpStake(lr *types.LogRecord) {
 	log.Noticef("delegation of %s to #%d locked until %s", lock.Delegator.String(), lock.ValidatorId, lock.LockedUntil.String())
 	err := repo.StoreLockedDelegation(&lock)
 	if err != nil {
-		log.Errorf("failed to store locked delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to store locked delegation; %s", err.Error())
 	}
 }
 
@@ -345,7 +345,7 @@ func handleLockedUpStake(lr *types.LogRecord) {
 func handleUnlockedStake(lr *types.LogRecord) {
 	// sanity check for data (3x topic + 2x uint256 = 64 bytes)
 	if len(lr.Topics) != 3 || len(lr.Data) != 64 {
-		log.Criticalf("%s is not event UnlockedStake; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s is not event UnlockedStake; expected 64 bytes, %d bytes given; expected 3 topics, %d given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -355,6 +355,22 @@ func handleUnlockedStake(lr *types.LogRecord) {
 		-types.LockedDelegationValue(new(big.Int).SetBytes(lr.Data[32:])),
 	)
 	if err != nil {
-		log.Errorf("failed to adjust locked delegation value; %s", err.Error())
+		handlerErrorf(lr, "failed to adjust locked delegation value; %s", err.Error())
+	}
+
+	// keep the unlock record so the paid penalty can be reported
+	du := types.DelegationUnlock{
+		Delegator:   common.BytesToAddress(lr.Topics[1].Bytes()),
+		ValidatorId: new(big.Int).SetBytes(lr.Topics[2].Bytes()).Int64(),
+		Trx:         lr.TxHash,
+		When:        time.Unix(int64(lr.Block.TimeStamp), 0),
+		Amount:      (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[:32])),
+		Penalty:     (hexutil.Big)(*new(big.Int).SetBytes(lr.Data[32:])),
+	}
+	du.Value = types.LockedDelegationValue(du.Amount.ToInt())
+	du.Fine = types.LockedDelegationValue(du.Penalty.ToInt())
+
+	if err := repo.StoreDelegationUnlock(&du); err != nil {
+		handlerErrorf(lr, "failed to store delegation unlock; %s", err.Error())
 	}
 }
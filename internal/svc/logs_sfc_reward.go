@@ -22,7 +22,7 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 		Amount:        (hexutil.Big)(*amo),
 		IsDelegated:   isRestake,
 	}); err != nil {
-		log.Criticalf("can not store rewards claim; %s", err.Error())
+		handlerCriticalf(lr, "can not store rewards claim; %s", err.Error())
 		return
 	}
 
@@ -30,7 +30,7 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 	if err := repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, valID, amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 }
 
@@ -38,7 +38,7 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 func handleSfcCommonRewardClaim(lr *types.LogRecord, isRestake bool) {
 	// sanity check for data (3x uint256 = 3x32 bytes = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -64,7 +64,7 @@ func handleSfcRestakeRewards(lr *types.LogRecord) {
 
 	// adjust locked stake amount (data should be 3x 32 bytes = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -77,7 +77,7 @@ func handleSfcRestakeRewards(lr *types.LogRecord) {
 		types.LockedDelegationValue(new(big.Int).Add(base, extra)),
 	)
 	if err != nil {
-		log.Errorf("could not adjust locked delegation; %s", err.Error())
+		handlerErrorf(lr, "could not adjust locked delegation; %s", err.Error())
 	}
 }
 
@@ -92,7 +92,7 @@ func handleSfcClaimedRewards(lr *types.LogRecord) {
 func handleSfc1ClaimedDelegationReward(lr *types.LogRecord) {
 	// sanity check for data (3x uint256 = 3x32 bytes = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -110,7 +110,7 @@ func handleSfc1ClaimedDelegationReward(lr *types.LogRecord) {
 func handleSfc1UnstashedReward(lr *types.LogRecord) {
 	// sanity check for data (1x uint256 = 1x32 bytes)
 	if len(lr.Data) != 32 {
-		log.Criticalf("%s lr invalid data length; expected 32 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 32 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -130,7 +130,7 @@ func handleSfc1UnstashedReward(lr *types.LogRecord) {
 func handleSfc1ClaimedValidatorReward(lr *types.LogRecord) {
 	// sanity check for data (3x uint256 = 3x32 bytes = 96 bytes)
 	if len(lr.Data) != 96 {
-		log.Criticalf("%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
 		return
 	}
 
@@ -141,7 +141,7 @@ func handleSfc1ClaimedValidatorReward(lr *types.LogRecord) {
 	// get the validator address since this a self stake
 	addr, err := repo.ValidatorAddress(valID)
 	if err != nil {
-		log.Errorf("validator #%d not found; %s", valID.ToInt().Uint64(), err.Error())
+		handlerErrorf(lr, "validator #%d not found; %s", valID.ToInt().Uint64(), err.Error())
 		return
 	}
 
@@ -26,7 +26,7 @@ func handleSfc1IncreasedStake(lr *types.LogRecord) {
 	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[1].Bytes()))
 	addr, err := repo.ValidatorAddress(valID)
 	if err != nil {
-		log.Errorf("unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
+		handlerErrorf(lr, "unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return
 	}
 
@@ -34,7 +34,7 @@ func handleSfc1IncreasedStake(lr *types.LogRecord) {
 	if err := repo.UpdateDelegationBalance(addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, addr, valID, amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 }
 
@@ -43,7 +43,7 @@ func handleSfc1IncreasedStake(lr *types.LogRecord) {
 func handleSfc1WithdrawnStake(lr *types.LogRecord) {
 	// sanity check for data (1 uint256 = 32 bytes)
 	if len(lr.Data) != 32 {
-		log.Criticalf("%s lr invalid data length; expected 32 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 32 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -51,7 +51,7 @@ func handleSfc1WithdrawnStake(lr *types.LogRecord) {
 	valID := new(big.Int).SetBytes(lr.Topics[1].Bytes())
 	addr, err := repo.ValidatorAddress((*hexutil.Big)(valID))
 	if err != nil {
-		log.Errorf("unknown validator #%d; %s", valID.Uint64(), err.Error())
+		handlerErrorf(lr, "unknown validator #%d; %s", valID.Uint64(), err.Error())
 		return
 	}
 
@@ -66,7 +66,7 @@ func handleSfc1WithdrawnStake(lr *types.LogRecord) {
 func handleSfc1DeactivatedStake(lr *types.LogRecord) {
 	// sanity check for data
 	if len(lr.Data) != 0 {
-		log.Criticalf("%s lr invalid data length; expected 0 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
+		handlerCriticalf(lr, "%s lr invalid data length; expected 0 bytes, %d bytes given, %d topics given", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 		return
 	}
 
@@ -74,7 +74,7 @@ func handleSfc1DeactivatedStake(lr *types.LogRecord) {
 	valID := new(big.Int).SetBytes(lr.Topics[1].Bytes())
 	addr, err := repo.ValidatorAddress((*hexutil.Big)(valID))
 	if err != nil {
-		log.Errorf("unknown validator #%d; %s", valID.Uint64(), err.Error())
+		handlerErrorf(lr, "unknown validator #%d; %s", valID.Uint64(), err.Error())
 		return
 	}
 
@@ -98,15 +98,15 @@ func handleSfc1UpdatedStake(lr *types.LogRecord) {
 	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[1].Bytes()))
 	addr, err := repo.ValidatorAddress(valID)
 	if err != nil {
-		log.Errorf("unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
+		handlerErrorf(lr, "unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return
 	}
 
 	// update the balance
 	if err := repo.UpdateDelegationBalance(addr, valID, func(amo *big.Int) error {
-		log.Criticalf("expected validator %d stake not found at %s", valID.ToInt().Uint64(), lr.TxHash.String())
+		handlerCriticalf(lr, "expected validator %d stake not found at %s", valID.ToInt().Uint64(), lr.TxHash.String())
 		return makeAdHocDelegation(lr, addr, valID, amo)
 	}); err != nil {
-		log.Errorf("failed to update delegation; %s", err.Error())
+		handlerErrorf(lr, "failed to update delegation; %s", err.Error())
 	}
 }
@@ -54,7 +54,7 @@ func handleUniswapSwap(lr *types.LogRecord) {
 
 	// sanity check for data (4 x uint256 = 4x32 bytes = 128 bytes), (1 x subject topic + 2 x address = 3 topics)
 	if len(lr.Data) != 128 || len(lr.Topics) != 3 {
-		log.Errorf("%s invalid data length; expected 128 bytes, %d bytes given; expected 3 topics, %d given",
+		handlerErrorf(lr, "%s invalid data length; expected 128 bytes, %d bytes given; expected 3 topics, %d given",
 			lr.TxHash.String(),
 			len(lr.Data),
 			len(lr.Topics),
@@ -98,7 +98,7 @@ func handleUniswapSwap(lr *types.LogRecord) {
 		Reserve1:    z,
 	})
 	if err != nil {
-		log.Errorf("%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+		handlerErrorf(lr, "%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
 	}
 }
 
@@ -113,7 +113,7 @@ func handleUniswapMint(lr *types.LogRecord) {
 
 	// sanity check for data (2 x uint256 = 2x32 bytes = 64 bytes), (1 x subject topic + 1 x address = 2 topics)
 	if len(lr.Data) != 64 || len(lr.Topics) != 2 {
-		log.Errorf("%s invalid data length; expected 64 bytes, %d bytes given; expected 2 topics, %d given",
+		handlerErrorf(lr, "%s invalid data length; expected 64 bytes, %d bytes given; expected 2 topics, %d given",
 			lr.TxHash.String(),
 			len(lr.Data),
 			len(lr.Topics),
@@ -153,7 +153,7 @@ func handleUniswapMint(lr *types.LogRecord) {
 		Reserve1:    z,
 	})
 	if err != nil {
-		log.Errorf("%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+		handlerErrorf(lr, "%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
 	}
 }
 
@@ -168,7 +168,7 @@ func handleUniswapBurn(lr *types.LogRecord) {
 
 	// sanity check for data (2 x uint256 = 2x32 bytes = 64 bytes), (1 x subject topic + 2 x address = 3 topics)
 	if len(lr.Data) != 64 || len(lr.Topics) != 3 {
-		log.Errorf("%s invalid data length; expected 64 bytes, %d bytes given; expected 3 topics, %d given",
+		handlerErrorf(lr, "%s invalid data length; expected 64 bytes, %d bytes given; expected 3 topics, %d given",
 			lr.TxHash.String(),
 			len(lr.Data),
 			len(lr.Topics),
@@ -208,7 +208,7 @@ func handleUniswapBurn(lr *types.LogRecord) {
 		Reserve1:    z,
 	})
 	if err != nil {
-		log.Errorf("%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+		handlerErrorf(lr, "%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
 	}
 }
 
@@ -222,7 +222,7 @@ func handleUniswapSync(lr *types.LogRecord) {
 
 	// sanity check for data (2 x uint112 = 2x32 bytes = 64 bytes)
 	if len(lr.Data) != 64 {
-		log.Errorf("%s invalid data length; expected 64 bytes, %d bytes given; expected 1 topic, %d given",
+		handlerErrorf(lr, "%s invalid data length; expected 64 bytes, %d bytes given; expected 1 topic, %d given",
 			lr.TxHash.String(),
 			len(lr.Data),
 			len(lr.Topics),
@@ -260,6 +260,6 @@ func handleUniswapSync(lr *types.LogRecord) {
 		Reserve1:    r1,
 	})
 	if err != nil {
-		log.Errorf("%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+		handlerErrorf(lr, "%s could not store uniswap event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
 	}
 }
@@ -0,0 +1,241 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// uniswapV3KnownPools represents a map of known pools and their participation in our configured v3 factory.
+var uniswapV3KnownPools = make(map[common.Address]bool, 1000)
+
+// isKnownUniswapV3Pool checks if the given (expected) pool address belongs to our configured v3 factory.
+func isKnownUniswapV3Pool(pool *common.Address) bool {
+	apr, ok := uniswapV3KnownPools[*pool]
+	if !ok {
+		apr = repo.IsUniswapV3Pool(pool)
+		uniswapV3KnownPools[*pool] = apr
+	}
+	return apr
+}
+
+// handleUniswapV3PoolCreated processes a new concentrated liquidity pool deployed by the v3 factory.
+// UniswapV3Factory::PoolCreated(address indexed token0, address indexed token1, uint24 indexed fee, int24 tickSpacing, address pool)
+func handleUniswapV3PoolCreated(lr *types.LogRecord) {
+	if lr.Address != repo.UniswapV3Factory() {
+		return
+	}
+
+	// sanity check for data (int24 + address = 2x32 bytes = 64 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 64 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 64 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	pool := types.UniswapV3Pool{
+		Address:     common.BytesToAddress(lr.Data[32:]),
+		Token0:      common.BytesToAddress(lr.Topics[1].Bytes()),
+		Token1:      common.BytesToAddress(lr.Topics[2].Bytes()),
+		Fee:         int32(new(big.Int).SetBytes(lr.Topics[3].Bytes()).Int64()),
+		TickSpacing: int32(types.UniswapV3Decode(lr.Data[:32]).Int64()),
+		Block:       lr.Block.Number,
+		Created:     time.Unix(int64(lr.Block.TimeStamp), 0),
+	}
+	log.Debugf("uniswap v3 pool %s created for %s/%s, fee %d",
+		pool.Address.String(), pool.Token0.String(), pool.Token1.String(), pool.Fee)
+
+	if err := repo.StoreUniswapV3Pool(&pool); err != nil {
+		handlerErrorf(lr, "%s could not store uniswap v3 pool %s; %s", lr.TxHash.String(), pool.Address.String(), err.Error())
+		return
+	}
+	uniswapV3KnownPools[pool.Address] = true
+}
+
+// handleUniswapV3Swap processes a swap on a concentrated liquidity pool.
+// UniswapV3Pool::Swap(address indexed sender, address indexed recipient, int256 amount0, int256 amount1, uint160 sqrtPriceX96, uint128 liquidity, int24 tick)
+func handleUniswapV3Swap(lr *types.LogRecord) {
+	if !isKnownUniswapV3Pool(&lr.Address) {
+		log.Debugf("rejected %s uniswap v3 swap #%d on unknown pool", lr.TxHash.String(), lr.Index)
+		return
+	}
+
+	// sanity check for data (5 x 32 bytes = 160 bytes), (1 x subject topic + 2 x address = 3 topics)
+	if len(lr.Data) != 160 || len(lr.Topics) != 3 {
+		handlerErrorf(lr, "%s invalid data length; expected 160 bytes, %d bytes given; expected 3 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := uniswapV3Action(lr, types.UniswapV3ActionSwap)
+	act.Sender = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.Owner = common.BytesToAddress(lr.Topics[2].Bytes())
+	act.Amount0 = hexutil.Big(*types.UniswapV3Decode(lr.Data[:32]))
+	act.Amount1 = hexutil.Big(*types.UniswapV3Decode(lr.Data[32:64]))
+	act.SqrtPriceX96 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[64:96]))
+	act.Liquidity = hexutil.Big(*new(big.Int).SetBytes(lr.Data[96:128]))
+	act.Tick = int32(types.UniswapV3Decode(lr.Data[128:]).Int64())
+
+	log.Debugf("uniswap v3 SWAP on pool %s, block #%d, amount0: %s, amount1: %s, tick: %d",
+		lr.Address.String(), lr.Block.Number, act.Amount0.String(), act.Amount1.String(), act.Tick)
+	storeUniswapV3Action(lr, act)
+}
+
+// handleUniswapV3Mint processes liquidity added to a concentrated liquidity pool.
+// UniswapV3Pool::Mint(address sender, address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1)
+func handleUniswapV3Mint(lr *types.LogRecord) {
+	if !isKnownUniswapV3Pool(&lr.Address) {
+		log.Debugf("rejected %s uniswap v3 mint #%d on unknown pool", lr.TxHash.String(), lr.Index)
+		return
+	}
+
+	// sanity check for data (4 x 32 bytes = 128 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 128 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 128 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := uniswapV3PositionAction(lr, types.UniswapV3ActionMint)
+	act.Sender = common.BytesToAddress(lr.Data[:32])
+	act.Liquidity = hexutil.Big(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.Amount0 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[64:96]))
+	act.Amount1 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[96:]))
+
+	log.Debugf("uniswap v3 MINT on pool %s, block #%d, ticks %d/%d, liquidity: %s",
+		lr.Address.String(), lr.Block.Number, act.TickLower, act.TickUpper, act.Liquidity.String())
+	storeUniswapV3Action(lr, act)
+}
+
+// handleUniswapV3Burn processes liquidity removed from a concentrated liquidity pool.
+// UniswapV3Pool::Burn(address indexed owner, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount, uint256 amount0, uint256 amount1)
+func handleUniswapV3Burn(lr *types.LogRecord) {
+	if !isKnownUniswapV3Pool(&lr.Address) {
+		log.Debugf("rejected %s uniswap v3 burn #%d on unknown pool", lr.TxHash.String(), lr.Index)
+		return
+	}
+
+	// sanity check for data (3 x 32 bytes = 96 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 96 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 96 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := uniswapV3PositionAction(lr, types.UniswapV3ActionBurn)
+	act.Sender = lr.Trx.From
+	act.Liquidity = hexutil.Big(*new(big.Int).SetBytes(lr.Data[:32]))
+	act.Amount0 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.Amount1 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[64:]))
+
+	log.Debugf("uniswap v3 BURN on pool %s, block #%d, ticks %d/%d, liquidity: %s",
+		lr.Address.String(), lr.Block.Number, act.TickLower, act.TickUpper, act.Liquidity.String())
+	storeUniswapV3Action(lr, act)
+}
+
+// handleUniswapV3Collect processes fees and released tokens collected from a concentrated liquidity pool.
+// UniswapV3Pool::Collect(address indexed owner, address recipient, int24 indexed tickLower, int24 indexed tickUpper, uint128 amount0, uint128 amount1)
+func handleUniswapV3Collect(lr *types.LogRecord) {
+	if !isKnownUniswapV3Pool(&lr.Address) {
+		log.Debugf("rejected %s uniswap v3 collect #%d on unknown pool", lr.TxHash.String(), lr.Index)
+		return
+	}
+
+	// sanity check for data (3 x 32 bytes = 96 bytes), (1 x subject topic + 3 x indexed = 4 topics)
+	if len(lr.Data) != 96 || len(lr.Topics) != 4 {
+		handlerErrorf(lr, "%s invalid data length; expected 96 bytes, %d bytes given; expected 4 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	act := uniswapV3PositionAction(lr, types.UniswapV3ActionCollect)
+	act.Sender = common.BytesToAddress(lr.Data[:32])
+	act.Amount0 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[32:64]))
+	act.Amount1 = hexutil.Big(*new(big.Int).SetBytes(lr.Data[64:]))
+
+	log.Debugf("uniswap v3 COLLECT on pool %s, block #%d, ticks %d/%d",
+		lr.Address.String(), lr.Block.Number, act.TickLower, act.TickUpper)
+	storeUniswapV3Action(lr, act)
+}
+
+// handleUniswapV3PositionChange processes a change of a liquidity position on the v3 position manager.
+// UniswapV3PositionManager::IncreaseLiquidity(uint256 indexed tokenId, uint128 liquidity, uint256 amount0, uint256 amount1)
+// UniswapV3PositionManager::DecreaseLiquidity(uint256 indexed tokenId, uint128 liquidity, uint256 amount0, uint256 amount1)
+// UniswapV3PositionManager::Collect(uint256 indexed tokenId, address recipient, uint256 amount0, uint256 amount1)
+func handleUniswapV3PositionChange(lr *types.LogRecord) {
+	if lr.Address != repo.UniswapV3PositionManager() {
+		return
+	}
+
+	// sanity check for topics (1 x subject topic + 1 x token id = 2 topics)
+	if len(lr.Topics) != 2 {
+		handlerErrorf(lr, "%s invalid topics; expected 2 topics, %d given", lr.TxHash.String(), len(lr.Topics))
+		return
+	}
+	updateUniswapV3Position(lr, new(big.Int).SetBytes(lr.Topics[1].Bytes()))
+}
+
+// handleUniswapV3PositionTransfer updates the owner of a liquidity position NFT transferred
+// on the v3 position manager. The ERC721 transfer itself is processed by the token handler.
+func handleUniswapV3PositionTransfer(lr *types.LogRecord, to common.Address, tokenId *big.Int) {
+	// burned positions can not be loaded from the manager anymore
+	if lr.Address != repo.UniswapV3PositionManager() || to == (common.Address{}) {
+		return
+	}
+	updateUniswapV3Position(lr, tokenId)
+}
+
+// updateUniswapV3Position refreshes the liquidity position of the given NFT token id.
+func updateUniswapV3Position(lr *types.LogRecord, tokenId *big.Int) {
+	if err := repo.UpdateUniswapV3Position(tokenId, lr.Block); err != nil {
+		handlerErrorf(lr, "%s could not update uniswap v3 position #%s; %s", lr.TxHash.String(), tokenId.String(), err.Error())
+	}
+}
+
+// uniswapV3Action prepares a pool action base for the given log record.
+func uniswapV3Action(lr *types.LogRecord, actionType int32) *types.UniswapV3Action {
+	return &types.UniswapV3Action{
+		OrdIndex:    uniswapOrdinalIndex(lr),
+		Type:        actionType,
+		Pool:        lr.Address,
+		TrxHash:     lr.TxHash,
+		LogIndex:    hexutil.Uint(lr.Index),
+		BlockNumber: lr.Block.Number,
+		TimeStamp:   lr.Block.TimeStamp,
+	}
+}
+
+// uniswapV3PositionAction prepares a pool action base with owner and position ticks
+// decoded from the indexed topics of the log record.
+func uniswapV3PositionAction(lr *types.LogRecord, actionType int32) *types.UniswapV3Action {
+	act := uniswapV3Action(lr, actionType)
+	act.Owner = common.BytesToAddress(lr.Topics[1].Bytes())
+	act.TickLower = int32(types.UniswapV3Decode(lr.Topics[2].Bytes()).Int64())
+	act.TickUpper = int32(types.UniswapV3Decode(lr.Topics[3].Bytes()).Int64())
+	return act
+}
+
+// storeUniswapV3Action stores the given pool action into the repository.
+func storeUniswapV3Action(lr *types.LogRecord, act *types.UniswapV3Action) {
+	if err := repo.AddUniswapV3Action(act); err != nil {
+		handlerErrorf(lr, "%s could not store uniswap v3 event #%d; %s", lr.TxHash.String(), lr.Index, err.Error())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"sync"
 )
 
@@ -21,6 +22,8 @@ type ServiceManager struct {
 	lgd *logDispatcher
 	bls *blkScanner
 	bud *burnDispatcher
+	flm *fLendMonitor
+	str *stakeReconciler
 
 	// collection of all the managed services
 	svc []Svc
@@ -56,6 +59,7 @@ func (mgr *ServiceManager) Run() {
 	for _, s := range mgr.svc {
 		s.init()
 	}
+	mgr.registerQueueMetrics()
 
 	// start services
 	for _, s := range mgr.svc {
@@ -91,6 +95,14 @@ func (mgr *ServiceManager) SetTrxChannel(ch chan *types.Transaction) {
 	mgr.trd.onTransaction = ch
 }
 
+// SetFLendPositionChannel registers a channel for notifying refreshed fLend positions.
+// The channel is not used if the fLend protocol is not configured.
+func (mgr *ServiceManager) SetFLendPositionChannel(ch chan *types.FLendPosition) {
+	if mgr.flm != nil {
+		mgr.flm.onPosition = ch
+	}
+}
+
 // Init the svc manager.
 func (mgr *ServiceManager) init() {
 	// make the block dispatcher
@@ -120,19 +132,48 @@ func (mgr *ServiceManager) init() {
 	// make epoch scanner
 	mgr.svc = append(mgr.svc, &epochScanner{service: service{mgr: mgr}})
 
+	// make validators directory snapshot refresh
+	mgr.svc = append(mgr.svc, &validatorDirectory{service: service{mgr: mgr}})
+
 	// make staker information scanner only if we have the contract address
 	if cfg.Staking.StiContract.String() != config.EmptyAddress {
 		mgr.svc = append(mgr.svc, &stiScanner{service: service{mgr: mgr}})
 	}
 
+	// make stake reconciler; it also restores the stake requested by the command line
+	mgr.str = &stakeReconciler{service: service{mgr: mgr}, restore: cfg.RepoCommand.RestoreStake}
+	mgr.svc = append(mgr.svc, mgr.str)
+
+	// make price history recorder only if we have any price symbols configured
+	if len(cfg.DeFi.PriceSymbols) > 0 {
+		mgr.svc = append(mgr.svc, &priceRecorder{service: service{mgr: mgr}, symbols: cfg.DeFi.PriceSymbols})
+	}
+
 	// make gas price suggestion monitor
 	mgr.svc = append(mgr.svc, &gpsMonitor{service: service{mgr: mgr}})
 
 	// make transaction flow monitor
 	mgr.svc = append(mgr.svc, &trxFlowMonitor{service: service{mgr: mgr}})
 
+	// make fMint account risk monitor only if we have the fMint protocol configured
+	if cfg.DeFi.FMint.AddressProvider.String() != config.EmptyAddress {
+		mgr.svc = append(mgr.svc, &fMintRiskMonitor{service: service{mgr: mgr}})
+	}
+
+	// make fLend position monitor only if we have the lending pool configured
+	if cfg.DeFi.FLend.LendingPool.String() != config.EmptyAddress {
+		mgr.flm = &fLendMonitor{service: service{mgr: mgr}}
+		mgr.svc = append(mgr.svc, mgr.flm)
+	}
+
+	// make the backfill of past logs not processed by the current log handlers
+	if lbf := newLogBackfill(mgr); lbf != nil {
+		mgr.svc = append(mgr.svc, lbf)
+	}
+
 	// make the network discovery
 	mgr.svc = append(mgr.svc, &netCrawler{service: service{mgr: mgr}})
+	mgr.svc = append(mgr.svc, &netStatsRecorder{service: service{mgr: mgr}})
 
 	// add orchestrator as the last service, so it can safely operate on all the other
 	mgr.ora = &orchestrator{service: service{mgr: mgr}}
@@ -160,3 +201,46 @@ func (mgr *ServiceManager) BlockHeight() uint64 {
 	}
 	return mgr.bls.blockHeight()
 }
+
+// ReScan requests the block scanner to scan and dispatch again the given number of blocks
+// below the last dispatched block.
+func (mgr *ServiceManager) ReScan(depth uint64) error {
+	if mgr.bls == nil || mgr.bls.inReScan == nil {
+		return fmt.Errorf("block scanner not available")
+	}
+
+	select {
+	case mgr.bls.inReScan <- depth:
+		return nil
+	default:
+		return fmt.Errorf("block re-scan already pending")
+	}
+}
+
+// RestoreStake requests a full stake reconciliation of the given stake owner.
+func (mgr *ServiceManager) RestoreStake(owner common.Address) error {
+	if mgr.str == nil || mgr.str.inRestore == nil {
+		return fmt.Errorf("stake reconciler not available")
+	}
+
+	select {
+	case mgr.str.inRestore <- owner.String():
+		return nil
+	default:
+		return fmt.Errorf("stake restore already pending")
+	}
+}
+
+// ReconcileStakes requests the stake of all the known delegators to be reconciled.
+func (mgr *ServiceManager) ReconcileStakes() error {
+	if mgr.str == nil || mgr.str.inReconcile == nil {
+		return fmt.Errorf("stake reconciler not available")
+	}
+
+	select {
+	case mgr.str.inReconcile <- struct{}{}:
+		return nil
+	default:
+		return fmt.Errorf("stake reconciliation already pending")
+	}
+}
@@ -0,0 +1,75 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	blocksProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "graphql_svc_blocks_processed_total",
+		Help: "The total number of blocks processed by the block dispatcher; use rate() to get blocks per second",
+	})
+	chainHeadBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "graphql_svc_chain_head_block",
+		Help: "The number of the chain head block as observed by the block scanner",
+	})
+	dispatchedBlock = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "graphql_svc_dispatched_block",
+		Help: "The number of the last block dispatched in sequence",
+	})
+	blocksLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "graphql_svc_blocks_lag",
+		Help: "The number of blocks between the chain head and the last block dispatched in sequence",
+	})
+	logHandlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "graphql_svc_log_handler_duration_seconds",
+		Help:    "The time spent processing a log record by the topic handler",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{
+		"handler",
+	})
+	logHandlerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "graphql_svc_log_handler_errors_total",
+		Help: "The total number of log records the topic handler failed to process",
+	}, []string{
+		"handler",
+	})
+)
+
+// registerQueueMetrics registers gauges of the pipeline queue depths.
+// The queues are created on the services init, so it has to be called after that.
+func (mgr *ServiceManager) registerQueueMetrics() {
+	queues := map[string]func() int{
+		"outBlock":       func() int { return len(mgr.bls.outBlock) },
+		"outTransaction": func() int { return len(mgr.bld.outTransaction) },
+		"outAccount":     func() int { return len(mgr.trd.outAccount) },
+		"outLog":         func() int { return len(mgr.trd.outLog) },
+	}
+
+	for name, depth := range queues {
+		depth := depth
+		err := prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name:        "graphql_svc_queue_length",
+			Help:        "The number of items waiting in the pipeline queue",
+			ConstLabels: prometheus.Labels{"queue": name},
+		}, func() float64 { return float64(depth()) }))
+		if err != nil {
+			log.Errorf("can not register %s queue metrics; %s", name, err.Error())
+		}
+	}
+}
+
+// handlerErrorf logs an error of the log record processing and marks the record failed.
+func handlerErrorf(lr *types.LogRecord, format string, args ...interface{}) {
+	lr.Failed = true
+	log.Errorf(format, args...)
+}
+
+// handlerCriticalf logs a critical failure of the log record processing and marks the record failed.
+func handlerCriticalf(lr *types.LogRecord, format string, args ...interface{}) {
+	lr.Failed = true
+	log.Criticalf(format, args...)
+}
@@ -0,0 +1,69 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// netStatsPeriod represents the period in which the network topology snapshot is taken.
+const netStatsPeriod = time.Hour
+
+// netStatsRecorder represents a service taking periodic snapshots of the network topology
+// collected by the network crawler so the network trends can be followed.
+type netStatsRecorder struct {
+	service
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (nsr *netStatsRecorder) name() string {
+	return "network stats recorder"
+}
+
+// run starts the network stats recorder.
+func (nsr *netStatsRecorder) run() {
+	// make sure we are orchestrated
+	if nsr.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", nsr.name()))
+	}
+
+	// start go routine for processing
+	nsr.mgr.started(nsr)
+	go nsr.execute()
+}
+
+// close terminates the network stats recorder.
+func (nsr *netStatsRecorder) close() {
+	if nsr.sigStop != nil {
+		close(nsr.sigStop)
+	}
+}
+
+// execute takes the network topology snapshots in regular intervals.
+func (nsr *netStatsRecorder) execute() {
+	ticker := time.NewTicker(netStatsPeriod)
+	defer func() {
+		ticker.Stop()
+		nsr.mgr.finished(nsr)
+	}()
+
+	nsr.record()
+	for {
+		select {
+		case <-nsr.sigStop:
+			return
+		case <-ticker.C:
+			nsr.record()
+		}
+	}
+}
+
+// record takes a new snapshot of the network topology.
+func (nsr *netStatsRecorder) record() {
+	ns, err := repo.TakeNetworkStats()
+	if err != nil {
+		log.Errorf("can not take network stats snapshot; %s", err.Error())
+		return
+	}
+	log.Debugf("network stats snapshot taken with %d active nodes", ns.Total)
+}
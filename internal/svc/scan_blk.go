@@ -37,6 +37,7 @@ type blkScanner struct {
 	outBlock       chan *types.Block
 	outStateSwitch chan bool
 	inDispatched   chan uint64
+	inReScan       chan uint64
 	observeTick    *time.Ticker
 	scanTick       *time.Ticker
 	onIdle         bool
@@ -57,6 +58,7 @@ func (bls *blkScanner) init() {
 	bls.sigStop = make(chan struct{})
 	bls.outStateSwitch = make(chan bool, 1)
 	bls.outBlock = make(chan *types.Block, blsBlockBufferCapacity)
+	bls.inReScan = make(chan uint64, 1)
 }
 
 // run starts the block dispatcher
@@ -137,6 +139,8 @@ func (bls *blkScanner) execute() {
 			if ok && (done == 0 || int64(bin)-int64(done) == 1) {
 				atomic.StoreUint64(&bls.done, bin)
 			}
+		case depth := <-bls.inReScan:
+			bls.reScan(depth)
 		case <-bls.observeTick.C:
 			bls.updateState(bls.observe())
 		case <-bls.scanTick.C:
@@ -160,6 +164,7 @@ func (bls *blkScanner) observe() bool {
 	// we compare current block height with the latest known dispatched block number
 	target := bh.ToInt().Uint64()
 	done := atomic.LoadUint64(&bls.done)
+	bls.updateMetrics(target, done)
 
 	if bls.onIdle && target < done+blsReScanHysteresis {
 		bls.next = done
@@ -174,6 +179,26 @@ func (bls *blkScanner) observe() bool {
 	return bls.to < bls.next
 }
 
+// reScan moves the scanner back by the given number of blocks below the last dispatched block,
+// so the blocks are scanned and dispatched again.
+func (bls *blkScanner) reScan(depth uint64) {
+	start := atomic.LoadUint64(&bls.done)
+	if start == 0 || bls.next < start {
+		start = bls.next
+	}
+
+	if start > depth {
+		start -= depth
+	} else {
+		start = 0
+	}
+
+	log.Noticef("block scanner re-scanning from #%d", start)
+	bls.from = start
+	bls.next = start
+	bls.updateState(false)
+}
+
 // updateState change scanner state if needed.
 // It resets the internal tickers according to the target state.
 func (bls *blkScanner) updateState(target bool) {
@@ -233,6 +258,17 @@ func (bls *blkScanner) shift() {
 	}
 }
 
+// updateMetrics updates the chain head and dispatched block metrics.
+func (bls *blkScanner) updateMetrics(head uint64, done uint64) {
+	chainHeadBlock.Set(float64(head))
+	dispatchedBlock.Set(float64(done))
+	if head > done {
+		blocksLag.Set(float64(head - done))
+	} else {
+		blocksLag.Set(0)
+	}
+}
+
 // blockHeight provides information about processed block height.
 func (bls *blkScanner) blockHeight() uint64 {
 	return atomic.LoadUint64(&bls.done)
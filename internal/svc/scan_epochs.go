@@ -26,6 +26,10 @@ type epochScanner struct {
 	current     uint64
 	top         *types.Epoch
 	queue       chan *types.Epoch
+
+	// epoch and time stamp of the last block processed by the block scanner
+	blkEpoch uint64
+	blkTime  uint64
 }
 
 // name returns the name of the service used by orchestrator.
@@ -53,6 +57,7 @@ func (eps *epochScanner) run() {
 		log.Criticalf("can not get the last known epoch; %s", err.Error())
 		eps.current = 1
 	}
+	eps.observeBlocks()
 
 	// signal orchestrator that we start two threads
 	eps.mgr.started(eps)
@@ -99,6 +104,7 @@ func (eps *epochScanner) execute() {
 				log.Noticef("epoch scan at #%d of #%d", eps.current, eps.top.Id)
 			}
 			eps.observe()
+			eps.observeBlocks()
 		}
 	}
 }
@@ -122,6 +128,16 @@ func (eps *epochScanner) observe() {
 	eps.top = ep
 }
 
+// observeBlocks updates the epoch and the time stamp of the last block processed by the block scanner.
+func (eps *epochScanner) observeBlocks() {
+	ep, stamp, err := repo.LastKnownBlockEpoch()
+	if err != nil {
+		log.Errorf("can not get the last known block epoch; %s", err.Error())
+		return
+	}
+	eps.blkEpoch, eps.blkTime = ep, stamp
+}
+
 // next processes epoch data based on the stored current epoch number.
 func (eps *epochScanner) next() {
 	// do we have a space to grow to
@@ -143,6 +159,12 @@ func (eps *epochScanner) next() {
 		return
 	}
 
+	// the blocks of the epoch must be scanned before we can process it
+	if !eps.isBlockScanDone(ep) {
+		log.Debugf("blocks of epoch #%d not scanned yet", ep.Id)
+		return
+	}
+
 	// process and move to the next epoch
 	log.Debugf("loaded epoch #%d", ep.Id)
 	select {
@@ -152,6 +174,17 @@ func (eps *epochScanner) next() {
 	}
 }
 
+// isBlockScanDone checks if the block scanner already passed the end of the given epoch.
+// If the node does not provide the epoch of blocks, we wait for the block scanner to pass
+// the end time of the epoch; the block range of such epoch is not known and the epoch
+// is stored without it, so it is not considered final.
+func (eps *epochScanner) isBlockScanDone(ep *types.Epoch) bool {
+	if eps.blkEpoch == 0 {
+		return eps.blkTime > uint64(ep.EndTime)
+	}
+	return eps.blkEpoch > uint64(ep.Id)
+}
+
 // dequeue consumes the queue and sends epochs to the persistent storage.
 func (eps *epochScanner) dequeue() {
 	for {
@@ -177,6 +210,22 @@ func (eps *epochScanner) store(ep *types.Epoch) {
 	// log what we do
 	log.Debugf("processing epoch #%d", ep.Id)
 
+	// snapshot validators of the epoch so we have their performance history
+	if err := repo.AddValidatorEpochs(ep); err != nil {
+		log.Errorf("can not store validators of epoch #%d; %s", ep.Id, err.Error())
+	}
+
+	// complete snapshots of older epochs failed to be taken before
+	if err := repo.BackfillValidatorEpochs(); err != nil {
+		log.Errorf("can not backfill validators of epochs; %s", err.Error())
+	}
+
+	// collect the block range, transactions and validators of the epoch;
+	// the epoch is stored even without the detail
+	if err := repo.EnrichEpoch(ep); err != nil {
+		log.Errorf("can not collect detail of epoch #%d; %s", ep.Id, err.Error())
+	}
+
 	// add the epoch to the database
 	err := repo.AddEpoch(ep)
 	if err != nil {
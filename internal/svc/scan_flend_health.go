@@ -0,0 +1,260 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+	"time"
+)
+
+const (
+	// fLendMonitorPeriod represents the period in which we refresh
+	// all the fLend positions even if no change has been signaled.
+	fLendMonitorPeriod = 10 * time.Minute
+
+	// fLendMonitorMinDelay represents the minimal delay between two refreshes
+	// of fLend positions; change signals received in the meantime are merged.
+	fLendMonitorMinDelay = 1 * time.Minute
+)
+
+// fLendMonitor represents a service tracking health factors of all the open
+// fLend positions so users can be warned before their positions are liquidated.
+type fLendMonitor struct {
+	service
+	sigChange  chan struct{}
+	onPosition chan *types.FLendPosition
+
+	// feeds maps price feed aggregators used by the fLend oracle to the assets they price
+	feeds map[common.Address][]common.Address
+
+	// pending changes waiting for the next refresh
+	mu            sync.Mutex
+	pendingUsers  map[common.Address]bool
+	pendingAssets map[common.Address]bool
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (flm *fLendMonitor) name() string {
+	return "fLend position monitor"
+}
+
+// init prepares the fLend monitor to perform its function.
+func (flm *fLendMonitor) init() {
+	flm.sigStop = make(chan struct{})
+	flm.sigChange = make(chan struct{}, 1)
+	flm.pendingUsers = make(map[common.Address]bool)
+	flm.pendingAssets = make(map[common.Address]bool)
+}
+
+// run starts the fLend position monitoring.
+func (flm *fLendMonitor) run() {
+	// make sure we are orchestrated
+	if flm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", flm.name()))
+	}
+
+	// start go routine for processing
+	flm.mgr.started(flm)
+	go flm.execute()
+}
+
+// close terminates the fLend position monitor.
+func (flm *fLendMonitor) close() {
+	if flm.sigStop != nil {
+		close(flm.sigStop)
+	}
+}
+
+// userChanged signals the monitor that the position of the given user changed
+// and should be refreshed. The call never blocks; pending signals are merged.
+func (flm *fLendMonitor) userChanged(user common.Address) {
+	flm.mu.Lock()
+	flm.pendingUsers[user] = true
+	flm.mu.Unlock()
+	flm.signal()
+}
+
+// priceChanged signals the monitor that the given price feed aggregator updated its price.
+// Only positions in the assets priced by the aggregator are refreshed;
+// updates of aggregators not used by the fLend oracle are ignored.
+func (flm *fLendMonitor) priceChanged(agg common.Address) {
+	flm.mu.Lock()
+	assets, ok := flm.feeds[agg]
+	for _, a := range assets {
+		flm.pendingAssets[a] = true
+	}
+	flm.mu.Unlock()
+
+	if ok {
+		flm.signal()
+	}
+}
+
+// signal wakes up the monitor without blocking.
+func (flm *fLendMonitor) signal() {
+	select {
+	case flm.sigChange <- struct{}{}:
+	default:
+	}
+}
+
+// pending collects and resets the pending changes; the users with changed positions
+// and the assets with changed prices.
+func (flm *fLendMonitor) pending() ([]common.Address, []common.Address) {
+	flm.mu.Lock()
+	defer flm.mu.Unlock()
+
+	users := make([]common.Address, 0, len(flm.pendingUsers))
+	for u := range flm.pendingUsers {
+		users = append(users, u)
+	}
+
+	assets := make([]common.Address, 0, len(flm.pendingAssets))
+	for a := range flm.pendingAssets {
+		assets = append(assets, a)
+	}
+
+	flm.pendingUsers = make(map[common.Address]bool)
+	flm.pendingAssets = make(map[common.Address]bool)
+	return users, assets
+}
+
+// execute refreshes the fLend positions on change signals and on regular ticker basis.
+func (flm *fLendMonitor) execute() {
+	ticker := time.NewTicker(fLendMonitorPeriod)
+	defer func() {
+		ticker.Stop()
+		flm.mgr.finished(flm)
+	}()
+
+	// do the initial refresh so the state is known from the start
+	flm.loadFeeds()
+	flm.refreshAll()
+	last := time.Now()
+
+	for {
+		select {
+		case <-flm.sigStop:
+			return
+		case <-ticker.C:
+			// the full refresh covers all the pending changes
+			flm.pending()
+			flm.loadFeeds()
+			flm.refreshAll()
+			last = time.Now()
+			continue
+		case <-flm.sigChange:
+			// do not refresh too often; wait for the minimal delay to pass
+			if wait := fLendMonitorMinDelay - time.Since(last); wait > 0 {
+				select {
+				case <-flm.sigStop:
+					return
+				case <-time.After(wait):
+				}
+			}
+		}
+
+		flm.refreshPending(flm.pending())
+		last = time.Now()
+	}
+}
+
+// loadFeeds updates the list of price feeds used by the fLend price oracle.
+// The previous list is kept if the feeds can not be resolved.
+func (flm *fLendMonitor) loadFeeds() {
+	feeds, err := repo.FLendPriceFeeds()
+	if err != nil {
+		log.Errorf("can not load fLend price feeds; %s", err.Error())
+		return
+	}
+
+	flm.mu.Lock()
+	flm.feeds = feeds
+	flm.mu.Unlock()
+}
+
+// refreshAll refreshes the state of all the known fLend positions.
+func (flm *fLendMonitor) refreshAll() {
+	// every user who ever deposited or borrowed is a candidate
+	users, err := repo.FLendUsers(nil)
+	if err != nil {
+		log.Errorf("can not load fLend users; %s", err.Error())
+		return
+	}
+	flm.refresh(users)
+}
+
+// refreshPending refreshes the state of the fLend positions of the given users
+// and of all the users with positions in the given assets.
+func (flm *fLendMonitor) refreshPending(users []common.Address, assets []common.Address) {
+	seen := make(map[common.Address]bool, len(users))
+	for _, u := range users {
+		seen[u] = true
+	}
+
+	for i := range assets {
+		list, err := repo.FLendUsers(&assets[i])
+		if err != nil {
+			log.Errorf("can not load fLend users of %s; %s", assets[i].String(), err.Error())
+			return
+		}
+
+		for _, u := range list {
+			if !seen[u] {
+				seen[u] = true
+				users = append(users, u)
+			}
+		}
+	}
+	flm.refresh(users)
+}
+
+// refresh loads the current state of the fLend positions of the given users
+// and stores it in the repository.
+func (flm *fLendMonitor) refresh(users []common.Address) {
+	for i := range users {
+		// terminate early if requested
+		select {
+		case <-flm.sigStop:
+			return
+		default:
+		}
+
+		pos, err := repo.RefreshFLendPosition(&users[i])
+		if err != nil {
+			log.Errorf("can not refresh fLend position of %s; %s", users[i].String(), err.Error())
+			continue
+		}
+
+		// broadcast positions with debt; if it can not be broadcast quickly, skip
+		if flm.onPosition != nil && pos.HasDebt() {
+			select {
+			case flm.onPosition <- pos:
+			case <-time.After(200 * time.Millisecond):
+			case <-flm.sigStop:
+				return
+			}
+		}
+	}
+	log.Debugf("fLend health refresh of %d positions done", len(users))
+}
+
+// notifyFLendUserChange signals the fLend position monitor, if any,
+// that the position of the given user should be refreshed.
+func notifyFLendUserChange(user common.Address) {
+	if manager == nil || manager.flm == nil {
+		return
+	}
+	manager.flm.userChanged(user)
+}
+
+// notifyFLendPriceChange signals the fLend position monitor, if any,
+// that the given price feed aggregator updated its price.
+func notifyFLendPriceChange(agg common.Address) {
+	if manager == nil || manager.flm == nil {
+		return
+	}
+	manager.flm.priceChanged(agg)
+}
@@ -0,0 +1,107 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"time"
+)
+
+// fMintRiskMonitorPeriod represents the period in which we take snapshots
+// of collateral and debt values of fMint accounts.
+const fMintRiskMonitorPeriod = 1 * time.Hour
+
+// fMintRiskMonitor represents a service collecting collateral to debt ratio
+// snapshots of fMint accounts so the ratio history can be provided.
+type fMintRiskMonitor struct {
+	service
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (frm *fMintRiskMonitor) name() string {
+	return "fMint risk monitor"
+}
+
+// run starts the fMint account risk monitoring.
+func (frm *fMintRiskMonitor) run() {
+	// make sure we are orchestrated
+	if frm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", frm.name()))
+	}
+
+	// start go routine for processing
+	frm.mgr.started(frm)
+	go frm.execute()
+}
+
+// close terminates the fMint risk monitor.
+func (frm *fMintRiskMonitor) close() {
+	if frm.sigStop != nil {
+		close(frm.sigStop)
+	}
+}
+
+// execute performs regular ticker based snapshots of fMint accounts.
+func (frm *fMintRiskMonitor) execute() {
+	ticker := time.NewTicker(fMintRiskMonitorPeriod)
+	defer func() {
+		ticker.Stop()
+		frm.mgr.finished(frm)
+	}()
+
+	for {
+		select {
+		case <-frm.sigStop:
+			return
+		case <-ticker.C:
+			frm.snapshot()
+		}
+	}
+}
+
+// snapshot collects the current collateral and debt values
+// of all the known fMint accounts and stores them in the repository.
+func (frm *fMintRiskMonitor) snapshot() {
+	// every user with a collateral deposit is a candidate
+	users, err := repo.FMintUsers(types.FMintTrxTypeDeposit)
+	if err != nil {
+		log.Errorf("can not load fMint users; %s", err.Error())
+		return
+	}
+
+	// use the same time stamp for all the accounts
+	now := time.Now().UTC().Truncate(time.Minute)
+	var count int
+	for _, u := range users {
+		// terminate early if requested
+		select {
+		case <-frm.sigStop:
+			return
+		default:
+		}
+
+		acc, err := repo.FMintAccount(u.User)
+		if err != nil {
+			log.Errorf("can not load fMint account %s; %s", u.User.String(), err.Error())
+			continue
+		}
+
+		// skip closed accounts
+		if acc.CollateralValue.ToInt().Sign() == 0 && acc.DebtValue.ToInt().Sign() == 0 {
+			continue
+		}
+
+		err = repo.AddFMintAccountRisk(&types.FMintAccountRisk{
+			User:            u.User,
+			TimeStamp:       now,
+			CollateralValue: acc.CollateralValue,
+			DebtValue:       acc.DebtValue,
+		})
+		if err != nil {
+			log.Errorf("can not store fMint risk of %s; %s", u.User.String(), err.Error())
+			continue
+		}
+		count++
+	}
+	log.Debugf("fMint risk snapshot of %d accounts done", count)
+}
@@ -0,0 +1,243 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+	"time"
+)
+
+const (
+	// logBackfillRange represents the number of blocks the logs are loaded for in one pass.
+	logBackfillRange = 10000
+
+	// logBackfillDelay represents the delay between passes, so the node is not overloaded.
+	logBackfillDelay = 250 * time.Millisecond
+)
+
+// logBackfillJob represents a one-off re-processing of past logs of a contract.
+// It is used when an index built from the logs is introduced on already synced deployments;
+// the logs emitted before the index was introduced are processed by the regular log handlers.
+type logBackfillJob struct {
+	name     string
+	contract common.Address
+	topics   []common.Hash
+
+	// withTrx signals the handlers need the transaction of the log, including its other logs
+	withTrx bool
+}
+
+// logBackfill implements a service processing past logs of the configured backfill jobs.
+// Each job scans blocks from the genesis to the last block known when it started;
+// the newer blocks are covered by the block scanner. The progress is persisted,
+// so an interrupted job continues where it stopped and a finished job is not repeated.
+type logBackfill struct {
+	service
+	jobs []logBackfillJob
+}
+
+// newLogBackfill creates the log backfill service with the jobs of the configured contracts.
+// Nil is returned if there is nothing to backfill.
+func newLogBackfill(mgr *ServiceManager) *logBackfill {
+	jobs := make([]logBackfillJob, 0)
+
+	// fLend lending pool actions were loaded from the node before they were indexed
+	if cfg.DeFi.FLend.LendingPool.String() != config.EmptyAddress {
+		jobs = append(jobs, logBackfillJob{
+			name:     "flend_actions",
+			contract: cfg.DeFi.FLend.LendingPool,
+			topics: []common.Hash{
+				/* LendingPool::Deposit(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint16 indexed referral) */
+				common.HexToHash("0xde6857219544bb5b7746f48ed30be6386fefc61b2f864cacf559893bf50fd951"),
+
+				/* LendingPool::Withdraw(address indexed reserve, address indexed user, address indexed to, uint256 amount) */
+				common.HexToHash("0x3115d1449a7b732c986cba18244e897a450f61e1bb8d589cd2e69e6c8924f9f7"),
+
+				/* LendingPool::Borrow(address indexed reserve, address user, address indexed onBehalfOf, uint256 amount, uint256 borrowRateMode, uint256 borrowRate, uint16 indexed referral) */
+				common.HexToHash("0xc6a898309e823ee50bac64e45ca8adba6690e99e7841c45d754e2a38e9019d9b"),
+
+				/* LendingPool::Repay(address indexed reserve, address indexed user, address indexed repayer, uint256 amount) */
+				common.HexToHash("0x4cdde6e09bb755c9a5589ebaec640bbfedff1362d4b255ebf8339782b9942faa"),
+
+				/* LendingPool::Swap(address indexed reserve, address indexed user, uint256 rateMode) */
+				common.HexToHash("0xea368a40e9570069bb8e6511d668293ad2e1f03b0d982431fd223de9f3b70ca6"),
+
+				/* LendingPool::RebalanceStableBorrowRate(address indexed reserve, address indexed user) */
+				common.HexToHash("0x9f439ae0c81e41a04d3fdfe07aed54e6a179fb0db15be7702eb66fa8ef6f5300"),
+
+				/* LendingPool::FlashLoan(address indexed target, address indexed initiator, address indexed asset, uint256 amount, uint256 premium, uint16 referralCode) */
+				common.HexToHash("0x631042c832b07452973831137f2d73e395028b44b250dedc5abb0ee766e168ac"),
+
+				/* LendingPool::LiquidationCall(address indexed collateralAsset, address indexed debtAsset, address indexed user, uint256 debtToCover, uint256 liquidatedCollateralAmount, address liquidator, bool receiveAToken) */
+				common.HexToHash("0xe413a321e8681d831f4dbccbca790d2952b56f977908e45be37335533e005286"),
+			},
+		})
+	}
+
+	// governance votes were indexed from the events after the first votes were cast;
+	// the vote weight overrides need the delegator vote of the same transaction
+	for _, gc := range cfg.Governance.ContractList() {
+		jobs = append(jobs, logBackfillJob{
+			name:     "governance_" + strings.ToLower(gc.Address.String()),
+			contract: gc.Address,
+			withTrx:  true,
+			topics: []common.Hash{
+				/* Governance::ProposalCreated(uint256 proposalID) */
+				common.HexToHash("0xc2c021f5d73c63c481d336fbbafec58f694fc45095f00b02d2deb8cca59afe07"),
+
+				/* Governance::ProposalResolved(uint256 proposalID) */
+				common.HexToHash("0x663674d96fd5c2a954bf75ad2e6795f9c9701eb687a7a8f3297c7a299467c941"),
+
+				/* Governance::ProposalRejected(uint256 proposalID) */
+				common.HexToHash("0xd92fba445edb3153b571e6df782d7a66fd0ce668519273670820ee3a86da0ef4"),
+
+				/* Governance::ProposalCanceled(uint256 proposalID) */
+				common.HexToHash("0x789cf55be980739dad1d0699b93b58e806b51c9d96619bfa8fe0a28abaa7b30c"),
+
+				/* Governance::Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight) */
+				governanceVotedTopic,
+
+				/* Governance::VoteCanceled(address voter, address delegatedTo, uint256 proposalID) */
+				governanceVoteCanceledTopic,
+
+				/* Governance::VoteWeightOverridden(address voter, uint256 diff) */
+				common.HexToHash("0x68fe85c5f71a2900fddf574935a27d4f1cb28af34d4fa2742b202684b45d3d14"),
+
+				/* Governance::VoteWeightUnOverridden(address voter, uint256 diff) */
+				common.HexToHash("0x11d7313426c62d856bd4ea20cdef8b93af4b40d2ea5b8f6f962fc705dbdcdbef"),
+			},
+		})
+	}
+
+	if len(jobs) == 0 {
+		return nil
+	}
+	return &logBackfill{service: service{mgr: mgr}, jobs: jobs}
+}
+
+// name returns the name of the service used by orchestrator.
+func (lbf *logBackfill) name() string {
+	return "log backfill"
+}
+
+// run starts the log backfill.
+func (lbf *logBackfill) run() {
+	// make sure we are orchestrated
+	if lbf.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", lbf.name()))
+	}
+
+	// signal orchestrator we started and go
+	lbf.mgr.started(lbf)
+	go lbf.execute()
+}
+
+// execute processes the backfill jobs one by one.
+func (lbf *logBackfill) execute() {
+	defer func() {
+		lbf.mgr.finished(lbf)
+	}()
+
+	for _, job := range lbf.jobs {
+		if !lbf.process(job) {
+			return
+		}
+	}
+}
+
+// process scans the remaining range of blocks of the given job.
+// It returns false if the service has been requested to terminate.
+func (lbf *logBackfill) process(job logBackfillJob) bool {
+	next, end, found, err := repo.LogBackfill(job.name)
+	if err != nil {
+		log.Errorf("log backfill %s not available; %s", job.name, err.Error())
+		return true
+	}
+
+	// the job scans all the blocks known at its start; the block scanner processes the rest
+	if !found {
+		end, err = repo.LastKnownBlock()
+		if err != nil {
+			log.Errorf("log backfill %s can not be started; %s", job.name, err.Error())
+			return true
+		}
+		if err := repo.UpdateLogBackfill(job.name, next, end); err != nil {
+			return true
+		}
+		log.Noticef("log backfill %s started for blocks #%d-#%d", job.name, next, end)
+	}
+
+	pending := next <= end
+	for next <= end {
+		select {
+		case <-lbf.sigStop:
+			return false
+		case <-time.After(logBackfillDelay):
+		}
+
+		to := next + logBackfillRange - 1
+		if to > end {
+			to = end
+		}
+
+		if err := lbf.scan(job, next, to); err != nil {
+			log.Errorf("log backfill %s failed at blocks #%d-#%d; %s", job.name, next, to, err.Error())
+			return true
+		}
+
+		next = to + 1
+		if err := repo.UpdateLogBackfill(job.name, next, end); err != nil {
+			return true
+		}
+	}
+
+	if pending {
+		log.Noticef("log backfill %s done", job.name)
+	}
+	return true
+}
+
+// scan loads the logs of the job in the given range of blocks and processes them
+// by the log handlers of the log dispatcher.
+func (lbf *logBackfill) scan(job logBackfillJob, from uint64, to uint64) error {
+	logs, err := repo.Logs(job.contract, job.topics, from, to)
+	if err != nil {
+		return err
+	}
+
+	blocks := make(map[uint64]*types.Block)
+	for _, l := range logs {
+		handler, ok := lbf.mgr.lgd.knownTopics[l.Topics[0]]
+		if !ok || l.Removed {
+			continue
+		}
+
+		blk, ok := blocks[l.BlockNumber]
+		if !ok {
+			num := hexutil.Uint64(l.BlockNumber)
+			blk, err = repo.BlockByNumber(&num)
+			if err != nil {
+				return err
+			}
+			blocks[l.BlockNumber] = blk
+		}
+
+		lr := types.LogRecord{Block: blk, Log: l}
+		if job.withTrx {
+			lr.Trx, err = repo.Transaction(&l.TxHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		handler(&lr)
+		if lr.Failed {
+			return fmt.Errorf("log #%d of %s not processed", l.Index, l.TxHash.String())
+		}
+	}
+	return nil
+}
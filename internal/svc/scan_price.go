@@ -0,0 +1,112 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// priceRecorderPeriod represents the period in which the native token price is recorded.
+	priceRecorderPeriod = 1 * time.Hour
+
+	// priceBackfillDelay represents the delay between two requests for the price history
+	// so the external API rate limit is not exceeded.
+	priceBackfillDelay = 2 * time.Second
+)
+
+// priceRecorder represents a service recording the price of the native token
+// in all the configured price symbols to keep a price history.
+type priceRecorder struct {
+	service
+	symbols []string
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (pr *priceRecorder) name() string {
+	return "price recorder"
+}
+
+// run starts the price recorder.
+func (pr *priceRecorder) run() {
+	// make sure we are orchestrated
+	if pr.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", pr.name()))
+	}
+
+	// start go routine for processing
+	pr.mgr.started(pr)
+	go pr.execute()
+}
+
+// close terminates the price recorder.
+func (pr *priceRecorder) close() {
+	if pr.sigStop != nil {
+		close(pr.sigStop)
+	}
+}
+
+// execute records the price of the native token in regular intervals.
+func (pr *priceRecorder) execute() {
+	ticker := time.NewTicker(priceRecorderPeriod)
+	defer func() {
+		ticker.Stop()
+		pr.mgr.finished(pr)
+	}()
+
+	pr.record()
+	pr.backfill()
+	for {
+		select {
+		case <-pr.sigStop:
+			return
+		case <-ticker.C:
+			pr.record()
+		}
+	}
+}
+
+// record stores the current price in all the configured symbols.
+func (pr *priceRecorder) record() {
+	for _, sym := range pr.symbols {
+		if err := repo.RecordPrice(sym); err != nil {
+			log.Errorf("can not record price of %s; %s", sym, err.Error())
+		}
+	}
+}
+
+// backfill loads the price history not recorded by the recorder from an external source.
+// Gaps of the recent history are filled first, the history is then extended
+// back in time from the oldest recorded price until the start of the trading.
+func (pr *priceRecorder) backfill() {
+	for _, sym := range pr.symbols {
+		if _, _, err := repo.BackfillPriceHistory(sym, time.Now().UTC()); err != nil {
+			log.Errorf("can not backfill recent price history of %s; %s", sym, err.Error())
+			continue
+		}
+
+		start, err := repo.PriceHistoryStart(sym)
+		if err != nil || start == nil {
+			continue
+		}
+
+		for {
+			select {
+			case <-pr.sigStop:
+				return
+			case <-time.After(priceBackfillDelay):
+			}
+
+			n, oldest, err := repo.BackfillPriceHistory(sym, *start)
+			if err != nil {
+				log.Errorf("can not backfill price history of %s before %s; %s", sym, start.String(), err.Error())
+				break
+			}
+			if n == 0 {
+				log.Noticef("price history of %s backfilled since %s", sym, start.String())
+				break
+			}
+			start = &oldest
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+const (
+	// stakeReconcilerPeriod represents the period in which all the known delegations
+	// are reconciled with the SFC state.
+	stakeReconcilerPeriod = 24 * time.Hour
+
+	// stakeReconcilerRetryPeriod represents the period in which a reconciliation postponed
+	// until the block scanner catches up with the chain head is retried.
+	stakeReconcilerRetryPeriod = 1 * time.Minute
+
+	// stakeReconcilerMaxLag represents the max distance in blocks between the block scanner
+	// and the chain head for the reconciliation to run. The stored state is reconciled
+	// with the SFC at the last scanned block, but we don't want to wait too long
+	// for a busy scanner to settle down.
+	stakeReconcilerMaxLag = 100
+)
+
+// stakeReconciler represents a service verifying stored delegations, locks
+// and withdraw requests against the SFC state and repairing the differences
+// caused by missed events.
+type stakeReconciler struct {
+	service
+	restore     string
+	inRestore   chan string
+	inReconcile chan struct{}
+
+	// reconciliation postponed until the block scanner catches up
+	pendingRestore   []string
+	pendingReconcile bool
+
+	// reconcileNext is the index of the next delegator of a postponed reconciliation
+	reconcileNext int
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (sr *stakeReconciler) name() string {
+	return "stake reconciler"
+}
+
+// init prepares the stake reconciler.
+func (sr *stakeReconciler) init() {
+	sr.service.init()
+	sr.inRestore = make(chan string, 1)
+	sr.inReconcile = make(chan struct{}, 1)
+}
+
+// run starts the stake reconciliation.
+func (sr *stakeReconciler) run() {
+	// make sure we are orchestrated
+	if sr.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", sr.name()))
+	}
+
+	// start go routine for processing
+	sr.mgr.started(sr)
+	go sr.execute()
+}
+
+// close terminates the stake reconciler.
+func (sr *stakeReconciler) close() {
+	if sr.sigStop != nil {
+		close(sr.sigStop)
+	}
+}
+
+// execute restores the stake requested on the command line, if any,
+// and performs regular reconciliation of all the delegators.
+func (sr *stakeReconciler) execute() {
+	ticker := time.NewTicker(stakeReconcilerPeriod)
+	retry := time.NewTicker(stakeReconcilerRetryPeriod)
+	defer func() {
+		ticker.Stop()
+		retry.Stop()
+		sr.mgr.finished(sr)
+	}()
+
+	// restore the stake requested by the command line
+	if sr.restore != "" {
+		sr.pendingRestore = append(sr.pendingRestore, sr.restore)
+		sr.processPending()
+	}
+
+	for {
+		select {
+		case <-sr.sigStop:
+			return
+		case owner := <-sr.inRestore:
+			sr.pendingRestore = append(sr.pendingRestore, owner)
+		case <-sr.inReconcile:
+			sr.pendingReconcile = true
+		case <-ticker.C:
+			sr.pendingReconcile = true
+		case <-retry.C:
+		}
+		sr.processPending()
+	}
+}
+
+// processPending performs the pending reconciliation requests,
+// if the block scanner is close enough to the chain head.
+func (sr *stakeReconciler) processPending() {
+	if len(sr.pendingRestore) == 0 && !sr.pendingReconcile {
+		return
+	}
+
+	blk := sr.scannedBlock()
+	if blk == nil {
+		return
+	}
+
+	for _, owner := range sr.pendingRestore {
+		sr.restoreStake(owner, blk)
+	}
+	sr.pendingRestore = sr.pendingRestore[:0]
+
+	if sr.pendingReconcile {
+		sr.pendingReconcile = !sr.reconcile()
+	}
+}
+
+// lastScanned provides the number of the last block processed by the block scanner.
+// The progress of the transaction dispatcher is used if available; the persisted
+// last known block may fall behind it.
+func (sr *stakeReconciler) lastScanned() (uint64, error) {
+	if sr.mgr != nil && sr.mgr.trd != nil {
+		if blk := sr.mgr.trd.blkLastSeen.Load(); blk != nil {
+			return uint64(blk.Number), nil
+		}
+	}
+	return repo.LastKnownBlock()
+}
+
+// lastScannedNumber provides the number of the last block processed by the block scanner
+// to the stake reconciliation; zero is provided if the number is not available.
+func (sr *stakeReconciler) lastScannedNumber() uint64 {
+	lnb, err := sr.lastScanned()
+	if err != nil {
+		return 0
+	}
+	return lnb
+}
+
+// scannedBlock provides the last block processed by the block scanner, if the scanner
+// is close enough to the chain head for the stored stake to be reconciled.
+func (sr *stakeReconciler) scannedBlock() *types.Block {
+	lnb, err := sr.lastScanned()
+	if err != nil {
+		log.Errorf("can not get the last scanned block; %s", err.Error())
+		return nil
+	}
+
+	head, err := repo.BlockHeight()
+	if err != nil {
+		log.Errorf("can not get the current block height; %s", err.Error())
+		return nil
+	}
+
+	if h := head.ToInt().Uint64(); h > lnb+stakeReconcilerMaxLag {
+		log.Noticef("stake reconciliation postponed, block scanner is %d blocks behind", h-lnb)
+		return nil
+	}
+
+	blk, err := repo.BlockByNumber((*hexutil.Uint64)(&lnb))
+	if err != nil {
+		log.Errorf("can not get block #%d; %s", lnb, err.Error())
+		return nil
+	}
+	return blk
+}
+
+// restoreStake performs a full stake reconciliation of the given address
+// requested by the command line, or the administration API.
+func (sr *stakeReconciler) restoreStake(owner string, blk *types.Block) {
+	if !common.IsHexAddress(owner) {
+		log.Errorf("invalid stake owner %s to be restored", owner)
+		return
+	}
+
+	addr := common.HexToAddress(owner)
+	log.Noticef("restoring stake of %s at block #%d", addr.String(), uint64(blk.Number))
+
+	fixes, err := repo.ReconcileStake(&addr, true, blk, sr.lastScannedNumber)
+	if err != nil {
+		log.Errorf("can not restore stake of %s; %s", addr.String(), err.Error())
+	}
+	log.Noticef("stake of %s restored with %d fixes", addr.String(), fixes)
+}
+
+// reconcile verifies the stake of all the known delegators. The stake of each delegator
+// is verified at the block processed by the block scanner at the time, so the scanner updates
+// made during the sweep are not mistaken for differences. If the scanner falls behind
+// the chain head, the sweep is postponed and false is returned; the postponed sweep
+// continues with the delegator it stopped at.
+func (sr *stakeReconciler) reconcile() bool {
+	dl, err := repo.Delegators()
+	if err != nil {
+		log.Errorf("can not load delegators; %s", err.Error())
+		return true
+	}
+
+	var total int
+	for ; sr.reconcileNext < len(dl); sr.reconcileNext++ {
+		// terminate early if requested
+		select {
+		case <-sr.sigStop:
+			return true
+		default:
+		}
+
+		blk := sr.scannedBlock()
+		if blk == nil {
+			return false
+		}
+
+		addr := dl[sr.reconcileNext]
+		fixes, err := repo.ReconcileStake(&addr, false, blk, sr.lastScannedNumber)
+		if err != nil {
+			log.Errorf("can not reconcile stake of %s; %s", addr.String(), err.Error())
+		}
+		total += fixes
+	}
+
+	log.Noticef("stake of %d delegators reconciled with %d fixes", len(dl), total)
+	sr.reconcileNext = 0
+	return true
+}
@@ -0,0 +1,69 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+	"time"
+)
+
+// validatorDirectoryPeriod represents the period in which the validators directory snapshot is refreshed.
+const validatorDirectoryPeriod = 5 * time.Minute
+
+// validatorDirectory represents a service refreshing the snapshot of all the validators
+// used to filter and sort the validators directory.
+type validatorDirectory struct {
+	service
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (vd *validatorDirectory) name() string {
+	return "validators directory"
+}
+
+// run starts the validators directory refresh.
+func (vd *validatorDirectory) run() {
+	// make sure we are orchestrated
+	if vd.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", vd.name()))
+	}
+
+	// start go routine for processing
+	vd.mgr.started(vd)
+	go vd.execute()
+}
+
+// close terminates the validators directory refresh.
+func (vd *validatorDirectory) close() {
+	if vd.sigStop != nil {
+		close(vd.sigStop)
+	}
+}
+
+// execute refreshes the validators directory snapshot in regular intervals.
+func (vd *validatorDirectory) execute() {
+	ticker := time.NewTicker(validatorDirectoryPeriod)
+	defer func() {
+		ticker.Stop()
+		vd.mgr.finished(vd)
+	}()
+
+	vd.refresh()
+	for {
+		select {
+		case <-vd.sigStop:
+			return
+		case <-ticker.C:
+			vd.refresh()
+		}
+	}
+}
+
+// refresh takes a new snapshot of the validators.
+func (vd *validatorDirectory) refresh() {
+	count, err := repo.RefreshValidatorDirectory()
+	if err != nil {
+		log.Errorf("can not refresh validators directory; %s", err.Error())
+		return
+	}
+	log.Debugf("validators directory refreshed with %d validators", count)
+}
@@ -0,0 +1,98 @@
+// Package tracing implements OpenTelemetry distributed tracing of the API server.
+// Spans are exported via OTLP/HTTP to a configured collector; W3C trace context
+// of incoming requests is honored, so the API server traces join the traces of its clients.
+package tracing
+
+import (
+	"context"
+	"fantom-api-graphql/cmd/apiserver/build"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// tracerName represents the name of the tracer used to record spans of the API server.
+const tracerName = "fantom-api-graphql"
+
+// shutdownTimeout represents the max time spent flushing pending spans on terminate.
+const shutdownTimeout = 5 * time.Second
+
+// Setup configures the global tracer provider and the trace context propagation.
+// It provides a function flushing pending spans and terminating the exporter.
+func Setup(cfg *config.Config, log logger.Logger) func() {
+	// trace context is propagated even if we don't record our own spans
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	if !cfg.Tracing.Enabled {
+		return func() {}
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Tracing.Endpoint)}
+	if cfg.Tracing.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	exp, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		log.Criticalf("can not create traces exporter; %s", err.Error())
+		return func() {}
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.AppName),
+		semconv.ServiceVersion(build.Version),
+	))
+	if err != nil {
+		log.Errorf("can not describe traces resource; %s", err.Error())
+		res = resource.Default()
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	log.Noticef("tracing to %s, sampling %.2f%% of new traces", cfg.Tracing.Endpoint, cfg.Tracing.SampleRatio*100)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := tp.Shutdown(ctx); err != nil {
+			log.Errorf("can not flush traces; %s", err.Error())
+		}
+	}
+}
+
+// Start starts a new span of the given name as a child of the span in the context, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartChild starts a new span of the given name only if the context already belongs to a recorded trace.
+// It's used for low level calls, which would only produce noise as traces of their own.
+func StartChild(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	return Start(ctx, name, attrs...)
+}
+
+// End ends the span recording the given error, if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -12,6 +12,9 @@ type Block struct {
 	// Number represents the block number. nil when its pending block.
 	Number hexutil.Uint64 `json:"number"`
 
+	// Epoch represents the id of the epoch the block belongs to.
+	Epoch hexutil.Uint64 `json:"epoch"`
+
 	// Hash represents hash of the block. nil when its pending block.
 	Hash common.Hash `json:"hash"`
 
@@ -114,9 +114,12 @@ type FLendBorrow struct {
 	// interest rate mode
 	InterestRateMode int32
 
-	// borrow rate
+	// borrow rate in basis points
 	BorrowRate int32
 
+	// borrow rate in ray
+	BorrowRateRay hexutil.Big
+
 	// referral code
 	ReferralCode int32
 
@@ -0,0 +1,168 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
+
+const (
+	FiFLendActionId        = "_id"
+	FiFLendActionType      = "typ"
+	FiFLendActionAsset     = "asset"
+	FiFLendActionUser      = "usr"
+	FiFLendActionTimeStamp = "date"
+	FiFLendActionOrdinal   = "orx"
+)
+
+// define types of fLend lending pool actions
+const (
+	FLendActionDeposit = iota
+	FLendActionWithdraw
+	FLendActionBorrow
+	FLendActionRepay
+	FLendActionSwap
+	FLendActionRebalanceStableBorrowRate
+	FLendActionFlashLoan
+	FLendActionLiquidationCall
+)
+
+// FLendAction represents an action (event) emitted by the fLend lending pool.
+// Meaning of the generic fields depends on the action type:
+//   - Asset is the reserve, the debt asset for liquidations, or the borrowed asset for flash loans.
+//   - User is the owner of the affected position, i.e. the on-behalf-of address for deposits and borrows,
+//     the liquidated user for liquidations, or the initiator for flash loans.
+//   - Counterparty is the caller for deposits and borrows, the recipient for withdrawals,
+//     the repayer for repays, the liquidator for liquidations, or the receiver contract for flash loans.
+//   - Amount is the amount of the action, or the debt covered by a liquidation.
+type FLendAction struct {
+	OrdIndex         uint64
+	Type             int32
+	Asset            common.Address
+	User             common.Address
+	Counterparty     common.Address
+	Amount           hexutil.Big
+	InterestRateMode int32
+	BorrowRate       hexutil.Big
+	Premium          hexutil.Big
+	CollateralAsset  common.Address
+	CollateralAmount hexutil.Big
+	ReceiveAToken    bool
+	ReferralCode     int32
+	TrxHash          common.Hash
+	LogIndex         hexutil.Uint
+	BlockNumber      hexutil.Uint64
+	TimeStamp        hexutil.Uint64
+}
+
+// Pk generates a unique primary key for the given lending pool action.
+func (fa *FLendAction) Pk() string {
+	bytes := make([]byte, 12)
+	copy(bytes, fa.TrxHash.Bytes()[:8])
+	bytes[8] = byte((fa.LogIndex >> 24) & 0xFF)
+	bytes[9] = byte((fa.LogIndex >> 16) & 0xFF)
+	bytes[10] = byte((fa.LogIndex >> 8) & 0xFF)
+	bytes[11] = byte(fa.LogIndex & 0xFF)
+	return hexutil.Encode(bytes)
+}
+
+// MarshalBSON creates a BSON representation of a lending pool action.
+func (fa *FLendAction) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		ID               string    `bson:"_id"`
+		Ordinal          int64     `bson:"orx"`
+		Type             int32     `bson:"typ"`
+		Asset            string    `bson:"asset"`
+		User             string    `bson:"usr"`
+		Counterparty     string    `bson:"cpt"`
+		Amount           string    `bson:"amo"`
+		InterestRateMode int32     `bson:"irm"`
+		BorrowRate       string    `bson:"rate"`
+		Premium          string    `bson:"prem"`
+		CollateralAsset  string    `bson:"col"`
+		CollateralAmount string    `bson:"col_amo"`
+		ReceiveAToken    bool      `bson:"atok"`
+		ReferralCode     int32     `bson:"ref"`
+		Trx              string    `bson:"trx"`
+		LogIndex         int32     `bson:"lix"`
+		Block            int64     `bson:"blk"`
+		TimeStamp        time.Time `bson:"date"`
+	}{
+		ID:               fa.Pk(),
+		Ordinal:          int64(fa.OrdIndex),
+		Type:             fa.Type,
+		Asset:            fa.Asset.String(),
+		User:             fa.User.String(),
+		Counterparty:     fa.Counterparty.String(),
+		Amount:           fa.Amount.String(),
+		InterestRateMode: fa.InterestRateMode,
+		BorrowRate:       fa.BorrowRate.String(),
+		Premium:          fa.Premium.String(),
+		CollateralAsset:  fa.CollateralAsset.String(),
+		CollateralAmount: fa.CollateralAmount.String(),
+		ReceiveAToken:    fa.ReceiveAToken,
+		ReferralCode:     fa.ReferralCode,
+		Trx:              fa.TrxHash.String(),
+		LogIndex:         int32(fa.LogIndex),
+		Block:            int64(fa.BlockNumber),
+		TimeStamp:        time.Unix(int64(fa.TimeStamp), 0),
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (fa *FLendAction) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		Ordinal          int64     `bson:"orx"`
+		Type             int32     `bson:"typ"`
+		Asset            string    `bson:"asset"`
+		User             string    `bson:"usr"`
+		Counterparty     string    `bson:"cpt"`
+		Amount           string    `bson:"amo"`
+		InterestRateMode int32     `bson:"irm"`
+		BorrowRate       string    `bson:"rate"`
+		Premium          string    `bson:"prem"`
+		CollateralAsset  string    `bson:"col"`
+		CollateralAmount string    `bson:"col_amo"`
+		ReceiveAToken    bool      `bson:"atok"`
+		ReferralCode     int32     `bson:"ref"`
+		Trx              string    `bson:"trx"`
+		LogIndex         int32     `bson:"lix"`
+		Block            int64     `bson:"blk"`
+		TimeStamp        time.Time `bson:"date"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	fa.OrdIndex = uint64(row.Ordinal)
+	fa.Type = row.Type
+	fa.Asset = common.HexToAddress(row.Asset)
+	fa.User = common.HexToAddress(row.User)
+	fa.Counterparty = common.HexToAddress(row.Counterparty)
+	fa.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
+	fa.InterestRateMode = row.InterestRateMode
+	fa.BorrowRate = (hexutil.Big)(*hexutil.MustDecodeBig(row.BorrowRate))
+	fa.Premium = (hexutil.Big)(*hexutil.MustDecodeBig(row.Premium))
+	fa.CollateralAsset = common.HexToAddress(row.CollateralAsset)
+	fa.CollateralAmount = (hexutil.Big)(*hexutil.MustDecodeBig(row.CollateralAmount))
+	fa.ReceiveAToken = row.ReceiveAToken
+	fa.ReferralCode = row.ReferralCode
+	fa.TrxHash = common.HexToHash(row.Trx)
+	fa.LogIndex = hexutil.Uint(row.LogIndex)
+	fa.BlockNumber = hexutil.Uint64(row.Block)
+	fa.TimeStamp = hexutil.Uint64(row.TimeStamp.Unix())
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"time"
+)
+
+const (
+	FiFLendPositionUser         = "_id"
+	FiFLendPositionHealthFactor = "hf"
+	FiFLendPositionHasDebt      = "dbt"
+
+	FiFLendHealthUser      = "usr"
+	FiFLendHealthTimeStamp = "date"
+)
+
+// FLendHealthFactorDecimals represents the decimals correction of the lending pool health factor.
+var FLendHealthFactorDecimals = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// FLendPosition represents the state of an fLend account position
+// as observed by the fLend position monitor.
+type FLendPosition struct {
+	User            common.Address
+	TotalCollateral hexutil.Big
+	TotalDebt       hexutil.Big
+	HealthFactor    hexutil.Big
+	Updated         time.Time
+}
+
+// FLendHealthPoint represents an aggregated point of fLend account
+// health factor time series.
+type FLendHealthPoint struct {
+	// Time represents ISO time tag of the point.
+	Time string `bson:"_id"`
+
+	// HealthFactor represents the health factor at the end of the period.
+	HealthFactor float64 `bson:"hf"`
+
+	// Low represents the lowest health factor observed in the period.
+	Low float64 `bson:"low"`
+}
+
+// HealthFactorValue returns the health factor of the position as a floating point value.
+// Positions without debt have an extremely high health factor.
+func (fp *FLendPosition) HealthFactorValue() float64 {
+	hf, _ := new(big.Float).Quo(new(big.Float).SetInt(fp.HealthFactor.ToInt()), FLendHealthFactorDecimals).Float64()
+	return hf
+}
+
+// HasDebt signals if the position has any debt and can be liquidated.
+func (fp *FLendPosition) HasDebt() bool {
+	return fp.TotalDebt.ToInt().Sign() > 0
+}
+
+// MarshalBSON creates a BSON representation of an fLend position.
+func (fp *FLendPosition) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		User         string    `bson:"_id"`
+		Collateral   string    `bson:"col"`
+		Debt         string    `bson:"debt"`
+		HasDebt      bool      `bson:"dbt"`
+		HealthFactor string    `bson:"hf_raw"`
+		Value        float64   `bson:"hf"`
+		Updated      time.Time `bson:"updated"`
+	}{
+		User:         fp.User.String(),
+		Collateral:   fp.TotalCollateral.String(),
+		Debt:         fp.TotalDebt.String(),
+		HasDebt:      fp.HasDebt(),
+		HealthFactor: fp.HealthFactor.String(),
+		Value:        fp.HealthFactorValue(),
+		Updated:      fp.Updated,
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (fp *FLendPosition) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		User         string    `bson:"_id"`
+		Collateral   string    `bson:"col"`
+		Debt         string    `bson:"debt"`
+		HealthFactor string    `bson:"hf_raw"`
+		Updated      time.Time `bson:"updated"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	fp.User = common.HexToAddress(row.User)
+	fp.TotalCollateral = (hexutil.Big)(*hexutil.MustDecodeBig(row.Collateral))
+	fp.TotalDebt = (hexutil.Big)(*hexutil.MustDecodeBig(row.Debt))
+	fp.HealthFactor = (hexutil.Big)(*hexutil.MustDecodeBig(row.HealthFactor))
+	fp.Updated = row.Updated
+	return nil
+}
@@ -47,7 +47,7 @@ type Delegation struct {
 	Address         common.Address `json:"address"`
 	ToStakerId      *hexutil.Big   `json:"toStakerID"`
 	ToStakerAddress common.Address `json:"toStakerAddr"`
-	CreatedTime     hexutil.Uint64 `json:"createdTime"`
+	CreatedTime     hexutil.Uint64 `json:"createdTime"` // zero if not known
 	Index           uint64         `json:"ordinalIndex"`
 
 	// AmountStaked represents the original staked amount
@@ -74,30 +74,58 @@ func (dl *Delegation) MarshalBSON() ([]byte, error) {
 
 	// do BSON encoding
 	return bson.Marshal(struct {
-		Orx    uint64    `bson:"orx"`
-		Trx    string    `bson:"trx"`
-		Addr   string    `bson:"adr"`
-		To     string    `bson:"to"`
-		ToAddr string    `bson:"toad"`
-		CrTime uint64    `bson:"crt"`
-		Staked string    `bson:"amo"`
-		Active string    `bson:"act"`
-		Value  uint64    `bson:"val"`
-		Stamp  time.Time `bson:"stamp"`
+		Orx    uint64     `bson:"orx"`
+		Trx    *string    `bson:"trx"`
+		Addr   string     `bson:"adr"`
+		To     string     `bson:"to"`
+		ToAddr string     `bson:"toad"`
+		CrTime *uint64    `bson:"crt"`
+		Staked string     `bson:"amo"`
+		Active string     `bson:"act"`
+		Value  uint64     `bson:"val"`
+		Stamp  *time.Time `bson:"stamp"`
 	}{
 		Orx:    dl.OrdinalIndex(),
-		Trx:    dl.Transaction.String(),
+		Trx:    dl.BsonTransaction(),
 		Addr:   dl.Address.String(),
 		To:     dl.ToStakerId.String(),
 		ToAddr: dl.ToStakerAddress.String(),
-		CrTime: uint64(dl.CreatedTime),
+		CrTime: dl.bsonCreatedTime(),
 		Staked: dl.AmountStaked.String(),
 		Active: dl.AmountDelegated.String(),
 		Value:  val,
-		Stamp:  time.Unix(int64(dl.CreatedTime), 0),
+		Stamp:  dl.BsonStamp(),
 	})
 }
 
+// BsonTransaction provides the BSON value of the delegation transaction;
+// nil if the transaction is not known, e.g. for delegations discovered
+// by the stake reconciliation.
+func (dl *Delegation) BsonTransaction() *string {
+	if dl.Transaction == (common.Hash{}) {
+		return nil
+	}
+	trx := dl.Transaction.String()
+	return &trx
+}
+
+// BsonStamp provides the BSON value of the delegation creation time stamp; nil if not known.
+func (dl *Delegation) BsonStamp() *time.Time {
+	if dl.CreatedTime == 0 {
+		return nil
+	}
+	ts := time.Unix(int64(dl.CreatedTime), 0)
+	return &ts
+}
+
+// bsonCreatedTime provides the BSON value of the delegation creation time; nil if not known.
+func (dl *Delegation) bsonCreatedTime() *uint64 {
+	if dl.CreatedTime == 0 {
+		return nil
+	}
+	return (*uint64)(&dl.CreatedTime)
+}
+
 // UnmarshalBSON updates the value from BSON source.
 func (dl *Delegation) UnmarshalBSON(data []byte) (err error) {
 	defer func() {
@@ -108,17 +136,17 @@ func (dl *Delegation) UnmarshalBSON(data []byte) (err error) {
 
 	// try to decode the BSON data
 	var row struct {
-		ID     string    `bson:"_id"`
-		Orx    uint64    `bson:"orx"`
-		Trx    string    `bson:"trx"`
-		Addr   string    `bson:"adr"`
-		To     string    `bson:"to"`
-		ToAddr string    `bson:"toad"`
-		CrTime uint64    `bson:"crt"`
-		Staked string    `bson:"amo"`
-		Active string    `bson:"act"`
-		Value  uint64    `bson:"val"`
-		Stamp  time.Time `bson:"stamp"`
+		ID     string     `bson:"_id"`
+		Orx    uint64     `bson:"orx"`
+		Trx    *string    `bson:"trx"`
+		Addr   string     `bson:"adr"`
+		To     string     `bson:"to"`
+		ToAddr string     `bson:"toad"`
+		CrTime *uint64    `bson:"crt"`
+		Staked string     `bson:"amo"`
+		Active string     `bson:"act"`
+		Value  uint64     `bson:"val"`
+		Stamp  *time.Time `bson:"stamp"`
 	}
 	if err = bson.Unmarshal(data, &row); err != nil {
 		return err
@@ -126,11 +154,17 @@ func (dl *Delegation) UnmarshalBSON(data []byte) (err error) {
 
 	// transfer values
 	dl.ID = row.ID
-	dl.Transaction = common.HexToHash(row.Trx)
+	dl.Transaction = common.Hash{}
+	if row.Trx != nil {
+		dl.Transaction = common.HexToHash(*row.Trx)
+	}
 	dl.Address = common.HexToAddress(row.Addr)
 	dl.ToStakerId = (*hexutil.Big)(hexutil.MustDecodeBig(row.To))
 	dl.ToStakerAddress = common.HexToAddress(row.ToAddr)
-	dl.CreatedTime = hexutil.Uint64(row.CrTime)
+	dl.CreatedTime = 0
+	if row.CrTime != nil {
+		dl.CreatedTime = hexutil.Uint64(*row.CrTime)
+	}
 	dl.AmountStaked = (*hexutil.Big)(hexutil.MustDecodeBig(row.Staked))
 	dl.AmountDelegated = (*hexutil.Big)(hexutil.MustDecodeBig(row.Active))
 	dl.Index = row.Orx
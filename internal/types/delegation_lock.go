@@ -2,8 +2,10 @@
 package types
 
 import (
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
 	"math/big"
 	"time"
 )
@@ -18,11 +20,14 @@ type DelegationLock struct {
 
 // DelegationUnlock represents an unlock request on a locked delegation.
 type DelegationUnlock struct {
-	When    time.Time   `bson:"when"`
-	Amount  hexutil.Big `bson:"amount"`
-	Penalty hexutil.Big `bson:"penalty"`
-	Value   int64       `bson:"value"`
-	Fine    int64       `bson:"fine"`
+	Delegator   common.Address
+	ValidatorId int64
+	Trx         common.Hash
+	When        time.Time
+	Amount      hexutil.Big
+	Penalty     hexutil.Big
+	Value       int64
+	Fine        int64
 }
 
 // LockedDelegation represents an information about locked delegation.
@@ -51,3 +56,69 @@ func (ld *LockedDelegation) SetAmount(amo *hexutil.Big) {
 	}
 	ld.Value = LockedDelegationValue(amo.ToInt())
 }
+
+// Pk returns the unique identifier of the delegation unlock.
+func (du *DelegationUnlock) Pk() string {
+	return fmt.Sprintf("%s-%s-%d", du.Trx.String(), du.Delegator.String(), du.ValidatorId)
+}
+
+// MarshalBSON creates a BSON representation of the delegation unlock.
+func (du *DelegationUnlock) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(struct {
+		Id        string    `bson:"_id"`
+		Delegator string    `bson:"from"`
+		Validator int64     `bson:"to"`
+		Trx       string    `bson:"trx"`
+		When      time.Time `bson:"when"`
+		Amount    string    `bson:"amount"`
+		Penalty   string    `bson:"penalty"`
+		Value     int64     `bson:"value"`
+		Fine      int64     `bson:"fine"`
+	}{
+		Id:        du.Pk(),
+		Delegator: du.Delegator.String(),
+		Validator: du.ValidatorId,
+		Trx:       du.Trx.String(),
+		When:      du.When,
+		Amount:    du.Amount.String(),
+		Penalty:   du.Penalty.String(),
+		Value:     du.Value,
+		Fine:      du.Fine,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (du *DelegationUnlock) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		Delegator string    `bson:"from"`
+		Validator int64     `bson:"to"`
+		Trx       string    `bson:"trx"`
+		When      time.Time `bson:"when"`
+		Amount    string    `bson:"amount"`
+		Penalty   string    `bson:"penalty"`
+		Value     int64     `bson:"value"`
+		Fine      int64     `bson:"fine"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	du.Delegator = common.HexToAddress(row.Delegator)
+	du.ValidatorId = row.Validator
+	du.Trx = common.HexToHash(row.Trx)
+	du.When = row.When
+	du.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
+	du.Penalty = (hexutil.Big)(*hexutil.MustDecodeBig(row.Penalty))
+	du.Value = row.Value
+	du.Fine = row.Fine
+	return nil
+}
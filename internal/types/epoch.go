@@ -22,23 +22,50 @@ type Epoch struct {
 	BaseRewardPerSecond   hexutil.Big    `json:"brw"`
 	StakeTotalAmount      hexutil.Big    `json:"stk"`
 	TotalSupply           hexutil.Big    `json:"sup"`
+
+	// the block range, transactions and validators of the epoch are known
+	// only for epochs processed by the epoch scanner
+	FirstBlock       *hexutil.Uint64  `json:"fbk,omitempty"`
+	LastBlock        *hexutil.Uint64  `json:"lbk,omitempty"`
+	TransactionCount *hexutil.Uint64  `json:"trx,omitempty"`
+	GasUsed          *hexutil.Uint64  `json:"gas,omitempty"`
+	ValidatorsCount  *hexutil.Uint64  `json:"vcn,omitempty"`
+	Validators       []EpochValidator `json:"val,omitempty"`
+}
+
+// EpochValidator represents a validator active in an epoch with its stake.
+type EpochValidator struct {
+	Id    hexutil.Big `json:"id"`
+	Stake hexutil.Big `json:"stk"`
 }
 
 // BsonEpoch represents the epoch data structure for BSON formatting.
 type BsonEpoch struct {
-	ID                  int64     `bson:"_id"`
-	EndTime             int64     `bson:"et"`
-	End                 time.Time `bson:"end"`
-	Fee                 string    `bson:"fee"`
-	FeeBurn             string    `bson:"feb"`
-	FeeTreasury         string    `bson:"fet"`
-	BaseRewardWeight    string    `bson:"brw"`
-	TrxRewardWeight     string    `bson:"trw"`
-	BaseRewardPerSecond string    `bson:"rew"`
-	TotalStake          string    `bson:"stake"`
-	TotalSupply         string    `bson:"supply"`
-	Burned              int64     `bson:"burned"`
-	Treasured           int64     `bson:"treasured"`
+	ID                  int64                `bson:"_id"`
+	EndTime             int64                `bson:"et"`
+	End                 time.Time            `bson:"end"`
+	Fee                 string               `bson:"fee"`
+	FeeBurn             string               `bson:"feb"`
+	FeeTreasury         string               `bson:"fet"`
+	BaseRewardWeight    string               `bson:"brw"`
+	TrxRewardWeight     string               `bson:"trw"`
+	BaseRewardPerSecond string               `bson:"rew"`
+	TotalStake          string               `bson:"stake"`
+	TotalSupply         string               `bson:"supply"`
+	Burned              int64                `bson:"burned"`
+	Treasured           int64                `bson:"treasured"`
+	FirstBlock          *int64               `bson:"fbk,omitempty"`
+	LastBlock           *int64               `bson:"lbk,omitempty"`
+	TrxCount            *int64               `bson:"trx_cnt,omitempty"`
+	GasUsed             *int64               `bson:"gas_use,omitempty"`
+	ValidatorsCount     *int64               `bson:"val_cnt,omitempty"`
+	Validators          []BsonEpochValidator `bson:"vals,omitempty"`
+}
+
+// BsonEpochValidator represents the epoch validator data structure for BSON formatting.
+type BsonEpochValidator struct {
+	Id    int64  `bson:"id"`
+	Stake string `bson:"stake"`
 }
 
 // UnmarshalEpoch parses the JSON-encoded Epoch data.
@@ -59,7 +86,7 @@ func (e *Epoch) MarshalBSON() ([]byte, error) {
 	amTreasured := new(big.Int).Div(e.EpochFeeTreasury.ToInt(), BurnDecimalsCorrection)
 
 	// prep the structure for saving
-	return bson.Marshal(BsonEpoch{
+	row := BsonEpoch{
 		ID:                  int64(e.Id),
 		EndTime:             int64(e.EndTime),
 		End:                 time.Unix(int64(e.EndTime), 0),
@@ -73,7 +100,18 @@ func (e *Epoch) MarshalBSON() ([]byte, error) {
 		TotalSupply:         e.TotalSupply.String(),
 		Burned:              amBurned.Int64(),
 		Treasured:           amTreasured.Int64(),
-	})
+		FirstBlock:          epochUint64ToInt64(e.FirstBlock),
+		LastBlock:           epochUint64ToInt64(e.LastBlock),
+		TrxCount:            epochUint64ToInt64(e.TransactionCount),
+		GasUsed:             epochUint64ToInt64(e.GasUsed),
+		ValidatorsCount:     epochUint64ToInt64(e.ValidatorsCount),
+	}
+
+	// add the validator set, if known
+	for _, v := range e.Validators {
+		row.Validators = append(row.Validators, BsonEpochValidator{Id: v.Id.ToInt().Int64(), Stake: v.Stake.String()})
+	}
+	return bson.Marshal(row)
 }
 
 // UnmarshalBSON updates the value from BSON source.
@@ -101,5 +139,39 @@ func (e *Epoch) UnmarshalBSON(data []byte) (err error) {
 	e.BaseRewardPerSecond = (hexutil.Big)(*hexutil.MustDecodeBig(row.BaseRewardPerSecond))
 	e.StakeTotalAmount = (hexutil.Big)(*hexutil.MustDecodeBig(row.TotalStake))
 	e.TotalSupply = (hexutil.Big)(*hexutil.MustDecodeBig(row.TotalSupply))
+	e.FirstBlock = epochInt64ToUint64(row.FirstBlock)
+	e.LastBlock = epochInt64ToUint64(row.LastBlock)
+	e.TransactionCount = epochInt64ToUint64(row.TrxCount)
+	e.GasUsed = epochInt64ToUint64(row.GasUsed)
+	e.ValidatorsCount = epochInt64ToUint64(row.ValidatorsCount)
+
+	e.Validators = nil
+	if row.Validators != nil {
+		e.Validators = make([]EpochValidator, len(row.Validators))
+		for i, v := range row.Validators {
+			e.Validators[i] = EpochValidator{
+				Id:    (hexutil.Big)(*big.NewInt(v.Id)),
+				Stake: (hexutil.Big)(*hexutil.MustDecodeBig(v.Stake)),
+			}
+		}
+	}
 	return nil
 }
+
+// epochUint64ToInt64 converts an optional epoch value to its BSON representation.
+func epochUint64ToInt64(v *hexutil.Uint64) *int64 {
+	if v == nil {
+		return nil
+	}
+	val := int64(*v)
+	return &val
+}
+
+// epochInt64ToUint64 converts an optional epoch value from its BSON representation.
+func epochInt64ToUint64(v *int64) *hexutil.Uint64 {
+	if v == nil {
+		return nil
+	}
+	val := hexutil.Uint64(*v)
+	return &val
+}
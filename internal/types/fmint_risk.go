@@ -0,0 +1,113 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"time"
+)
+
+const (
+	FiFMintRiskUser      = "usr"
+	FiFMintRiskTimeStamp = "date"
+	FiFMintRiskColValue  = "col_val"
+	FiFMintRiskDebtValue = "dbt_val"
+	FiFMintRiskRatio     = "ratio"
+)
+
+// FMintAccountRisk represents a snapshot of collateral and debt values
+// of an fMint account taken at the given time.
+type FMintAccountRisk struct {
+	User            common.Address
+	TimeStamp       time.Time
+	CollateralValue hexutil.Big
+	DebtValue       hexutil.Big
+}
+
+// FMintRiskPoint represents an aggregated point of fMint account
+// collateral to debt ratio time series.
+type FMintRiskPoint struct {
+	// Time represents ISO time tag of the point.
+	Time string `bson:"_id"`
+
+	// CollateralValue represents the collateral value in fUSD
+	// with the FMintAmountDecimalsCorrection applied.
+	CollateralValue int64 `bson:"col_val"`
+
+	// DebtValue represents the debt value in fUSD
+	// with the FMintAmountDecimalsCorrection applied.
+	DebtValue int64 `bson:"dbt_val"`
+
+	// Ratio represents the collateral to debt ratio, zero if no debt exists.
+	Ratio float64 `bson:"ratio"`
+}
+
+// Pk generates a unique primary key for the given fMint account risk snapshot.
+func (far *FMintAccountRisk) Pk() string {
+	return fmt.Sprintf("%s%08x", far.User.String(), far.TimeStamp.Unix())
+}
+
+// Ratio calculates the collateral to debt ratio of the snapshot.
+// Zero is returned if the account does not have any debt.
+func (far *FMintAccountRisk) Ratio() float64 {
+	if far.DebtValue.ToInt().Sign() == 0 {
+		return 0
+	}
+	r, _ := new(big.Float).Quo(new(big.Float).SetInt(far.CollateralValue.ToInt()), new(big.Float).SetInt(far.DebtValue.ToInt())).Float64()
+	return r
+}
+
+// MarshalBSON creates a BSON representation of an fMint account risk snapshot.
+func (far *FMintAccountRisk) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		ID        string    `bson:"_id"`
+		User      string    `bson:"usr"`
+		TimeStamp time.Time `bson:"date"`
+		Col       string    `bson:"col"`
+		Debt      string    `bson:"dbt"`
+		ColValue  int64     `bson:"col_val"`
+		DebtValue int64     `bson:"dbt_val"`
+		Ratio     float64   `bson:"ratio"`
+	}{
+		ID:        far.Pk(),
+		User:      far.User.String(),
+		TimeStamp: far.TimeStamp,
+		Col:       far.CollateralValue.String(),
+		Debt:      far.DebtValue.String(),
+		ColValue:  new(big.Int).Div(far.CollateralValue.ToInt(), FMintAmountDecimalsCorrection).Int64(),
+		DebtValue: new(big.Int).Div(far.DebtValue.ToInt(), FMintAmountDecimalsCorrection).Int64(),
+		Ratio:     far.Ratio(),
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (far *FMintAccountRisk) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		User      string    `bson:"usr"`
+		TimeStamp time.Time `bson:"date"`
+		Col       string    `bson:"col"`
+		Debt      string    `bson:"dbt"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	far.User = common.HexToAddress(row.User)
+	far.TimeStamp = row.TimeStamp
+	far.CollateralValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.Col))
+	far.DebtValue = (hexutil.Big)(*hexutil.MustDecodeBig(row.Debt))
+	return nil
+}
@@ -15,6 +15,7 @@ const (
 	FiFMintTransactionId        = "_id"
 	FiFMintTransactionToken     = "tok"
 	FiFMintTransactionUser      = "usr"
+	FiFMintTransactionType      = "typ"
 	FiFMintTransactionTimestamp = "stamp"
 	FiFMintTransactionOrdinal   = "orx"
 )
@@ -25,6 +26,7 @@ const (
 	FMintTrxTypeWithdraw
 	FMintTrxTypeMint
 	FMintTrxTypeRepay
+	FMintTrxTypeReward
 )
 
 // FMintAmountDecimalsCorrection represents the correction applied to base fMint trx amount to get apr value.
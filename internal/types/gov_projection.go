@@ -0,0 +1,81 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// GovernanceScenarioCurrent represents the outcome of the votes placed so far.
+	GovernanceScenarioCurrent = "current"
+
+	// GovernanceScenarioOverridesOppose represents the outcome if all the delegators able to override
+	// their validator vote did so with the lowest opinion on all the options.
+	GovernanceScenarioOverridesOppose = "overrides_oppose"
+
+	// GovernanceScenarioOverridesFavor represents the outcome if all the delegators able to override
+	// their validator vote did so with the highest opinion on all the options.
+	GovernanceScenarioOverridesFavor = "overrides_favor"
+
+	// GovernanceScenarioWhatIf represents the outcome of the votes placed so far
+	// extended with the given hypothetical votes.
+	GovernanceScenarioWhatIf = "what_if"
+)
+
+// GovernanceProjection represents a projected outcome of a Governance Proposal.
+type GovernanceProjection struct {
+	// TotalWeight is the total voting weight available.
+	TotalWeight hexutil.Big
+
+	// VotedWeight is the current weight of the votes placed.
+	VotedWeight hexutil.Big
+
+	// MinVotes is the absolute weight of votes required to settle the Proposal.
+	MinVotes hexutil.Big
+
+	// Overridable is the list of voting power able to override the vote of its validator.
+	Overridable []*GovernanceOverridableWeight
+
+	// Scenarios is the list of projected outcomes.
+	Scenarios []*GovernanceScenario
+}
+
+// GovernanceOverridableWeight represents the weight of delegators of a voting validator
+// who did not vote on their own and are still able to override the validator vote.
+type GovernanceOverridableWeight struct {
+	Validator   common.Address
+	ValidatorId hexutil.Big
+	Weight      hexutil.Big
+	Delegators  int32
+}
+
+// GovernanceScenario represents a projected outcome of a Governance Proposal.
+type GovernanceScenario struct {
+	Name                string
+	Votes               hexutil.Big
+	MinVotesReached     bool
+	MinAgreementReached bool
+	WinnerId            *hexutil.Big
+	Options             []*GovernanceOptionState
+}
+
+// GovernanceVotingPower represents the voting power of an address
+// in the context of a Governance contract.
+type GovernanceVotingPower struct {
+	// Own is the weight of the address own stake, if the address is a validator.
+	Own hexutil.Big
+
+	// Received is the weight delegated to the address, if the address is a validator.
+	Received hexutil.Big
+
+	// Delegations is the list of weights the address delegated to validators.
+	Delegations []*GovernanceDelegatedPower
+}
+
+// GovernanceDelegatedPower represents the voting weight delegated to a validator.
+type GovernanceDelegatedPower struct {
+	DelegatedTo common.Address
+	ValidatorId hexutil.Big
+	Weight      hexutil.Big
+}
@@ -0,0 +1,243 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"time"
+)
+
+const (
+	// GovernanceProposalCreated represents the event of a new proposal being created.
+	GovernanceProposalCreated = "created"
+
+	// GovernanceProposalResolved represents the event of a proposal being resolved.
+	GovernanceProposalResolved = "resolved"
+
+	// GovernanceProposalRejected represents the event of a proposal being rejected.
+	GovernanceProposalRejected = "rejected"
+
+	// GovernanceProposalCanceled represents the event of a proposal being canceled.
+	GovernanceProposalCanceled = "canceled"
+)
+
+const (
+	FiGovernanceVoteGovernance  = "gov"
+	FiGovernanceVoteProposal    = "prop"
+	FiGovernanceVoteVoter       = "voter"
+	FiGovernanceVoteDelegatedTo = "dlg"
+	FiGovernanceVoteOrdinal     = "orx"
+	FiGovernanceVoteCanceled    = "canceled"
+	FiGovernanceVoteWeight      = "weight"
+	FiGovernanceVoteValue       = "val"
+
+	// FiGovernanceVoteCanceledOrdinal is the ordinal index of the event canceling the vote.
+	FiGovernanceVoteCanceledOrdinal = "corx"
+
+	// FiGovernanceVoteWeightOrdinal is the ordinal index of the event changing the vote weight.
+	FiGovernanceVoteWeightOrdinal = "worx"
+
+	FiGovernanceProposalEventGovernance = "gov"
+	FiGovernanceProposalEventProposal   = "prop"
+	FiGovernanceProposalEventOrdinal    = "orx"
+)
+
+// GovernanceVoteRecord represents an indexed vote placed on a Governance Proposal.
+type GovernanceVoteRecord struct {
+	GovernanceVote
+
+	// Trx is the hash of the transaction placing the vote.
+	Trx common.Hash
+
+	// TimeStamp is the time the vote was placed.
+	TimeStamp time.Time
+
+	// Ordinal is the ordinal index of the vote used for sorting.
+	Ordinal int64
+
+	// Canceled is the time the vote was canceled, if any.
+	Canceled *time.Time
+}
+
+// GovernanceVoteList represents a list of indexed Governance votes.
+type GovernanceVoteList struct {
+	Collection []*GovernanceVoteRecord
+	Total      uint64
+	IsStart    bool
+	IsEnd      bool
+}
+
+// GovernanceProposalEvent represents an indexed life cycle event of a Governance Proposal.
+type GovernanceProposalEvent struct {
+	Id           string
+	GovernanceId common.Address
+	ProposalId   hexutil.Big
+
+	// Type is the type of the event; created, resolved, rejected, or canceled.
+	Type      string
+	Trx       common.Hash
+	TimeStamp time.Time
+	Ordinal   int64
+}
+
+// GovernanceOrdinalIndex returns an ordinal index of a Governance event
+// for the given block number and log index.
+func GovernanceOrdinalIndex(block uint64, logIndex uint) int64 {
+	return int64(block<<14) | int64(logIndex&0x3fff)
+}
+
+// GovernanceVoteId returns the identifier of the vote of the voter on the given proposal.
+func GovernanceVoteId(gov common.Address, propId *hexutil.Big, voter common.Address, delegatedTo common.Address) string {
+	return fmt.Sprintf("%s:%s:%s:%s", gov.String(), propId.String(), voter.String(), delegatedTo.String())
+}
+
+// Pk returns the primary key of the indexed vote.
+func (gv *GovernanceVoteRecord) Pk() string {
+	dlg := gv.From
+	if gv.DelegatedTo != nil {
+		dlg = *gv.DelegatedTo
+	}
+	return GovernanceVoteId(gv.GovernanceId, &gv.ProposalId, gv.From, dlg)
+}
+
+// MarshalBSON creates a BSON representation of an indexed Governance vote.
+func (gv *GovernanceVoteRecord) MarshalBSON() ([]byte, error) {
+	dlg := gv.From
+	if gv.DelegatedTo != nil {
+		dlg = *gv.DelegatedTo
+	}
+
+	choices := make([]int64, len(gv.Choices))
+	for i, c := range gv.Choices {
+		choices[i] = int64(c)
+	}
+
+	// weight in tokens with 9 digits precision is used for aggregations
+	val := new(big.Int).Div(gv.Weight.ToInt(), DelegationDecimalsCorrection).Int64()
+
+	return bson.Marshal(struct {
+		Id          string     `bson:"_id"`
+		Governance  string     `bson:"gov"`
+		Proposal    string     `bson:"prop"`
+		Voter       string     `bson:"voter"`
+		DelegatedTo string     `bson:"dlg"`
+		Weight      string     `bson:"weight"`
+		Value       int64      `bson:"val"`
+		Choices     []int64    `bson:"choices"`
+		Trx         string     `bson:"trx"`
+		TimeStamp   time.Time  `bson:"stamp"`
+		Ordinal     int64      `bson:"orx"`
+		Canceled    *time.Time `bson:"canceled"`
+	}{
+		Id:          gv.Pk(),
+		Governance:  gv.GovernanceId.String(),
+		Proposal:    gv.ProposalId.String(),
+		Voter:       gv.From.String(),
+		DelegatedTo: dlg.String(),
+		Weight:      gv.Weight.String(),
+		Value:       val,
+		Choices:     choices,
+		Trx:         gv.Trx.String(),
+		TimeStamp:   gv.TimeStamp,
+		Ordinal:     gv.Ordinal,
+		Canceled:    gv.Canceled,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (gv *GovernanceVoteRecord) UnmarshalBSON(data []byte) (err error) {
+	var row struct {
+		Governance  string     `bson:"gov"`
+		Proposal    string     `bson:"prop"`
+		Voter       string     `bson:"voter"`
+		DelegatedTo string     `bson:"dlg"`
+		Weight      string     `bson:"weight"`
+		Choices     []int64    `bson:"choices"`
+		Trx         string     `bson:"trx"`
+		TimeStamp   time.Time  `bson:"stamp"`
+		Ordinal     int64      `bson:"orx"`
+		Canceled    *time.Time `bson:"canceled"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	prop, err := hexutil.DecodeBig(row.Proposal)
+	if err != nil {
+		return err
+	}
+	weight, err := hexutil.DecodeBig(row.Weight)
+	if err != nil {
+		return err
+	}
+
+	dlg := common.HexToAddress(row.DelegatedTo)
+	gv.GovernanceId = common.HexToAddress(row.Governance)
+	gv.ProposalId = hexutil.Big(*prop)
+	gv.From = common.HexToAddress(row.Voter)
+	gv.DelegatedTo = &dlg
+	gv.Weight = hexutil.Big(*weight)
+	gv.Choices = make([]hexutil.Uint64, len(row.Choices))
+	for i, c := range row.Choices {
+		gv.Choices[i] = hexutil.Uint64(c)
+	}
+	gv.Trx = common.HexToHash(row.Trx)
+	gv.TimeStamp = row.TimeStamp
+	gv.Ordinal = row.Ordinal
+	gv.Canceled = row.Canceled
+	return nil
+}
+
+// MarshalBSON creates a BSON representation of a Governance Proposal event.
+func (ge *GovernanceProposalEvent) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(struct {
+		Id         string    `bson:"_id"`
+		Governance string    `bson:"gov"`
+		Proposal   string    `bson:"prop"`
+		Type       string    `bson:"type"`
+		Trx        string    `bson:"trx"`
+		TimeStamp  time.Time `bson:"stamp"`
+		Ordinal    int64     `bson:"orx"`
+	}{
+		Id:         ge.Id,
+		Governance: ge.GovernanceId.String(),
+		Proposal:   ge.ProposalId.String(),
+		Type:       ge.Type,
+		Trx:        ge.Trx.String(),
+		TimeStamp:  ge.TimeStamp,
+		Ordinal:    ge.Ordinal,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ge *GovernanceProposalEvent) UnmarshalBSON(data []byte) (err error) {
+	var row struct {
+		Id         string    `bson:"_id"`
+		Governance string    `bson:"gov"`
+		Proposal   string    `bson:"prop"`
+		Type       string    `bson:"type"`
+		Trx        string    `bson:"trx"`
+		TimeStamp  time.Time `bson:"stamp"`
+		Ordinal    int64     `bson:"orx"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	prop, err := hexutil.DecodeBig(row.Proposal)
+	if err != nil {
+		return err
+	}
+
+	ge.Id = row.Id
+	ge.GovernanceId = common.HexToAddress(row.Governance)
+	ge.ProposalId = hexutil.Big(*prop)
+	ge.Type = row.Type
+	ge.Trx = common.HexToHash(row.Trx)
+	ge.TimeStamp = row.TimeStamp
+	ge.Ordinal = row.Ordinal
+	return nil
+}
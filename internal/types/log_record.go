@@ -2,13 +2,18 @@
 package types
 
 import (
+	"context"
 	retypes "github.com/ethereum/go-ethereum/core/types"
 	"sync"
 )
 
 // LogRecord represents a log record to be processed.
+// The context carries the trace of the block processing the log belongs to.
+// Handlers mark the record failed if the processing could not be finished.
 type LogRecord struct {
 	WatchDog *sync.WaitGroup
+	Ctx      context.Context
+	Failed   bool
 	Block    *Block
 	Trx      *Transaction
 	retypes.Log
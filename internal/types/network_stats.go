@@ -0,0 +1,67 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"time"
+)
+
+const (
+	// NetworkStatsByCountry groups the network statistics by country of the nodes.
+	NetworkStatsByCountry = "country"
+
+	// NetworkStatsByContinent groups the network statistics by continent of the nodes.
+	NetworkStatsByContinent = "continent"
+
+	// NetworkStatsByVersion groups the network statistics by client version of the nodes.
+	NetworkStatsByVersion = "version"
+
+	// NetworkStatsByHost groups the network statistics by hosting network (ASN) of the nodes.
+	NetworkStatsByHost = "asn"
+
+	// NetworkStatsBySynced groups the network statistics by the synced status of the nodes.
+	NetworkStatsBySynced = "synced"
+)
+
+// NetworkStatsGroup represents the number of active nodes sharing the same property.
+type NetworkStatsGroup struct {
+	Key    string `bson:"key"`
+	Count  int32  `bson:"count"`
+	Synced int32  `bson:"synced"`
+}
+
+// NetworkStats represents a snapshot of the Opera network topology.
+type NetworkStats struct {
+	// TimeStamp is the unix time of the snapshot; used as the primary key.
+	TimeStamp int64     `bson:"_id"`
+	Stamp     time.Time `bson:"stamp"`
+
+	// Total and Synced represent the number of active nodes.
+	Total  int32 `bson:"total"`
+	Synced int32 `bson:"synced"`
+
+	// the number of active nodes grouped by the given property
+	Countries  []NetworkStatsGroup `bson:"country"`
+	Continents []NetworkStatsGroup `bson:"continent"`
+	Versions   []NetworkStatsGroup `bson:"version"`
+	Hosts      []NetworkStatsGroup `bson:"asn"`
+}
+
+// Groups provides the node groups of the snapshot for the given grouping.
+func (ns *NetworkStats) Groups(groupBy string) []NetworkStatsGroup {
+	switch groupBy {
+	case NetworkStatsByCountry:
+		return ns.Countries
+	case NetworkStatsByContinent:
+		return ns.Continents
+	case NetworkStatsByVersion:
+		return ns.Versions
+	case NetworkStatsByHost:
+		return ns.Hosts
+	case NetworkStatsBySynced:
+		return []NetworkStatsGroup{
+			{Key: "synced", Count: ns.Synced, Synced: ns.Synced},
+			{Key: "lagging", Count: ns.Total - ns.Synced},
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package types
 
 import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"strings"
 	"time"
 )
 
@@ -22,6 +23,10 @@ type GeoLocation struct {
 	Latitude  float64 `bson:"lat"`
 	Longitude float64 `bson:"lon"`
 	Accuracy  uint16  `bson:"accuracy"`
+
+	// ASN and ASOrganization identify the hosting network, if known.
+	ASN            uint32 `bson:"asn"`
+	ASOrganization string `bson:"as_org"`
 }
 
 // OperaNode represents a node on Fantom Opera network.
@@ -54,6 +59,8 @@ type OperaNode struct {
 // obtained from a direct p2p communication with the node.
 type OperaNodeInformation struct {
 	Name             string    `bson:"name"`
+	Client           string    `bson:"client"`
+	ClientVersion    string    `bson:"client_ver"`
 	Version          string    `bson:"ver"`
 	Epoch            int64     `bson:"epoch"`
 	BlockHeight      int64     `bson:"block"`
@@ -80,3 +87,53 @@ type OperaNodeLocationAggregate struct {
 	// Count represents the number of nodes in the aggregation group.
 	Count int32 `bson:"total"`
 }
+
+// OperaNodeVersionAggregate represents an aggregated summary of Opera network nodes
+// based on their client software version.
+type OperaNodeVersionAggregate struct {
+	Client  string `bson:"client"`
+	Version string `bson:"client_ver"`
+
+	// Count represents the number of nodes in the aggregation group.
+	Count int32 `bson:"total"`
+
+	// Synced represents the number of synced nodes in the aggregation group.
+	Synced int32 `bson:"synced"`
+}
+
+// OperaNodeFilter represents a filter of the Opera network nodes list.
+// Nil members are not applied.
+type OperaNodeFilter struct {
+	IsOpera       *bool
+	IsSynced      *bool
+	IsResponsive  *bool
+	Client        *string
+	ClientVersion *string
+	Country       *string
+}
+
+// OperaNodeList represents a page of the Opera network nodes list.
+type OperaNodeList struct {
+	Collection []*OperaNode
+	Total      uint64
+	IsStart    bool
+	IsEnd      bool
+}
+
+// OperaNodeListCursor represents the position of an Opera network node in the list of nodes
+// sorted by the time the node was last seen and its network identifier.
+type OperaNodeListCursor struct {
+	LastSeen time.Time
+	Id       enode.ID
+}
+
+// OperaNodeClient parses the client software name and version
+// from the node name advertised in the p2p hello message,
+// i.e. "go-x1/v1.1.5-rc.1/linux-amd64/go1.21.3".
+func OperaNodeClient(name string) (string, string) {
+	parts := strings.Split(name, "/")
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,16 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+const (
+	FiPriceTickSymbol    = "sym"
+	FiPriceTickTimeStamp = "date"
+)
+
+// PriceTick represents a recorded price of the native token in the given fiat symbol.
+type PriceTick struct {
+	Symbol    string    `bson:"sym"`
+	Price     float64   `bson:"price"`
+	TimeStamp time.Time `bson:"date"`
+}
@@ -0,0 +1,35 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// StakeFixDelegation represents a fix of the delegation amount.
+	StakeFixDelegation = "delegation"
+
+	// StakeFixDelegationCreated represents a delegation created from the SFC state.
+	StakeFixDelegationCreated = "delegation_created"
+
+	// StakeFixLock represents a fix of the delegation lock.
+	StakeFixLock = "lock"
+
+	// StakeFixWithdrawAmount represents a fix of the withdraw request amount.
+	StakeFixWithdrawAmount = "withdraw_amount"
+
+	// StakeFixWithdrawClosed represents a withdraw request closed by the SFC state.
+	StakeFixWithdrawClosed = "withdraw_closed"
+)
+
+// StakeFix represents an audit record of a stake state repair
+// made by the stake reconciliation.
+type StakeFix struct {
+	Address     common.Address `bson:"adr"`
+	ValidatorId int64          `bson:"val"`
+	Kind        string         `bson:"kind"`
+	Previous    string         `bson:"prev"`
+	Current     string         `bson:"cur"`
+	TimeStamp   time.Time      `bson:"date"`
+}
@@ -0,0 +1,36 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+const (
+	// StakeScheduleUnlock represents an expiration of a delegation lock.
+	StakeScheduleUnlock = "unlock"
+
+	// StakeScheduleWithdrawal represents a pending withdraw request becoming claimable.
+	StakeScheduleWithdrawal = "withdrawal"
+)
+
+// StakeScheduleItem represents an upcoming unlock, or withdrawal of a delegation.
+type StakeScheduleItem struct {
+	Type        string
+	ValidatorId hexutil.Big
+	Date        time.Time
+	Amount      hexutil.Big
+
+	// RequestId represents the withdraw request ID; nil for unlocks.
+	RequestId *hexutil.Big
+}
+
+// StakeScheduleTick represents an aggregated amount of stake
+// unlocking and becoming withdrawable in a time period.
+type StakeScheduleTick struct {
+	Date          time.Time
+	Unlocking     hexutil.Big
+	UnlockCount   int
+	Withdrawable  hexutil.Big
+	WithdrawCount int
+}
@@ -0,0 +1,63 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+const (
+	// StakingReportClaim represents a reward claimed to the delegator account.
+	StakingReportClaim = "claim"
+
+	// StakingReportRestake represents a reward restaked into the delegation.
+	StakingReportRestake = "restake"
+
+	// StakingReportLockPenalty represents a penalty paid on premature stake unlock.
+	StakingReportLockPenalty = "lock_penalty"
+
+	// StakingReportWithdrawal represents a finalized stake withdrawal.
+	StakingReportWithdrawal = "withdrawal"
+)
+
+// StakingReport represents a staking rewards report of a delegator
+// with fiat valuation of the reported events.
+type StakingReport struct {
+	Address  common.Address
+	Currency string
+	From     time.Time
+	To       time.Time
+	Entries  []*StakingReportEntry
+	Totals   []*StakingReportTotal
+}
+
+// StakingReportEntry represents a single staking event of the report.
+type StakingReportEntry struct {
+	Type        string
+	TimeStamp   time.Time
+	Epoch       uint64
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+	Trx         common.Hash
+
+	// Price represents the price of the native token at the time of the event;
+	// nil if the price history is not available for the time.
+	Price *float64
+
+	// FiatValue represents the value of the amount at the time of the event;
+	// nil if the price history is not available for the time.
+	FiatValue *float64
+}
+
+// StakingReportTotal represents a total of a staking event type in a year.
+type StakingReportTotal struct {
+	Year      int
+	Type      string
+	Count     int
+	Amount    hexutil.Big
+	FiatValue float64
+
+	// Unvalued represents the number of events without fiat valuation.
+	Unvalued int
+}
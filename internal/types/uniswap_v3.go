@@ -0,0 +1,390 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	FiUniswapV3ActionId        = "_id"
+	FiUniswapV3ActionPool      = "pool"
+	FiUniswapV3ActionType      = "typ"
+	FiUniswapV3ActionOwner     = "owner"
+	FiUniswapV3ActionTickLower = "tl"
+	FiUniswapV3ActionTickUpper = "tu"
+	FiUniswapV3ActionPrice     = "price"
+	FiUniswapV3ActionTimeStamp = "date"
+	FiUniswapV3ActionOrdinal   = "orx"
+
+	FiUniswapV3PoolAddress = "_id"
+	FiUniswapV3PoolToken0  = "tok0"
+	FiUniswapV3PoolToken1  = "tok1"
+	FiUniswapV3PoolCreated = "created"
+
+	FiUniswapV3PositionTokenId = "_id"
+	FiUniswapV3PositionOwner   = "owner"
+	FiUniswapV3PositionPool    = "pool"
+)
+
+// define types of concentrated liquidity pool actions
+const (
+	UniswapV3ActionSwap = iota
+	UniswapV3ActionMint
+	UniswapV3ActionBurn
+	UniswapV3ActionCollect
+)
+
+// uniswapV3Q96 represents the fixed point base used by v3 pools to encode square root price.
+var uniswapV3Q96 = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96))
+
+// UniswapV3Pool represents a concentrated liquidity pool registered on the configured v3 factory.
+type UniswapV3Pool struct {
+	Address     common.Address
+	Token0      common.Address
+	Token1      common.Address
+	Fee         int32
+	TickSpacing int32
+	Block       hexutil.Uint64
+	Created     time.Time
+}
+
+// MarshalBSON creates a BSON representation of a concentrated liquidity pool.
+func (up *UniswapV3Pool) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		Address     string    `bson:"_id"`
+		Token0      string    `bson:"tok0"`
+		Token1      string    `bson:"tok1"`
+		Fee         int32     `bson:"fee"`
+		TickSpacing int32     `bson:"spacing"`
+		Block       int64     `bson:"blk"`
+		Created     time.Time `bson:"created"`
+	}{
+		Address:     up.Address.String(),
+		Token0:      up.Token0.String(),
+		Token1:      up.Token1.String(),
+		Fee:         up.Fee,
+		TickSpacing: up.TickSpacing,
+		Block:       int64(up.Block),
+		Created:     up.Created,
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (up *UniswapV3Pool) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	var row struct {
+		Address     string    `bson:"_id"`
+		Token0      string    `bson:"tok0"`
+		Token1      string    `bson:"tok1"`
+		Fee         int32     `bson:"fee"`
+		TickSpacing int32     `bson:"spacing"`
+		Block       int64     `bson:"blk"`
+		Created     time.Time `bson:"created"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	up.Address = common.HexToAddress(row.Address)
+	up.Token0 = common.HexToAddress(row.Token0)
+	up.Token1 = common.HexToAddress(row.Token1)
+	up.Fee = row.Fee
+	up.TickSpacing = row.TickSpacing
+	up.Block = hexutil.Uint64(row.Block)
+	up.Created = row.Created
+	return nil
+}
+
+// UniswapV3PoolState represents the current on-chain state of a concentrated liquidity pool.
+type UniswapV3PoolState struct {
+	SqrtPriceX96 hexutil.Big
+	Tick         int32
+	Liquidity    hexutil.Big
+	FeeGrowth0   hexutil.Big
+	FeeGrowth1   hexutil.Big
+}
+
+// Price calculates the raw price of token0 denominated in token1 from the pool state.
+func (ps *UniswapV3PoolState) Price() float64 {
+	return UniswapV3SqrtPriceToPrice(ps.SqrtPriceX96.ToInt())
+}
+
+// UniswapV3Tick represents liquidity information of an initialized tick of a pool.
+type UniswapV3Tick struct {
+	Index          int32
+	LiquidityGross hexutil.Big
+	LiquidityNet   hexutil.Big
+	Initialized    bool
+}
+
+// UniswapV3Position represents a liquidity position NFT of the v3 position manager.
+type UniswapV3Position struct {
+	TokenId     hexutil.Big
+	Owner       common.Address
+	Pool        common.Address
+	Token0      common.Address
+	Token1      common.Address
+	Fee         int32
+	TickLower   int32
+	TickUpper   int32
+	Liquidity   hexutil.Big
+	TokensOwed0 hexutil.Big
+	TokensOwed1 hexutil.Big
+	Updated     hexutil.Uint64
+}
+
+// UniswapV3Action represents a swap, mint, burn, or collect action on a concentrated liquidity pool.
+type UniswapV3Action struct {
+	OrdIndex     uint64
+	Type         int32
+	Pool         common.Address
+	Sender       common.Address
+	Owner        common.Address
+	TickLower    int32
+	TickUpper    int32
+	Tick         int32
+	Amount0      hexutil.Big
+	Amount1      hexutil.Big
+	Liquidity    hexutil.Big
+	SqrtPriceX96 hexutil.Big
+	TrxHash      common.Hash
+	LogIndex     hexutil.Uint
+	BlockNumber  hexutil.Uint64
+	TimeStamp    hexutil.Uint64
+}
+
+// UniswapV3SqrtPriceToPrice converts the Q64.96 square root price into raw price of token0 in token1.
+func UniswapV3SqrtPriceToPrice(sqrtPriceX96 *big.Int) float64 {
+	if sqrtPriceX96 == nil || sqrtPriceX96.Sign() == 0 {
+		return 0
+	}
+	sp, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX96), uniswapV3Q96).Float64()
+	return sp * sp
+}
+
+// UniswapV3Decode decodes a signed ABI word into a big integer.
+func UniswapV3Decode(word []byte) *big.Int {
+	val := new(big.Int).SetBytes(word)
+	if len(word) > 0 && word[0]&0x80 != 0 {
+		val.Sub(val, new(big.Int).Lsh(big.NewInt(1), uint(len(word)*8)))
+	}
+	return val
+}
+
+// uniswapV3MustDecodeSigned decodes a signed decimal amount as stored in the database.
+// Swap amounts are signed, so we can not use the hex encoding used elsewhere.
+func uniswapV3MustDecodeSigned(val string) *big.Int {
+	v, ok := new(big.Int).SetString(val, 10)
+	if !ok {
+		panic(fmt.Errorf("invalid signed amount %s", val))
+	}
+	return v
+}
+
+// Pk generates a unique primary key for the given pool action.
+func (ua *UniswapV3Action) Pk() string {
+	bytes := make([]byte, 12)
+	copy(bytes, ua.TrxHash.Bytes()[:8])
+	bytes[8] = byte((ua.LogIndex >> 24) & 0xFF)
+	bytes[9] = byte((ua.LogIndex >> 16) & 0xFF)
+	bytes[10] = byte((ua.LogIndex >> 8) & 0xFF)
+	bytes[11] = byte(ua.LogIndex & 0xFF)
+	return hexutil.Encode(bytes)
+}
+
+// Price returns the raw price of token0 denominated in token1 after the action, if known.
+func (ua *UniswapV3Action) Price() float64 {
+	return UniswapV3SqrtPriceToPrice(ua.SqrtPriceX96.ToInt())
+}
+
+// MarshalBSON creates a BSON representation of a pool action.
+func (ua *UniswapV3Action) MarshalBSON() ([]byte, error) {
+	// price is stored for swaps only, it's used by OHLC aggregation
+	var price *float64
+	if ua.Type == UniswapV3ActionSwap {
+		p := ua.Price()
+		if !math.IsInf(p, 0) && !math.IsNaN(p) {
+			price = &p
+		}
+	}
+
+	pom := struct {
+		ID           string    `bson:"_id"`
+		Ordinal      int64     `bson:"orx"`
+		Type         int32     `bson:"typ"`
+		Pool         string    `bson:"pool"`
+		Sender       string    `bson:"sender"`
+		Owner        string    `bson:"owner"`
+		TickLower    int32     `bson:"tl"`
+		TickUpper    int32     `bson:"tu"`
+		Tick         int32     `bson:"tick"`
+		Amount0      string    `bson:"am0"`
+		Amount1      string    `bson:"am1"`
+		Liquidity    string    `bson:"liq"`
+		SqrtPriceX96 string    `bson:"sqrtp"`
+		Price        *float64  `bson:"price,omitempty"`
+		Trx          string    `bson:"trx"`
+		LogIndex     int32     `bson:"lix"`
+		Block        int64     `bson:"blk"`
+		TimeStamp    time.Time `bson:"date"`
+	}{
+		ID:           ua.Pk(),
+		Ordinal:      int64(ua.OrdIndex),
+		Type:         ua.Type,
+		Pool:         ua.Pool.String(),
+		Sender:       ua.Sender.String(),
+		Owner:        ua.Owner.String(),
+		TickLower:    ua.TickLower,
+		TickUpper:    ua.TickUpper,
+		Tick:         ua.Tick,
+		Amount0:      ua.Amount0.ToInt().String(),
+		Amount1:      ua.Amount1.ToInt().String(),
+		Liquidity:    ua.Liquidity.String(),
+		SqrtPriceX96: ua.SqrtPriceX96.String(),
+		Price:        price,
+		Trx:          ua.TrxHash.String(),
+		LogIndex:     int32(ua.LogIndex),
+		Block:        int64(ua.BlockNumber),
+		TimeStamp:    time.Unix(int64(ua.TimeStamp), 0),
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ua *UniswapV3Action) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		Ordinal      int64     `bson:"orx"`
+		Type         int32     `bson:"typ"`
+		Pool         string    `bson:"pool"`
+		Sender       string    `bson:"sender"`
+		Owner        string    `bson:"owner"`
+		TickLower    int32     `bson:"tl"`
+		TickUpper    int32     `bson:"tu"`
+		Tick         int32     `bson:"tick"`
+		Amount0      string    `bson:"am0"`
+		Amount1      string    `bson:"am1"`
+		Liquidity    string    `bson:"liq"`
+		SqrtPriceX96 string    `bson:"sqrtp"`
+		Trx          string    `bson:"trx"`
+		LogIndex     int32     `bson:"lix"`
+		Block        int64     `bson:"blk"`
+		TimeStamp    time.Time `bson:"date"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	ua.OrdIndex = uint64(row.Ordinal)
+	ua.Type = row.Type
+	ua.Pool = common.HexToAddress(row.Pool)
+	ua.Sender = common.HexToAddress(row.Sender)
+	ua.Owner = common.HexToAddress(row.Owner)
+	ua.TickLower = row.TickLower
+	ua.TickUpper = row.TickUpper
+	ua.Tick = row.Tick
+	ua.Amount0 = (hexutil.Big)(*uniswapV3MustDecodeSigned(row.Amount0))
+	ua.Amount1 = (hexutil.Big)(*uniswapV3MustDecodeSigned(row.Amount1))
+	ua.Liquidity = (hexutil.Big)(*hexutil.MustDecodeBig(row.Liquidity))
+	ua.SqrtPriceX96 = (hexutil.Big)(*hexutil.MustDecodeBig(row.SqrtPriceX96))
+	ua.TrxHash = common.HexToHash(row.Trx)
+	ua.LogIndex = hexutil.Uint(row.LogIndex)
+	ua.BlockNumber = hexutil.Uint64(row.Block)
+	ua.TimeStamp = hexutil.Uint64(uint64(row.TimeStamp.Unix()))
+	return nil
+}
+
+// MarshalBSON creates a BSON representation of a liquidity position.
+func (up *UniswapV3Position) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		ID          string `bson:"_id"`
+		Owner       string `bson:"owner"`
+		Pool        string `bson:"pool"`
+		Token0      string `bson:"tok0"`
+		Token1      string `bson:"tok1"`
+		Fee         int32  `bson:"fee"`
+		TickLower   int32  `bson:"tl"`
+		TickUpper   int32  `bson:"tu"`
+		Liquidity   string `bson:"liq"`
+		TokensOwed0 string `bson:"owed0"`
+		TokensOwed1 string `bson:"owed1"`
+		Updated     int64  `bson:"upd"`
+	}{
+		ID:          up.TokenId.String(),
+		Owner:       up.Owner.String(),
+		Pool:        up.Pool.String(),
+		Token0:      up.Token0.String(),
+		Token1:      up.Token1.String(),
+		Fee:         up.Fee,
+		TickLower:   up.TickLower,
+		TickUpper:   up.TickUpper,
+		Liquidity:   up.Liquidity.String(),
+		TokensOwed0: up.TokensOwed0.String(),
+		TokensOwed1: up.TokensOwed1.String(),
+		Updated:     int64(up.Updated),
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (up *UniswapV3Position) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	var row struct {
+		ID          string `bson:"_id"`
+		Owner       string `bson:"owner"`
+		Pool        string `bson:"pool"`
+		Token0      string `bson:"tok0"`
+		Token1      string `bson:"tok1"`
+		Fee         int32  `bson:"fee"`
+		TickLower   int32  `bson:"tl"`
+		TickUpper   int32  `bson:"tu"`
+		Liquidity   string `bson:"liq"`
+		TokensOwed0 string `bson:"owed0"`
+		TokensOwed1 string `bson:"owed1"`
+		Updated     int64  `bson:"upd"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	up.TokenId = (hexutil.Big)(*hexutil.MustDecodeBig(row.ID))
+	up.Owner = common.HexToAddress(row.Owner)
+	up.Pool = common.HexToAddress(row.Pool)
+	up.Token0 = common.HexToAddress(row.Token0)
+	up.Token1 = common.HexToAddress(row.Token1)
+	up.Fee = row.Fee
+	up.TickLower = row.TickLower
+	up.TickUpper = row.TickUpper
+	up.Liquidity = (hexutil.Big)(*hexutil.MustDecodeBig(row.Liquidity))
+	up.TokensOwed0 = (hexutil.Big)(*hexutil.MustDecodeBig(row.TokensOwed0))
+	up.TokensOwed1 = (hexutil.Big)(*hexutil.MustDecodeBig(row.TokensOwed1))
+	up.Updated = hexutil.Uint64(row.Updated)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// AlertTypeOffline represents a rule raised when a validator goes offline.
+	AlertTypeOffline = "offline"
+
+	// AlertTypeDowntime represents a rule raised when a validator downtime exceeds the threshold.
+	AlertTypeDowntime = "downtime"
+
+	// AlertTypeNotVoting represents a rule raised when an active validator stops voting.
+	AlertTypeNotVoting = "not_voting"
+
+	// AlertTypeCheater represents a rule raised when a validator is marked as a cheater.
+	AlertTypeCheater = "cheater"
+
+	// AlertTypeSelfStake represents a rule raised when a validator self-stake drops below the minimum.
+	AlertTypeSelfStake = "self_stake"
+
+	// AlertTypeLockExpiring represents a rule raised when a validator self-stake lock is about to expire.
+	AlertTypeLockExpiring = "lock_expiring"
+)
+
+const (
+	FiValidatorAlertValidator = "val"
+	FiValidatorAlertRaised    = "raised"
+	FiValidatorAlertResolved  = "resolved"
+)
+
+// ValidatorAlert represents an alert raised by a validator alert rule.
+type ValidatorAlert struct {
+	Id          string     `bson:"_id"`
+	ValidatorId uint64     `bson:"val"`
+	Address     string     `bson:"addr"`
+	Rule        string     `bson:"rule"`
+	Type        string     `bson:"type"`
+	Message     string     `bson:"msg"`
+	Raised      time.Time  `bson:"raised"`
+	Resolved    *time.Time `bson:"resolved"`
+	Notified    time.Time  `bson:"notified"`
+}
+
+// AlertKey returns the key identifying the rule and validator of the alert.
+func (va *ValidatorAlert) AlertKey() string {
+	return ValidatorAlertKey(va.Rule, va.ValidatorId)
+}
+
+// ValidatorAlertKey returns the key identifying an alert of the given rule and validator.
+func ValidatorAlertKey(rule string, valID uint64) string {
+	return fmt.Sprintf("%s-%d", rule, valID)
+}
@@ -0,0 +1,176 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"time"
+)
+
+const (
+	FiValidatorEpochValidator = "val"
+	FiValidatorEpochEpoch     = "epoch"
+)
+
+// secondsPerYear represents the number of seconds in a year used to annualize epoch rewards.
+const secondsPerYear = 365 * 24 * 60 * 60
+
+// rewardPerTokenDecimals represents the decimals correction of SFC reward per token values.
+var rewardPerTokenDecimals = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil))
+
+// ValidatorEpoch represents a snapshot of a validator state taken at the epoch seal.
+type ValidatorEpoch struct {
+	ValidatorId hexutil.Big
+	Epoch       hexutil.Uint64
+	EndTime     hexutil.Uint64
+
+	// Duration represents the length of the epoch in seconds.
+	Duration hexutil.Uint64
+
+	// SelfStake represents the validator self stake; the SFC does not keep
+	// its history, so it's known only if the snapshot is taken right at the seal.
+	SelfStake *hexutil.Big
+
+	// ReceivedStake represents the total stake of the validator in the epoch.
+	ReceivedStake hexutil.Big
+
+	// Uptime represents the number of seconds the validator was online in the epoch.
+	Uptime hexutil.Uint64
+
+	// OfflineTime and OfflineBlocks represent the validator downtime counters at the epoch seal.
+	OfflineTime   hexutil.Uint64
+	OfflineBlocks hexutil.Uint64
+
+	// OriginatedFee represents the fee of transactions originated by the validator in the epoch.
+	OriginatedFee hexutil.Big
+
+	// AccumulatedRewardPerToken represents the reward per token accumulated up to the epoch.
+	AccumulatedRewardPerToken hexutil.Big
+
+	// RewardPerToken represents the reward per staked token received in the epoch.
+	RewardPerToken hexutil.Big
+}
+
+// ValidatorEpochGap represents an epoch with missing validator snapshots
+// waiting to be backfilled.
+type ValidatorEpochGap struct {
+	Epoch   int64 `bson:"_id"`
+	EndTime int64 `bson:"end"`
+
+	// Validators represents the IDs of validators with missing snapshots;
+	// an empty list means the whole epoch is missing.
+	Validators []int64 `bson:"val"`
+}
+
+// Pk returns the unique identifier of the validator epoch snapshot.
+func (ve *ValidatorEpoch) Pk() string {
+	return fmt.Sprintf("%s-%x", ve.ValidatorId.String(), uint64(ve.Epoch))
+}
+
+// DelegatedAmount returns the amount delegated to the validator by other accounts,
+// if the self stake of the validator is known.
+func (ve *ValidatorEpoch) DelegatedAmount() *hexutil.Big {
+	if ve.SelfStake == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Sub(ve.ReceivedStake.ToInt(), ve.SelfStake.ToInt()))
+}
+
+// Apr returns the realized annual percentage rate of the epoch rewards
+// for a token delegated to the validator.
+func (ve *ValidatorEpoch) Apr() float64 {
+	if ve.Duration == 0 {
+		return 0
+	}
+
+	rpt, _ := new(big.Float).Quo(new(big.Float).SetInt(ve.RewardPerToken.ToInt()), rewardPerTokenDecimals).Float64()
+	return rpt * secondsPerYear / float64(ve.Duration) * 100
+}
+
+// MarshalBSON creates a BSON representation of the validator epoch snapshot.
+func (ve *ValidatorEpoch) MarshalBSON() ([]byte, error) {
+	pom := struct {
+		Id             string    `bson:"_id"`
+		Validator      int64     `bson:"val"`
+		Epoch          int64     `bson:"epoch"`
+		End            time.Time `bson:"end"`
+		Duration       int64     `bson:"dur"`
+		SelfStake      *string   `bson:"self"`
+		ReceivedStake  string    `bson:"stake"`
+		Uptime         int64     `bson:"up"`
+		OfflineTime    int64     `bson:"off_time"`
+		OfflineBlocks  int64     `bson:"off_blk"`
+		OriginatedFee  string    `bson:"fee"`
+		AccRewPerToken string    `bson:"acc_rpt"`
+		RewPerToken    string    `bson:"rpt"`
+		Apr            float64   `bson:"apr"`
+	}{
+		Id:             ve.Pk(),
+		Validator:      ve.ValidatorId.ToInt().Int64(),
+		Epoch:          int64(ve.Epoch),
+		End:            time.Unix(int64(ve.EndTime), 0).UTC(),
+		Duration:       int64(ve.Duration),
+		ReceivedStake:  ve.ReceivedStake.String(),
+		Uptime:         int64(ve.Uptime),
+		OfflineTime:    int64(ve.OfflineTime),
+		OfflineBlocks:  int64(ve.OfflineBlocks),
+		OriginatedFee:  ve.OriginatedFee.String(),
+		AccRewPerToken: ve.AccumulatedRewardPerToken.String(),
+		RewPerToken:    ve.RewardPerToken.String(),
+		Apr:            ve.Apr(),
+	}
+	if ve.SelfStake != nil {
+		ss := ve.SelfStake.String()
+		pom.SelfStake = &ss
+	}
+	return bson.Marshal(pom)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (ve *ValidatorEpoch) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row struct {
+		Validator      int64     `bson:"val"`
+		Epoch          int64     `bson:"epoch"`
+		End            time.Time `bson:"end"`
+		Duration       int64     `bson:"dur"`
+		SelfStake      *string   `bson:"self"`
+		ReceivedStake  string    `bson:"stake"`
+		Uptime         int64     `bson:"up"`
+		OfflineTime    int64     `bson:"off_time"`
+		OfflineBlocks  int64     `bson:"off_blk"`
+		OriginatedFee  string    `bson:"fee"`
+		AccRewPerToken string    `bson:"acc_rpt"`
+		RewPerToken    string    `bson:"rpt"`
+	}
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	ve.ValidatorId = (hexutil.Big)(*big.NewInt(row.Validator))
+	ve.Epoch = hexutil.Uint64(row.Epoch)
+	ve.EndTime = hexutil.Uint64(row.End.Unix())
+	ve.Duration = hexutil.Uint64(row.Duration)
+	ve.ReceivedStake = (hexutil.Big)(*hexutil.MustDecodeBig(row.ReceivedStake))
+	ve.Uptime = hexutil.Uint64(row.Uptime)
+	ve.OfflineTime = hexutil.Uint64(row.OfflineTime)
+	ve.OfflineBlocks = hexutil.Uint64(row.OfflineBlocks)
+	ve.OriginatedFee = (hexutil.Big)(*hexutil.MustDecodeBig(row.OriginatedFee))
+	ve.AccumulatedRewardPerToken = (hexutil.Big)(*hexutil.MustDecodeBig(row.AccRewPerToken))
+	ve.RewardPerToken = (hexutil.Big)(*hexutil.MustDecodeBig(row.RewPerToken))
+	ve.SelfStake = nil
+	if row.SelfStake != nil {
+		ve.SelfStake = (*hexutil.Big)(hexutil.MustDecodeBig(*row.SelfStake))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+const (
+	FiValidatorSnapshotActive     = "active"
+	FiValidatorSnapshotOffline    = "offline"
+	FiValidatorSnapshotStake      = "stake_val"
+	FiValidatorSnapshotDelegators = "dlg_count"
+	FiValidatorSnapshotUptime     = "uptime"
+	FiValidatorSnapshotLocked     = "locked_until"
+	FiValidatorSnapshotCommission = "commission"
+	FiValidatorSnapshotApr        = "apr"
+	FiValidatorSnapshotHasInfo    = "has_info"
+	FiValidatorSnapshotTime       = "snap"
+)
+
+// ValidatorSnapshot represents a periodically refreshed snapshot of a validator state
+// used to filter and sort the validators directory.
+type ValidatorSnapshot struct {
+	Id          uint64 `bson:"_id"`
+	Address     string `bson:"addr"`
+	Status      uint64 `bson:"status"`
+	IsActive    bool   `bson:"active"`
+	IsOffline   bool   `bson:"offline"`
+	IsWithdrawn bool   `bson:"withdrawn"`
+	IsCheater   bool   `bson:"cheater"`
+
+	// TotalStake and SelfStake are stored as hex encoded WEI amounts;
+	// the StakeValue is the total stake in tokens used to filter and sort.
+	TotalStake string  `bson:"stake"`
+	SelfStake  string  `bson:"self"`
+	StakeValue float64 `bson:"stake_val"`
+
+	DelegatorCount int64     `bson:"dlg_count"`
+	LockedUntil    time.Time `bson:"locked_until"`
+
+	// Uptime represents the percentage of time the validator was online in recent epochs.
+	Uptime float64 `bson:"uptime"`
+
+	// Commission represents the validator commission in percent.
+	Commission float64 `bson:"commission"`
+
+	// Apr represents the realized annual percentage rate of the last sealed epoch.
+	Apr float64 `bson:"apr"`
+
+	HasInfo  bool      `bson:"has_info"`
+	Name     string    `bson:"name"`
+	Snapshot time.Time `bson:"snap"`
+}
+
+// ValidatorSnapshotFilter represents a filter of the validators directory.
+// Nil members are not applied.
+type ValidatorSnapshotFilter struct {
+	IsActive      *bool
+	IsOffline     *bool
+	IsLocked      *bool
+	HasInfo       *bool
+	MinCommission *float64
+	MaxCommission *float64
+	MinStake      *big.Int
+}
+
+// ValidatorSnapshotList represents a page of the validators directory.
+type ValidatorSnapshotList struct {
+	Collection []*ValidatorSnapshot
+
+	// Offset represents the position of the first item of the collection in the directory.
+	Offset uint64
+	Total  uint64
+
+	IsStart bool
+	IsEnd   bool
+}
@@ -21,10 +21,12 @@ const (
 	FiWithdrawalCreated     = "crt"
 	FiWithdrawalStamp       = "stamp"
 	FiWithdrawalValue       = "val"
-	FiWithdrawalSlash       = "slash"
+	FiWithdrawalPenalty     = "penalty"
+	FiWithdrawalLegacySlash = "slash"
 	FiWithdrawalRequestTrx  = "req_trx"
 	FiWithdrawalFinTrx      = "fin_trx"
 	FiWithdrawalFinTime     = "fin_time"
+	FiWithdrawalClosed      = "closed"
 
 	WithdrawTypeUndelegated     = "SFC3:Undelegated"
 	WithdrawTypeWithdrawRequest = "SFC1:WithdrawRequest"
@@ -49,6 +51,10 @@ type WithdrawRequest struct {
 	WithdrawTrx  *common.Hash
 	WithdrawTime *hexutil.Uint64
 	Penalty      *hexutil.Big
+
+	// Closed signals the request has been withdrawn even if the withdraw
+	// transaction and time are not known, e.g. if the withdrawal event was missed.
+	Closed bool
 }
 
 // BsonWithdrawRequest represents a structure of withdraw request in BSON format.
@@ -61,11 +67,12 @@ type BsonWithdrawRequest struct {
 	CrTime  uint64    `bson:"crt"`
 	Stamp   time.Time `bson:"stamp"`
 	Amount  string    `bson:"amo"`
-	Penalty *string   `bson:"slash"`
+	Penalty *string   `bson:"penalty"`
 	Value   uint64    `bson:"val"`
 	ReqTrx  string    `bson:"req_trx"`
 	FinTrx  *string   `bson:"fin_trx"`
 	FinTime *uint64   `bson:"fin_time"`
+	Closed  bool      `bson:"closed"`
 	Type    string    `bson:"type"`
 }
 
@@ -78,6 +85,11 @@ func (wr *WithdrawRequest) OrdinalIndex() uint64 {
 	return (uint64(wr.CreatedTime)&0xFFFFFFFFFF)<<24 | (wr.StakerID.ToInt().Uint64()&0xFFF)<<12 | (binary.BigEndian.Uint64(wr.RequestTrx[:8]) & 0xFFF)
 }
 
+// IsClosed checks if the withdraw request has been withdrawn.
+func (wr *WithdrawRequest) IsClosed() bool {
+	return wr.Closed || wr.WithdrawTrx != nil
+}
+
 // MarshalBSON returns a BSON document for the withdrawal request.
 func (wr *WithdrawRequest) MarshalBSON() ([]byte, error) {
 	// calculate the value to 9 digits (and 18 billions remain available)
@@ -95,6 +107,7 @@ func (wr *WithdrawRequest) MarshalBSON() ([]byte, error) {
 		Stamp:   time.Unix(int64(wr.CreatedTime), 0),
 		Amount:  wr.Amount.String(),
 		Value:   val.Uint64(),
+		Closed:  wr.IsClosed(),
 		Type:    wr.Type,
 	}
 	if wr.WithdrawTrx != nil {
@@ -105,7 +118,7 @@ func (wr *WithdrawRequest) MarshalBSON() ([]byte, error) {
 		pom.FinTime = (*uint64)(wr.WithdrawTime)
 	}
 	if wr.Penalty != nil {
-		val := wr.WithdrawTrx.String()
+		val := wr.Penalty.String()
 		pom.Penalty = &val
 	}
 	return bson.Marshal(pom)
@@ -133,9 +146,11 @@ func (wr *WithdrawRequest) UnmarshalBSON(data []byte) (err error) {
 	wr.StakerID = (*hexutil.Big)(hexutil.MustDecodeBig(row.To))
 	wr.CreatedTime = hexutil.Uint64(row.CrTime)
 	wr.Amount = (*hexutil.Big)(hexutil.MustDecodeBig(row.Amount))
+	wr.Closed = row.Closed
 	if row.FinTrx != nil {
 		val := common.HexToHash(*row.FinTrx)
 		wr.WithdrawTrx = &val
+		wr.Closed = true
 	}
 	if row.FinTime != nil {
 		wr.WithdrawTime = (*hexutil.Uint64)(row.FinTime)